@@ -7,6 +7,26 @@ import (
 	"github.com/fatih/color"
 )
 
+// ExitCodeError is an error that specifies the exit code with which the
+// process should terminate. It can be returned from command entry points in
+// order to convey results via distinct exit codes.
+type ExitCodeError struct {
+	// Code is the exit code.
+	Code int
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements error.Error.
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *ExitCodeError) Unwrap() error {
+	return e.Err
+}
+
 // Warning prints a warning message to standard error.
 func Warning(message string) {
 	fmt.Fprintln(color.Error, color.YellowString("Warning:"), message)
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/execution"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/mutagen"
+)
+
+// executorMain is the entry point for the executor command.
+func executorMain(_ *cobra.Command, _ []string) error {
+	// Create a channel to track termination signals. We do this before creating
+	// and starting other infrastructure so that we can ensure things terminate
+	// smoothly, not mid-initialization.
+	signalTermination := make(chan os.Signal, 1)
+	signal.Notify(signalTermination, cmd.TerminationSignals...)
+
+	// Set up a logger on the standard error stream.
+	logLevel := logging.LevelInfo
+	if executorConfiguration.logLevel != "" {
+		if l, ok := logging.NameToLevel(executorConfiguration.logLevel); !ok {
+			return fmt.Errorf("invalid log level specified: %s", executorConfiguration.logLevel)
+		} else {
+			logLevel = l
+		}
+	}
+	logger := logging.NewLogger(logLevel, os.Stderr)
+
+	// Create a stream using standard input/output.
+	stream := newStdioStream()
+
+	// Perform an agent handshake.
+	if err := agent.ServerHandshake(stream); err != nil {
+		return fmt.Errorf("server handshake failed: %w", err)
+	}
+
+	// Perform a version handshake.
+	if err := mutagen.ServerVersionHandshake(stream); err != nil {
+		return fmt.Errorf("version handshake error: %w", err)
+	}
+
+	// Serve an execution on standard input/output and monitor for its
+	// termination.
+	executionTermination := make(chan error, 1)
+	go func() {
+		executionTermination <- execution.Serve(logger, stream)
+	}()
+
+	// Wait for termination from a signal or the execution.
+	select {
+	case s := <-signalTermination:
+		return fmt.Errorf("terminated by signal: %s", s)
+	case err := <-executionTermination:
+		if err != nil {
+			return fmt.Errorf("execution failed: %w", err)
+		}
+		return nil
+	}
+}
+
+// executorCommand is the executor command.
+var executorCommand = &cobra.Command{
+	Use:          agent.CommandExecutor,
+	Short:        "Run the agent in executor mode",
+	Args:         cmd.DisallowArguments,
+	RunE:         executorMain,
+	SilenceUsage: true,
+}
+
+// executorConfiguration stores configuration for the executor command.
+var executorConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// logLevel indicates the log level to use.
+	logLevel string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := executorCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&executorConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up logging flags.
+	flags.StringVar(&executorConfiguration.logLevel, agent.FlagLogLevel, "", "Set the log level")
+}
@@ -66,6 +66,7 @@ func init() {
 		installCommand,
 		synchronizerCommand,
 		forwarderCommand,
+		executorCommand,
 		versionCommand,
 		legalCommand,
 	)
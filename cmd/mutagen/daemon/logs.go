@@ -0,0 +1,171 @@
+package daemon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+
+	"github.com/mutagen-io/mutagen/pkg/daemon"
+)
+
+const (
+	// logsFollowInterval is the interval at which the daemon log file is
+	// polled for new content when following.
+	logsFollowInterval = 250 * time.Millisecond
+)
+
+// tailLines returns the suffix of data containing the last count lines. If
+// count is 0, then data is returned unmodified.
+func tailLines(data []byte, count uint) []byte {
+	// If no limit has been specified, then return all data.
+	if count == 0 {
+		return data
+	}
+
+	// Walk backward through the data to find the start of the requested
+	// lines, ignoring any trailing newline.
+	end := len(data)
+	if end > 0 && data[end-1] == '\n' {
+		end--
+	}
+	for index := end; index > 0; {
+		index = bytes.LastIndexByte(data[:index], '\n')
+		if index < 0 {
+			break
+		}
+		count--
+		if count == 0 {
+			return data[index+1:]
+		}
+	}
+
+	// There are fewer lines than requested, so return all data.
+	return data
+}
+
+// followLog prints content appended to the log file at the specified path,
+// starting at the specified offset within the specified file. It handles log
+// rotation by switching to the new file when the path's file changes or when
+// the file is truncated. It only returns if an error occurs.
+func followLog(path string, file *os.File, offset int64) error {
+	for {
+		// Wait before polling.
+		time.Sleep(logsFollowInterval)
+
+		// Print any new content from the current file.
+		if n, err := io.Copy(os.Stdout, file); err != nil {
+			return fmt.Errorf("unable to read log file: %w", err)
+		} else {
+			offset += n
+		}
+
+		// Check whether or not the log file has been rotated or truncated. If
+		// it doesn't exist, then it may be mid-rotation, so just wait.
+		current, err := file.Stat()
+		if err != nil {
+			return fmt.Errorf("unable to query log file metadata: %w", err)
+		}
+		latest, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("unable to query log file metadata: %w", err)
+		}
+		if os.SameFile(current, latest) && latest.Size() >= offset {
+			continue
+		}
+
+		// Print any content written to the current file between our last read
+		// and its rotation, then switch to the latest file, starting at its
+		// beginning.
+		if _, err := io.Copy(os.Stdout, file); err != nil {
+			return fmt.Errorf("unable to read log file: %w", err)
+		}
+		replacement, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("unable to open log file: %w", err)
+		}
+		file.Close()
+		file = replacement
+		offset = 0
+	}
+}
+
+// logsMain is the entry point for the logs command.
+func logsMain(_ *cobra.Command, _ []string) error {
+	// Compute the path to the daemon log file.
+	path, err := daemon.LogPath()
+	if err != nil {
+		return fmt.Errorf("unable to compute log file path: %w", err)
+	}
+
+	// Open the log file and defer its closure.
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errors.New("daemon log file does not exist")
+		}
+		return fmt.Errorf("unable to open log file: %w", err)
+	}
+	defer file.Close()
+
+	// Read and print the requested portion of the existing content.
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return fmt.Errorf("unable to read log file: %w", err)
+	}
+	os.Stdout.Write(tailLines(data, logsConfiguration.lines))
+
+	// If we're not following the log, then we're done.
+	if !logsConfiguration.follow {
+		return nil
+	}
+
+	// Follow the log.
+	return followLog(path, file, int64(len(data)))
+}
+
+// logsCommand is the logs command.
+var logsCommand = &cobra.Command{
+	Use:          "logs",
+	Short:        "Show the Mutagen daemon log",
+	Args:         cmd.DisallowArguments,
+	RunE:         logsMain,
+	SilenceUsage: true,
+}
+
+// logsConfiguration stores configuration for the logs command.
+var logsConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// follow indicates whether or not to continue printing log content as it's
+	// written.
+	follow bool
+	// lines is the number of trailing lines to print. If 0, then all lines are
+	// printed.
+	lines uint
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := logsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&logsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up logs flags.
+	flags.BoolVarP(&logsConfiguration.follow, "follow", "f", false, "Continue printing log content as it's written")
+	flags.UintVarP(&logsConfiguration.lines, "lines", "n", 0, "Print only the specified number of trailing lines")
+}
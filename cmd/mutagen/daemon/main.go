@@ -49,6 +49,7 @@ func init() {
 		runCommand,
 		startCommand,
 		stopCommand,
+		logsCommand,
 	}
 	if daemon.RegistrationSupported {
 		supportedCommands = append(supportedCommands,
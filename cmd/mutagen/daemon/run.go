@@ -2,6 +2,9 @@ package daemon
 
 import (
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"os"
 	"os/signal"
 
@@ -11,11 +14,13 @@ import (
 
 	"github.com/mutagen-io/mutagen/cmd"
 
+	"github.com/mutagen-io/mutagen/pkg/configuration/global"
 	"github.com/mutagen-io/mutagen/pkg/daemon"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/grpcutil"
 	"github.com/mutagen-io/mutagen/pkg/ipc"
 	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/metrics"
 	daemonsvc "github.com/mutagen-io/mutagen/pkg/service/daemon"
 	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
@@ -30,6 +35,19 @@ import (
 	_ "github.com/mutagen-io/mutagen/pkg/synchronization/protocols/ssh"
 )
 
+// bestEffortWriter is an io.Writer that wraps another writer and ignores any
+// errors that it returns.
+type bestEffortWriter struct {
+	// Writer is the underlying writer.
+	io.Writer
+}
+
+// Write implements io.Writer.Write.
+func (w bestEffortWriter) Write(data []byte) (int, error) {
+	w.Writer.Write(data)
+	return len(data), nil
+}
+
 // runMain is the entry point for the run command.
 func runMain(_ *cobra.Command, _ []string) error {
 	// Attempt to acquire the daemon lock and defer its release.
@@ -54,7 +72,50 @@ func runMain(_ *cobra.Command, _ []string) error {
 			logLevel = l
 		}
 	}
-	logger := logging.NewLogger(logLevel, os.Stderr)
+	logFormat := logging.FormatText
+	logFormatName := runConfiguration.logFormat
+	if logFormatName == "" {
+		logFormatName = os.Getenv("MUTAGEN_LOG_FORMAT")
+	}
+	if logFormatName != "" {
+		if f, ok := logging.NameToFormat(logFormatName); !ok {
+			return fmt.Errorf("invalid log format specified: %s", logFormatName)
+		} else {
+			logFormat = f
+		}
+	}
+
+	// Load the global configuration, if present. We allow it to not exist.
+	var logConfiguration global.DaemonLogConfiguration
+	if globalConfigurationPath, err := global.ConfigurationPath(); err != nil {
+		return fmt.Errorf("unable to compute path to global configuration file: %w", err)
+	} else if globalConfiguration, err := global.LoadConfiguration(globalConfigurationPath); err != nil {
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("unable to load global configuration: %w", err)
+		}
+	} else {
+		logConfiguration = globalConfiguration.Daemon.Logs
+	}
+
+	// Unless disabled, open the daemon log file, defer its closure, and
+	// include it as a log target. We write to the log file first and ignore
+	// failures writing to standard error (which may be closed or detached if
+	// the daemon was started in the background), since io.MultiWriter stops at
+	// the first failing writer.
+	logWriter := io.Writer(os.Stderr)
+	if !logConfiguration.Disabled {
+		logPath, err := daemon.LogPath()
+		if err != nil {
+			return fmt.Errorf("unable to compute log file path: %w", err)
+		}
+		logFile, err := logging.NewRotatingFile(logPath, logConfiguration.RotationPolicy())
+		if err != nil {
+			return fmt.Errorf("unable to open log file: %w", err)
+		}
+		defer logFile.Close()
+		logWriter = io.MultiWriter(logFile, bestEffortWriter{os.Stderr})
+	}
+	logger := logging.NewLoggerWithFormat(logLevel, logFormat, logWriter)
 
 	// Create a forwarding session manager and defer its shutdown.
 	forwardingManager, err := forwarding.NewManager(logger.Sublogger("forward"))
@@ -111,11 +172,36 @@ func runMain(_ *cobra.Command, _ []string) error {
 	defer listener.Close()
 
 	// Serve incoming requests and watch for server failure.
-	serverErrors := make(chan error, 1)
+	serverErrors := make(chan error, 2)
 	go func() {
 		serverErrors <- server.Serve(listener)
 	}()
 
+	// If a metrics address has been specified, then create the metrics
+	// listener, defer its closure, and serve metrics. Metrics server failure is
+	// treated identically to daemon server failure.
+	metricsAddress := runConfiguration.metricsAddress
+	if metricsAddress == "" {
+		metricsAddress = os.Getenv("MUTAGEN_DAEMON_METRICS_ADDRESS")
+	}
+	if metricsAddress != "" {
+		metricsListener, err := net.Listen("tcp", metricsAddress)
+		if err != nil {
+			return fmt.Errorf("unable to create metrics listener: %w", err)
+		}
+		defer metricsListener.Close()
+		metricsHandler := http.NewServeMux()
+		metricsHandler.Handle("/metrics", metrics.NewExporter(synchronizationManager, forwardingManager))
+		metricsServer := &http.Server{Handler: metricsHandler}
+		defer metricsServer.Close()
+		logger.Info("Serving metrics on", metricsListener.Addr())
+		go func() {
+			if err := metricsServer.Serve(metricsListener); err != http.ErrServerClosed {
+				serverErrors <- fmt.Errorf("metrics server failure: %w", err)
+			}
+		}()
+	}
+
 	// Wait for termination from a signal, the daemon service, or the gRPC
 	// server. We treat termination via the daemon service as a non-error.
 	select {
@@ -145,6 +231,10 @@ var runCommand = &cobra.Command{
 var runConfiguration struct {
 	// help indicates whether or not to show help information and exit.
 	help bool
+	// logFormat is the log output format to use.
+	logFormat string
+	// metricsAddress is the TCP address on which to serve metrics, if any.
+	metricsAddress string
 }
 
 func init() {
@@ -157,4 +247,10 @@ func init() {
 	// Manually add a help flag to override the default message. Cobra will
 	// still implement its logic automatically.
 	flags.BoolVarP(&runConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up logging flags.
+	flags.StringVar(&runConfiguration.logFormat, "log-format", "", "Specify the log output format (text|json)")
+
+	// Wire up metrics flags.
+	flags.StringVar(&runConfiguration.metricsAddress, "metrics-address", "", "Serve Prometheus metrics on the specified TCP address (e.g. localhost:9477)")
 }
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/execution"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// resolveExecutionTarget resolves a session or URL specification to the URL of
+// the endpoint on which a command should be run. Remote synchronization URLs
+// are used directly. Any other specification is treated as a synchronization
+// session specification, in which case the endpoint is selected based on the
+// specified endpoint name (either "alpha" or "beta"). If no endpoint name is
+// specified, then the session must have exactly one remote endpoint, which is
+// used.
+func resolveExecutionTarget(daemonConnection *grpc.ClientConn, specification, endpoint string) (*url.URL, error) {
+	// Handle remote URLs.
+	if target, err := url.Parse(specification, url.Kind_Synchronization, true); err == nil && target.Protocol != url.Protocol_Local {
+		if endpoint != "" {
+			return nil, errors.New("endpoint can only be specified for sessions")
+		}
+		return target, nil
+	}
+
+	// Look up the session.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.ListRequest{
+		Selection: &selection.Selection{Specifications: []string{specification}},
+	}
+	response, err := synchronizationService.List(context.Background(), request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate session: %w", grpcutil.PeelAwayRPCErrorLayer(err))
+	} else if err = response.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid list response received: %w", err)
+	} else if len(response.SessionStates) != 1 {
+		return nil, errors.New("invalid list response session count")
+	}
+	session := response.SessionStates[0].Session
+
+	// Select the endpoint.
+	switch endpoint {
+	case "alpha":
+		return session.Alpha, nil
+	case "beta":
+		return session.Beta, nil
+	case "":
+		alphaRemote := session.Alpha.Protocol != url.Protocol_Local
+		betaRemote := session.Beta.Protocol != url.Protocol_Local
+		if alphaRemote && !betaRemote {
+			return session.Alpha, nil
+		} else if betaRemote && !alphaRemote {
+			return session.Beta, nil
+		}
+		return nil, errors.New("session doesn't have exactly one remote endpoint (specify --endpoint)")
+	default:
+		return nil, fmt.Errorf("invalid endpoint specification: %s", endpoint)
+	}
+}
+
+// execute runs a command as specified by the exec command's arguments and
+// returns its exit code.
+func execute(arguments []string) (int, error) {
+	// Extract the target and command. We allow (but don't require) a "--"
+	// delimiter between them. Command arguments are joined in the same manner
+	// as OpenSSH, with the result interpreted by the shell on the endpoint.
+	if len(arguments) == 0 {
+		return 0, errors.New("session or URL not specified")
+	}
+	target, commandArguments := arguments[0], arguments[1:]
+	if len(commandArguments) > 0 && commandArguments[0] == "--" {
+		commandArguments = commandArguments[1:]
+	}
+	if len(commandArguments) == 0 {
+		return 0, errors.New("command not specified")
+	}
+	command := strings.Join(commandArguments, " ")
+
+	// Connect to the daemon and defer closure of the connection. The daemon is
+	// used both for session lookup and for hosting prompting.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return 0, fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Resolve the target endpoint.
+	endpoint, err := resolveExecutionTarget(daemonConnection, target, execConfiguration.endpoint)
+	if err != nil {
+		return 0, err
+	}
+
+	// Create a logger for agent connections. Agent failures are reported via
+	// returned errors, so we don't need any log output.
+	logger := logging.NewLogger(logging.LevelDisabled, os.Stderr)
+
+	// Handle local endpoints.
+	if endpoint.Protocol == url.Protocol_Local {
+		return execution.Run(logger, endpoint, "", command, os.Stdin, os.Stdout, os.Stderr)
+	}
+
+	// Initiate command line prompting. Transports prompt via the daemon (which
+	// relays prompts to the hosted prompter), while agent dialing messages the
+	// prompter directly, so we also register the prompter locally.
+	statusLinePrinter := &cmd.StatusLinePrinter{UseStandardError: true}
+	statusLinePrompter := &cmd.StatusLinePrompter{Printer: statusLinePrinter}
+	promptingCtx, promptingCancel := context.WithCancel(context.Background())
+	prompter, promptingErrors, err := promptingsvc.Host(
+		promptingCtx, promptingsvc.NewPromptingClient(daemonConnection),
+		statusLinePrompter, true,
+	)
+	if err != nil {
+		promptingCancel()
+		return 0, fmt.Errorf("unable to initiate prompting: %w", err)
+	}
+	defer func() {
+		promptingCancel()
+		<-promptingErrors
+	}()
+	if err := prompting.RegisterPrompterWithIdentifier(prompter, statusLinePrompter); err != nil {
+		return 0, fmt.Errorf("unable to register prompter: %w", err)
+	}
+	defer prompting.UnregisterPrompter(prompter)
+
+	// Connect to the endpoint.
+	stream, err := execution.Dial(logger, endpoint, prompter)
+	if err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return 0, fmt.Errorf("unable to connect to endpoint: %w", err)
+	}
+	statusLinePrinter.Clear()
+
+	// Run the command.
+	return execution.Execute(stream, command, endpoint.Path, os.Stdin, os.Stdout, os.Stderr)
+}
+
+// execMain is the entry point for the exec command.
+func execMain(_ *cobra.Command, arguments []string) error {
+	// Run the command. Errors are printed here because the command silences
+	// Cobra's error printing in order to avoid printing anything when only
+	// propagating a non-zero exit code.
+	exitCode, err := execute(arguments)
+	if err != nil {
+		cmd.Error(err)
+		return err
+	}
+
+	// Propagate the command's exit code.
+	if exitCode != 0 {
+		return &cmd.ExitCodeError{Code: exitCode, Err: fmt.Errorf("command exited with code %d", exitCode)}
+	}
+
+	// Success.
+	return nil
+}
+
+// execCommand is the exec command.
+var execCommand = &cobra.Command{
+	Use:           "exec <session-or-url> [--] <command>...",
+	Short:         "Run a command on a synchronization endpoint",
+	RunE:          execMain,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+}
+
+// execConfiguration stores configuration for the exec command.
+var execConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// endpoint is the session endpoint on which to run the command.
+	endpoint string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := execCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Stop flag parsing at the first positional argument so that command
+	// arguments (which may look like flags) are passed through unmodified.
+	flags.SetInterspersed(false)
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&execConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up endpoint flags.
+	flags.StringVar(&execConfiguration.endpoint, "endpoint", "", "Specify the session endpoint on which to run the command (alpha|beta)")
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
 	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
@@ -160,6 +161,14 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert the background prompting mode specification.
+	var backgroundPromptingMode prompting.BackgroundPromptingMode
+	if createConfiguration.backgroundPrompting != "" {
+		if err := backgroundPromptingMode.UnmarshalText([]byte(createConfiguration.backgroundPrompting)); err != nil {
+			return fmt.Errorf("unable to parse background prompting mode: %w", err)
+		}
+	}
+
 	// Validate and convert socket overwrite mode specifications.
 	var socketOverwriteMode, socketOverwriteModeSource, socketOverwriteModeDestination forwarding.SocketOverwriteMode
 	if createConfiguration.socketOverwriteMode != "" {
@@ -245,10 +254,13 @@ func createMain(_ *cobra.Command, arguments []string) error {
 	// Create the command line configuration and merge it into our cumulative
 	// configuration.
 	configuration = forwarding.MergeConfigurations(configuration, &forwarding.Configuration{
-		SocketOverwriteMode:  socketOverwriteMode,
-		SocketOwner:          createConfiguration.socketOwner,
-		SocketGroup:          createConfiguration.socketGroup,
-		SocketPermissionMode: uint32(socketPermissionMode),
+		BackgroundPromptingMode:   backgroundPromptingMode,
+		DisconnectedTimeout:       createConfiguration.disconnectedTimeout,
+		DisconnectedRetryInterval: createConfiguration.disconnectedRetryInterval,
+		SocketOverwriteMode:       socketOverwriteMode,
+		SocketOwner:               createConfiguration.socketOwner,
+		SocketGroup:               createConfiguration.socketGroup,
+		SocketPermissionMode:      uint32(socketPermissionMode),
 	})
 
 	// Create the creation specification.
@@ -318,6 +330,15 @@ var createConfiguration struct {
 	// configurationFile specifies a file from which to load configuration. It
 	// should be a path relative to the working directory.
 	configurationFile string
+	// backgroundPrompting specifies the background prompting mode to use for
+	// the session.
+	backgroundPrompting string
+	// disconnectedTimeout specifies the duration (in seconds) after which a
+	// disconnected session is suspended.
+	disconnectedTimeout uint32
+	// disconnectedRetryInterval specifies the interval (in seconds) at which a
+	// session suspended due to disconnection attempts reconnection.
+	disconnectedRetryInterval uint32
 	// socketOverwriteMode specifies the socket overwrite mode to use for the
 	// session.
 	socketOverwriteMode string
@@ -387,6 +408,13 @@ func init() {
 	flags.BoolVar(&createConfiguration.noGlobalConfiguration, "no-global-configuration", false, "Ignore the global configuration file")
 	flags.StringVarP(&createConfiguration.configurationFile, "configuration-file", "c", "", "Specify a file from which to load additional default configuration")
 
+	// Wire up connection flags.
+	flags.StringVar(&createConfiguration.backgroundPrompting, "background-prompting", "", "Specify whether automatic reconnections may use the default prompter (enabled|disabled)")
+
+	// Wire up policy flags.
+	flags.Uint32Var(&createConfiguration.disconnectedTimeout, "disconnected-timeout", 0, "Specify the time (in seconds) after which a disconnected session is suspended")
+	flags.Uint32Var(&createConfiguration.disconnectedRetryInterval, "disconnected-retry-interval", 0, "Specify the interval (in seconds) at which a suspended disconnected session retries connection")
+
 	// Wire up socket flags.
 	flags.StringVar(&createConfiguration.socketOverwriteMode, "socket-overwrite-mode", "", "Specify socket overwrite mode (leave|overwrite)")
 	flags.StringVar(&createConfiguration.socketOverwriteModeSource, "socket-overwrite-mode-source", "", "Specify socket overwrite mode for source (leave|overwrite)")
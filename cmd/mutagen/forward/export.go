@@ -0,0 +1,126 @@
+package forward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+)
+
+// ExportWithSelection is an orchestration convenience method that performs an
+// export operation using the provided daemon connection and session selection
+// and returns the resulting session definitions.
+func ExportWithSelection(
+	daemonConnection *grpc.ClientConn,
+	selection *selection.Selection,
+) (*forwarding.SessionExport, error) {
+	// Perform the export operation and handle errors.
+	forwardingService := forwardingsvc.NewForwardingClient(daemonConnection)
+	request := &forwardingsvc.ExportRequest{
+		Selection: selection,
+	}
+	response, err := forwardingService.Export(context.Background(), request)
+	if err != nil {
+		return nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid export response received: %w", err)
+	}
+
+	// Success.
+	return response.Export, nil
+}
+
+// exportMain is the entry point for the export command.
+func exportMain(_ *cobra.Command, arguments []string) error {
+	// Ensure that an output path has been specified.
+	if exportConfiguration.output == "" {
+		return errors.New("no output path specified")
+	}
+
+	// Create session selection specification.
+	selection := &selection.Selection{
+		All:            len(arguments) == 0 && exportConfiguration.labelSelector == "" && len(exportConfiguration.selectors) == 0,
+		Specifications: arguments,
+		LabelSelector:  exportConfiguration.labelSelector,
+		Selectors:      exportConfiguration.selectors,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Perform the export operation.
+	export, err := ExportWithSelection(daemonConnection, selection)
+	if err != nil {
+		return err
+	}
+
+	// Save the export.
+	if err := encoding.MarshalAndSaveProtobuf(exportConfiguration.output, export); err != nil {
+		return fmt.Errorf("unable to save export: %w", err)
+	}
+
+	// Print the number of exported sessions.
+	fmt.Printf("Exported %d session(s) to %s\n", len(export.Sessions), exportConfiguration.output)
+
+	// Success.
+	return nil
+}
+
+// exportCommand is the export command.
+var exportCommand = &cobra.Command{
+	Use:          "export [<session>...]",
+	Short:        "Export forwarding session definitions to a file",
+	RunE:         exportMain,
+	SilenceUsage: true,
+}
+
+// exportConfiguration stores configuration for the export command.
+var exportConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// labelSelector encodes a label selector to be used in identifying which
+	// sessions should be exported.
+	labelSelector string
+	// selectors are selector specifications to be used in identifying which
+	// sessions should be exported.
+	selectors []string
+	// output is the path to which the export should be written.
+	output string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := exportCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&exportConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up selection flags.
+	flags.StringVar(&exportConfiguration.labelSelector, "label-selector", "", "Export sessions matching the specified label selector")
+	flags.StringArrayVar(&exportConfiguration.selectors, "select", nil, "Export sessions matching the specified selector (may be repeated)")
+
+	// Wire up export flags.
+	flags.StringVarP(&exportConfiguration.output, "output", "o", "", "Specify the path of the export file")
+}
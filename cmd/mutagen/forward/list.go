@@ -81,9 +81,10 @@ func ListWithSelection(
 func listMain(_ *cobra.Command, arguments []string) error {
 	// Create session selection specification.
 	selection := &selection.Selection{
-		All:            len(arguments) == 0 && listConfiguration.labelSelector == "",
+		All:            len(arguments) == 0 && listConfiguration.labelSelector == "" && len(listConfiguration.selectors) == 0,
 		Specifications: arguments,
 		LabelSelector:  listConfiguration.labelSelector,
+		Selectors:      listConfiguration.selectors,
 	}
 	if err := selection.EnsureValid(); err != nil {
 		return fmt.Errorf("invalid session selection specification: %w", err)
@@ -117,6 +118,9 @@ var listConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// selectors are selector specifications to be used in identifying which
+	// sessions should be selected.
+	selectors []string
 	// TemplateFlags store custom templating behavior.
 	templating.TemplateFlags
 }
@@ -135,6 +139,7 @@ func init() {
 	// Wire up list flags.
 	flags.BoolVarP(&listConfiguration.long, "long", "l", false, "Show detailed session information")
 	flags.StringVar(&listConfiguration.labelSelector, "label-selector", "", "List sessions matching the specified label selector")
+	flags.StringArrayVar(&listConfiguration.selectors, "select", nil, "List sessions matching the specified selector (may be repeated)")
 
 	// Wire up templating flags.
 	listConfiguration.TemplateFlags.Register(flags)
@@ -102,11 +102,33 @@ func printSession(state *forwarding.State, mode common.SessionDisplayMode) {
 			}
 		}
 
-		// Print the configuration header and configuration.
-		// TODO: Implement this in the future if we have any session-level
-		// configuration behaviors. We currently only have endpoint-level
-		// configuration behaviors and thus we don't print any session-level
-		// configuration information like we do for synchronization.
+		// Print the configuration header.
+		fmt.Println("Configuration:")
+
+		// Extract configuration.
+		configuration := state.Session.Configuration
+
+		// Compute and print background prompting mode.
+		backgroundPromptingModeDescription := configuration.BackgroundPromptingMode.Description()
+		if configuration.BackgroundPromptingMode.IsDefault() {
+			defaultBackgroundPromptingMode := state.Session.Version.DefaultBackgroundPromptingMode()
+			backgroundPromptingModeDescription += fmt.Sprintf(" (%s)", defaultBackgroundPromptingMode.Description())
+		}
+		fmt.Println("\tBackground prompting:", backgroundPromptingModeDescription)
+
+		// Print disconnection policy parameters.
+		if configuration.DisconnectedTimeout != 0 {
+			fmt.Printf("\tDisconnected timeout: %d seconds\n", configuration.DisconnectedTimeout)
+			var disconnectedRetryIntervalDescription string
+			if configuration.DisconnectedRetryInterval == 0 {
+				disconnectedRetryIntervalDescription = fmt.Sprintf("Default (%d seconds)", state.Session.Version.DefaultDisconnectedRetryInterval())
+			} else {
+				disconnectedRetryIntervalDescription = fmt.Sprintf("%d seconds", configuration.DisconnectedRetryInterval)
+			}
+			fmt.Println("\tDisconnected retry interval:", disconnectedRetryIntervalDescription)
+		} else {
+			fmt.Println("\tDisconnected timeout: None")
+		}
 	}
 
 	// Compute and print source-specific configuration.
@@ -149,6 +171,8 @@ func printSession(state *forwarding.State, mode common.SessionDisplayMode) {
 	statusString := state.Status.Description()
 	if state.Session.Paused {
 		statusString = color.YellowString("[Paused]")
+	} else if state.Suspension != "" {
+		statusString = color.YellowString("[Suspended: %s]", state.Suspension)
 	}
 	fmt.Fprintln(color.Output, "Status:", statusString)
 
@@ -0,0 +1,118 @@
+package forward
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+)
+
+// logsMain is the entry point for the logs command.
+func logsMain(_ *cobra.Command, arguments []string) error {
+	// Ensure that exactly one session has been specified.
+	if len(arguments) == 0 {
+		return errors.New("session not specified")
+	} else if len(arguments) > 1 {
+		return errors.New("only one session may be specified")
+	}
+
+	// Validate the log level, if specified.
+	if logsConfiguration.level != "" {
+		if _, ok := logging.NameToLevel(logsConfiguration.level); !ok {
+			return fmt.Errorf("invalid log level: %s", logsConfiguration.level)
+		}
+	}
+
+	// Create session selection specification.
+	selection := &selection.Selection{
+		Specifications: arguments,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Create a session service client.
+	sessionService := forwardingsvc.NewForwardingClient(daemonConnection)
+
+	// Create the logs request that we'll use. The capture level is only raised
+	// while requests are being made, so we include it in every request.
+	request := &forwardingsvc.LogsRequest{
+		Selection: selection,
+		Level:     logsConfiguration.level,
+	}
+
+	// Loop and print log entries, exiting after the first iteration if we're
+	// not following the log.
+	for {
+		// Perform a logs operation.
+		response, err := sessionService.Logs(context.Background(), request)
+		if err != nil {
+			return fmt.Errorf("unable to read logs: %w", grpcutil.PeelAwayRPCErrorLayer(err))
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid logs response received: %w", err)
+		}
+
+		// Print the entries.
+		for _, entry := range response.Entries {
+			fmt.Print(entry.Format())
+		}
+
+		// If we're not following the log, then we're done.
+		if !logsConfiguration.follow {
+			return nil
+		}
+
+		// Update the request for the next iteration.
+		request.PreviousIndex = response.Index
+	}
+}
+
+// logsCommand is the logs command.
+var logsCommand = &cobra.Command{
+	Use:          "logs <session>",
+	Short:        "Show captured logs for a forwarding session",
+	RunE:         logsMain,
+	SilenceUsage: true,
+}
+
+// logsConfiguration stores configuration for the logs command.
+var logsConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// follow indicates whether or not to continue printing log entries as
+	// they're captured.
+	follow bool
+	// level is the log level at which the session should capture entries.
+	level string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := logsCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&logsConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up logs flags.
+	flags.BoolVarP(&logsConfiguration.follow, "follow", "f", false, "Continue printing log entries as they're captured")
+	flags.StringVar(&logsConfiguration.level, "level", "", "Raise the session's log capture level while the command runs (disabled|error|warn|info|debug|trace)")
+}
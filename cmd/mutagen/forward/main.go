@@ -46,8 +46,12 @@ func init() {
 		createCommand,
 		listCommand,
 		monitorCommand,
+		logsCommand,
 		pauseCommand,
 		resumeCommand,
+		labelCommand,
+		exportCommand,
+		importCommand,
 		terminateCommand,
 	)
 }
@@ -32,6 +32,8 @@ func computeMonitorStatusLine(state *forwarding.State) string {
 	var status string
 	if state.Session.Paused {
 		status += color.YellowString("[Paused]")
+	} else if state.Suspension != "" {
+		status += color.YellowString("[Suspended] ") + state.Suspension
 	} else {
 		// Add an error flag if there is one present.
 		if state.LastError != "" {
@@ -62,9 +64,10 @@ func monitorMain(_ *cobra.Command, arguments []string) error {
 	// Create the session selection specification that will select our initial
 	// batch of sessions.
 	selection := &selectionpkg.Selection{
-		All:            len(arguments) == 0 && monitorConfiguration.labelSelector == "",
+		All:            len(arguments) == 0 && monitorConfiguration.labelSelector == "" && len(monitorConfiguration.selectors) == 0,
 		Specifications: arguments,
 		LabelSelector:  monitorConfiguration.labelSelector,
+		Selectors:      monitorConfiguration.selectors,
 	}
 	if err := selection.EnsureValid(); err != nil {
 		return fmt.Errorf("invalid session selection specification: %w", err)
@@ -205,6 +208,9 @@ var monitorConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// selectors are selector specifications to be used in identifying which
+	// sessions should be selected.
+	selectors []string
 	// TemplateFlags store custom templating behavior.
 	templating.TemplateFlags
 }
@@ -223,6 +229,7 @@ func init() {
 	// Wire up monitor flags.
 	flags.BoolVarP(&monitorConfiguration.long, "long", "l", false, "Show detailed session information")
 	flags.StringVar(&monitorConfiguration.labelSelector, "label-selector", "", "Monitor the most recently created session matching the specified label selector")
+	flags.StringArrayVar(&monitorConfiguration.selectors, "select", nil, "Monitor the most recently created session matching the specified selector (may be repeated)")
 
 	// Wire up templating flags.
 	monitorConfiguration.TemplateFlags.Register(flags)
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -75,7 +76,9 @@ func init() {
 		sync.SyncCommand,
 		forward.ForwardCommand,
 		project.ProjectCommand,
+		execCommand,
 		daemon.DaemonCommand,
+		promptAgentCommand,
 		versionCommand,
 		legalCommand,
 		generateCommand,
@@ -102,8 +105,13 @@ func main() {
 	// we should proceed normally.
 	cmd.HandleTerminalCompatibility()
 
-	// Execute the root command.
+	// Execute the root command. If the command specified an exit code, then
+	// use that, otherwise use a generic error exit code.
 	if err := rootCommand.Execute(); err != nil {
+		var exitCodeError *cmd.ExitCodeError
+		if errors.As(err, &exitCodeError) {
+			os.Exit(exitCodeError.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -3,6 +3,7 @@
 package project
 
 import (
+	"context"
 	"os"
 	"os/exec"
 )
@@ -19,3 +20,10 @@ func runInShell(command string) error {
 	// Run the process and wait for its completion.
 	return process.Run()
 }
+
+// probeInShell runs the specified command using the system shell, discarding
+// its output. On POSIX systems, this is /bin/sh. The process is terminated if
+// the context is cancelled.
+func probeInShell(ctx context.Context, command string) error {
+	return exec.CommandContext(ctx, "/bin/sh", "-c", command).Run()
+}
@@ -1,6 +1,7 @@
 package project
 
 import (
+	"context"
 	"os"
 	"os/exec"
 )
@@ -23,3 +24,18 @@ func runInShell(command string) error {
 	// Run the process and wait for its completion.
 	return process.Run()
 }
+
+// probeInShell runs the specified command using the system shell, discarding
+// its output. On Windows systems, this is %COMSPEC% (with a fallback to
+// cmd.exe if unspecified). The process is terminated if the context is
+// cancelled.
+func probeInShell(ctx context.Context, command string) error {
+	// Determine the shell to use.
+	shell := os.Getenv("COMSPEC")
+	if shell == "" {
+		shell = "cmd.exe"
+	}
+
+	// Run the process and wait for its completion.
+	return exec.CommandContext(ctx, shell, "/c", command).Run()
+}
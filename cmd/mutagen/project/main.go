@@ -50,7 +50,9 @@ func init() {
 	ProjectCommand.AddCommand(
 		startCommand,
 		runCommand,
+		watchCommand,
 		listCommand,
+		statusCommand,
 		flushCommand,
 		pauseCommand,
 		resumeCommand,
@@ -0,0 +1,251 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+const (
+	// readinessCommandRetryInterval is the interval at which readiness
+	// commands are retried.
+	readinessCommandRetryInterval = time.Second
+)
+
+// errReadinessTimeout indicates that a session didn't become ready within its
+// readiness timeout.
+var errReadinessTimeout = errors.New("timed out waiting for session readiness")
+
+// synchronizationConditionSatisfied determines whether or not a
+// synchronization session state satisfies a readiness condition. It returns an
+// error if the session is in a state from which it can't become ready without
+// intervention.
+func synchronizationConditionSatisfied(state *synchronization.State, condition project.ReadinessCondition) (bool, error) {
+	// Check for states that require intervention.
+	if state.Session.Paused {
+		return false, errors.New("session is paused")
+	} else if state.Status.IsHalted() {
+		return false, fmt.Errorf("session is halted: %s", state.Status.Description())
+	}
+
+	// Check the condition.
+	switch condition {
+	case project.ReadinessConditionDefault, project.ReadinessConditionNone:
+		return true, nil
+	case project.ReadinessConditionConnected:
+		return state.AlphaState.Connected && state.BetaState.Connected, nil
+	case project.ReadinessConditionSynchronized:
+		return state.SuccessfulCycles > 0, nil
+	default:
+		return false, errors.New("unknown readiness condition")
+	}
+}
+
+// forwardingConditionSatisfied determines whether or not a forwarding session
+// state satisfies a readiness condition. It returns an error if the session is
+// in a state from which it can't become ready without intervention. Note that
+// the connected condition only indicates that the session is forwarding
+// connections, not that the destination is accepting them, which can only be
+// verified using a readiness command.
+func forwardingConditionSatisfied(state *forwarding.State, condition project.ReadinessCondition) (bool, error) {
+	// Check for states that require intervention.
+	if state.Session.Paused {
+		return false, errors.New("session is paused")
+	}
+
+	// Check the condition.
+	switch condition {
+	case project.ReadinessConditionDefault, project.ReadinessConditionNone:
+		return true, nil
+	case project.ReadinessConditionConnected:
+		return state.Status == forwarding.Status_ForwardingConnections, nil
+	default:
+		return false, fmt.Errorf("unsupported readiness condition: %s", condition)
+	}
+}
+
+// readinessWaiter tracks the readiness of project sessions.
+type readinessWaiter struct {
+	// daemonConnection is the daemon connection.
+	daemonConnection *grpc.ClientConn
+	// readiness are the readiness criteria for each session.
+	readiness map[project.SessionReference]project.Readiness
+	// identifiers are the identifiers of sessions that have been created.
+	identifiers map[project.SessionReference]string
+	// ready is the set of sessions that are known to be ready.
+	ready map[project.SessionReference]bool
+}
+
+// newReadinessWaiter creates a new readiness waiter using the specified daemon
+// connection and readiness criteria.
+func newReadinessWaiter(
+	daemonConnection *grpc.ClientConn,
+	readiness map[project.SessionReference]project.Readiness,
+) *readinessWaiter {
+	return &readinessWaiter{
+		daemonConnection: daemonConnection,
+		readiness:        readiness,
+		identifiers:      make(map[project.SessionReference]string),
+		ready:            make(map[project.SessionReference]bool),
+	}
+}
+
+// created records the identifier for a newly created session.
+func (w *readinessWaiter) created(reference project.SessionReference, identifier string) {
+	w.identifiers[reference] = identifier
+}
+
+// waitForDependencies waits for the sessions identified by the specified
+// dependency specifications to become ready. All of the sessions must have
+// already been created.
+func (w *readinessWaiter) waitForDependencies(configuration *project.Configuration, specifications []string) error {
+	// Resolve dependencies.
+	dependencies, err := configuration.ResolveDependencies(specifications)
+	if err != nil {
+		return err
+	}
+
+	// Wait for each dependency to become ready.
+	for _, dependency := range dependencies {
+		if w.ready[dependency] {
+			continue
+		} else if err := w.waitForSession(dependency); err != nil {
+			return fmt.Errorf("%s session %s did not become ready: %w", dependency.Kind, dependency.Name, err)
+		}
+		w.ready[dependency] = true
+	}
+
+	// Success.
+	return nil
+}
+
+// waitForSession waits for a session to become ready.
+func (w *readinessWaiter) waitForSession(reference project.SessionReference) error {
+	// Look up the session identifier and readiness criteria. If the criteria
+	// are trivially satisfied, then there's nothing to wait for.
+	identifier, ok := w.identifiers[reference]
+	if !ok {
+		return errors.New("session not created")
+	}
+	readiness := w.readiness[reference]
+	if readiness.IsTrivial() {
+		return nil
+	}
+
+	// Set up a context to enforce the timeout.
+	ctx, cancel := context.WithTimeout(context.Background(), readiness.EffectiveTimeout())
+	defer cancel()
+
+	// Wait for the readiness condition to be satisfied.
+	fmt.Printf("Waiting for %s session %s to become ready...\n", reference.Kind, reference.Name)
+	var err error
+	if reference.Kind == project.SessionKindForwarding {
+		err = waitForForwardingCondition(ctx, w.daemonConnection, identifier, readiness.Condition)
+	} else {
+		err = waitForSynchronizationCondition(ctx, w.daemonConnection, identifier, readiness.Condition)
+	}
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return errReadinessTimeout
+		}
+		return err
+	}
+
+	// Wait for the readiness command (if any) to succeed.
+	if readiness.Command != "" {
+		for {
+			if probeInShell(ctx, readiness.Command) == nil {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				return errReadinessTimeout
+			case <-time.After(readinessCommandRetryInterval):
+			}
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// waitForSynchronizationCondition waits for a synchronization session to
+// satisfy a readiness condition.
+func waitForSynchronizationCondition(
+	ctx context.Context,
+	daemonConnection *grpc.ClientConn,
+	identifier string,
+	condition project.ReadinessCondition,
+) error {
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.ListRequest{
+		Selection: &selection.Selection{Specifications: []string{identifier}},
+	}
+	for {
+		// Perform a (blocking) list operation.
+		response, err := synchronizationService.List(ctx, request)
+		if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid list response received: %w", err)
+		} else if len(response.SessionStates) != 1 {
+			return errors.New("invalid list response session count")
+		}
+
+		// Check the condition.
+		if satisfied, err := synchronizationConditionSatisfied(response.SessionStates[0], condition); err != nil {
+			return err
+		} else if satisfied {
+			return nil
+		}
+
+		// Wait for the next state change.
+		request.PreviousStateIndex = response.StateIndex
+	}
+}
+
+// waitForForwardingCondition waits for a forwarding session to satisfy a
+// readiness condition.
+func waitForForwardingCondition(
+	ctx context.Context,
+	daemonConnection *grpc.ClientConn,
+	identifier string,
+	condition project.ReadinessCondition,
+) error {
+	forwardingService := forwardingsvc.NewForwardingClient(daemonConnection)
+	request := &forwardingsvc.ListRequest{
+		Selection: &selection.Selection{Specifications: []string{identifier}},
+	}
+	for {
+		// Perform a (blocking) list operation.
+		response, err := forwardingService.List(ctx, request)
+		if err != nil {
+			return grpcutil.PeelAwayRPCErrorLayer(err)
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid list response received: %w", err)
+		} else if len(response.SessionStates) != 1 {
+			return errors.New("invalid list response session count")
+		}
+
+		// Check the condition.
+		if satisfied, err := forwardingConditionSatisfied(response.SessionStates[0], condition); err != nil {
+			return err
+		} else if satisfied {
+			return nil
+		}
+
+		// Wait for the next state change.
+		request.PreviousStateIndex = response.StateIndex
+	}
+}
@@ -116,6 +116,11 @@ func startMain(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("unable to load configuration file: %w", err)
 	}
 
+	// Restrict sessions to those enabled by the selected profiles.
+	if err := configuration.SelectProfiles(startConfiguration.profiles); err != nil {
+		return fmt.Errorf("unable to select profiles: %w", err)
+	}
+
 	// Unless disabled, attempt to load configuration from the global
 	// configuration file and use it as the base for our core session
 	// configurations.
@@ -148,12 +153,14 @@ func startMain(_ *cobra.Command, _ []string) error {
 
 	// Extract and validate forwarding defaults.
 	var defaultSource, defaultDestination string
+	var defaultReadinessForwarding project.Readiness
 	defaultConfigurationForwarding := &forwarding.Configuration{}
 	defaultConfigurationSource := &forwarding.Configuration{}
 	defaultConfigurationDestination := &forwarding.Configuration{}
 	if defaults, ok := configuration.Forwarding["defaults"]; ok {
 		defaultSource = defaults.Source
 		defaultDestination = defaults.Destination
+		defaultReadinessForwarding = defaults.Readiness
 		defaultConfigurationForwarding = defaults.Configuration.ToInternal()
 		if err := defaultConfigurationForwarding.EnsureValid(false); err != nil {
 			return fmt.Errorf("invalid default forwarding configuration: %w", err)
@@ -171,6 +178,7 @@ func startMain(_ *cobra.Command, _ []string) error {
 	// Extract and validate synchronization defaults.
 	var defaultAlpha, defaultBeta string
 	var defaultFlushOnCreate project.FlushOnCreateBehavior
+	var defaultReadinessSynchronization project.Readiness
 	defaultConfigurationSynchronization := &synchronization.Configuration{}
 	defaultConfigurationAlpha := &synchronization.Configuration{}
 	defaultConfigurationBeta := &synchronization.Configuration{}
@@ -178,6 +186,7 @@ func startMain(_ *cobra.Command, _ []string) error {
 		defaultAlpha = defaults.Alpha
 		defaultBeta = defaults.Beta
 		defaultFlushOnCreate = defaults.FlushOnCreate
+		defaultReadinessSynchronization = defaults.Readiness
 		defaultConfigurationSynchronization = defaults.Configuration.ToInternal()
 		if err := defaultConfigurationSynchronization.EnsureValid(false); err != nil {
 			return fmt.Errorf("invalid default synchronization configuration: %w", err)
@@ -202,8 +211,23 @@ func startMain(_ *cobra.Command, _ []string) error {
 		defaultConfigurationSynchronization,
 	)
 
+	// Compute the order in which sessions should be created.
+	creationOrder, err := configuration.DependencyOrder()
+	if err != nil {
+		return fmt.Errorf("invalid session dependencies: %w", err)
+	}
+
+	// Validate change hooks. They aren't run by this command, but we don't want
+	// to start a project with a configuration that can't be watched.
+	if err := configuration.EnsureChangeHooksValid(); err != nil {
+		return err
+	}
+
+	// Track the readiness criteria for each session.
+	readiness := make(map[project.SessionReference]project.Readiness)
+
 	// Generate forward session creation specifications.
-	var forwardingSpecifications []*forwardingsvc.CreationSpecification
+	forwardingSpecifications := make(map[string]*forwardingsvc.CreationSpecification)
 	for name, session := range configuration.Forwarding {
 		// Ignore defaults.
 		if name == "defaults" {
@@ -256,8 +280,17 @@ func startMain(_ *cobra.Command, _ []string) error {
 		}
 		destinationConfiguration = forwarding.MergeConfigurations(defaultConfigurationDestination, destinationConfiguration)
 
+		// Compute and validate readiness criteria.
+		sessionReadiness := session.Readiness.Merge(defaultReadinessForwarding)
+		if sessionReadiness.Condition == project.ReadinessConditionSynchronized {
+			return fmt.Errorf("invalid readiness condition for forwarding session %s: %s", name, sessionReadiness.Condition)
+		} else if sessionReadiness.Condition == project.ReadinessConditionConnected && sessionReadiness.Command == "" {
+			cmd.Warning(fmt.Sprintf("Forwarding session %s uses the connected readiness condition without a readiness command, so destination availability won't be verified", name))
+		}
+		readiness[project.SessionReference{Kind: project.SessionKindForwarding, Name: name}] = sessionReadiness
+
 		// Record the specification.
-		forwardingSpecifications = append(forwardingSpecifications, &forwardingsvc.CreationSpecification{
+		forwardingSpecifications[name] = &forwardingsvc.CreationSpecification{
 			Source:                   sourceURL,
 			Destination:              destinationURL,
 			Configuration:            configuration,
@@ -268,13 +301,13 @@ func startMain(_ *cobra.Command, _ []string) error {
 				project.LabelKey: identifier,
 			},
 			Paused: startConfiguration.paused,
-		})
+		}
 	}
 
 	// Generate synchronization session creation specifications and keep track
 	// of those that we should flush on creation.
-	var synchronizationSpecifications []*synchronizationsvc.CreationSpecification
-	var flushOnCreateByIndex []bool
+	synchronizationSpecifications := make(map[string]*synchronizationsvc.CreationSpecification)
+	flushOnCreateByName := make(map[string]bool)
 	for name, session := range configuration.Synchronization {
 		// Ignore defaults.
 		if name == "defaults" {
@@ -327,8 +360,12 @@ func startMain(_ *cobra.Command, _ []string) error {
 		}
 		betaConfiguration = synchronization.MergeConfigurations(defaultConfigurationBeta, betaConfiguration)
 
+		// Compute readiness criteria.
+		readiness[project.SessionReference{Kind: project.SessionKindSynchronization, Name: name}] =
+			session.Readiness.Merge(defaultReadinessSynchronization)
+
 		// Record the specification.
-		synchronizationSpecifications = append(synchronizationSpecifications, &synchronizationsvc.CreationSpecification{
+		synchronizationSpecifications[name] = &synchronizationsvc.CreationSpecification{
 			Alpha:              alphaURL,
 			Beta:               betaURL,
 			Configuration:      configuration,
@@ -339,13 +376,13 @@ func startMain(_ *cobra.Command, _ []string) error {
 				project.LabelKey: identifier,
 			},
 			Paused: startConfiguration.paused,
-		})
+		}
 
 		// Compute and store flush-on-creation behavior.
 		if session.FlushOnCreate.IsDefault() {
-			flushOnCreateByIndex = append(flushOnCreateByIndex, defaultFlushOnCreate.FlushOnCreate())
+			flushOnCreateByName[name] = defaultFlushOnCreate.FlushOnCreate()
 		} else {
-			flushOnCreateByIndex = append(flushOnCreateByIndex, session.FlushOnCreate.FlushOnCreate())
+			flushOnCreateByName[name] = session.FlushOnCreate.FlushOnCreate()
 		}
 	}
 
@@ -368,25 +405,48 @@ func startMain(_ *cobra.Command, _ []string) error {
 		}
 	}
 
-	// Create forwarding sessions.
-	for _, specification := range forwardingSpecifications {
-		if _, err := forward.CreateWithSpecification(daemonConnection, specification); err != nil {
-			return fmt.Errorf("unable to create forwarding session (%s): %v", specification.Name, err)
-		}
-	}
+	// Create a readiness waiter to track session readiness.
+	waiter := newReadinessWaiter(daemonConnection, readiness)
 
-	// Create synchronization sessions and track those that we should flush.
+	// Create sessions in dependency order, waiting for each session's
+	// dependencies to become ready before creating it (unless sessions are
+	// being created pre-paused, in which case they'll never become ready), and
+	// track the synchronization sessions that we should flush.
 	var sessionsToFlush []string
-	for s, specification := range synchronizationSpecifications {
-		// Perform session creation.
-		session, err := sync.CreateWithSpecification(daemonConnection, specification)
-		if err != nil {
-			return fmt.Errorf("unable to create synchronization session (%s): %v", specification.Name, err)
+	for _, reference := range creationOrder {
+		// Wait for dependencies to become ready.
+		if !startConfiguration.paused {
+			var dependsOn []string
+			if reference.Kind == project.SessionKindForwarding {
+				dependsOn = configuration.Forwarding[reference.Name].DependsOn
+			} else {
+				dependsOn = configuration.Synchronization[reference.Name].DependsOn
+			}
+			if err := waiter.waitForDependencies(configuration, dependsOn); err != nil {
+				return fmt.Errorf("unable to wait for %s session (%s) dependencies: %w", reference.Kind, reference.Name, err)
+			}
 		}
 
-		// Determine whether or not to flush this session.
-		if !startConfiguration.paused && flushOnCreateByIndex[s] {
-			sessionsToFlush = append(sessionsToFlush, session)
+		// Create the session.
+		if reference.Kind == project.SessionKindForwarding {
+			specification := forwardingSpecifications[reference.Name]
+			session, err := forward.CreateWithSpecification(daemonConnection, specification)
+			if err != nil {
+				return fmt.Errorf("unable to create forwarding session (%s): %v", specification.Name, err)
+			}
+			waiter.created(reference, session)
+		} else {
+			specification := synchronizationSpecifications[reference.Name]
+			session, err := sync.CreateWithSpecification(daemonConnection, specification)
+			if err != nil {
+				return fmt.Errorf("unable to create synchronization session (%s): %v", specification.Name, err)
+			}
+			waiter.created(reference, session)
+
+			// Determine whether or not to flush this session.
+			if !startConfiguration.paused && flushOnCreateByName[reference.Name] {
+				sessionsToFlush = append(sessionsToFlush, session)
+			}
 		}
 	}
 
@@ -398,10 +458,16 @@ func startMain(_ *cobra.Command, _ []string) error {
 		}
 	}
 
-	// Perform post-creation commands.
-	for _, command := range configuration.AfterCreate {
-		fmt.Println(">", command)
-		if err := runInShell(command); err != nil {
+	// Perform post-creation commands, waiting for their dependencies to become
+	// ready (unless sessions were created pre-paused).
+	for _, hook := range configuration.AfterCreate {
+		if !startConfiguration.paused {
+			if err := waiter.waitForDependencies(configuration, hook.DependsOn); err != nil {
+				return fmt.Errorf("unable to wait for post-create command dependencies: %w", err)
+			}
+		}
+		fmt.Println(">", hook.Command)
+		if err := runInShell(hook.Command); err != nil {
 			return fmt.Errorf("post-create command failed: %w", err)
 		}
 	}
@@ -425,6 +491,9 @@ var startConfiguration struct {
 	help bool
 	// projectFile is the path to the project file, if non-default.
 	projectFile string
+	// profiles are the names of the profiles whose sessions should be
+	// created in addition to those that don't specify any profiles.
+	profiles []string
 	// paused indicates whether or not to create sessions in a pre-paused state.
 	paused bool
 	// noGlobalConfiguration specifies whether or not the global configuration
@@ -446,6 +515,9 @@ func init() {
 	// Wire up project file flags.
 	flags.StringVarP(&startConfiguration.projectFile, "project-file", "f", "", "Specify project file")
 
+	// Wire up profile flags.
+	flags.StringSliceVar(&startConfiguration.profiles, "profile", nil, "Enable sessions in the specified profiles")
+
 	// Wire up paused flags.
 	flags.BoolVarP(&startConfiguration.paused, "paused", "p", false, "Create the session pre-paused")
 
@@ -0,0 +1,454 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/identifier"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	forwardingsvc "github.com/mutagen-io/mutagen/pkg/service/forwarding"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+const (
+	// statusExitCodeNotRunning is the exit code used by the status command if
+	// the project isn't running.
+	statusExitCodeNotRunning = 2
+	// statusExitCodeNotReady is the exit code used by the status command if
+	// the project is running but at least one session isn't yet ready (e.g.
+	// because it's connecting or synchronizing).
+	statusExitCodeNotReady = 3
+	// statusExitCodeDegraded is the exit code used by the status command if at
+	// least one session requires attention (e.g. because it's paused, halted,
+	// or has conflicts, problems, or errors).
+	statusExitCodeDegraded = 4
+
+	// statusPollingInterval is the interval at which session status is polled
+	// when waiting for project readiness.
+	statusPollingInterval = time.Second
+)
+
+// sessionHealth represents the health of a project session.
+type sessionHealth uint8
+
+const (
+	// sessionHealthReady indicates that a session is ready.
+	sessionHealthReady sessionHealth = iota
+	// sessionHealthNotReady indicates that a session isn't yet ready, but is
+	// expected to become ready without intervention.
+	sessionHealthNotReady
+	// sessionHealthDegraded indicates that a session requires attention.
+	sessionHealthDegraded
+)
+
+// description returns a human-readable description of the session health.
+func (h sessionHealth) description() string {
+	switch h {
+	case sessionHealthReady:
+		return "Ready"
+	case sessionHealthNotReady:
+		return "Not ready"
+	case sessionHealthDegraded:
+		return "Degraded"
+	default:
+		return "Unknown"
+	}
+}
+
+// sessionStatus is the evaluated status of a project session.
+type sessionStatus struct {
+	// name is the session name.
+	name string
+	// health is the session health.
+	health sessionHealth
+	// reason is a human-readable explanation of the health, if not ready.
+	reason string
+	// stalled indicates that the session can't become ready without
+	// intervention (e.g. because it's paused, halted, or has conflicts).
+	stalled bool
+}
+
+// forwardingSessionStatus evaluates the status of a forwarding session.
+func forwardingSessionStatus(state *forwarding.State) sessionStatus {
+	// Create the result.
+	result := sessionStatus{name: state.Session.Name}
+	if result.name == "" {
+		result.name = state.Session.Identifier
+	}
+
+	// Evaluate the session state.
+	if state.Session.Paused {
+		result.health, result.reason, result.stalled = sessionHealthDegraded, "paused", true
+	} else if state.Suspension != "" {
+		result.health, result.reason = sessionHealthNotReady, "suspended: "+state.Suspension
+	} else if state.Status != forwarding.Status_ForwardingConnections {
+		result.health, result.reason = sessionHealthNotReady, strings.ToLower(state.Status.Description())
+		if state.LastError != "" {
+			result.reason += ": " + state.LastError
+		}
+	}
+
+	// Done.
+	return result
+}
+
+// synchronizationSessionStatus evaluates the status of a synchronization
+// session.
+func synchronizationSessionStatus(state *synchronization.State) sessionStatus {
+	// Create the result.
+	result := sessionStatus{name: state.Session.Name}
+	if result.name == "" {
+		result.name = state.Session.Identifier
+	}
+
+	// Count problems.
+	problems := len(state.AlphaState.ScanProblems) + int(state.AlphaState.ExcludedScanProblems) +
+		len(state.AlphaState.TransitionProblems) + int(state.AlphaState.ExcludedTransitionProblems) +
+		len(state.BetaState.ScanProblems) + int(state.BetaState.ExcludedScanProblems) +
+		len(state.BetaState.TransitionProblems) + int(state.BetaState.ExcludedTransitionProblems)
+
+	// Evaluate the session state. Conditions that require intervention take
+	// precedence, though we treat disconnection as transient (and thus check
+	// it before the last error, which will typically reflect the disconnect).
+	// Sessions that are paused, halted, or have conflicts are considered
+	// stalled, since they won't become ready without intervention.
+	if state.Session.Paused {
+		result.health, result.reason, result.stalled = sessionHealthDegraded, "paused", true
+	} else if state.Status.IsHalted() {
+		result.health, result.reason, result.stalled = sessionHealthDegraded, strings.ToLower(state.Status.Description()), true
+	} else if conflicts := uint64(len(state.Conflicts)) + state.ExcludedConflicts; conflicts > 0 {
+		result.health, result.reason, result.stalled = sessionHealthDegraded, fmt.Sprintf("%d conflict(s)", conflicts), true
+	} else if problems > 0 {
+		result.health, result.reason = sessionHealthDegraded, fmt.Sprintf("%d problem(s)", problems)
+	} else if state.Suspension != "" {
+		result.health, result.reason = sessionHealthNotReady, "suspended: "+state.Suspension
+	} else if !state.AlphaState.Connected || !state.BetaState.Connected {
+		result.health, result.reason = sessionHealthNotReady, strings.ToLower(state.Status.Description())
+	} else if state.LastError != "" {
+		result.health, result.reason = sessionHealthDegraded, "last synchronization cycle failed: "+state.LastError
+	} else if state.SuccessfulCycles == 0 {
+		result.health, result.reason = sessionHealthNotReady, "initial synchronization incomplete"
+	} else if state.Status != synchronization.Status_Watching {
+		result.health, result.reason = sessionHealthNotReady, strings.ToLower(state.Status.Description())
+	}
+
+	// Done.
+	return result
+}
+
+// projectStatus is the evaluated status of a project.
+type projectStatus struct {
+	// forwarding are the statuses of forwarding sessions.
+	forwarding []sessionStatus
+	// synchronization are the statuses of synchronization sessions.
+	synchronization []sessionStatus
+	// health is the overall project health, which is the worst health of
+	// any session.
+	health sessionHealth
+	// stalled indicates whether or not any session is stalled.
+	stalled bool
+}
+
+// empty returns whether or not the project status includes no sessions, in
+// which case the project isn't running.
+func (s *projectStatus) empty() bool {
+	return len(s.forwarding) == 0 && len(s.synchronization) == 0
+}
+
+// evaluateProjectStatus queries and evaluates the status of the sessions
+// matching the specified selection.
+func evaluateProjectStatus(daemonConnection *grpc.ClientConn, selection *selection.Selection) (*projectStatus, error) {
+	// Create the result.
+	result := &projectStatus{}
+
+	// Query and evaluate forwarding sessions.
+	forwardingService := forwardingsvc.NewForwardingClient(daemonConnection)
+	forwardingResponse, err := forwardingService.List(context.Background(), &forwardingsvc.ListRequest{
+		Selection: selection,
+	})
+	if err != nil {
+		return nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = forwardingResponse.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid forwarding list response received: %w", err)
+	}
+	for _, state := range forwardingResponse.SessionStates {
+		status := forwardingSessionStatus(state)
+		if status.health > result.health {
+			result.health = status.health
+		}
+		result.stalled = result.stalled || status.stalled
+		result.forwarding = append(result.forwarding, status)
+	}
+
+	// Query and evaluate synchronization sessions.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	synchronizationResponse, err := synchronizationService.List(context.Background(), &synchronizationsvc.ListRequest{
+		Selection: selection,
+	})
+	if err != nil {
+		return nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = synchronizationResponse.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid synchronization list response received: %w", err)
+	}
+	for _, state := range synchronizationResponse.SessionStates {
+		status := synchronizationSessionStatus(state)
+		if status.health > result.health {
+			result.health = status.health
+		}
+		result.stalled = result.stalled || status.stalled
+		result.synchronization = append(result.synchronization, status)
+	}
+
+	// Success.
+	return result, nil
+}
+
+// print prints the project status.
+func (s *projectStatus) print() {
+	// Create a closure to print session statuses.
+	printSessions := func(title string, statuses []sessionStatus) {
+		fmt.Println(title)
+		if len(statuses) == 0 {
+			fmt.Println("\tNone")
+		}
+		for _, status := range statuses {
+			if status.reason != "" {
+				fmt.Printf("\t%s: %s (%s)\n", status.name, status.health.description(), status.reason)
+			} else {
+				fmt.Printf("\t%s: %s\n", status.name, status.health.description())
+			}
+		}
+	}
+
+	// Print session statuses.
+	printSessions("Forwarding sessions:", s.forwarding)
+	printSessions("Synchronization sessions:", s.synchronization)
+
+	// Print the overall status.
+	fmt.Println("Project status:", s.health.description())
+}
+
+// parseWaitTimeout parses a wait timeout specification, which may be either a
+// duration (e.g. "90s") or an integer number of seconds. A zero value
+// indicates no timeout.
+func parseWaitTimeout(specification string) (time.Duration, error) {
+	if seconds, err := strconv.ParseUint(specification, 10, 32); err == nil {
+		return time.Duration(seconds) * time.Second, nil
+	}
+	timeout, err := time.ParseDuration(specification)
+	if err != nil {
+		return 0, err
+	} else if timeout < 0 {
+		return 0, errors.New("negative timeout")
+	}
+	return timeout, nil
+}
+
+// statusMain is the entry point for the status command.
+func statusMain(command *cobra.Command, _ []string) error {
+	// Determine whether or not to wait for readiness and parse any timeout.
+	wait := command.Flags().Changed("wait")
+	var timeout time.Duration
+	if wait {
+		if t, err := parseWaitTimeout(statusConfiguration.wait); err != nil {
+			return fmt.Errorf("invalid wait timeout: %w", err)
+		} else {
+			timeout = t
+		}
+	}
+
+	// Compute the name of the configuration file and ensure that our working
+	// directory is that in which the file resides. This is required for
+	// relative paths (including relative synchronization paths and relative
+	// Unix Domain Socket paths) to be resolved relative to the project
+	// configuration file.
+	configurationFileName := project.DefaultConfigurationFileName
+	if statusConfiguration.projectFile != "" {
+		var directory string
+		directory, configurationFileName = filepath.Split(statusConfiguration.projectFile)
+		if directory != "" {
+			if err := os.Chdir(directory); err != nil {
+				return fmt.Errorf("unable to switch to target directory: %w", err)
+			}
+		}
+	}
+
+	// Compute the lock path.
+	lockPath := configurationFileName + project.LockFileExtension
+
+	// Track whether or not we should remove the lock file on return.
+	var removeLockFileOnReturn bool
+
+	// Create a locker and defer its closure and potential removal. On Windows
+	// systems, we have to handle this removal after the file is closed.
+	locker, err := locking.NewLocker(lockPath, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create project locker: %w", err)
+	}
+	defer func() {
+		locker.Close()
+		if removeLockFileOnReturn && runtime.GOOS == "windows" {
+			os.Remove(lockPath)
+		}
+	}()
+
+	// Acquire the project lock. Unlike other project commands, we only hold
+	// the lock while reading the project identifier, since waiting for
+	// readiness may take an arbitrary amount of time and we don't want to block
+	// other project commands in the meantime.
+	if err := locker.Lock(true); err != nil {
+		return fmt.Errorf("unable to acquire project lock: %w", err)
+	}
+
+	// Read the project identifier from the lock file and release the lock. If
+	// the lock file is empty, then we can assume that we created it when we
+	// created the lock and just remove it. On Windows systems, we can't remove
+	// the lock file while it's open, so we handle removal for Windows systems
+	// after we close the lock file (see above).
+	buffer := &bytes.Buffer{}
+	length, err := buffer.ReadFrom(locker)
+	if err == nil && length == 0 {
+		removeLockFileOnReturn = true
+		if runtime.GOOS != "windows" {
+			os.Remove(lockPath)
+		}
+	}
+	locker.Unlock()
+	if err != nil {
+		return fmt.Errorf("unable to read project lock: %w", err)
+	} else if length == 0 {
+		return &cmd.ExitCodeError{Code: statusExitCodeNotRunning, Err: errors.New("project not running")}
+	}
+	projectIdentifier := buffer.String()
+
+	// Ensure that the project identifier is valid.
+	if !identifier.IsValid(projectIdentifier) {
+		return errors.New("invalid project identifier found in project lock")
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Compute the selection that we're going to use to query sessions.
+	selection := &selection.Selection{
+		LabelSelector: fmt.Sprintf("%s=%s", project.LabelKey, projectIdentifier),
+	}
+
+	// Evaluate the project status, waiting for readiness if requested.
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	var status *projectStatus
+	var timedOut bool
+	for {
+		// Evaluate the project status.
+		status, err = evaluateProjectStatus(daemonConnection, selection)
+		if err != nil {
+			return fmt.Errorf("unable to evaluate project status: %w", err)
+		}
+
+		// Check whether or not we're done. We don't bother waiting if the
+		// project has no sessions or if any session is stalled, since neither
+		// condition will resolve without intervention.
+		if !wait || status.health == sessionHealthReady || status.empty() || status.stalled {
+			break
+		} else if !deadline.IsZero() && time.Now().After(deadline) {
+			timedOut = true
+			break
+		}
+
+		// Wait before polling again.
+		time.Sleep(statusPollingInterval)
+	}
+
+	// Print the project status.
+	status.print()
+
+	// If there are no sessions, then the project isn't running.
+	if status.empty() {
+		return &cmd.ExitCodeError{Code: statusExitCodeNotRunning, Err: errors.New("project not running (no sessions found)")}
+	}
+
+	// Convert the project health to a result.
+	var message string
+	switch status.health {
+	case sessionHealthReady:
+		return nil
+	case sessionHealthNotReady:
+		message = "project not ready"
+	case sessionHealthDegraded:
+		message = "project degraded"
+	}
+	if timedOut {
+		message = "timed out waiting for readiness: " + message
+	}
+	code := statusExitCodeNotReady
+	if status.health == sessionHealthDegraded {
+		code = statusExitCodeDegraded
+	}
+	return &cmd.ExitCodeError{Code: code, Err: errors.New(message)}
+}
+
+// statusCommand is the status command.
+var statusCommand = &cobra.Command{
+	Use:          "status",
+	Short:        "Show aggregate project health",
+	Args:         cmd.DisallowArguments,
+	RunE:         statusMain,
+	SilenceUsage: true,
+}
+
+// statusConfiguration stores configuration for the status command.
+var statusConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// projectFile is the path to the project file, if non-default.
+	projectFile string
+	// wait is the wait timeout specification. It is only used if the wait
+	// flag is specified.
+	wait string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := statusCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&statusConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up project file flags.
+	flags.StringVarP(&statusConfiguration.projectFile, "project-file", "f", "", "Specify project file")
+
+	// Wire up wait flags.
+	flags.StringVar(&statusConfiguration.wait, "wait", "", "Wait for the project to become ready, with an optional timeout (e.g. --wait=2m)")
+	flags.Lookup("wait").NoOptDefVal = "0"
+}
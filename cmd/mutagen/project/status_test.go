@@ -0,0 +1,167 @@
+package project
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// TestForwardingSessionStatus tests forwardingSessionStatus.
+func TestForwardingSessionStatus(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		state    *forwarding.State
+		expected sessionStatus
+	}{
+		{
+			&forwarding.State{
+				Session: &forwarding.Session{Identifier: "identifier", Name: "web"},
+				Status:  forwarding.Status_ForwardingConnections,
+			},
+			sessionStatus{name: "web"},
+		},
+		{
+			&forwarding.State{
+				Session: &forwarding.Session{Identifier: "identifier"},
+				Status:  forwarding.Status_ForwardingConnections,
+			},
+			sessionStatus{name: "identifier"},
+		},
+		{
+			&forwarding.State{
+				Session: &forwarding.Session{Name: "web", Paused: true},
+			},
+			sessionStatus{name: "web", health: sessionHealthDegraded, reason: "paused", stalled: true},
+		},
+		{
+			&forwarding.State{
+				Session:    &forwarding.Session{Name: "web"},
+				Status:     forwarding.Status_Disconnected,
+				Suspension: "destination unreachable",
+			},
+			sessionStatus{name: "web", health: sessionHealthNotReady, reason: "suspended: destination unreachable"},
+		},
+		{
+			&forwarding.State{
+				Session:   &forwarding.Session{Name: "web"},
+				Status:    forwarding.Status_ConnectingDestination,
+				LastError: "connection refused",
+			},
+			sessionStatus{name: "web", health: sessionHealthNotReady, reason: "connecting to destination: connection refused"},
+		},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if status := forwardingSessionStatus(testCase.state); status != testCase.expected {
+			t.Errorf("test case %d: status does not match expected: %+v != %+v", i, status, testCase.expected)
+		}
+	}
+}
+
+// TestSynchronizationSessionStatus tests synchronizationSessionStatus.
+func TestSynchronizationSessionStatus(t *testing.T) {
+	// Create a closure to generate session states.
+	newState := func(status synchronization.Status, paused bool, cycles uint64) *synchronization.State {
+		return &synchronization.State{
+			Session:          &synchronization.Session{Identifier: "identifier", Name: "code", Paused: paused},
+			Status:           status,
+			SuccessfulCycles: cycles,
+			AlphaState:       &synchronization.EndpointState{Connected: true},
+			BetaState:        &synchronization.EndpointState{Connected: true},
+		}
+	}
+
+	// Create states with specific conditions.
+	unnamed := newState(synchronization.Status_Watching, false, 1)
+	unnamed.Session.Name = ""
+	conflicted := newState(synchronization.Status_Watching, false, 1)
+	conflicted.ExcludedConflicts = 2
+	conflicted.Conflicts = []*core.Conflict{{}}
+	problematic := newState(synchronization.Status_Watching, false, 1)
+	problematic.BetaState.TransitionProblems = []*core.Problem{{Path: "file", Error: "error"}}
+	suspended := newState(synchronization.Status_Disconnected, false, 1)
+	suspended.Suspension = "disk space low"
+	disconnected := newState(synchronization.Status_ConnectingBeta, false, 1)
+	disconnected.BetaState.Connected = false
+	disconnected.LastError = "connection lost"
+	failed := newState(synchronization.Status_Watching, false, 1)
+	failed.LastError = "transition failed"
+
+	// Define test cases.
+	testCases := []struct {
+		state    *synchronization.State
+		expected sessionStatus
+	}{
+		{newState(synchronization.Status_Watching, false, 1), sessionStatus{name: "code"}},
+		{unnamed, sessionStatus{name: "identifier"}},
+		{
+			newState(synchronization.Status_Watching, true, 1),
+			sessionStatus{name: "code", health: sessionHealthDegraded, reason: "paused", stalled: true},
+		},
+		{
+			newState(synchronization.Status_HaltedOnRootDeletion, false, 1),
+			sessionStatus{name: "code", health: sessionHealthDegraded, reason: "halted due to root deletion", stalled: true},
+		},
+		{conflicted, sessionStatus{name: "code", health: sessionHealthDegraded, reason: "3 conflict(s)", stalled: true}},
+		{problematic, sessionStatus{name: "code", health: sessionHealthDegraded, reason: "1 problem(s)"}},
+		{suspended, sessionStatus{name: "code", health: sessionHealthNotReady, reason: "suspended: disk space low"}},
+		{disconnected, sessionStatus{name: "code", health: sessionHealthNotReady, reason: "connecting to beta"}},
+		{
+			failed,
+			sessionStatus{name: "code", health: sessionHealthDegraded, reason: "last synchronization cycle failed: transition failed"},
+		},
+		{
+			newState(synchronization.Status_Watching, false, 0),
+			sessionStatus{name: "code", health: sessionHealthNotReady, reason: "initial synchronization incomplete"},
+		},
+		{
+			newState(synchronization.Status_Scanning, false, 1),
+			sessionStatus{name: "code", health: sessionHealthNotReady, reason: "scanning files"},
+		},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if status := synchronizationSessionStatus(testCase.state); status != testCase.expected {
+			t.Errorf("test case %d: status does not match expected: %+v != %+v", i, status, testCase.expected)
+		}
+	}
+}
+
+// TestParseWaitTimeout tests parseWaitTimeout.
+func TestParseWaitTimeout(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		specification string
+		expected      time.Duration
+		expectFailure bool
+	}{
+		{"0", 0, false},
+		{"90", 90 * time.Second, false},
+		{"90s", 90 * time.Second, false},
+		{"2m", 2 * time.Minute, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"-5s", 0, true},
+		{"-5", 0, true},
+		{"", 0, true},
+		{"soon", 0, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		timeout, err := parseWaitTimeout(testCase.specification)
+		if testCase.expectFailure {
+			if err == nil {
+				t.Errorf("test case %d: parsing succeeded unexpectedly", i)
+			}
+		} else if err != nil {
+			t.Errorf("test case %d: parsing failed unexpectedly: %v", i, err)
+		} else if timeout != testCase.expected {
+			t.Errorf("test case %d: timeout does not match expected: %v != %v", i, timeout, testCase.expected)
+		}
+	}
+}
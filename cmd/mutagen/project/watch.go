@@ -0,0 +1,325 @@
+package project
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/execution"
+	"github.com/mutagen-io/mutagen/pkg/filesystem/locking"
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/identifier"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// changeTracker tracks the synchronization cycles that have been processed for
+// each synchronization session.
+type changeTracker struct {
+	// lastCycles maps session identifiers to the last processed cycle.
+	lastCycles map[string]uint64
+	// lastChanges maps session identifiers to the most recent change record
+	// that had been recorded when the session's state was last processed, if
+	// any. It's used to detect session state resets.
+	lastChanges map[string]*synchronization.CycleChanges
+}
+
+// newChangeTracker creates a new change tracker.
+func newChangeTracker() *changeTracker {
+	return &changeTracker{
+		lastCycles:  make(map[string]uint64),
+		lastChanges: make(map[string]*synchronization.CycleChanges),
+	}
+}
+
+// stateReset determines whether or not a session's state has been reset (which
+// occurs when a session reconnects) since a previously processed state whose
+// last processed cycle was last and whose most recent change record was
+// previous. A reset is detected if the previous record is no longer present (or
+// has changed) even though the recorded change history hasn't been truncated
+// past it, or if there was no previous record but the history now contains
+// records for cycles that had already been processed. Resets that are followed
+// by enough cycles to truncate the history can't be detected using the change
+// history, but in that case the cycle count will generally have decreased.
+func stateReset(state *synchronization.State, last uint64, previous *synchronization.CycleChanges) bool {
+	// If there was no previous record, then any record for an already
+	// processed cycle indicates a reset.
+	if previous == nil {
+		return len(state.RecentChanges) > 0 && state.RecentChanges[0].Cycle <= last
+	}
+
+	// Look for the previous record.
+	for _, changes := range state.RecentChanges {
+		if changes.Cycle == previous.Cycle {
+			return !proto.Equal(changes, previous)
+		}
+	}
+
+	// If the previous record wasn't found, then the state has been reset
+	// unless the history has been truncated past the previous record.
+	return len(state.RecentChanges) == 0 || state.RecentChanges[0].Cycle < previous.Cycle
+}
+
+// triggeredHooks processes a synchronization session state and returns the
+// names of the change hooks (in sorted order) that are triggered by changes
+// made since the session's state was last processed. The first time that a
+// session is encountered, its existing changes are ignored.
+func (t *changeTracker) triggeredHooks(state *synchronization.State, hooks map[string]project.ChangeHook) []string {
+	// Update the last processed cycle and change record and handle sessions
+	// that haven't been seen before.
+	identifier := state.Session.Identifier
+	last, seen := t.lastCycles[identifier]
+	previous := t.lastChanges[identifier]
+	t.lastCycles[identifier] = state.SuccessfulCycles
+	if count := len(state.RecentChanges); count > 0 {
+		t.lastChanges[identifier] = state.RecentChanges[count-1]
+	} else {
+		delete(t.lastChanges, identifier)
+	}
+	if !seen {
+		return nil
+	}
+
+	// If the session's state has been reset since it was last processed (e.g.
+	// due to reconnection), then all of its recorded changes are new. This is
+	// the case if the cycle count has decreased or if the change history no
+	// longer contains the previously processed changes.
+	if state.SuccessfulCycles < last || stateReset(state, last, previous) {
+		last = 0
+	}
+
+	// Aggregate changes made since the last processed cycle. If the changes
+	// for some cycles might have been discarded from the state before we saw
+	// them, then treat the change lists for both endpoints as truncated.
+	var alpha, beta []string
+	var alphaTruncated, betaTruncated bool
+	if len(state.RecentChanges) == synchronization.MaximumRecentChangeCycles &&
+		state.RecentChanges[0].Cycle > last+1 {
+		alphaTruncated, betaTruncated = true, true
+	}
+	for _, changes := range state.RecentChanges {
+		if changes.Cycle <= last {
+			continue
+		}
+		alpha = append(alpha, changes.AlphaChanges...)
+		alphaTruncated = alphaTruncated || changes.ExcludedAlphaChanges > 0
+		beta = append(beta, changes.BetaChanges...)
+		betaTruncated = betaTruncated || changes.ExcludedBetaChanges > 0
+	}
+	if len(alpha) == 0 && len(beta) == 0 && !alphaTruncated && !betaTruncated {
+		return nil
+	}
+
+	// Determine which hooks have been triggered.
+	var result []string
+	for name, hook := range hooks {
+		if hook.Session == state.Session.Name &&
+			hook.Triggered(alpha, alphaTruncated, beta, betaTruncated) {
+			result = append(result, name)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// runChangeHook runs a change hook on behalf of the specified session.
+func runChangeHook(logger *logging.Logger, name string, hook project.ChangeHook, session *synchronization.Session) error {
+	// Run the hook command in the appropriate location.
+	fmt.Printf("Running change hook %s (triggered by session %s)\n", name, session.Name)
+	var exitCode int
+	var err error
+	switch hook.RunOn {
+	case project.HookLocationDefault, project.HookLocationLocal:
+		return runInShell(hook.Command)
+	case project.HookLocationAlpha:
+		exitCode, err = execution.Run(logger, session.Alpha, "", hook.Command, nil, os.Stdout, os.Stderr)
+	case project.HookLocationBeta:
+		exitCode, err = execution.Run(logger, session.Beta, "", hook.Command, nil, os.Stdout, os.Stderr)
+	default:
+		return errors.New("unknown hook location")
+	}
+
+	// Convert the result to an error.
+	if err != nil {
+		return err
+	} else if exitCode != 0 {
+		return fmt.Errorf("exit status %d", exitCode)
+	}
+	return nil
+}
+
+// watchMain is the entry point for the watch command.
+func watchMain(_ *cobra.Command, _ []string) error {
+	// Compute the name of the configuration file and ensure that our working
+	// directory is that in which the file resides. This is required for
+	// relative paths (including relative synchronization paths and relative
+	// Unix Domain Socket paths) to be resolved relative to the project
+	// configuration file.
+	configurationFileName := project.DefaultConfigurationFileName
+	if watchConfiguration.projectFile != "" {
+		var directory string
+		directory, configurationFileName = filepath.Split(watchConfiguration.projectFile)
+		if directory != "" {
+			if err := os.Chdir(directory); err != nil {
+				return fmt.Errorf("unable to switch to target directory: %w", err)
+			}
+		}
+	}
+
+	// Compute the lock path.
+	lockPath := configurationFileName + project.LockFileExtension
+
+	// Track whether or not we should remove the lock file on return.
+	var removeLockFileOnReturn bool
+
+	// Create a locker and defer its closure and potential removal. On Windows
+	// systems, we have to handle this removal after the file is closed.
+	locker, err := locking.NewLocker(lockPath, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to create project locker: %w", err)
+	}
+	defer func() {
+		locker.Close()
+		if removeLockFileOnReturn && runtime.GOOS == "windows" {
+			os.Remove(lockPath)
+		}
+	}()
+
+	// Acquire the project lock. As with the status command, we only hold the
+	// lock while reading the project identifier, since watching continues
+	// indefinitely and we don't want to block other project commands.
+	if err := locker.Lock(true); err != nil {
+		return fmt.Errorf("unable to acquire project lock: %w", err)
+	}
+
+	// Read the project identifier from the lock file and release the lock. If
+	// the lock file is empty, then we can assume that we created it when we
+	// created the lock and just remove it. On Windows systems, we can't remove
+	// the lock file while it's open, so we handle removal for Windows systems
+	// after we close the lock file (see above).
+	buffer := &bytes.Buffer{}
+	length, err := buffer.ReadFrom(locker)
+	if err == nil && length == 0 {
+		removeLockFileOnReturn = true
+		if runtime.GOOS != "windows" {
+			os.Remove(lockPath)
+		}
+	}
+	locker.Unlock()
+	if err != nil {
+		return fmt.Errorf("unable to read project lock: %w", err)
+	} else if length == 0 {
+		return errors.New("project not running")
+	}
+	projectIdentifier := buffer.String()
+
+	// Ensure that the project identifier is valid.
+	if !identifier.IsValid(projectIdentifier) {
+		return errors.New("invalid project identifier found in project lock")
+	}
+
+	// Load the configuration file and validate change hooks.
+	configuration, err := project.LoadConfiguration(configurationFileName)
+	if err != nil {
+		return fmt.Errorf("unable to load configuration file: %w", err)
+	} else if err = configuration.EnsureChangeHooksValid(); err != nil {
+		return err
+	} else if len(configuration.OnChange) == 0 {
+		return errors.New("no change hooks defined")
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Create a synchronization service client.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+
+	// Create a logger for agent connections. Agent failures are reported via
+	// returned errors, so we don't need any log output.
+	logger := logging.NewLogger(logging.LevelDisabled, os.Stderr)
+
+	// Monitor project synchronization sessions and run change hooks as they're
+	// triggered.
+	tracker := newChangeTracker()
+	request := &synchronizationsvc.ListRequest{
+		Selection: &selection.Selection{
+			LabelSelector: fmt.Sprintf("%s=%s", project.LabelKey, projectIdentifier),
+		},
+	}
+	fmt.Println("Watching for changes...")
+	for {
+		// Perform a (blocking) list operation.
+		response, err := synchronizationService.List(context.Background(), request)
+		if err != nil {
+			return fmt.Errorf("unable to list synchronization sessions: %w", grpcutil.PeelAwayRPCErrorLayer(err))
+		} else if err = response.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid list response received: %w", err)
+		} else if len(response.SessionStates) == 0 {
+			return errors.New("no project synchronization sessions found")
+		}
+
+		// Run any triggered hooks. Hook failures are reported but don't stop
+		// watching.
+		for _, state := range response.SessionStates {
+			for _, name := range tracker.triggeredHooks(state, configuration.OnChange) {
+				if err := runChangeHook(logger, name, configuration.OnChange[name], state.Session); err != nil {
+					cmd.Error(fmt.Errorf("change hook %s failed: %w", name, err))
+				}
+			}
+		}
+
+		// Wait for the next state change.
+		request.PreviousStateIndex = response.StateIndex
+	}
+}
+
+// watchCommand is the watch command.
+var watchCommand = &cobra.Command{
+	Use:          "watch",
+	Short:        "Run change hooks as synchronization changes occur",
+	Args:         cmd.DisallowArguments,
+	RunE:         watchMain,
+	SilenceUsage: true,
+}
+
+// watchConfiguration stores configuration for the watch command.
+var watchConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// projectFile is the path to the project file, if non-default.
+	projectFile string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := watchCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&watchConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up project file flags.
+	flags.StringVarP(&watchConfiguration.projectFile, "project-file", "f", "", "Specify project file")
+}
@@ -0,0 +1,123 @@
+package project
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/project"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// testSessionState creates a synchronization session state with the specified
+// cycle count and change history.
+func testSessionState(cycles uint64, changes ...*synchronization.CycleChanges) *synchronization.State {
+	return &synchronization.State{
+		Session:          &synchronization.Session{Identifier: "identifier", Name: "code"},
+		SuccessfulCycles: cycles,
+		RecentChanges:    changes,
+	}
+}
+
+// TestChangeTrackerTriggeredHooks tests changeTracker.triggeredHooks across
+// sequences of session states.
+func TestChangeTrackerTriggeredHooks(t *testing.T) {
+	// Set up hooks.
+	hooks := map[string]project.ChangeHook{
+		"build": {Session: "code", Paths: []string{"src/**"}, Command: "make"},
+		"docs":  {Session: "code", Paths: []string{"docs/**"}, Command: "make docs"},
+		"other": {Session: "other", Paths: []string{"**"}, Command: "true"},
+	}
+
+	// Define test cases.
+	testCases := []struct {
+		description string
+		states      []*synchronization.State
+		expected    [][]string
+	}{
+		{
+			"new changes",
+			[]*synchronization.State{
+				testSessionState(1),
+				testSessionState(2, &synchronization.CycleChanges{Cycle: 2, AlphaChanges: []string{"src/main.c"}}),
+				testSessionState(3,
+					&synchronization.CycleChanges{Cycle: 2, AlphaChanges: []string{"src/main.c"}},
+					&synchronization.CycleChanges{Cycle: 3, BetaChanges: []string{"docs/index.md"}},
+				),
+			},
+			[][]string{nil, {"build"}, {"docs"}},
+		},
+		{
+			"existing changes ignored",
+			[]*synchronization.State{
+				testSessionState(2, &synchronization.CycleChanges{Cycle: 2, AlphaChanges: []string{"src/main.c"}}),
+				testSessionState(3, &synchronization.CycleChanges{Cycle: 2, AlphaChanges: []string{"src/main.c"}}),
+			},
+			[][]string{nil, nil},
+		},
+		{
+			"reset with decreased cycle count",
+			[]*synchronization.State{
+				testSessionState(5),
+				testSessionState(1, &synchronization.CycleChanges{Cycle: 1, AlphaChanges: []string{"src/main.c"}}),
+			},
+			[][]string{nil, {"build"}},
+		},
+		{
+			"reset without previous changes",
+			[]*synchronization.State{
+				testSessionState(3),
+				testSessionState(3,
+					&synchronization.CycleChanges{Cycle: 1, AlphaChanges: []string{"src/main.c"}},
+					&synchronization.CycleChanges{Cycle: 3, AlphaChanges: []string{"docs/index.md"}},
+				),
+			},
+			[][]string{nil, {"build", "docs"}},
+		},
+		{
+			"reset with replaced previous changes",
+			[]*synchronization.State{
+				testSessionState(2, &synchronization.CycleChanges{Cycle: 2, AlphaChanges: []string{"src/main.c"}}),
+				testSessionState(2, &synchronization.CycleChanges{Cycle: 2, AlphaChanges: []string{"docs/index.md"}}),
+			},
+			[][]string{nil, {"docs"}},
+		},
+		{
+			"reset with missing previous changes",
+			[]*synchronization.State{
+				testSessionState(3, &synchronization.CycleChanges{Cycle: 3, AlphaChanges: []string{"src/main.c"}}),
+				testSessionState(3, &synchronization.CycleChanges{Cycle: 1, AlphaChanges: []string{"docs/index.md"}}),
+			},
+			[][]string{nil, {"docs"}},
+		},
+		{
+			"reset with empty history",
+			[]*synchronization.State{
+				testSessionState(3, &synchronization.CycleChanges{Cycle: 3, AlphaChanges: []string{"src/main.c"}}),
+				testSessionState(4),
+				testSessionState(4, &synchronization.CycleChanges{Cycle: 2, AlphaChanges: []string{"src/main.c"}}),
+			},
+			[][]string{nil, nil, {"build"}},
+		},
+		{
+			"truncated history",
+			[]*synchronization.State{
+				testSessionState(1, &synchronization.CycleChanges{Cycle: 1, AlphaChanges: []string{"src/main.c"}}),
+				testSessionState(30, &synchronization.CycleChanges{Cycle: 30, BetaChanges: []string{"docs/index.md"}}),
+			},
+			[][]string{nil, {"docs"}},
+		},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		tracker := newChangeTracker()
+		for s, state := range testCase.states {
+			triggered := tracker.triggeredHooks(state, hooks)
+			if !reflect.DeepEqual(triggered, testCase.expected[s]) {
+				t.Errorf("test case %d (%s) state %d: triggered hooks do not match expected: %v != %v",
+					i, testCase.description, s, triggered, testCase.expected[s],
+				)
+			}
+		}
+	}
+}
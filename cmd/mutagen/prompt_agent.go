@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+)
+
+// promptAgentMain is the entry point for the prompt-agent command.
+func promptAgentMain(_ *cobra.Command, _ []string) error {
+	// Set up signal handling.
+	signalTermination := make(chan os.Signal, 1)
+	signal.Notify(signalTermination, cmd.TerminationSignals...)
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Host the default prompter and defer cancellation of hosting.
+	statusLinePrinter := &cmd.StatusLinePrinter{}
+	promptingCtx, promptingCancel := context.WithCancel(context.Background())
+	defer promptingCancel()
+	_, promptingErrors, err := promptingsvc.HostDefault(
+		promptingCtx, promptingsvc.NewPromptingClient(daemonConnection),
+		&cmd.StatusLinePrompter{Printer: statusLinePrinter},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to initiate prompting: %w", err)
+	}
+
+	// Wait for termination or a hosting error.
+	select {
+	case <-signalTermination:
+		statusLinePrinter.BreakIfPopulated()
+		return nil
+	case err, ok := <-promptingErrors:
+		statusLinePrinter.BreakIfPopulated()
+		if !ok || err == nil {
+			return errors.New("prompt hosting terminated unexpectedly")
+		}
+		return fmt.Errorf("prompt hosting failed: %w", err)
+	}
+}
+
+// promptAgentCommand is the prompt-agent command.
+var promptAgentCommand = &cobra.Command{
+	Use:          "prompt-agent",
+	Short:        "Act as the daemon's default prompter for background reconnections",
+	Args:         cmd.DisallowArguments,
+	RunE:         promptAgentMain,
+	SilenceUsage: true,
+}
+
+// promptAgentConfiguration stores configuration for the prompt-agent command.
+var promptAgentConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := promptAgentCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&promptAgentConfiguration.help, "help", "h", false, "Show help information")
+}
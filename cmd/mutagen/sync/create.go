@@ -20,6 +20,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
 	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
 	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
@@ -239,6 +240,16 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert the in-place update threshold.
+	var inPlaceUpdateThreshold uint64
+	if createConfiguration.inPlaceUpdateThreshold != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.inPlaceUpdateThreshold); err != nil {
+			return fmt.Errorf("unable to parse in-place update threshold: %w", err)
+		} else {
+			inPlaceUpdateThreshold = s
+		}
+	}
+
 	// Validate and convert the symbolic link mode specification.
 	var symbolicLinkMode core.SymbolicLinkMode
 	if createConfiguration.symbolicLinkMode != "" {
@@ -285,6 +296,20 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		ignoreVCSMode = core.IgnoreVCSMode_IgnoreVCSModePropagate
 	}
 
+	// Validate ignore file names.
+	for _, name := range createConfiguration.ignoreFiles {
+		if !core.ValidIgnoreFileName(name) {
+			return fmt.Errorf("invalid ignore file name: %s", name)
+		}
+	}
+
+	// Validate include paths.
+	for _, include := range createConfiguration.includes {
+		if !core.ValidIncludePath(include) {
+			return fmt.Errorf("invalid include path: %s", include)
+		}
+	}
+
 	// Validate and convert the permissions mode specification.
 	var permissionsMode core.PermissionsMode
 	if createConfiguration.permissionsMode != "" {
@@ -400,25 +425,126 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		}
 	}
 
+	// Validate and convert maximum snapshot size specifications.
+	var maximumSnapshotSize, maximumSnapshotSizeAlpha, maximumSnapshotSizeBeta uint64
+	if createConfiguration.maximumSnapshotSize != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumSnapshotSize); err != nil {
+			return fmt.Errorf("unable to parse maximum snapshot size: %w", err)
+		} else {
+			maximumSnapshotSize = s
+		}
+	}
+	if createConfiguration.maximumSnapshotSizeAlpha != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumSnapshotSizeAlpha); err != nil {
+			return fmt.Errorf("unable to parse maximum snapshot size for alpha: %w", err)
+		} else {
+			maximumSnapshotSizeAlpha = s
+		}
+	}
+	if createConfiguration.maximumSnapshotSizeBeta != "" {
+		if s, err := humanize.ParseBytes(createConfiguration.maximumSnapshotSizeBeta); err != nil {
+			return fmt.Errorf("unable to parse maximum snapshot size for beta: %w", err)
+		} else {
+			maximumSnapshotSizeBeta = s
+		}
+	}
+
+	// Validate and convert the modification time mode specification.
+	var modificationTimeMode core.ModificationTimeMode
+	if createConfiguration.modificationTimeMode != "" {
+		if err := modificationTimeMode.UnmarshalText([]byte(createConfiguration.modificationTimeMode)); err != nil {
+			return fmt.Errorf("unable to parse modification time mode: %w", err)
+		}
+	}
+
+	// Validate and convert the hard link mode specification.
+	var hardLinkMode core.HardLinkMode
+	if createConfiguration.hardLinkMode != "" {
+		if err := hardLinkMode.UnmarshalText([]byte(createConfiguration.hardLinkMode)); err != nil {
+			return fmt.Errorf("unable to parse hard link mode: %w", err)
+		}
+	}
+
+	// Validate and convert the name collision policy specification.
+	var nameCollisionPolicy core.NameCollisionPolicy
+	if createConfiguration.nameCollisionPolicy != "" {
+		if err := nameCollisionPolicy.UnmarshalText([]byte(createConfiguration.nameCollisionPolicy)); err != nil {
+			return fmt.Errorf("unable to parse name collision policy: %w", err)
+		}
+	}
+
+	// Validate and convert the background prompting mode specification.
+	var backgroundPromptingMode prompting.BackgroundPromptingMode
+	if createConfiguration.backgroundPrompting != "" {
+		if err := backgroundPromptingMode.UnmarshalText([]byte(createConfiguration.backgroundPrompting)); err != nil {
+			return fmt.Errorf("unable to parse background prompting mode: %w", err)
+		}
+	}
+
+	// Validate and convert the battery policy specification.
+	var batteryPolicy synchronization.ConditionPolicy
+	if createConfiguration.onBattery != "" {
+		if err := batteryPolicy.UnmarshalText([]byte(createConfiguration.onBattery)); err != nil {
+			return fmt.Errorf("unable to parse battery policy: %w", err)
+		}
+	}
+
+	// Validate and convert the metered connection policy specification.
+	var meteredPolicy synchronization.ConditionPolicy
+	if createConfiguration.onMetered != "" {
+		if err := meteredPolicy.UnmarshalText([]byte(createConfiguration.onMetered)); err != nil {
+			return fmt.Errorf("unable to parse metered connection policy: %w", err)
+		}
+	}
+
+	// Validate the active hours specification.
+	if createConfiguration.activeHours != "" {
+		if _, err := synchronization.ParseActiveHours(createConfiguration.activeHours); err != nil {
+			return fmt.Errorf("unable to parse active hours: %w", err)
+		}
+	}
+
+	// Validate extended attribute patterns.
+	for _, pattern := range createConfiguration.extendedAttributes {
+		if !core.ValidExtendedAttributePattern(pattern) {
+			return fmt.Errorf("invalid extended attribute pattern: %s", pattern)
+		}
+	}
+
 	// Create the command line configuration and merge it into our cumulative
 	// configuration.
 	configuration = synchronization.MergeConfigurations(configuration, &synchronization.Configuration{
-		SynchronizationMode:    synchronizationMode,
-		MaximumEntryCount:      createConfiguration.maximumEntryCount,
-		MaximumStagingFileSize: maximumStagingFileSize,
-		ProbeMode:              probeMode,
-		ScanMode:               scanMode,
-		StageMode:              stageMode,
-		SymbolicLinkMode:       symbolicLinkMode,
-		WatchMode:              watchMode,
-		WatchPollingInterval:   createConfiguration.watchPollingInterval,
-		Ignores:                createConfiguration.ignores,
-		IgnoreVCSMode:          ignoreVCSMode,
-		PermissionsMode:        permissionsMode,
-		DefaultFileMode:        uint32(defaultFileMode),
-		DefaultDirectoryMode:   uint32(defaultDirectoryMode),
-		DefaultOwner:           createConfiguration.defaultOwner,
-		DefaultGroup:           createConfiguration.defaultGroup,
+		SynchronizationMode:       synchronizationMode,
+		MaximumEntryCount:         createConfiguration.maximumEntryCount,
+		MaximumStagingFileSize:    maximumStagingFileSize,
+		ProbeMode:                 probeMode,
+		ScanMode:                  scanMode,
+		ScanParallelism:           createConfiguration.scanParallelism,
+		StageMode:                 stageMode,
+		InPlaceUpdateThreshold:    inPlaceUpdateThreshold,
+		SymbolicLinkMode:          symbolicLinkMode,
+		WatchMode:                 watchMode,
+		WatchPollingInterval:      createConfiguration.watchPollingInterval,
+		Ignores:                   createConfiguration.ignores,
+		IgnoreVCSMode:             ignoreVCSMode,
+		IgnoreFiles:               createConfiguration.ignoreFiles,
+		Includes:                  createConfiguration.includes,
+		PermissionsMode:           permissionsMode,
+		DefaultFileMode:           uint32(defaultFileMode),
+		DefaultDirectoryMode:      uint32(defaultDirectoryMode),
+		DefaultOwner:              createConfiguration.defaultOwner,
+		DefaultGroup:              createConfiguration.defaultGroup,
+		MaximumSnapshotSize:       maximumSnapshotSize,
+		ModificationTimeMode:      modificationTimeMode,
+		HardLinkMode:              hardLinkMode,
+		NameCollisionPolicy:       nameCollisionPolicy,
+		ExtendedAttributes:        createConfiguration.extendedAttributes,
+		BackgroundPromptingMode:   backgroundPromptingMode,
+		BatteryPolicy:             batteryPolicy,
+		MeteredPolicy:             meteredPolicy,
+		ActiveHours:               createConfiguration.activeHours,
+		DisconnectedTimeout:       createConfiguration.disconnectedTimeout,
+		DisconnectedRetryInterval: createConfiguration.disconnectedRetryInterval,
 	})
 
 	// Create the creation specification.
@@ -429,6 +555,7 @@ func createMain(_ *cobra.Command, arguments []string) error {
 		ConfigurationAlpha: &synchronization.Configuration{
 			ProbeMode:            probeModeAlpha,
 			ScanMode:             scanModeAlpha,
+			ScanParallelism:      createConfiguration.scanParallelismAlpha,
 			StageMode:            stageModeAlpha,
 			WatchMode:            watchModeAlpha,
 			WatchPollingInterval: createConfiguration.watchPollingIntervalAlpha,
@@ -436,10 +563,12 @@ func createMain(_ *cobra.Command, arguments []string) error {
 			DefaultDirectoryMode: uint32(defaultDirectoryModeAlpha),
 			DefaultOwner:         createConfiguration.defaultOwnerAlpha,
 			DefaultGroup:         createConfiguration.defaultGroupAlpha,
+			MaximumSnapshotSize:  maximumSnapshotSizeAlpha,
 		},
 		ConfigurationBeta: &synchronization.Configuration{
 			ProbeMode:            probeModeBeta,
 			ScanMode:             scanModeBeta,
+			ScanParallelism:      createConfiguration.scanParallelismBeta,
 			StageMode:            stageModeBeta,
 			WatchMode:            watchModeBeta,
 			WatchPollingInterval: createConfiguration.watchPollingIntervalBeta,
@@ -447,6 +576,7 @@ func createMain(_ *cobra.Command, arguments []string) error {
 			DefaultDirectoryMode: uint32(defaultDirectoryModeBeta),
 			DefaultOwner:         createConfiguration.defaultOwnerBeta,
 			DefaultGroup:         createConfiguration.defaultGroupBeta,
+			MaximumSnapshotSize:  maximumSnapshotSizeBeta,
 		},
 		Name:   createConfiguration.name,
 		Labels: labels,
@@ -522,6 +652,17 @@ var createConfiguration struct {
 	// scanModeBeta specifies the scan mode to use for the session, taking
 	// priority over scanMode on beta if specified.
 	scanModeBeta string
+	// scanParallelism specifies the number of workers to use when scanning for
+	// the session.
+	scanParallelism uint32
+	// scanParallelismAlpha specifies the number of workers to use when scanning
+	// for the session, taking priority over scanParallelism on alpha if
+	// specified.
+	scanParallelismAlpha uint32
+	// scanParallelismBeta specifies the number of workers to use when scanning
+	// for the session, taking priority over scanParallelism on beta if
+	// specified.
+	scanParallelismBeta uint32
 	// stageMode specifies the file staging mode to use for the session.
 	stageMode string
 	// stageModeAlpha specifies the file staging mode to use for the session,
@@ -530,6 +671,9 @@ var createConfiguration struct {
 	// stageModeBeta specifies the file staging mode to use for the session,
 	// taking priority over stageMode on beta if specified.
 	stageModeBeta string
+	// inPlaceUpdateThreshold specifies the minimum size of existing files that
+	// will be updated in place by applying block-level deltas.
+	inPlaceUpdateThreshold string
 	// symbolicLinkMode specifies the symbolic link handling mode to use for
 	// the session.
 	symbolicLinkMode string
@@ -559,6 +703,11 @@ var createConfiguration struct {
 	// noIgnoreVCS specifies whether or not to disable VCS ignores for the
 	// session.
 	noIgnoreVCS bool
+	// ignoreFiles is the list of per-directory ignore file names for the
+	// session.
+	ignoreFiles []string
+	// includes is the list of include paths for the session.
+	includes []string
 	// permissionsMode specifies the permissions mdoe to use for the session.
 	permissionsMode string
 	// defaultFileMode specifies the default permission mode to use for new
@@ -613,6 +762,45 @@ var createConfiguration struct {
 	// permission propagation mode, taking priority over defaultGroup on beta if
 	// specified.
 	defaultGroupBeta string
+	// maximumSnapshotSize specifies the maximum total size of file contents
+	// that endpoints will retain for point-in-time restoration, with
+	// endpoint-specific specifications taking priority. It can be specified in
+	// human-friendly units.
+	maximumSnapshotSize string
+	// maximumSnapshotSizeAlpha specifies the maximum snapshot size for alpha,
+	// taking priority over maximumSnapshotSize on alpha if specified.
+	maximumSnapshotSizeAlpha string
+	// maximumSnapshotSizeBeta specifies the maximum snapshot size for beta,
+	// taking priority over maximumSnapshotSize on beta if specified.
+	maximumSnapshotSizeBeta string
+	// modificationTimeMode specifies the modification time mode to use for the
+	// session.
+	modificationTimeMode string
+	// hardLinkMode specifies the hard link mode to use for the session.
+	hardLinkMode string
+	// nameCollisionPolicy specifies the name collision policy to use for the
+	// session.
+	nameCollisionPolicy string
+	// extendedAttributes is the list of extended attribute name patterns to
+	// propagate for the session.
+	extendedAttributes []string
+	// backgroundPrompting specifies the background prompting mode to use for
+	// the session.
+	backgroundPrompting string
+	// onBattery specifies the battery policy to use for the session.
+	onBattery string
+	// onMetered specifies the metered connection policy to use for the
+	// session.
+	onMetered string
+	// activeHours specifies the daily time range during which the session may
+	// synchronize.
+	activeHours string
+	// disconnectedTimeout specifies the duration (in seconds) after which a
+	// disconnected session is suspended.
+	disconnectedTimeout uint32
+	// disconnectedRetryInterval specifies the interval (in seconds) at which a
+	// session suspended due to disconnection attempts reconnection.
+	disconnectedRetryInterval uint32
 }
 
 func init() {
@@ -644,12 +832,16 @@ func init() {
 	flags.StringVar(&createConfiguration.probeMode, "probe-mode", "", "Specify probe mode (probe|assume)")
 	flags.StringVar(&createConfiguration.probeModeAlpha, "probe-mode-alpha", "", "Specify probe mode for alpha (probe|assume)")
 	flags.StringVar(&createConfiguration.probeModeBeta, "probe-mode-beta", "", "Specify probe mode for beta (probe|assume)")
-	flags.StringVar(&createConfiguration.scanMode, "scan-mode", "", "Specify scan mode (full|accelerated)")
-	flags.StringVar(&createConfiguration.scanModeAlpha, "scan-mode-alpha", "", "Specify scan mode for alpha (full|accelerated)")
-	flags.StringVar(&createConfiguration.scanModeBeta, "scan-mode-beta", "", "Specify scan mode for beta (full|accelerated)")
+	flags.StringVar(&createConfiguration.scanMode, "scan-mode", "", "Specify scan mode (full|accelerated|trusted)")
+	flags.StringVar(&createConfiguration.scanModeAlpha, "scan-mode-alpha", "", "Specify scan mode for alpha (full|accelerated|trusted)")
+	flags.StringVar(&createConfiguration.scanModeBeta, "scan-mode-beta", "", "Specify scan mode for beta (full|accelerated|trusted)")
+	flags.Uint32Var(&createConfiguration.scanParallelism, "scan-parallelism", 0, "Specify the number of workers used to traverse directories and hash files when scanning")
+	flags.Uint32Var(&createConfiguration.scanParallelismAlpha, "scan-parallelism-alpha", 0, "Specify the number of workers used to traverse directories and hash files when scanning on alpha")
+	flags.Uint32Var(&createConfiguration.scanParallelismBeta, "scan-parallelism-beta", 0, "Specify the number of workers used to traverse directories and hash files when scanning on beta")
 	flags.StringVar(&createConfiguration.stageMode, "stage-mode", "", "Specify staging mode (mutagen|neighboring)")
 	flags.StringVar(&createConfiguration.stageModeAlpha, "stage-mode-alpha", "", "Specify staging mode for alpha (mutagen|neighboring)")
 	flags.StringVar(&createConfiguration.stageModeBeta, "stage-mode-beta", "", "Specify staging mode for beta (mutagen|neighboring)")
+	flags.StringVar(&createConfiguration.inPlaceUpdateThreshold, "in-place-threshold", "", "Specify the minimum size of existing files that will be updated in place by applying block-level deltas")
 
 	// Wire up symbolic link flags.
 	flags.StringVar(&createConfiguration.symbolicLinkMode, "symlink-mode", "", "Specify symlink mode (ignore|portable|posix-raw)")
@@ -666,6 +858,8 @@ func init() {
 	flags.StringSliceVarP(&createConfiguration.ignores, "ignore", "i", nil, "Specify ignore paths")
 	flags.BoolVar(&createConfiguration.ignoreVCS, "ignore-vcs", false, "Ignore VCS directories")
 	flags.BoolVar(&createConfiguration.noIgnoreVCS, "no-ignore-vcs", false, "Propagate VCS directories")
+	flags.StringSliceVar(&createConfiguration.ignoreFiles, "ignore-file", nil, "Specify names of per-directory ignore files (e.g. .gitignore, .mutagenignore)")
+	flags.StringSliceVar(&createConfiguration.includes, "include", nil, "Limit synchronization to the specified root-relative paths")
 
 	// Wire up permission flags.
 	flags.StringVar(&createConfiguration.permissionsMode, "permissions-mode", "", "Specify permissions mode (portable|manual)")
@@ -681,4 +875,25 @@ func init() {
 	flags.StringVar(&createConfiguration.defaultGroup, "default-group", "", "Specify default file/directory group")
 	flags.StringVar(&createConfiguration.defaultGroupAlpha, "default-group-alpha", "", "Specify default file/directory group for alpha")
 	flags.StringVar(&createConfiguration.defaultGroupBeta, "default-group-beta", "", "Specify default file/directory group for beta")
+
+	// Wire up snapshot flags.
+	flags.StringVar(&createConfiguration.maximumSnapshotSize, "max-snapshot-size", "", "Specify the maximum total size of content snapshots retained by endpoints")
+	flags.StringVar(&createConfiguration.maximumSnapshotSizeAlpha, "max-snapshot-size-alpha", "", "Specify the maximum total size of content snapshots retained by alpha")
+	flags.StringVar(&createConfiguration.maximumSnapshotSizeBeta, "max-snapshot-size-beta", "", "Specify the maximum total size of content snapshots retained by beta")
+
+	// Wire up metadata flags.
+	flags.StringVar(&createConfiguration.modificationTimeMode, "modification-time-mode", "", "Specify modification time mode (ignore|preserve)")
+	flags.StringVar(&createConfiguration.hardLinkMode, "hard-link-mode", "", "Specify hard link mode (independent|preserve)")
+	flags.StringVar(&createConfiguration.nameCollisionPolicy, "name-collision-policy", "", "Specify the policy for names that collide on case- or normalization-insensitive endpoints (report|first|last)")
+	flags.StringSliceVar(&createConfiguration.extendedAttributes, "extended-attribute", nil, "Specify extended attribute name patterns to propagate (e.g. user.*, system.posix_acl_*)")
+
+	// Wire up connection flags.
+	flags.StringVar(&createConfiguration.backgroundPrompting, "background-prompting", "", "Specify whether automatic reconnections may use the default prompter (enabled|disabled)")
+
+	// Wire up policy flags.
+	flags.StringVar(&createConfiguration.onBattery, "on-battery", "", "Specify the policy to apply while running on battery power (ignore|pause)")
+	flags.StringVar(&createConfiguration.onMetered, "on-metered", "", "Specify the policy to apply while using a metered connection (ignore|pause)")
+	flags.StringVar(&createConfiguration.activeHours, "active-hours", "", "Specify the daily time range during which synchronization is allowed (HH:MM-HH:MM)")
+	flags.Uint32Var(&createConfiguration.disconnectedTimeout, "disconnected-timeout", 0, "Specify the time (in seconds) after which a disconnected session is suspended")
+	flags.Uint32Var(&createConfiguration.disconnectedRetryInterval, "disconnected-retry-interval", 0, "Specify the interval (in seconds) at which a suspended disconnected session retries connection")
 }
@@ -66,6 +66,7 @@ func flushMain(_ *cobra.Command, arguments []string) error {
 		All:            flushConfiguration.all,
 		Specifications: arguments,
 		LabelSelector:  flushConfiguration.labelSelector,
+		Selectors:      flushConfiguration.selectors,
 	}
 	if err := selection.EnsureValid(); err != nil {
 		return fmt.Errorf("invalid session selection specification: %w", err)
@@ -99,6 +100,9 @@ var flushConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// selectors are selector specifications to be used in identifying which
+	// sessions should be selected.
+	selectors []string
 	// skipWait indicates whether or not the flush operation should block until
 	// a synchronization cycle completes for each sesion requested.
 	skipWait bool
@@ -118,5 +122,6 @@ func init() {
 	// Wire up flush flags.
 	flags.BoolVarP(&flushConfiguration.all, "all", "a", false, "Flush all sessions")
 	flags.StringVar(&flushConfiguration.labelSelector, "label-selector", "", "Flush sessions matching the specified label selector")
+	flags.StringArrayVar(&flushConfiguration.selectors, "select", nil, "Flush sessions matching the specified selector (may be repeated)")
 	flags.BoolVar(&flushConfiguration.skipWait, "skip-wait", false, "Avoid waiting for the resulting synchronization cycle(s) to complete")
 }
@@ -0,0 +1,151 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+// ImportWithPath is an orchestration convenience method that performs an
+// import operation using the provided daemon connection and export file path.
+// The export is read by the daemon, so the path must be absolute. It returns
+// the identifiers of the newly created sessions.
+func ImportWithPath(
+	daemonConnection *grpc.ClientConn,
+	path string,
+	useArchives, paused, force bool,
+) ([]string, error) {
+	// Initiate command line prompting.
+	statusLinePrinter := &cmd.StatusLinePrinter{}
+	promptingCtx, promptingCancel := context.WithCancel(context.Background())
+	prompter, promptingErrors, err := promptingsvc.Host(
+		promptingCtx, promptingsvc.NewPromptingClient(daemonConnection),
+		&cmd.StatusLinePrompter{Printer: statusLinePrinter}, true,
+	)
+	if err != nil {
+		promptingCancel()
+		return nil, fmt.Errorf("unable to initiate prompting: %w", err)
+	}
+
+	// Perform the import operation, cancel prompting, and handle errors.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.ImportRequest{
+		Prompter:    prompter,
+		Path:        path,
+		UseArchives: useArchives,
+		Paused:      paused,
+		Force:       force,
+	}
+	response, err := synchronizationService.Import(context.Background(), request)
+	promptingCancel()
+	<-promptingErrors
+	if err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return nil, fmt.Errorf("invalid import response received: %w", err)
+	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return response.Sessions, nil
+}
+
+// importMain is the entry point for the import command.
+func importMain(_ *cobra.Command, arguments []string) error {
+	// Ensure that exactly one export file has been specified.
+	if len(arguments) == 0 {
+		return errors.New("export file not specified")
+	} else if len(arguments) > 1 {
+		return errors.New("only one export file may be specified")
+	}
+
+	// Ensure that forcing is only requested with archives.
+	if importConfiguration.force && !importConfiguration.useArchives {
+		return errors.New("forced archive use requested without using archives")
+	}
+
+	// Convert the export path to an absolute path, since the export is read by
+	// the daemon.
+	path, err := filepath.Abs(arguments[0])
+	if err != nil {
+		return fmt.Errorf("unable to compute absolute export path: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Perform the import operation.
+	identifiers, err := ImportWithPath(
+		daemonConnection, path,
+		importConfiguration.useArchives, importConfiguration.paused, importConfiguration.force,
+	)
+	if err != nil {
+		return err
+	}
+
+	// Print the session identifiers.
+	for _, identifier := range identifiers {
+		fmt.Println("Imported session", identifier)
+	}
+
+	// Success.
+	return nil
+}
+
+// importCommand is the import command.
+var importCommand = &cobra.Command{
+	Use:          "import <file>",
+	Short:        "Create synchronization sessions from an export file",
+	RunE:         importMain,
+	SilenceUsage: true,
+}
+
+// importConfiguration stores configuration for the import command.
+var importConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// paused indicates whether or not to create the sessions in a pre-paused
+	// state.
+	paused bool
+	// useArchives indicates whether or not ancestor archives included in the
+	// export should be used to seed the sessions.
+	useArchives bool
+	// force indicates whether or not ancestor archives should be used even if
+	// endpoint contents don't match them.
+	force bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := importCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&importConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up import flags.
+	flags.BoolVarP(&importConfiguration.paused, "paused", "p", false, "Create the sessions pre-paused")
+	flags.BoolVar(&importConfiguration.useArchives, "use-archives", false, "Seed the sessions with ancestor archives included in the export")
+	flags.BoolVar(&importConfiguration.force, "force", false, "Use ancestor archives even if endpoint contents don't match them")
+}
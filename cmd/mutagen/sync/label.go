@@ -0,0 +1,181 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"google.golang.org/grpc"
+
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+)
+
+// LabelWithSelection is an orchestration convenience method that performs a
+// label operation using the provided daemon connection and session selection.
+// The name is only changed if rename is true.
+func LabelWithSelection(
+	daemonConnection *grpc.ClientConn,
+	selection *selection.Selection,
+	rename bool, name string,
+	labels map[string]string, removals []string, overwrite bool,
+) error {
+	// Perform the label operation and handle errors.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.LabelRequest{
+		Selection:     selection,
+		Rename:        rename,
+		Name:          name,
+		Labels:        labels,
+		RemovedLabels: removals,
+		Overwrite:     overwrite,
+	}
+	response, err := synchronizationService.Label(context.Background(), request)
+	if err != nil {
+		return grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid label response received: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// parseLabelChanges parses and validates label specifications (which take the
+// form KEY or KEY=VALUE) and ensures that some change (a rename, label
+// addition, or label removal) has been requested. It returns the parsed labels.
+func parseLabelChanges(rename bool, specifications, removals []string) (map[string]string, error) {
+	// Parse, validate, and record labels.
+	var labels map[string]string
+	if len(specifications) > 0 {
+		labels = make(map[string]string, len(specifications))
+	}
+	for _, label := range specifications {
+		components := strings.SplitN(label, "=", 2)
+		var key, value string
+		key = components[0]
+		if len(components) == 2 {
+			value = components[1]
+		}
+		if err := selection.EnsureLabelKeyValid(key); err != nil {
+			return nil, fmt.Errorf("invalid label key: %w", err)
+		} else if err := selection.EnsureLabelValueValid(value); err != nil {
+			return nil, fmt.Errorf("invalid label value: %w", err)
+		}
+		labels[key] = value
+	}
+
+	// Validate removals.
+	for _, key := range removals {
+		if err := selection.EnsureLabelKeyValid(key); err != nil {
+			return nil, fmt.Errorf("invalid label key for removal: %w", err)
+		}
+	}
+
+	// Ensure that some change has been requested.
+	if !rename && len(labels) == 0 && len(removals) == 0 {
+		return nil, errors.New("no name or label changes specified")
+	}
+
+	// Success.
+	return labels, nil
+}
+
+// labelMain is the entry point for the label command.
+func labelMain(command *cobra.Command, arguments []string) error {
+	// Determine whether or not a rename has been requested. We check whether or
+	// not the flag was specified (rather than whether or not it's empty) so
+	// that an empty name can be used to remove the existing name.
+	rename := command.Flags().Changed("name")
+
+	// Parse and validate the requested changes.
+	labels, err := parseLabelChanges(rename, labelConfiguration.labels, labelConfiguration.removals)
+	if err != nil {
+		return err
+	}
+
+	// Create session selection specification.
+	selection := &selection.Selection{
+		All:            labelConfiguration.all,
+		Specifications: arguments,
+		LabelSelector:  labelConfiguration.labelSelector,
+		Selectors:      labelConfiguration.selectors,
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Perform the label operation.
+	return LabelWithSelection(
+		daemonConnection, selection,
+		rename, labelConfiguration.name,
+		labels, labelConfiguration.removals, labelConfiguration.overwrite,
+	)
+}
+
+// labelCommand is the label command.
+var labelCommand = &cobra.Command{
+	Use:          "label [<session>...]",
+	Short:        "Change the name and labels of synchronization sessions",
+	RunE:         labelMain,
+	SilenceUsage: true,
+}
+
+// labelConfiguration stores configuration for the label command.
+var labelConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// all indicates whether or not all sessions should be labeled.
+	all bool
+	// labelSelector encodes a label selector to be used in identifying which
+	// sessions should be labeled.
+	labelSelector string
+	// selectors are selector specifications to be used in identifying which
+	// sessions should be labeled.
+	selectors []string
+	// name is the new name for the session.
+	name string
+	// labels are the label specifications to add to the sessions.
+	labels []string
+	// removals are the keys of labels to remove from the sessions.
+	removals []string
+	// overwrite indicates whether or not existing label values may be
+	// replaced.
+	overwrite bool
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := labelCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&labelConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up selection flags.
+	flags.BoolVarP(&labelConfiguration.all, "all", "a", false, "Label all sessions")
+	flags.StringVar(&labelConfiguration.labelSelector, "label-selector", "", "Label sessions matching the specified label selector")
+	flags.StringArrayVar(&labelConfiguration.selectors, "select", nil, "Label sessions matching the specified selector (may be repeated)")
+
+	// Wire up name and label flags.
+	flags.StringVarP(&labelConfiguration.name, "name", "n", "", "Rename the session (an empty name removes the existing name)")
+	flags.StringSliceVarP(&labelConfiguration.labels, "label", "l", nil, "Specify labels to add")
+	flags.StringSliceVarP(&labelConfiguration.removals, "remove-label", "r", nil, "Specify keys of labels to remove")
+	flags.BoolVar(&labelConfiguration.overwrite, "overwrite", false, "Allow existing label values to be replaced")
+}
@@ -0,0 +1,43 @@
+package sync
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseLabelChanges tests parseLabelChanges.
+func TestParseLabelChanges(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		rename         bool
+		specifications []string
+		removals       []string
+		expected       map[string]string
+		expectFailure  bool
+	}{
+		{false, nil, nil, nil, true},
+		{true, nil, nil, nil, false},
+		{false, nil, []string{"key"}, nil, false},
+		{false, []string{"key=value"}, nil, map[string]string{"key": "value"}, false},
+		{false, []string{"key"}, nil, map[string]string{"key": ""}, false},
+		{false, []string{"key=value=more"}, nil, nil, true},
+		{false, []string{"first=1", "second=2"}, nil, map[string]string{"first": "1", "second": "2"}, false},
+		{false, []string{"=value"}, nil, nil, true},
+		{false, []string{"key=in valid"}, nil, nil, true},
+		{false, nil, []string{"in valid"}, nil, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		labels, err := parseLabelChanges(testCase.rename, testCase.specifications, testCase.removals)
+		if testCase.expectFailure {
+			if err == nil {
+				t.Errorf("test case %d: parsing succeeded unexpectedly", i)
+			}
+		} else if err != nil {
+			t.Errorf("test case %d: parsing failed unexpectedly: %v", i, err)
+		} else if !reflect.DeepEqual(labels, testCase.expected) {
+			t.Errorf("test case %d: labels do not match expected: %v != %v", i, labels, testCase.expected)
+		}
+	}
+}
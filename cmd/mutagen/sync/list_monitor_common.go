@@ -3,6 +3,7 @@ package sync
 import (
 	"fmt"
 	"math"
+	"strings"
 
 	"github.com/dustin/go-humanize"
 
@@ -127,6 +128,15 @@ func printEndpoint(name string, url *url.URL, configuration *synchronization.Con
 		}
 		fmt.Println("\t\tScan mode:", scanModeDescription)
 
+		// Compute and print the scan parallelism.
+		var scanParallelismDescription string
+		if configuration.ScanParallelism == 0 {
+			scanParallelismDescription = "Default (one worker per CPU)"
+		} else {
+			scanParallelismDescription = fmt.Sprintf("%d workers", configuration.ScanParallelism)
+		}
+		fmt.Println("\t\tScan parallelism:", scanParallelismDescription)
+
 		// Compute and print the staging mode.
 		stageModeDescription := configuration.StageMode.Description()
 		if configuration.StageMode.IsDefault() {
@@ -165,6 +175,13 @@ func printEndpoint(name string, url *url.URL, configuration *synchronization.Con
 			defaultGroupDescription = configuration.DefaultGroup
 		}
 		fmt.Println("\t\tDefault file/directory group:", defaultGroupDescription)
+
+		// Compute and print the maximum snapshot size.
+		maximumSnapshotSizeDescription := "Disabled"
+		if configuration.MaximumSnapshotSize != 0 {
+			maximumSnapshotSizeDescription = humanize.Bytes(configuration.MaximumSnapshotSize)
+		}
+		fmt.Println("\t\tMaximum snapshot size:", maximumSnapshotSizeDescription)
 	}
 
 	// At this point, there's no other status information that will be displayed
@@ -266,6 +283,35 @@ func printConflicts(conflicts []*core.Conflict, excludedConflicts uint64) {
 	}
 }
 
+// printNameCollisionCount prints a count of name collisions.
+func printNameCollisionCount(collisions []*core.NameCollision, excludedCollisions uint64) {
+	color.Red("Name collisions: %d\n", uint64(len(collisions))+excludedCollisions)
+}
+
+// printNameCollisions prints a list of name collisions.
+func printNameCollisions(collisions []*core.NameCollision, excludedCollisions uint64) {
+	// Print the header.
+	color.Red("Name collisions:\n")
+
+	// Print collisions.
+	for _, c := range collisions {
+		paths := make([]string, len(c.Paths))
+		for p, path := range c.Paths {
+			paths[p] = formatPath(path)
+		}
+		if c.Winner != "" {
+			color.Red("\t%s (synchronizing %s)\n", strings.Join(paths, ", "), formatPath(c.Winner))
+		} else {
+			color.Red("\t%s\n", strings.Join(paths, ", "))
+		}
+	}
+
+	// Print excluded collisions.
+	if excludedCollisions > 0 {
+		color.Red("\t...+%d more...\n", excludedCollisions)
+	}
+}
+
 // printSession prints the configuration and status of a synchronization
 // session and its endpoints.
 func printSession(state *synchronization.State, mode common.SessionDisplayMode) {
@@ -335,6 +381,17 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 		}
 		fmt.Println("\tMaximum staging file size:", maximumStagingFileSizeDescription)
 
+		// Compute and print the in-place update threshold.
+		inPlaceUpdateThresholdDescription := "Disabled"
+		if configuration.InPlaceUpdateThreshold != 0 {
+			inPlaceUpdateThresholdDescription = fmt.Sprintf(
+				"%d (%s)",
+				configuration.InPlaceUpdateThreshold,
+				humanize.Bytes(configuration.InPlaceUpdateThreshold),
+			)
+		}
+		fmt.Println("\tIn-place update threshold:", inPlaceUpdateThresholdDescription)
+
 		// Compute and print symbolic link mode.
 		symbolicLinkModeDescription := configuration.SymbolicLinkMode.Description()
 		if configuration.SymbolicLinkMode.IsDefault() {
@@ -370,6 +427,26 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 			fmt.Println("\tIgnores: None")
 		}
 
+		// Print per-directory ignore file names.
+		if len(configuration.IgnoreFiles) > 0 {
+			fmt.Println("\tIgnore files:")
+			for _, n := range configuration.IgnoreFiles {
+				fmt.Printf("\t\t%s\n", n)
+			}
+		} else {
+			fmt.Println("\tIgnore files: None")
+		}
+
+		// Print include paths.
+		if len(configuration.Includes) > 0 {
+			fmt.Println("\tIncludes:")
+			for _, p := range configuration.Includes {
+				fmt.Printf("\t\t%s\n", p)
+			}
+		} else {
+			fmt.Println("\tIncludes: All")
+		}
+
 		// Compute and print permissions mode.
 		permissionsModeDescription := configuration.PermissionsMode.Description()
 		if configuration.PermissionsMode.IsDefault() {
@@ -377,6 +454,85 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 			permissionsModeDescription += fmt.Sprintf(" (%s)", defaultPermissionsMode.Description())
 		}
 		fmt.Println("\tPermissions mode:", permissionsModeDescription)
+
+		// Compute and print modification time mode.
+		modificationTimeModeDescription := configuration.ModificationTimeMode.Description()
+		if configuration.ModificationTimeMode.IsDefault() {
+			defaultModificationTimeMode := state.Session.Version.DefaultModificationTimeMode()
+			modificationTimeModeDescription += fmt.Sprintf(" (%s)", defaultModificationTimeMode.Description())
+		}
+		fmt.Println("\tModification time mode:", modificationTimeModeDescription)
+
+		// Compute and print hard link mode.
+		hardLinkModeDescription := configuration.HardLinkMode.Description()
+		if configuration.HardLinkMode.IsDefault() {
+			defaultHardLinkMode := state.Session.Version.DefaultHardLinkMode()
+			hardLinkModeDescription += fmt.Sprintf(" (%s)", defaultHardLinkMode.Description())
+		}
+		fmt.Println("\tHard link mode:", hardLinkModeDescription)
+
+		// Compute and print name collision policy.
+		nameCollisionPolicyDescription := configuration.NameCollisionPolicy.Description()
+		if configuration.NameCollisionPolicy.IsDefault() {
+			defaultNameCollisionPolicy := state.Session.Version.DefaultNameCollisionPolicy()
+			nameCollisionPolicyDescription += fmt.Sprintf(" (%s)", defaultNameCollisionPolicy.Description())
+		}
+		fmt.Println("\tName collision policy:", nameCollisionPolicyDescription)
+
+		// Print extended attribute patterns.
+		if len(configuration.ExtendedAttributes) > 0 {
+			fmt.Println("\tExtended attributes:")
+			for _, p := range configuration.ExtendedAttributes {
+				fmt.Printf("\t\t%s\n", p)
+			}
+		} else {
+			fmt.Println("\tExtended attributes: None")
+		}
+
+		// Compute and print background prompting mode.
+		backgroundPromptingModeDescription := configuration.BackgroundPromptingMode.Description()
+		if configuration.BackgroundPromptingMode.IsDefault() {
+			defaultBackgroundPromptingMode := state.Session.Version.DefaultBackgroundPromptingMode()
+			backgroundPromptingModeDescription += fmt.Sprintf(" (%s)", defaultBackgroundPromptingMode.Description())
+		}
+		fmt.Println("\tBackground prompting:", backgroundPromptingModeDescription)
+
+		// Compute and print battery policy.
+		batteryPolicyDescription := configuration.BatteryPolicy.Description()
+		if configuration.BatteryPolicy.IsDefault() {
+			defaultBatteryPolicy := state.Session.Version.DefaultConditionPolicy()
+			batteryPolicyDescription += fmt.Sprintf(" (%s)", defaultBatteryPolicy.Description())
+		}
+		fmt.Println("\tOn battery:", batteryPolicyDescription)
+
+		// Compute and print metered connection policy.
+		meteredPolicyDescription := configuration.MeteredPolicy.Description()
+		if configuration.MeteredPolicy.IsDefault() {
+			defaultMeteredPolicy := state.Session.Version.DefaultConditionPolicy()
+			meteredPolicyDescription += fmt.Sprintf(" (%s)", defaultMeteredPolicy.Description())
+		}
+		fmt.Println("\tOn metered connection:", meteredPolicyDescription)
+
+		// Print active hours.
+		if configuration.ActiveHours != "" {
+			fmt.Println("\tActive hours:", configuration.ActiveHours)
+		} else {
+			fmt.Println("\tActive hours: Always")
+		}
+
+		// Print disconnection policy parameters.
+		if configuration.DisconnectedTimeout != 0 {
+			fmt.Printf("\tDisconnected timeout: %d seconds\n", configuration.DisconnectedTimeout)
+			var disconnectedRetryIntervalDescription string
+			if configuration.DisconnectedRetryInterval == 0 {
+				disconnectedRetryIntervalDescription = fmt.Sprintf("Default (%d seconds)", state.Session.Version.DefaultDisconnectedRetryInterval())
+			} else {
+				disconnectedRetryIntervalDescription = fmt.Sprintf("%d seconds", configuration.DisconnectedRetryInterval)
+			}
+			fmt.Println("\tDisconnected retry interval:", disconnectedRetryIntervalDescription)
+		} else {
+			fmt.Println("\tDisconnected timeout: None")
+		}
 	}
 
 	// Compute and print alpha-specific configuration.
@@ -419,6 +575,15 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 		}
 	}
 
+	// Print name collisions, if any.
+	if len(state.NameCollisions) > 0 {
+		if mode == common.SessionDisplayModeList {
+			printNameCollisionCount(state.NameCollisions, state.ExcludedNameCollisions)
+		} else if mode == common.SessionDisplayModeListLong {
+			printNameCollisions(state.NameCollisions, state.ExcludedNameCollisions)
+		}
+	}
+
 	// Print the last error, if any.
 	if state.LastError != "" {
 		color.Red("Last error: %s\n", state.LastError)
@@ -428,6 +593,8 @@ func printSession(state *synchronization.State, mode common.SessionDisplayMode)
 	statusString := state.Status.Description()
 	if state.Session.Paused {
 		statusString = color.YellowString("[Paused]")
+	} else if state.Suspension != "" {
+		statusString = color.YellowString("[Suspended: %s]", state.Suspension)
 	}
 	fmt.Fprintln(color.Output, "Status:", statusString)
 
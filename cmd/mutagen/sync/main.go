@@ -46,10 +46,15 @@ func init() {
 		createCommand,
 		listCommand,
 		monitorCommand,
+		logsCommand,
 		flushCommand,
 		pauseCommand,
 		resumeCommand,
 		resetCommand,
+		restoreCommand,
+		labelCommand,
+		exportCommand,
+		importCommand,
 		terminateCommand,
 	)
 }
@@ -64,6 +64,7 @@ func pauseMain(_ *cobra.Command, arguments []string) error {
 		All:            pauseConfiguration.all,
 		Specifications: arguments,
 		LabelSelector:  pauseConfiguration.labelSelector,
+		Selectors:      pauseConfiguration.selectors,
 	}
 	if err := selection.EnsureValid(); err != nil {
 		return fmt.Errorf("invalid session selection specification: %w", err)
@@ -97,6 +98,9 @@ var pauseConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// selectors are selector specifications to be used in identifying which
+	// sessions should be selected.
+	selectors []string
 }
 
 func init() {
@@ -113,4 +117,5 @@ func init() {
 	// Wire up pause flags.
 	flags.BoolVarP(&pauseConfiguration.all, "all", "a", false, "Pause all sessions")
 	flags.StringVar(&pauseConfiguration.labelSelector, "label-selector", "", "Pause sessions matching the specified label selector")
+	flags.StringArrayVar(&pauseConfiguration.selectors, "select", nil, "Pause sessions matching the specified selector (may be repeated)")
 }
@@ -64,6 +64,7 @@ func resetMain(_ *cobra.Command, arguments []string) error {
 		All:            resetConfiguration.all,
 		Specifications: arguments,
 		LabelSelector:  resetConfiguration.labelSelector,
+		Selectors:      resetConfiguration.selectors,
 	}
 	if err := selection.EnsureValid(); err != nil {
 		return fmt.Errorf("invalid session selection specification: %w", err)
@@ -97,6 +98,9 @@ var resetConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// selectors are selector specifications to be used in identifying which
+	// sessions should be selected.
+	selectors []string
 }
 
 func init() {
@@ -113,4 +117,5 @@ func init() {
 	// Wire up reset flags.
 	flags.BoolVarP(&resetConfiguration.all, "all", "a", false, "Reset all sessions")
 	flags.StringVar(&resetConfiguration.labelSelector, "label-selector", "", "Reset sessions matching the specified label selector")
+	flags.StringArrayVar(&resetConfiguration.selectors, "select", nil, "Reset sessions matching the specified selector (may be repeated)")
 }
@@ -0,0 +1,175 @@
+package sync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/fatih/color"
+
+	"google.golang.org/grpc"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mutagen-io/mutagen/cmd"
+	"github.com/mutagen-io/mutagen/cmd/mutagen/daemon"
+
+	"github.com/mutagen-io/mutagen/pkg/grpcutil"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	promptingsvc "github.com/mutagen-io/mutagen/pkg/service/prompting"
+	synchronizationsvc "github.com/mutagen-io/mutagen/pkg/service/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// RestoreWithSelection is an orchestration convenience method that performs a
+// restore operation using the provided daemon connection and session
+// selection. It returns the per-session restoration results.
+func RestoreWithSelection(
+	daemonConnection *grpc.ClientConn,
+	selection *selection.Selection,
+	before time.Time,
+	paths []string,
+) ([]*synchronization.RestoreResult, error) {
+	// Initiate command line prompting.
+	statusLinePrinter := &cmd.StatusLinePrinter{}
+	promptingCtx, promptingCancel := context.WithCancel(context.Background())
+	prompter, promptingErrors, err := promptingsvc.Host(
+		promptingCtx, promptingsvc.NewPromptingClient(daemonConnection),
+		&cmd.StatusLinePrompter{Printer: statusLinePrinter}, true,
+	)
+	if err != nil {
+		promptingCancel()
+		return nil, fmt.Errorf("unable to initiate prompting: %w", err)
+	}
+
+	// Perform the restore operation, cancel prompting, and handle errors.
+	synchronizationService := synchronizationsvc.NewSynchronizationClient(daemonConnection)
+	request := &synchronizationsvc.RestoreRequest{
+		Prompter:  prompter,
+		Selection: selection,
+		Before:    timestamppb.New(before),
+		Paths:     paths,
+	}
+	response, err := synchronizationService.Restore(context.Background(), request)
+	promptingCancel()
+	<-promptingErrors
+	if err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return nil, grpcutil.PeelAwayRPCErrorLayer(err)
+	} else if err = response.EnsureValid(); err != nil {
+		statusLinePrinter.BreakIfPopulated()
+		return nil, fmt.Errorf("invalid restore response received: %w", err)
+	}
+
+	// Success.
+	statusLinePrinter.Clear()
+	return response.Results, nil
+}
+
+// parseRestoreTime parses a restoration time specification, which may either
+// be an RFC 3339 timestamp or a duration relative to the current time.
+func parseRestoreTime(specification string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, specification); err == nil {
+		return t, nil
+	} else if d, err := time.ParseDuration(specification); err == nil {
+		if d < 0 {
+			return time.Time{}, errors.New("negative duration")
+		}
+		return time.Now().Add(-d), nil
+	}
+	return time.Time{}, errors.New("time must be an RFC 3339 timestamp or a duration")
+}
+
+// printRestoredEndpoint prints restoration results for a single endpoint.
+func printRestoredEndpoint(name string, restored []string, problems []*core.Problem) {
+	fmt.Printf("\t%s: %d restored\n", name, len(restored))
+	for _, path := range restored {
+		fmt.Printf("\t\t%s\n", formatPath(path))
+	}
+	for _, p := range problems {
+		color.Red("\t\t%s: %v\n", formatPath(p.Path), p.Error)
+	}
+}
+
+// restoreMain is the entry point for the restore command.
+func restoreMain(_ *cobra.Command, arguments []string) error {
+	// Ensure that a session has been specified.
+	if len(arguments) == 0 {
+		return errors.New("session not specified")
+	}
+
+	// Validate and parse the restoration time.
+	if restoreConfiguration.before == "" {
+		return errors.New("restoration time must be specified")
+	}
+	before, err := parseRestoreTime(restoreConfiguration.before)
+	if err != nil {
+		return fmt.Errorf("unable to parse restoration time: %w", err)
+	}
+
+	// Create session selection specification.
+	selection := &selection.Selection{
+		Specifications: arguments[:1],
+	}
+	if err := selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session selection specification: %w", err)
+	}
+
+	// Connect to the daemon and defer closure of the connection.
+	daemonConnection, err := daemon.Connect(true, true)
+	if err != nil {
+		return fmt.Errorf("unable to connect to daemon: %w", err)
+	}
+	defer daemonConnection.Close()
+
+	// Perform the restore operation.
+	results, err := RestoreWithSelection(daemonConnection, selection, before, arguments[1:])
+	if err != nil {
+		return err
+	}
+
+	// Print results.
+	for _, result := range results {
+		fmt.Println("Session:", result.Session)
+		printRestoredEndpoint("Alpha", result.AlphaRestored, result.AlphaProblems)
+		printRestoredEndpoint("Beta", result.BetaRestored, result.BetaProblems)
+	}
+
+	// Success.
+	return nil
+}
+
+// restoreCommand is the restore command.
+var restoreCommand = &cobra.Command{
+	Use:          "restore <session> [<path>...]",
+	Short:        "Restore snapshotted content that existed at a point in time",
+	RunE:         restoreMain,
+	SilenceUsage: true,
+}
+
+// restoreConfiguration stores configuration for the restore command.
+var restoreConfiguration struct {
+	// help indicates whether or not to show help information and exit.
+	help bool
+	// before is the point in time before which content should be restored.
+	before string
+}
+
+func init() {
+	// Grab a handle for the command line flags.
+	flags := restoreCommand.Flags()
+
+	// Disable alphabetical sorting of flags in help output.
+	flags.SortFlags = false
+
+	// Manually add a help flag to override the default message. Cobra will
+	// still implement its logic automatically.
+	flags.BoolVarP(&restoreConfiguration.help, "help", "h", false, "Show help information")
+
+	// Wire up restore flags.
+	flags.StringVar(&restoreConfiguration.before, "before", "", "Specify the point in time to restore (RFC 3339 timestamp or duration ago)")
+}
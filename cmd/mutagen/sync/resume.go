@@ -64,6 +64,7 @@ func resumeMain(_ *cobra.Command, arguments []string) error {
 		All:            resumeConfiguration.all,
 		Specifications: arguments,
 		LabelSelector:  resumeConfiguration.labelSelector,
+		Selectors:      resumeConfiguration.selectors,
 	}
 	if err := selection.EnsureValid(); err != nil {
 		return fmt.Errorf("invalid session selection specification: %w", err)
@@ -97,6 +98,9 @@ var resumeConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// selectors are selector specifications to be used in identifying which
+	// sessions should be selected.
+	selectors []string
 }
 
 func init() {
@@ -113,4 +117,5 @@ func init() {
 	// Wire up resume flags.
 	flags.BoolVarP(&resumeConfiguration.all, "all", "a", false, "Resume all sessions")
 	flags.StringVar(&resumeConfiguration.labelSelector, "label-selector", "", "Resume sessions matching the specified label selector")
+	flags.StringArrayVar(&resumeConfiguration.selectors, "select", nil, "Resume sessions matching the specified selector (may be repeated)")
 }
@@ -65,6 +65,7 @@ func terminateMain(_ *cobra.Command, arguments []string) error {
 		All:            terminateConfiguration.all,
 		Specifications: arguments,
 		LabelSelector:  terminateConfiguration.labelSelector,
+		Selectors:      terminateConfiguration.selectors,
 	}
 	if err := selection.EnsureValid(); err != nil {
 		return fmt.Errorf("invalid session selection specification: %w", err)
@@ -98,6 +99,9 @@ var terminateConfiguration struct {
 	// labelSelector encodes a label selector to be used in identifying which
 	// sessions should be paused.
 	labelSelector string
+	// selectors are selector specifications to be used in identifying which
+	// sessions should be selected.
+	selectors []string
 }
 
 func init() {
@@ -114,4 +118,5 @@ func init() {
 	// Wire up terminate flags.
 	flags.BoolVarP(&terminateConfiguration.all, "all", "a", false, "Terminate all sessions")
 	flags.StringVar(&terminateConfiguration.labelSelector, "label-selector", "", "Terminate sessions matching the specified label selector")
+	flags.StringArrayVar(&terminateConfiguration.selectors, "select", nil, "Terminate sessions matching the specified selector (may be repeated)")
 }
@@ -178,7 +178,7 @@ func connect(logger *logging.Logger, transport Transport, mode, prompter string,
 // connection mode, and prompter.
 func Dial(logger *logging.Logger, transport Transport, mode, prompter string) (io.ReadWriteCloser, error) {
 	// Validate that the mode is sane.
-	if !(mode == CommandSynchronizer || mode == CommandForwarder) {
+	if !(mode == CommandSynchronizer || mode == CommandForwarder || mode == CommandExecutor) {
 		return nil, errors.New("invalid agent dial mode")
 	}
 
@@ -3,10 +3,14 @@ package forwarding
 import (
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
 )
 
 // Configuration represents forwarding session configuration.
 type Configuration struct {
+	// BackgroundPrompting specifies whether or not automatic reconnections may
+	// use the daemon's default prompter.
+	BackgroundPrompting prompting.BackgroundPromptingMode `json:"backgroundPrompting,omitempty" yaml:"backgroundPrompting" mapstructure:"backgroundPrompting"`
 	// Socket contains parameters related to Unix domain socket handling.
 	Socket struct {
 		// OverwriteMode specifies the default socket overwrite mode to use for
@@ -22,16 +26,36 @@ type Configuration struct {
 		// listener sockets.
 		PermissionMode filesystem.Mode `json:"permissionMode,omitempty" yaml:"permissionMode" mapstructure:"permissionMode"`
 	} `json:"socket" yaml:"socket" mapstructure:"socket"`
+	// Policy contains parameters related to automatic session suspension.
+	Policy struct {
+		// DisconnectedTimeout specifies the duration (in seconds) for which
+		// the session can remain disconnected before it's suspended. A value
+		// of 0 indicates that the session is never suspended due to
+		// disconnection.
+		DisconnectedTimeout uint32 `json:"disconnectedTimeout,omitempty" yaml:"disconnectedTimeout" mapstructure:"disconnectedTimeout"`
+		// DisconnectedRetryInterval specifies the interval (in seconds) at
+		// which reconnection is attempted once the session has been suspended
+		// due to disconnection. A value of 0 specifies that Mutagen's internal
+		// default interval should be used.
+		DisconnectedRetryInterval uint32 `json:"disconnectedRetryInterval,omitempty" yaml:"disconnectedRetryInterval" mapstructure:"disconnectedRetryInterval"`
+	} `json:"policy" yaml:"policy" mapstructure:"policy"`
 }
 
 // loadFromInternal sets a configuration to match an internal Protocol Buffers
 // representation. The configuration must be valid.
 func (c *Configuration) loadFromInternal(configuration *forwarding.Configuration) {
+	// Propagate top-level configuration.
+	c.BackgroundPrompting = configuration.BackgroundPromptingMode
+
 	// Propagate socket configuration.
 	c.Socket.OverwriteMode = configuration.SocketOverwriteMode
 	c.Socket.Owner = configuration.SocketOwner
 	c.Socket.Group = configuration.SocketGroup
 	c.Socket.PermissionMode = filesystem.Mode(configuration.SocketPermissionMode)
+
+	// Propagate policy configuration.
+	c.Policy.DisconnectedTimeout = configuration.DisconnectedTimeout
+	c.Policy.DisconnectedRetryInterval = configuration.DisconnectedRetryInterval
 }
 
 // ToInternal converts a public configuration representation to an internal
@@ -39,9 +63,12 @@ func (c *Configuration) loadFromInternal(configuration *forwarding.Configuration
 // configuration.
 func (c *Configuration) ToInternal() *forwarding.Configuration {
 	return &forwarding.Configuration{
-		SocketOverwriteMode:  c.Socket.OverwriteMode,
-		SocketOwner:          c.Socket.Owner,
-		SocketGroup:          c.Socket.Group,
-		SocketPermissionMode: uint32(c.Socket.PermissionMode),
+		BackgroundPromptingMode:   c.BackgroundPrompting,
+		DisconnectedTimeout:       c.Policy.DisconnectedTimeout,
+		DisconnectedRetryInterval: c.Policy.DisconnectedRetryInterval,
+		SocketOverwriteMode:       c.Socket.OverwriteMode,
+		SocketOwner:               c.Socket.Owner,
+		SocketGroup:               c.Socket.Group,
+		SocketPermissionMode:      uint32(c.Socket.PermissionMode),
 	}
 }
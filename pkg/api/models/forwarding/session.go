@@ -52,6 +52,9 @@ type SessionState struct {
 	// TotalInboundData is the total amount of data (in bytes) that has been
 	// transmitted from destination to source across all forwarded connections.
 	TotalInboundData uint64 `json:"totalInboundData"`
+	// Suspension describes why forwarding has been suspended by the session's
+	// policies, if applicable.
+	Suspension string `json:"suspension,omitempty"`
 }
 
 // loadFromInternal sets a session to match an internal Protocol Buffers session
@@ -96,6 +99,7 @@ func (s *Session) loadFromInternal(state *forwarding.State) {
 			TotalConnections:  state.TotalConnections,
 			TotalOutboundData: state.TotalOutboundData,
 			TotalInboundData:  state.TotalInboundData,
+			Suspension:        state.Suspension,
 		}
 	}
 }
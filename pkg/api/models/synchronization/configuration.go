@@ -4,6 +4,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/api/models/types"
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 )
@@ -22,8 +23,26 @@ type Configuration struct {
 	ProbeMode behavior.ProbeMode `json:"probeMode,omitempty" yaml:"probeMode" mapstructure:"probeMode"`
 	// ScanMode specifies the filesystem scanning mode.
 	ScanMode synchronization.ScanMode `json:"scanMode,omitempty" yaml:"scanMode" mapstructure:"scanMode"`
+	// ScanParallelism specifies the number of workers used to traverse
+	// directories and compute file digests when scanning.
+	ScanParallelism uint32 `json:"scanParallelism,omitempty" yaml:"scanParallelism" mapstructure:"scanParallelism"`
 	// StageMode specifies the filesystem staging mode.
 	StageMode synchronization.StageMode `json:"stageMode,omitempty" yaml:"stageMode" mapstructure:"stageMode"`
+	// InPlaceUpdateThreshold is the minimum size of existing files that
+	// endpoints will update in place by applying block-level deltas. It can be
+	// specified in human-friendly units.
+	InPlaceUpdateThreshold types.ByteSize `json:"inPlaceUpdateThreshold,omitempty" yaml:"inPlaceUpdateThreshold" mapstructure:"inPlaceUpdateThreshold"`
+	// NameCollisionPolicy specifies the policy for handling names that would
+	// collide on an endpoint that ignores case or Unicode normalization.
+	NameCollisionPolicy core.NameCollisionPolicy `json:"nameCollisionPolicy,omitempty" yaml:"nameCollisionPolicy" mapstructure:"nameCollisionPolicy"`
+	// HardLinkMode specifies the hard link handling mode.
+	HardLinkMode core.HardLinkMode `json:"hardLinkMode,omitempty" yaml:"hardLinkMode" mapstructure:"hardLinkMode"`
+	// Includes specifies synchronization root-relative paths to which
+	// synchronization should be limited.
+	Includes []string `json:"includes,omitempty" yaml:"includes" mapstructure:"includes"`
+	// BackgroundPrompting specifies whether or not automatic reconnections may
+	// use the daemon's default prompter.
+	BackgroundPrompting prompting.BackgroundPromptingMode `json:"backgroundPrompting,omitempty" yaml:"backgroundPrompting" mapstructure:"backgroundPrompting"`
 	// Ignore contains parameters related to synchronization ignore
 	// specifications.
 	Ignore struct {
@@ -31,6 +50,8 @@ type Configuration struct {
 		Paths []string `json:"paths,omitempty" yaml:"paths" mapstructure:"paths"`
 		// VCS specifies the VCS ignore mode.
 		VCS core.IgnoreVCSMode `json:"vcs,omitempty" yaml:"vcs" mapstructure:"vcs"`
+		// Files specifies the names of per-directory ignore files.
+		Files []string `json:"files,omitempty" yaml:"files" mapstructure:"files"`
 	} `json:"ignore" yaml:"ignore" mapstructure:"ignore"`
 	// Symlink contains parameters related to symbolic link handling.
 	Symlink struct {
@@ -65,6 +86,43 @@ type Configuration struct {
 		// permission propagation mode.
 		DefaultGroup string `json:"defaultGroup,omitempty" yaml:"defaultGroup" mapstructure:"defaultGroup"`
 	} `json:"permissions" yaml:"permissions" mapstructure:"permissions"`
+	// Snapshot contains parameters related to point-in-time content snapshots.
+	Snapshot struct {
+		// MaximumSize specifies the maximum total size of file contents that
+		// an endpoint will retain for point-in-time restoration. A zero value
+		// indicates that snapshots are disabled.
+		MaximumSize types.ByteSize `json:"maxSize,omitempty" yaml:"maxSize" mapstructure:"maxSize"`
+	} `json:"snapshot" yaml:"snapshot" mapstructure:"snapshot"`
+	// Metadata contains parameters related to file metadata propagation.
+	Metadata struct {
+		// ModificationTimes specifies the modification time mode.
+		ModificationTimes core.ModificationTimeMode `json:"modificationTimes,omitempty" yaml:"modificationTimes" mapstructure:"modificationTimes"`
+		// ExtendedAttributes specifies name patterns for extended attributes
+		// (including POSIX ACLs) that should be propagated.
+		ExtendedAttributes []string `json:"extendedAttributes,omitempty" yaml:"extendedAttributes" mapstructure:"extendedAttributes"`
+	} `json:"metadata" yaml:"metadata" mapstructure:"metadata"`
+	// Policy contains parameters related to automatic session suspension.
+	Policy struct {
+		// OnBattery specifies how the session responds to the host running on
+		// battery power.
+		OnBattery synchronization.ConditionPolicy `json:"onBattery,omitempty" yaml:"onBattery" mapstructure:"onBattery"`
+		// OnMetered specifies how the session responds to the host using a
+		// metered network connection.
+		OnMetered synchronization.ConditionPolicy `json:"onMetered,omitempty" yaml:"onMetered" mapstructure:"onMetered"`
+		// ActiveHours specifies the daily time range (in the form
+		// "HH:MM-HH:MM") during which synchronization is allowed.
+		ActiveHours string `json:"activeHours,omitempty" yaml:"activeHours" mapstructure:"activeHours"`
+		// DisconnectedTimeout specifies the duration (in seconds) for which
+		// the session can remain disconnected before it's suspended. A value
+		// of 0 indicates that the session is never suspended due to
+		// disconnection.
+		DisconnectedTimeout uint32 `json:"disconnectedTimeout,omitempty" yaml:"disconnectedTimeout" mapstructure:"disconnectedTimeout"`
+		// DisconnectedRetryInterval specifies the interval (in seconds) at
+		// which reconnection is attempted once the session has been suspended
+		// due to disconnection. A value of 0 specifies that Mutagen's internal
+		// default interval should be used.
+		DisconnectedRetryInterval uint32 `json:"disconnectedRetryInterval,omitempty" yaml:"disconnectedRetryInterval" mapstructure:"disconnectedRetryInterval"`
+	} `json:"policy" yaml:"policy" mapstructure:"policy"`
 }
 
 // loadFromInternal sets a configuration to match an internal
@@ -76,13 +134,20 @@ func (c *Configuration) loadFromInternal(configuration *synchronization.Configur
 	c.MaximumStagingFileSize = types.ByteSize(configuration.MaximumStagingFileSize)
 	c.ProbeMode = configuration.ProbeMode
 	c.ScanMode = configuration.ScanMode
+	c.ScanParallelism = configuration.ScanParallelism
 	c.StageMode = configuration.StageMode
+	c.InPlaceUpdateThreshold = types.ByteSize(configuration.InPlaceUpdateThreshold)
+	c.NameCollisionPolicy = configuration.NameCollisionPolicy
+	c.HardLinkMode = configuration.HardLinkMode
+	c.Includes = configuration.Includes
+	c.BackgroundPrompting = configuration.BackgroundPromptingMode
 
 	// Propagate ignore configuration.
 	c.Ignore.Paths = make([]string, 0, len(configuration.DefaultIgnores)+len(configuration.Ignores))
 	c.Ignore.Paths = append(c.Ignore.Paths, configuration.DefaultIgnores...)
 	c.Ignore.Paths = append(c.Ignore.Paths, configuration.Ignores...)
 	c.Ignore.VCS = configuration.IgnoreVCSMode
+	c.Ignore.Files = configuration.IgnoreFiles
 
 	// Propagate symbolic link configuration.
 	c.Symlink.Mode = configuration.SymbolicLinkMode
@@ -97,6 +162,20 @@ func (c *Configuration) loadFromInternal(configuration *synchronization.Configur
 	c.Permissions.DefaultDirectoryMode = filesystem.Mode(configuration.DefaultDirectoryMode)
 	c.Permissions.DefaultOwner = configuration.DefaultOwner
 	c.Permissions.DefaultGroup = configuration.DefaultGroup
+
+	// Propagate snapshot configuration.
+	c.Snapshot.MaximumSize = types.ByteSize(configuration.MaximumSnapshotSize)
+
+	// Propagate metadata configuration.
+	c.Metadata.ModificationTimes = configuration.ModificationTimeMode
+	c.Metadata.ExtendedAttributes = configuration.ExtendedAttributes
+
+	// Propagate policy configuration.
+	c.Policy.OnBattery = configuration.BatteryPolicy
+	c.Policy.OnMetered = configuration.MeteredPolicy
+	c.Policy.ActiveHours = configuration.ActiveHours
+	c.Policy.DisconnectedTimeout = configuration.DisconnectedTimeout
+	c.Policy.DisconnectedRetryInterval = configuration.DisconnectedRetryInterval
 }
 
 // ToInternal converts a public configuration representation to an internal
@@ -104,21 +183,36 @@ func (c *Configuration) loadFromInternal(configuration *synchronization.Configur
 // configuration.
 func (c *Configuration) ToInternal() *synchronization.Configuration {
 	return &synchronization.Configuration{
-		SynchronizationMode:    c.Mode,
-		MaximumEntryCount:      c.MaximumEntryCount,
-		MaximumStagingFileSize: uint64(c.MaximumStagingFileSize),
-		ProbeMode:              c.ProbeMode,
-		ScanMode:               c.ScanMode,
-		StageMode:              c.StageMode,
-		SymbolicLinkMode:       c.Symlink.Mode,
-		WatchMode:              c.Watch.Mode,
-		WatchPollingInterval:   c.Watch.PollingInterval,
-		Ignores:                c.Ignore.Paths,
-		IgnoreVCSMode:          c.Ignore.VCS,
-		PermissionsMode:        c.Permissions.Mode,
-		DefaultFileMode:        uint32(c.Permissions.DefaultFileMode),
-		DefaultDirectoryMode:   uint32(c.Permissions.DefaultDirectoryMode),
-		DefaultOwner:           c.Permissions.DefaultOwner,
-		DefaultGroup:           c.Permissions.DefaultGroup,
+		SynchronizationMode:       c.Mode,
+		MaximumEntryCount:         c.MaximumEntryCount,
+		MaximumStagingFileSize:    uint64(c.MaximumStagingFileSize),
+		ProbeMode:                 c.ProbeMode,
+		ScanMode:                  c.ScanMode,
+		ScanParallelism:           c.ScanParallelism,
+		StageMode:                 c.StageMode,
+		InPlaceUpdateThreshold:    uint64(c.InPlaceUpdateThreshold),
+		NameCollisionPolicy:       c.NameCollisionPolicy,
+		HardLinkMode:              c.HardLinkMode,
+		SymbolicLinkMode:          c.Symlink.Mode,
+		WatchMode:                 c.Watch.Mode,
+		WatchPollingInterval:      c.Watch.PollingInterval,
+		Ignores:                   c.Ignore.Paths,
+		IgnoreVCSMode:             c.Ignore.VCS,
+		IgnoreFiles:               c.Ignore.Files,
+		Includes:                  c.Includes,
+		PermissionsMode:           c.Permissions.Mode,
+		DefaultFileMode:           uint32(c.Permissions.DefaultFileMode),
+		DefaultDirectoryMode:      uint32(c.Permissions.DefaultDirectoryMode),
+		DefaultOwner:              c.Permissions.DefaultOwner,
+		DefaultGroup:              c.Permissions.DefaultGroup,
+		MaximumSnapshotSize:       uint64(c.Snapshot.MaximumSize),
+		ModificationTimeMode:      c.Metadata.ModificationTimes,
+		ExtendedAttributes:        c.Metadata.ExtendedAttributes,
+		BackgroundPromptingMode:   c.BackgroundPrompting,
+		BatteryPolicy:             c.Policy.OnBattery,
+		MeteredPolicy:             c.Policy.OnMetered,
+		ActiveHours:               c.Policy.ActiveHours,
+		DisconnectedTimeout:       c.Policy.DisconnectedTimeout,
+		DisconnectedRetryInterval: c.Policy.DisconnectedRetryInterval,
 	}
 }
@@ -0,0 +1,41 @@
+package synchronization
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// NameCollision represents a set of names that would refer to the same entry on
+// an endpoint that ignores case or Unicode normalization.
+type NameCollision struct {
+	// Paths are the colliding paths, relative to the synchronization root.
+	Paths []string `json:"paths"`
+	// Winner is the path that is synchronized based on the name collision
+	// policy, if any.
+	Winner string `json:"winner,omitempty"`
+}
+
+// loadFromInternal sets a name collision to match an internal Protocol Buffers
+// representation. The name collision must be valid.
+func (c *NameCollision) loadFromInternal(collision *core.NameCollision) {
+	c.Paths = collision.Paths
+	c.Winner = collision.Winner
+}
+
+// exportNameCollisions is a convenience function that calls
+// NameCollision.loadFromInternal for a slice of name collisions.
+func exportNameCollisions(collisions []*core.NameCollision) []NameCollision {
+	// If there are no name collisions, then just return a nil slice.
+	count := len(collisions)
+	if count == 0 {
+		return nil
+	}
+
+	// Create the resulting slice.
+	results := make([]NameCollision, count)
+	for i := 0; i < count; i++ {
+		results[i].loadFromInternal(collisions[i])
+	}
+
+	// Done.
+	return results
+}
@@ -51,6 +51,17 @@ type SessionState struct {
 	// Conflicts due to truncation. This value can only be non-zero if conflicts
 	// is non-empty.
 	ExcludedConflicts uint64 `json:"excludedConflicts,omitempty"`
+	// NameCollisions are the name collisions identified prior to
+	// reconciliation. This list may be a truncated version of the full list if
+	// too many collisions are encountered to report via the API.
+	NameCollisions []NameCollision `json:"nameCollisions,omitempty"`
+	// ExcludedNameCollisions is the number of name collisions that have been
+	// excluded from NameCollisions due to truncation. This value can only be
+	// non-zero if name collisions is non-empty.
+	ExcludedNameCollisions uint64 `json:"excludedNameCollisions,omitempty"`
+	// Suspension describes why synchronization has been suspended by the
+	// session's policies, if applicable.
+	Suspension string `json:"suspension,omitempty"`
 }
 
 // loadFromInternal sets a session to match an internal Protocol Buffers session
@@ -90,10 +101,13 @@ func (s *Session) loadFromInternal(state *synchronization.State) {
 		s.SessionState = nil
 	} else {
 		s.SessionState = &SessionState{
-			LastError:         state.LastError,
-			SuccessfulCycles:  state.SuccessfulCycles,
-			Conflicts:         exportConflicts(state.Conflicts),
-			ExcludedConflicts: state.ExcludedConflicts,
+			LastError:              state.LastError,
+			SuccessfulCycles:       state.SuccessfulCycles,
+			Conflicts:              exportConflicts(state.Conflicts),
+			ExcludedConflicts:      state.ExcludedConflicts,
+			NameCollisions:         exportNameCollisions(state.NameCollisions),
+			ExcludedNameCollisions: state.ExcludedNameCollisions,
+			Suspension:             state.Suspension,
 		}
 	}
 }
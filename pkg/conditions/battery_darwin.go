@@ -0,0 +1,17 @@
+package conditions
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// onBattery determines whether or not the host is running on battery power.
+func onBattery() bool {
+	// Query the power source using pmset, whose output begins with a line of
+	// the form "Now drawing from 'Battery Power'".
+	output, err := exec.Command("pmset", "-g", "batt").Output()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(output), "'Battery Power'")
+}
@@ -0,0 +1,56 @@
+package conditions
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// powerSupplyDirectory is the sysfs directory containing power supply
+// information.
+const powerSupplyDirectory = "/sys/class/power_supply"
+
+// readPowerSupplyAttribute reads a power supply attribute from sysfs.
+func readPowerSupplyAttribute(supply, attribute string) string {
+	contents, err := os.ReadFile(filepath.Join(supply, attribute))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(contents))
+}
+
+// onBatteryWithPowerSupplies determines whether or not the power supplies in
+// the specified directory indicate that the host is running on battery power.
+// This is the case if there's no online mains power supply and at least one
+// battery is discharging.
+func onBatteryWithPowerSupplies(directory string) bool {
+	// List the power supplies.
+	supplies, err := os.ReadDir(directory)
+	if err != nil {
+		return false
+	}
+
+	// Check each power supply.
+	var discharging bool
+	for _, s := range supplies {
+		supply := filepath.Join(directory, s.Name())
+		switch readPowerSupplyAttribute(supply, "type") {
+		case "Mains":
+			if readPowerSupplyAttribute(supply, "online") == "1" {
+				return false
+			}
+		case "Battery":
+			if readPowerSupplyAttribute(supply, "status") == "Discharging" {
+				discharging = true
+			}
+		}
+	}
+
+	// Done.
+	return discharging
+}
+
+// onBattery determines whether or not the host is running on battery power.
+func onBattery() bool {
+	return onBatteryWithPowerSupplies(powerSupplyDirectory)
+}
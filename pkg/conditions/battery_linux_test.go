@@ -0,0 +1,67 @@
+package conditions
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestOnBatteryWithPowerSupplies tests onBatteryWithPowerSupplies.
+func TestOnBatteryWithPowerSupplies(t *testing.T) {
+	// Set up test cases. Each power supply is specified as a map of attribute
+	// names to values.
+	testCases := []struct {
+		description string
+		supplies    map[string]map[string]string
+		expected    bool
+	}{
+		{"no power supplies", nil, false},
+		{"mains only", map[string]map[string]string{
+			"AC": {"type": "Mains", "online": "1"},
+		}, false},
+		{"discharging without mains", map[string]map[string]string{
+			"BAT0": {"type": "Battery", "status": "Discharging"},
+		}, true},
+		{"discharging with offline mains", map[string]map[string]string{
+			"AC":   {"type": "Mains", "online": "0"},
+			"BAT0": {"type": "Battery", "status": "Discharging"},
+		}, true},
+		{"discharging with online mains", map[string]map[string]string{
+			"AC":   {"type": "Mains", "online": "1"},
+			"BAT0": {"type": "Battery", "status": "Discharging"},
+		}, false},
+		{"charging", map[string]map[string]string{
+			"AC":   {"type": "Mains", "online": "0"},
+			"BAT0": {"type": "Battery", "status": "Charging"},
+		}, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		// Create the power supply directory.
+		directory := t.TempDir()
+		for name, attributes := range testCase.supplies {
+			supply := filepath.Join(directory, name)
+			if err := os.Mkdir(supply, 0700); err != nil {
+				t.Fatal("unable to create power supply directory:", err)
+			}
+			for attribute, value := range attributes {
+				if err := os.WriteFile(filepath.Join(supply, attribute), []byte(value+"\n"), 0600); err != nil {
+					t.Fatal("unable to write power supply attribute:", err)
+				}
+			}
+		}
+
+		// Check the result.
+		if result := onBatteryWithPowerSupplies(directory); result != testCase.expected {
+			t.Errorf("%s: battery status (%t) does not match expected (%t)",
+				testCase.description, result, testCase.expected,
+			)
+		}
+	}
+
+	// Verify that a missing directory is treated as not on battery.
+	if onBatteryWithPowerSupplies(filepath.Join(t.TempDir(), "missing")) {
+		t.Error("missing power supply directory treated as on battery")
+	}
+}
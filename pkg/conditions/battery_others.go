@@ -0,0 +1,9 @@
+//go:build !linux && !darwin && !windows
+
+package conditions
+
+// onBattery determines whether or not the host is running on battery power. It
+// always returns false on this platform.
+func onBattery() bool {
+	return false
+}
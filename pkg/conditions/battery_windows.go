@@ -0,0 +1,41 @@
+package conditions
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var (
+	// kernel32 is the kernel32.dll library.
+	kernel32 = windows.NewLazySystemDLL("kernel32.dll")
+	// getSystemPowerStatus is the GetSystemPowerStatus function.
+	getSystemPowerStatus = kernel32.NewProc("GetSystemPowerStatus")
+)
+
+// systemPowerStatus is the Go representation of SYSTEM_POWER_STATUS.
+type systemPowerStatus struct {
+	// acLineStatus is the AC power status (0 for offline, 1 for online, and
+	// 255 for unknown).
+	acLineStatus byte
+	// batteryFlag is the battery charge status.
+	batteryFlag byte
+	// batteryLifePercent is the percentage of remaining battery charge.
+	batteryLifePercent byte
+	// systemStatusFlag is the battery saver status.
+	systemStatusFlag byte
+	// batteryLifeTime is the number of seconds of remaining battery life.
+	batteryLifeTime uint32
+	// batteryFullLifeTime is the number of seconds of battery life when fully
+	// charged.
+	batteryFullLifeTime uint32
+}
+
+// onBattery determines whether or not the host is running on battery power.
+func onBattery() bool {
+	var status systemPowerStatus
+	if result, _, _ := getSystemPowerStatus.Call(uintptr(unsafe.Pointer(&status))); result == 0 {
+		return false
+	}
+	return status.acLineStatus == 0
+}
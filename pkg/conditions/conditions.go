@@ -0,0 +1,20 @@
+package conditions
+
+// Conditions encodes the current host conditions. Conditions that can't be
+// detected on the current platform are always reported as false.
+type Conditions struct {
+	// OnBattery indicates whether or not the host is running on battery power.
+	OnBattery bool
+	// Metered indicates whether or not the host's primary network connection
+	// is metered.
+	Metered bool
+}
+
+// Detect determines the current host conditions. Detection is best-effort, and
+// any condition that can't be determined is reported as false.
+func Detect() Conditions {
+	return Conditions{
+		OnBattery: onBattery(),
+		Metered:   metered(),
+	}
+}
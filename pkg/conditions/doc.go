@@ -0,0 +1,4 @@
+// Package conditions provides detection of host conditions (such as the power
+// source and network connection type) that can influence whether or not
+// background operations should be performed.
+package conditions
@@ -0,0 +1,36 @@
+package conditions
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// meteredFromNetworkManagerProperty determines whether or not the value of
+// NetworkManager's Metered property (as printed by busctl) indicates a metered
+// connection. The property uses the NMMetered enumeration, for which 1 and 3
+// indicate a definitely or probably metered connection, respectively.
+func meteredFromNetworkManagerProperty(output string) bool {
+	switch strings.TrimSpace(output) {
+	case "u 1", "u 3":
+		return true
+	default:
+		return false
+	}
+}
+
+// metered determines whether or not the host's primary network connection is
+// metered. It relies on NetworkManager, so hosts without NetworkManager are
+// always treated as unmetered.
+func metered() bool {
+	output, err := exec.Command(
+		"busctl", "get-property",
+		"org.freedesktop.NetworkManager",
+		"/org/freedesktop/NetworkManager",
+		"org.freedesktop.NetworkManager",
+		"Metered",
+	).Output()
+	if err != nil {
+		return false
+	}
+	return meteredFromNetworkManagerProperty(string(output))
+}
@@ -0,0 +1,31 @@
+package conditions
+
+import (
+	"testing"
+)
+
+// TestMeteredFromNetworkManagerProperty tests
+// meteredFromNetworkManagerProperty.
+func TestMeteredFromNetworkManagerProperty(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		output   string
+		expected bool
+	}{
+		{"", false},
+		{"u 0\n", false},
+		{"u 1\n", true},
+		{"u 2\n", false},
+		{"u 3\n", true},
+		{"u 4\n", false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if result := meteredFromNetworkManagerProperty(testCase.output); result != testCase.expected {
+			t.Errorf("metered status (%t) for output %q does not match expected (%t)",
+				result, testCase.output, testCase.expected,
+			)
+		}
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !linux
+
+package conditions
+
+// metered determines whether or not the host's primary network connection is
+// metered. It always returns false on this platform.
+func metered() bool {
+	return false
+}
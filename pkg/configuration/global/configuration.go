@@ -1,13 +1,78 @@
 package global
 
 import (
+	"time"
+
 	"github.com/mutagen-io/mutagen/pkg/api/models/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/api/models/types"
 	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+const (
+	// defaultMaximumDaemonLogSize is the default size beyond which the daemon
+	// log file is rotated.
+	defaultMaximumDaemonLogSize = 10 * 1024 * 1024
+	// defaultMaximumDaemonLogAge is the default maximum age of rotated daemon
+	// log segments.
+	defaultMaximumDaemonLogAge = 7 * 24 * time.Hour
+	// defaultMaximumDaemonLogSegments is the default maximum number of rotated
+	// daemon log segments.
+	defaultMaximumDaemonLogSegments = 5
 )
 
+// DaemonLogConfiguration is the daemon log file configuration.
+type DaemonLogConfiguration struct {
+	// Disabled indicates that the daemon should not log to a file.
+	Disabled bool `yaml:"disabled"`
+	// MaximumSize is the size beyond which the log file is rotated. If zero,
+	// then a default value is used.
+	MaximumSize types.ByteSize `yaml:"maxSize"`
+	// MaximumAge is the maximum age of rotated log segments. If zero, then a
+	// default value is used.
+	MaximumAge time.Duration `yaml:"maxAge"`
+	// MaximumSegments is the maximum number of rotated log segments to retain.
+	// If zero, then a default value is used.
+	MaximumSegments uint `yaml:"maxSegments"`
+	// DisableCompression indicates that rotated log segments should not be
+	// compressed.
+	DisableCompression bool `yaml:"disableCompression"`
+}
+
+// RotationPolicy computes the log rotation policy for the daemon log file,
+// filling in default values where necessary.
+func (c *DaemonLogConfiguration) RotationPolicy() logging.RotationPolicy {
+	// Create the policy with default values.
+	policy := logging.RotationPolicy{
+		MaximumSize:     defaultMaximumDaemonLogSize,
+		MaximumAge:      defaultMaximumDaemonLogAge,
+		MaximumSegments: defaultMaximumDaemonLogSegments,
+		Compress:        !c.DisableCompression,
+	}
+
+	// Apply overrides.
+	if c.MaximumSize != 0 {
+		policy.MaximumSize = uint64(c.MaximumSize)
+	}
+	if c.MaximumAge > 0 {
+		policy.MaximumAge = c.MaximumAge
+	}
+	if c.MaximumSegments != 0 {
+		policy.MaximumSegments = int(c.MaximumSegments)
+	}
+
+	// Done.
+	return policy
+}
+
 // Configuration is the global YAML configuration object type.
 type Configuration struct {
+	// Daemon is the global daemon configuration.
+	Daemon struct {
+		// Logs is the daemon log file configuration.
+		Logs DaemonLogConfiguration `yaml:"logs"`
+	} `yaml:"daemon"`
 	// Forwarding is the global forwarding configuration.
 	Forwarding struct {
 		// Defaults are the global forwarding configuration defaults.
@@ -1,3 +1,94 @@
 package global
 
-// TODO: Implement.
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestLoadConfigurationDaemonLogs tests loading of daemon log configuration and
+// computation of the resulting rotation policy.
+func TestLoadConfigurationDaemonLogs(t *testing.T) {
+	// Write a configuration file with daemon log settings.
+	path := filepath.Join(t.TempDir(), "configuration.yml")
+	contents := "daemon:\n  logs:\n    maxSize: \"1 MB\"\n    maxAge: \"48h\"\n    disableCompression: true\n"
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal("unable to write configuration file:", err)
+	}
+
+	// Load the configuration.
+	configuration, err := LoadConfiguration(path)
+	if err != nil {
+		t.Fatal("unable to load configuration:", err)
+	}
+
+	// Verify the resulting rotation policy.
+	policy := configuration.Daemon.Logs.RotationPolicy()
+	if policy.MaximumSize != 1000*1000 {
+		t.Error("maximum size does not match expected:", policy.MaximumSize)
+	}
+	if policy.MaximumAge != 48*time.Hour {
+		t.Error("maximum age does not match expected:", policy.MaximumAge)
+	}
+	if policy.MaximumSegments != defaultMaximumDaemonLogSegments {
+		t.Error("maximum segments does not match default:", policy.MaximumSegments)
+	}
+	if policy.Compress {
+		t.Error("compression enabled despite being disabled")
+	}
+}
+
+// TestDaemonLogConfigurationDefaults tests the default daemon log rotation
+// policy.
+func TestDaemonLogConfigurationDefaults(t *testing.T) {
+	policy := (&DaemonLogConfiguration{}).RotationPolicy()
+	if policy.MaximumSize != defaultMaximumDaemonLogSize {
+		t.Error("maximum size does not match default:", policy.MaximumSize)
+	}
+	if policy.MaximumAge != defaultMaximumDaemonLogAge {
+		t.Error("maximum age does not match default:", policy.MaximumAge)
+	}
+	if !policy.Compress {
+		t.Error("compression not enabled by default")
+	}
+}
+
+// TestLoadConfigurationSynchronizationPolicy tests loading of synchronization
+// policy configuration.
+func TestLoadConfigurationSynchronizationPolicy(t *testing.T) {
+	// Write a configuration file with synchronization policy settings.
+	path := filepath.Join(t.TempDir(), "configuration.yml")
+	contents := "sync:\n  defaults:\n    policy:\n      onBattery: \"pause\"\n      onMetered: \"ignore\"\n" +
+		"      activeHours: \"09:00-18:00\"\n      disconnectedTimeout: 7200\n      disconnectedRetryInterval: 1800\n"
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal("unable to write configuration file:", err)
+	}
+
+	// Load the configuration and convert the synchronization defaults.
+	configuration, err := LoadConfiguration(path)
+	if err != nil {
+		t.Fatal("unable to load configuration:", err)
+	}
+	defaults := configuration.Synchronization.Defaults.ToInternal()
+	if err := defaults.EnsureValid(false); err != nil {
+		t.Fatal("synchronization defaults invalid:", err)
+	}
+
+	// Verify the resulting policies.
+	if !defaults.BatteryPolicy.Pauses() {
+		t.Error("battery policy does not match expected:", defaults.BatteryPolicy)
+	}
+	if defaults.MeteredPolicy.Pauses() || defaults.MeteredPolicy.IsDefault() {
+		t.Error("metered policy does not match expected:", defaults.MeteredPolicy)
+	}
+	if defaults.ActiveHours != "09:00-18:00" {
+		t.Error("active hours do not match expected:", defaults.ActiveHours)
+	}
+	if defaults.DisconnectedTimeout != 7200 {
+		t.Error("disconnected timeout does not match expected:", defaults.DisconnectedTimeout)
+	}
+	if defaults.DisconnectedRetryInterval != 1800 {
+		t.Error("disconnected retry interval does not match expected:", defaults.DisconnectedRetryInterval)
+	}
+}
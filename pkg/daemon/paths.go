@@ -14,6 +14,10 @@ const (
 	// endpointName is the name of the daemon IPC endpoint. It resides within
 	// the daemon subdirectory of the Mutagen directory.
 	endpointName = "daemon.sock"
+	// logName is the name of the daemon log file. It resides within the daemon
+	// subdirectory of the Mutagen directory. Rotated log segments are stored
+	// alongside it.
+	logName = "daemon.log"
 )
 
 // subpath computes a subpath of the daemon subdirectory, creating the daemon
@@ -40,3 +44,9 @@ func lockPath() (string, error) {
 func EndpointPath() (string, error) {
 	return subpath(endpointName)
 }
+
+// LogPath computes the path to the daemon log file, creating any intermediate
+// directories as necessary.
+func LogPath() (string, error) {
+	return subpath(logName)
+}
@@ -0,0 +1,129 @@
+package execution
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/multiplexing"
+)
+
+// Execute runs a command via an execution agent connected over the specified
+// stream, forwarding input from stdin (which may be nil if no input should be
+// provided) and copying the command's output to the specified writers. The
+// working directory may be empty, in which case the agent's working directory
+// is used. It returns the command's exit code. It enforces that the provided
+// stream is closed by the time this function returns, regardless of failure.
+func Execute(
+	stream io.ReadWriteCloser,
+	command, workingDirectory string,
+	stdin io.Reader, stdout, stderr io.Writer,
+) (int, error) {
+	// Adapt the stream to serve as a multiplexer carrier. This will also give
+	// us the buffering functionality we'll need for initialization.
+	carrier := multiplexing.NewCarrierFromStream(stream)
+
+	// Defer closure of the carrier in the event that initialization isn't
+	// successful. Otherwise, we'll rely on closure of the multiplexer to close
+	// the carrier.
+	var initializationSuccessful bool
+	defer func() {
+		if !initializationSuccessful {
+			carrier.Close()
+		}
+	}()
+
+	// Create and send the initialization request.
+	request := &Request{
+		Command:          command,
+		WorkingDirectory: workingDirectory,
+	}
+	if err := encoding.EncodeProtobuf(carrier, request); err != nil {
+		return 0, fmt.Errorf("unable to send initialization request: %w", err)
+	}
+
+	// Receive the initialization response, ensure that it's valid, and check
+	// for initialization errors.
+	response := &Response{}
+	if err := encoding.DecodeProtobuf(carrier, response); err != nil {
+		return 0, fmt.Errorf("unable to receive initialization response: %w", err)
+	} else if err = response.ensureValid(); err != nil {
+		return 0, fmt.Errorf("invalid initialization response received: %w", err)
+	} else if response.Error != "" {
+		return 0, fmt.Errorf("remote initialization failure: %w", errors.New(response.Error))
+	}
+
+	// Mark initialization as successful.
+	initializationSuccessful = true
+
+	// Multiplex the carrier and defer closure of the multiplexer.
+	multiplexer := multiplexing.Multiplex(carrier, false, nil)
+	defer multiplexer.Close()
+
+	// Open the standard input, standard output, standard error, and status
+	// streams. The order here must match the order in which the server accepts
+	// them.
+	stdinStream, err := multiplexer.OpenStream(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("unable to open standard input stream: %w", err)
+	}
+	stdoutStream, err := multiplexer.OpenStream(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("unable to open standard output stream: %w", err)
+	}
+	stderrStream, err := multiplexer.OpenStream(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("unable to open standard error stream: %w", err)
+	}
+	statusStream, err := multiplexer.OpenStream(context.Background())
+	if err != nil {
+		return 0, fmt.Errorf("unable to open status stream: %w", err)
+	}
+
+	// Start forwarding input. We don't wait for forwarding to complete since
+	// the command may exit without consuming all of its input.
+	if stdin != nil {
+		go func() {
+			io.Copy(stdinStream, stdin)
+			stdinStream.CloseWrite()
+		}()
+	} else {
+		stdinStream.CloseWrite()
+	}
+
+	// Start copying output.
+	outputDone := &sync.WaitGroup{}
+	outputDone.Add(2)
+	go func() {
+		io.Copy(stdout, stdoutStream)
+		outputDone.Done()
+	}()
+	go func() {
+		io.Copy(stderr, stderrStream)
+		outputDone.Done()
+	}()
+
+	// Receive the exit status and ensure that it's valid.
+	exitStatus := &ExitStatus{}
+	if err := encoding.DecodeProtobuf(bufio.NewReader(statusStream), exitStatus); err != nil {
+		return 0, fmt.Errorf("unable to receive exit status: %w", err)
+	} else if err = exitStatus.ensureValid(); err != nil {
+		return 0, fmt.Errorf("invalid exit status received: %w", err)
+	}
+
+	// Wait for output copying to complete. The server closes the output streams
+	// before transmitting the exit status, so this won't block indefinitely.
+	outputDone.Wait()
+
+	// Check for execution errors.
+	if exitStatus.Error != "" {
+		return 0, fmt.Errorf("unable to run command: %w", errors.New(exitStatus.Error))
+	}
+
+	// Success.
+	return int(exitStatus.ExitCode), nil
+}
@@ -0,0 +1,102 @@
+package execution
+
+import (
+	"bytes"
+	"io"
+	"net"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/logging"
+)
+
+// TestExecute tests running a command via an in-memory execution connection.
+func TestExecute(t *testing.T) {
+	// Skip this test on Windows, where our test command isn't valid.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create an in-memory connection and serve executions on one end.
+	clientConnection, serverConnection := net.Pipe()
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- Serve(logging.NewLogger(logging.LevelDisabled, io.Discard), serverConnection)
+	}()
+
+	// Run a command that produces output on both streams and exits with a
+	// non-zero exit code.
+	directory := t.TempDir()
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	exitCode, err := Execute(clientConnection, "pwd; echo error >&2; exit 3", directory, nil, stdout, stderr)
+	if err != nil {
+		t.Fatal("execution failed:", err)
+	}
+
+	// Verify results.
+	if exitCode != 3 {
+		t.Error("exit code mismatch:", exitCode, "!=", 3)
+	}
+	expected, _ := filepath.EvalSymlinks(directory)
+	if output, _ := filepath.EvalSymlinks(strings.TrimSpace(stdout.String())); output != expected {
+		t.Errorf("standard output mismatch: %q != %q", stdout.String(), expected)
+	}
+	if stderr.String() != "error\n" {
+		t.Errorf("standard error mismatch: %q", stderr.String())
+	}
+	if err := <-serveErrors; err != nil {
+		t.Error("serving failed:", err)
+	}
+}
+
+// TestExecuteInput tests that input is forwarded to commands and that commands
+// can exit without consuming their input.
+func TestExecuteInput(t *testing.T) {
+	// Skip this test on Windows, where our test commands aren't valid.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Run a command that echoes its input.
+	clientConnection, serverConnection := net.Pipe()
+	go Serve(logging.NewLogger(logging.LevelDisabled, io.Discard), serverConnection)
+	stdout := &bytes.Buffer{}
+	if exitCode, err := Execute(clientConnection, "cat", "", strings.NewReader("input"), stdout, io.Discard); err != nil {
+		t.Fatal("execution failed:", err)
+	} else if exitCode != 0 {
+		t.Error("non-zero exit code:", exitCode)
+	} else if stdout.String() != "input" {
+		t.Errorf("standard output mismatch: %q", stdout.String())
+	}
+
+	// Run a command that ignores its input, which never ends.
+	inputReader, inputWriter := io.Pipe()
+	defer inputWriter.Close()
+	clientConnection, serverConnection = net.Pipe()
+	go Serve(logging.NewLogger(logging.LevelDisabled, io.Discard), serverConnection)
+	if exitCode, err := Execute(clientConnection, "exit 0", "", inputReader, io.Discard, io.Discard); err != nil {
+		t.Fatal("execution failed:", err)
+	} else if exitCode != 0 {
+		t.Error("non-zero exit code:", exitCode)
+	}
+}
+
+// TestExecuteInvalidRequest tests that empty commands are rejected.
+func TestExecuteInvalidRequest(t *testing.T) {
+	// Create an in-memory connection and serve executions on one end.
+	clientConnection, serverConnection := net.Pipe()
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- Serve(logging.NewLogger(logging.LevelDisabled, io.Discard), serverConnection)
+	}()
+
+	// Attempt to run an empty command.
+	if _, err := Execute(clientConnection, "", "", nil, io.Discard, io.Discard); err == nil {
+		t.Error("empty command accepted")
+	}
+	if err := <-serveErrors; err == nil {
+		t.Error("serving succeeded for empty command")
+	}
+}
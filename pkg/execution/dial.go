@@ -0,0 +1,77 @@
+package execution
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/mutagen-io/mutagen/pkg/agent"
+	"github.com/mutagen-io/mutagen/pkg/agent/transport/docker"
+	"github.com/mutagen-io/mutagen/pkg/agent/transport/ssh"
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	urlpkg "github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// Dial connects to an execution agent on the endpoint identified by the
+// specified URL. The URL must use an agent-based protocol.
+func Dial(logger *logging.Logger, url *urlpkg.URL, prompter string) (io.ReadWriteCloser, error) {
+	// Create an agent transport for the URL.
+	var transport agent.Transport
+	var err error
+	switch url.Protocol {
+	case urlpkg.Protocol_SSH:
+		if transport, err = ssh.NewTransport(url.User, url.Host, uint16(url.Port), prompter); err != nil {
+			return nil, fmt.Errorf("unable to create SSH transport: %w", err)
+		}
+	case urlpkg.Protocol_Docker:
+		if transport, err = docker.NewTransport(url.Host, url.User, url.Environment, url.Parameters, prompter); err != nil {
+			return nil, fmt.Errorf("unable to create Docker transport: %w", err)
+		}
+	default:
+		return nil, errors.New("URL protocol doesn't support agents")
+	}
+
+	// Dial the agent.
+	stream, err := agent.Dial(logger, transport, agent.CommandExecutor, prompter)
+	if err != nil {
+		return nil, fmt.Errorf("unable to dial agent: %w", err)
+	}
+
+	// Success.
+	return stream, nil
+}
+
+// Run runs a command within the path of the endpoint identified by the
+// specified URL, forwarding input from stdin (which may be nil if no input
+// should be provided) and copying the command's output to the specified
+// writers. Local endpoints run the command directly, while remote endpoints
+// run the command via an execution agent. It returns the command's exit code.
+func Run(
+	logger *logging.Logger,
+	url *urlpkg.URL,
+	prompter string,
+	command string,
+	stdin io.Reader, stdout, stderr io.Writer,
+) (int, error) {
+	// Handle local endpoints.
+	if url.Protocol == urlpkg.Protocol_Local {
+		workingDirectory, err := filesystem.Normalize(url.Path)
+		if err != nil {
+			return 0, fmt.Errorf("unable to normalize working directory: %w", err)
+		}
+		process := shellCommand(command)
+		process.Dir = workingDirectory
+		process.Stdin = stdin
+		process.Stdout = stdout
+		process.Stderr = stderr
+		return runProcess(process)
+	}
+
+	// Handle remote endpoints.
+	stream, err := Dial(logger, url, prompter)
+	if err != nil {
+		return 0, err
+	}
+	return Execute(stream, command, url.Path, stdin, stdout, stderr)
+}
@@ -0,0 +1,3 @@
+// Package execution provides a client/server architecture for running commands
+// on remote endpoints via agents.
+package execution
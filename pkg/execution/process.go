@@ -0,0 +1,27 @@
+package execution
+
+import (
+	"errors"
+	"os/exec"
+)
+
+// exitCode converts the error returned by running or waiting on a process to
+// the process' exit code, which is -1 if the process was terminated by a
+// signal. An error is only returned if the process couldn't be run.
+func exitCode(err error) (int, error) {
+	if err != nil {
+		var exitError *exec.ExitError
+		if errors.As(err, &exitError) {
+			return exitError.ExitCode(), nil
+		}
+		return 0, err
+	}
+	return 0, nil
+}
+
+// runProcess runs a process and waits for it to terminate. It returns the
+// process' exit code, which is -1 if the process was terminated by a signal.
+// An error is only returned if the process couldn't be run.
+func runProcess(process *exec.Cmd) (int, error) {
+	return exitCode(process.Run())
+}
@@ -0,0 +1,50 @@
+package execution
+
+import (
+	"errors"
+)
+
+// ensureValid ensures that Request's invariants are respected.
+func (r *Request) ensureValid() error {
+	// A nil request is invalid.
+	if r == nil {
+		return errors.New("nil request")
+	}
+
+	// Ensure that a command has been specified.
+	if r.Command == "" {
+		return errors.New("empty command")
+	}
+
+	// There's no verification to be performed on the working directory.
+
+	// Success.
+	return nil
+}
+
+// ensureValid ensures that Response's invariants are respected.
+func (r *Response) ensureValid() error {
+	// A nil response is invalid.
+	if r == nil {
+		return errors.New("nil response")
+	}
+
+	// There's no verification to be performed on the error message.
+
+	// Success.
+	return nil
+}
+
+// ensureValid ensures that ExitStatus' invariants are respected.
+func (s *ExitStatus) ensureValid() error {
+	// A nil exit status is invalid.
+	if s == nil {
+		return errors.New("nil exit status")
+	}
+
+	// There's no verification to be performed on the exit code or error
+	// message.
+
+	// Success.
+	return nil
+}
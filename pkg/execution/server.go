@@ -0,0 +1,143 @@
+package execution
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/multiplexing"
+)
+
+// Serve runs a single command on behalf of a client connected via the
+// specified stream. It enforces that the provided stream is closed by the time
+// this function returns, regardless of failure. The provided stream must
+// unblock read and write operations when closed.
+func Serve(logger *logging.Logger, stream io.ReadWriteCloser) error {
+	// Adapt the connection to serve as a multiplexer carrier. This will also
+	// give us the buffering functionality we'll need for initialization.
+	carrier := multiplexing.NewCarrierFromStream(stream)
+
+	// Defer closure of the carrier in the event that initialization isn't
+	// successful. Otherwise, we'll rely on closure of the multiplexer to close
+	// the carrier.
+	var initializationError error
+	defer func() {
+		if initializationError != nil {
+			carrier.Close()
+		}
+	}()
+
+	// Receive the initialization request, ensure that it's valid, and resolve
+	// the working directory.
+	request := &Request{}
+	var workingDirectory string
+	if err := encoding.DecodeProtobuf(carrier, request); err != nil {
+		initializationError = fmt.Errorf("unable to receive initialization request: %w", err)
+	} else if err = request.ensureValid(); err != nil {
+		initializationError = fmt.Errorf("invalid initialization request received: %w", err)
+	} else if request.WorkingDirectory != "" {
+		if workingDirectory, err = filesystem.Normalize(request.WorkingDirectory); err != nil {
+			initializationError = fmt.Errorf("unable to normalize working directory: %w", err)
+		}
+	}
+
+	// Send the initialization response, indicating any initialization error
+	// that occurred.
+	response := &Response{}
+	if initializationError != nil {
+		response.Error = initializationError.Error()
+	}
+	if err := encoding.EncodeProtobuf(carrier, response); err != nil {
+		return fmt.Errorf("unable to send initialization response: %w", err)
+	}
+
+	// If initialization failed, then bail.
+	if initializationError != nil {
+		return fmt.Errorf("execution initialization failed: %w", initializationError)
+	}
+
+	// Multiplex the carrier and defer closure of the multiplexer.
+	multiplexer := multiplexing.Multiplex(carrier, true, nil)
+	defer multiplexer.Close()
+
+	// Accept the standard input, standard output, standard error, and status
+	// streams, which the client opens in that order.
+	stdin, err := multiplexer.AcceptStream(context.Background())
+	if err != nil {
+		return fmt.Errorf("unable to accept standard input stream: %w", err)
+	}
+	stdout, err := multiplexer.AcceptStream(context.Background())
+	if err != nil {
+		return fmt.Errorf("unable to accept standard output stream: %w", err)
+	}
+	stderr, err := multiplexer.AcceptStream(context.Background())
+	if err != nil {
+		return fmt.Errorf("unable to accept standard error stream: %w", err)
+	}
+	status, err := multiplexer.AcceptStream(context.Background())
+	if err != nil {
+		return fmt.Errorf("unable to accept status stream: %w", err)
+	}
+
+	// Set up the process. We use an explicit pipe for standard input (rather
+	// than letting the process copy from the stream) because the process would
+	// otherwise wait for the client to close standard input before exiting.
+	logger.Debugf("Running command: %s", request.Command)
+	process := shellCommand(request.Command)
+	process.Dir = workingDirectory
+	process.Stdout = stdout
+	process.Stderr = stderr
+	processStdin, err := process.StdinPipe()
+	if err != nil {
+		return fmt.Errorf("unable to create standard input pipe: %w", err)
+	}
+
+	// Start the process, forward standard input, and wait for the process to
+	// terminate. If the client disconnects before the process terminates, then
+	// we terminate the process.
+	exitStatus := &ExitStatus{}
+	if err := process.Start(); err != nil {
+		exitStatus.Error = err.Error()
+	} else {
+		go func() {
+			io.Copy(processStdin, stdin)
+			processStdin.Close()
+		}()
+		waitDone := make(chan struct{})
+		go func() {
+			select {
+			case <-multiplexer.Closed():
+				process.Process.Kill()
+			case <-waitDone:
+			}
+		}()
+		code, err := exitCode(process.Wait())
+		close(waitDone)
+		if err != nil {
+			exitStatus.Error = err.Error()
+		} else {
+			exitStatus.ExitCode = int32(code)
+		}
+	}
+
+	// Signal the end of output and close standard input.
+	stdout.CloseWrite()
+	stderr.CloseWrite()
+	stdin.Close()
+
+	// Transmit the exit status.
+	if err := encoding.EncodeProtobuf(status, exitStatus); err != nil {
+		return fmt.Errorf("unable to transmit exit status: %w", err)
+	}
+
+	// Wait for the client to close the status stream (or the multiplexer),
+	// indicating that it has received the exit status. We can't return before
+	// that point because closing the multiplexer might discard pending data.
+	status.Read(make([]byte, 1))
+
+	// Success.
+	return nil
+}
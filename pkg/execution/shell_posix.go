@@ -0,0 +1,13 @@
+//go:build !windows
+
+package execution
+
+import (
+	"os/exec"
+)
+
+// shellCommand creates a process that runs the specified command using the
+// system shell. On POSIX systems, this is /bin/sh.
+func shellCommand(command string) *exec.Cmd {
+	return exec.Command("/bin/sh", "-c", command)
+}
@@ -0,0 +1,20 @@
+package execution
+
+import (
+	"os"
+	"os/exec"
+)
+
+// shellCommand creates a process that runs the specified command using the
+// system shell. On Windows systems, this is %COMSPEC% (with a fallback to
+// cmd.exe if unspecified).
+func shellCommand(command string) *exec.Cmd {
+	// Determine the shell to use.
+	shell := os.Getenv("COMSPEC")
+	if shell == "" {
+		shell = "cmd.exe"
+	}
+
+	// Create the process.
+	return exec.Command(shell, "/c", command)
+}
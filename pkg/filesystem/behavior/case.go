@@ -0,0 +1,71 @@
+package behavior
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+const (
+	// assumeCaseInsensitivity indicates whether or not case-insensitivity
+	// should be assumed for the platform. The default filesystem
+	// configurations on Windows and macOS are case-insensitive.
+	assumeCaseInsensitivity = runtime.GOOS == "windows" || runtime.GOOS == "darwin"
+
+	// upperCaseFileNamePrefix is the prefix used for temporary files created
+	// by the case-insensitivity test.
+	upperCaseFileNamePrefix = filesystem.TemporaryNamePrefix + "case-test-ENTRY"
+	// lowerCaseFileNamePrefix is the lower-case equivalent of
+	// upperCaseFileNamePrefix.
+	lowerCaseFileNamePrefix = filesystem.TemporaryNamePrefix + "case-test-entry"
+)
+
+// IgnoresCase determines whether or not the specified directory (and its
+// underlying filesystem) treats names that differ only in case as referring to
+// the same entry. The second value returned by this function indicates whether
+// or not probe files were used in determining behavior.
+func IgnoresCase(directory *filesystem.Directory, probeMode ProbeMode) (bool, bool, error) {
+	// Check the filesystem probing mode and see if we can return an assumption.
+	if probeMode == ProbeMode_ProbeModeAssume {
+		return assumeCaseInsensitivity, false, nil
+	} else if !probeMode.Supported() {
+		panic("invalid probe mode")
+	}
+
+	// Check if we have a fast test that will work.
+	if result, ok := probeCaseInsensitivityFast(directory); ok {
+		return result, false, nil
+	} else if runtime.GOOS == "windows" {
+		panic("fast path not used on Windows")
+	}
+
+	// Create and close a temporary file using the upper-case name.
+	upperCaseName, file, err := directory.CreateTemporaryFile(upperCaseFileNamePrefix)
+	if err != nil {
+		return false, true, fmt.Errorf("unable to create test file: %w", err)
+	} else if err = file.Close(); err != nil {
+		return false, true, fmt.Errorf("unable to close test file: %w", err)
+	}
+
+	// Defer removal of the file.
+	defer directory.RemoveFile(upperCaseName)
+
+	// Compute the lower-case variant of the name and check whether or not it
+	// resolves to an entry.
+	lowerCaseName := strings.Replace(
+		upperCaseName,
+		upperCaseFileNamePrefix,
+		lowerCaseFileNamePrefix,
+		1,
+	)
+	if _, err := directory.ReadContentMetadata(lowerCaseName); err == nil {
+		return true, true, nil
+	} else if os.IsNotExist(err) {
+		return false, true, nil
+	} else {
+		return false, true, fmt.Errorf("unable to query test file metadata: %w", err)
+	}
+}
@@ -0,0 +1,13 @@
+package behavior
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior/internal/format"
+)
+
+// probeCaseInsensitivityFastByFormat checks if the specified format matches
+// well-known case-insensitivity behavior. Both APFS and HFS+ volumes can be
+// formatted as either case-sensitive or case-insensitive, so the format alone
+// is never sufficient to determine behavior.
+func probeCaseInsensitivityFastByFormat(_ format.Format) (bool, bool) {
+	return false, false
+}
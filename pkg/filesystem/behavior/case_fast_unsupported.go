@@ -0,0 +1,14 @@
+//go:build !windows && !darwin && !linux
+
+package behavior
+
+import (
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+// probeCaseInsensitivityFast attempts to perform a fast case-insensitivity
+// test, without probe files. The successfulness of the test is indicated by
+// the second return parameter.
+func probeCaseInsensitivityFast(_ *filesystem.Directory) (bool, bool) {
+	return false, false
+}
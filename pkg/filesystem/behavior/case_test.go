@@ -0,0 +1,94 @@
+package behavior
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+// ignoresCaseTestCase represents a test case for IgnoresCase.
+type ignoresCaseTestCase struct {
+	// path is the path to test.
+	path string
+	// assume indicates that an assumption should be generated as opposed to
+	// actual probing.
+	assume bool
+	// expected is the expected result of the case-insensitivity test.
+	expected bool
+}
+
+// run executes the test in the provided test context.
+func (c *ignoresCaseTestCase) run(t *testing.T) {
+	// Mark ourselves as a helper function.
+	t.Helper()
+
+	// Open the path, ensure that it's a directory, and defer its closure.
+	directory, _, err := filesystem.OpenDirectory(c.path, false)
+	if err != nil {
+		t.Fatal("unable to open path:", err)
+	}
+	defer directory.Close()
+
+	// Determine the probing mode.
+	probeMode := ProbeMode_ProbeModeProbe
+	if c.assume {
+		probeMode = ProbeMode_ProbeModeAssume
+	}
+
+	// Probe the behavior of the root and ensure it matches what's expected.
+	if ignores, _, err := IgnoresCase(directory, probeMode); err != nil {
+		t.Fatal("unable to probe case-insensitivity:", err)
+	} else if ignores != c.expected {
+		t.Error("case-insensitivity behavior does not match expected")
+	}
+}
+
+// TestIgnoresCaseAssumed tests assumed case-insensitivity behavior.
+func TestIgnoresCaseAssumed(t *testing.T) {
+	// Create the test case.
+	testCase := &ignoresCaseTestCase{
+		path:     t.TempDir(),
+		assume:   true,
+		expected: assumeCaseInsensitivity,
+	}
+
+	// Run the test case.
+	testCase.run(t)
+}
+
+// TestIgnoresCaseLinux tests probed case-insensitivity behavior on Linux, where
+// the default temporary directory is expected to be case-sensitive.
+func TestIgnoresCaseLinux(t *testing.T) {
+	// If we're not on Linux, then skip this test, because the default
+	// filesystem behavior varies on other platforms.
+	if runtime.GOOS != "linux" {
+		t.Skip()
+	}
+
+	// Create the test case.
+	testCase := &ignoresCaseTestCase{
+		path:     t.TempDir(),
+		expected: false,
+	}
+
+	// Run the test case.
+	testCase.run(t)
+}
+
+// TestIgnoresCaseWindows tests probed case-insensitivity behavior on Windows.
+func TestIgnoresCaseWindows(t *testing.T) {
+	// If we're not on Windows, then skip this test.
+	if runtime.GOOS != "windows" {
+		t.Skip()
+	}
+
+	// Create the test case.
+	testCase := &ignoresCaseTestCase{
+		path:     t.TempDir(),
+		expected: true,
+	}
+
+	// Run the test case.
+	testCase.run(t)
+}
@@ -171,6 +171,21 @@ func (d *Directory) CreateSymbolicLink(name, target string) error {
 	return symlinkatRetryingOnEINTR(target, d.descriptor, name)
 }
 
+// CreateHardLink creates a new hard link with the specified name inside the
+// directory, referencing the existing file with the specified name inside the
+// source directory. The link is not allowed to replace existing content.
+func (d *Directory) CreateHardLink(name string, source *Directory, sourceName string) error {
+	// Verify that the names are valid.
+	if err := ensureValidName(name); err != nil {
+		return err
+	} else if err = ensureValidName(sourceName); err != nil {
+		return fmt.Errorf("source name invalid: %w", err)
+	}
+
+	// Create the hard link.
+	return linkatRetryingOnEINTR(source.descriptor, sourceName, d.descriptor, name, 0)
+}
+
 // SetPermissions sets the permissions on the content within the directory
 // specified by name. Ownership information is set first, followed by
 // permissions extracted from the mode using ModePermissionsMask. Ownership
@@ -220,6 +235,25 @@ func (d *Directory) SetPermissions(name string, ownership *OwnershipSpecificatio
 	return nil
 }
 
+// SetModificationTime sets the modification time on the content within the
+// directory specified by name. The access time is set to the same value.
+// Symbolic links are not followed.
+func (d *Directory) SetModificationTime(name string, modificationTime time.Time) error {
+	// Verify that the name is valid.
+	if err := ensureValidName(name); err != nil {
+		return err
+	}
+
+	// Set the times.
+	timespec := unix.NsecToTimespec(modificationTime.UnixNano())
+	if err := utimensatRetryingOnEINTR(d.descriptor, name, []unix.Timespec{timespec, timespec}, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+		return fmt.Errorf("unable to set modification time: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
 // open is the underlying open implementation shared by OpenDirectory and
 // OpenFile. It returns the file descriptor corresponding to the target, the
 // target metadata if the target is a file (nil otherwise), or any error.
@@ -420,6 +454,40 @@ func (d *Directory) OpenFile(name string) (io.ReadSeekCloser, *Metadata, error)
 	return file(descriptor), metadata, err
 }
 
+// OpenFileForUpdating opens the existing file within the directory specified by
+// name for reading and writing. The file is not truncated. Symbolic links are
+// not followed, and the target must be a regular file with a single link (to
+// ensure that writes can't affect content at other paths).
+func (d *Directory) OpenFileForUpdating(name string) (*os.File, error) {
+	// Verify that the name is valid.
+	if err := ensureValidName(name); err != nil {
+		return nil, err
+	}
+
+	// Open the file for reading and writing while avoiding symbolic link
+	// traversal.
+	descriptor, err := openatRetryingOnEINTR(d.descriptor, name, unix.O_RDWR|unix.O_NOFOLLOW|unix.O_CLOEXEC|extraOpenFlags, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that we've opened a file with a single link.
+	var rawMetadata unix.Stat_t
+	if err := fstatRetryingOnEINTR(descriptor, &rawMetadata); err != nil {
+		closeConsideringEINTR(descriptor)
+		return nil, fmt.Errorf("unable to query file metadata: %w", err)
+	} else if Mode(rawMetadata.Mode)&ModeTypeMask != ModeTypeFile {
+		closeConsideringEINTR(descriptor)
+		return nil, errors.New("path is not a file")
+	} else if rawMetadata.Nlink > 1 {
+		closeConsideringEINTR(descriptor)
+		return nil, errors.New("file has multiple links")
+	}
+
+	// Success.
+	return os.NewFile(uintptr(descriptor), name), nil
+}
+
 // readlinkInitialBufferSize specifies the initial buffer size to use for
 // readlinkat operations. It should be large enough to accommodate most symbolic
 // links but not so large that every readlinkat operation incurs an inordinate
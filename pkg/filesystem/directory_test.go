@@ -128,3 +128,46 @@ func TestDirectorySymbolicLinkRemoval(t *testing.T) {
 		t.Error("unable to read target content metadata:", err)
 	}
 }
+
+// TestDirectoryCreateHardLink tests that hard links can be created between
+// directories and that existing content isn't replaced.
+func TestDirectoryCreateHardLink(t *testing.T) {
+	// Create source and target directories with a file in the source.
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "source"), 0700); err != nil {
+		t.Fatal("unable to create source directory:", err)
+	} else if err = os.Mkdir(filepath.Join(root, "target"), 0700); err != nil {
+		t.Fatal("unable to create target directory:", err)
+	} else if err = os.WriteFile(filepath.Join(root, "source", "file"), []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create source file:", err)
+	}
+
+	// Open directory handles and defer their closure.
+	source, _, err := OpenDirectory(filepath.Join(root, "source"), false)
+	if err != nil {
+		t.Fatal("unable to open source directory:", err)
+	}
+	defer source.Close()
+	target, _, err := OpenDirectory(filepath.Join(root, "target"), false)
+	if err != nil {
+		t.Fatal("unable to open target directory:", err)
+	}
+	defer target.Close()
+
+	// Create the link and verify that it references the same file.
+	if err := target.CreateHardLink("link", source, "file"); err != nil {
+		t.Fatal("unable to create hard link:", err)
+	}
+	if original, err := os.Stat(filepath.Join(root, "source", "file")); err != nil {
+		t.Fatal("unable to query source file:", err)
+	} else if link, err := os.Stat(filepath.Join(root, "target", "link")); err != nil {
+		t.Fatal("unable to query link:", err)
+	} else if !os.SameFile(original, link) {
+		t.Error("hard link does not reference source file")
+	}
+
+	// Ensure that existing content isn't replaced.
+	if err := target.CreateHardLink("link", source, "file"); err == nil {
+		t.Error("hard link creation replaced existing content")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 
 	"golang.org/x/sys/windows"
 
@@ -131,6 +132,21 @@ func (d *Directory) CreateSymbolicLink(name, target string) error {
 	return os.Symlink(target, filepath.Join(d.file.Name(), name))
 }
 
+// CreateHardLink creates a new hard link with the specified name inside the
+// directory, referencing the existing file with the specified name inside the
+// source directory. The link is not allowed to replace existing content.
+func (d *Directory) CreateHardLink(name string, source *Directory, sourceName string) error {
+	// Verify that the names are valid.
+	if err := ensureValidName(name); err != nil {
+		return err
+	} else if err = ensureValidName(sourceName); err != nil {
+		return fmt.Errorf("source name invalid: %w", err)
+	}
+
+	// Create the hard link.
+	return os.Link(filepath.Join(source.file.Name(), sourceName), filepath.Join(d.file.Name(), name))
+}
+
 // SetPermissions sets the permissions on the content within the directory
 // specified by name. Ownership information is set first, followed by
 // permissions extracted from the mode using ModePermissionsMask. Ownership
@@ -204,6 +220,26 @@ func (d *Directory) SetPermissions(name string, ownership *OwnershipSpecificatio
 	return nil
 }
 
+// SetModificationTime sets the modification time on the content within the
+// directory specified by name. The access time is set to the same value.
+func (d *Directory) SetModificationTime(name string, modificationTime time.Time) error {
+	// Verify that the name is valid.
+	if err := ensureValidName(name); err != nil {
+		return err
+	}
+
+	// Compute the target path and fix long paths.
+	path := osvendor.FixLongPath(filepath.Join(d.file.Name(), name))
+
+	// Set the times.
+	if err := os.Chtimes(path, modificationTime, modificationTime); err != nil {
+		return fmt.Errorf("unable to set modification time: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
 // openHandle is the underlying open implementation shared by OpenDirectory and
 // OpenFile. It returns the full target path, the Windows file handle
 // corresponding to the target, the target metadata, or any error.
@@ -417,6 +453,48 @@ func (d *Directory) OpenFile(name string) (io.ReadSeekCloser, *Metadata, error)
 	return file, metadata, nil
 }
 
+// OpenFileForUpdating opens the existing file within the directory specified by
+// name for reading and writing. The file is not truncated. The target must be a
+// regular file with a single link (to ensure that writes can't affect content
+// at other paths).
+func (d *Directory) OpenFileForUpdating(name string) (*os.File, error) {
+	// Verify that the name is valid.
+	if err := ensureValidName(name); err != nil {
+		return nil, err
+	}
+
+	// Compute the full path and fix long paths.
+	path := osvendor.FixLongPath(filepath.Join(d.file.Name(), name))
+
+	// Verify that the target is a regular file. Unlike openHandle, we can't
+	// avoid symbolic link traversal while opening for writing, so we have to
+	// live with a small race window here.
+	if metadata, err := os.Lstat(path); err != nil {
+		return nil, err
+	} else if !metadata.Mode().IsRegular() {
+		return nil, errors.New("path is not a file")
+	}
+
+	// Open the file.
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify that the file has a single link.
+	var information windows.ByHandleFileInformation
+	if err := windows.GetFileInformationByHandle(windows.Handle(file.Fd()), &information); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("unable to query file information: %w", err)
+	} else if information.NumberOfLinks > 1 {
+		file.Close()
+		return nil, errors.New("file has multiple links")
+	}
+
+	// Success.
+	return file, nil
+}
+
 // ReadSymbolicLink reads the target of the symbolic link within the directory
 // specified by name.
 func (d *Directory) ReadSymbolicLink(name string) (string, error) {
@@ -0,0 +1,63 @@
+package filesystem
+
+import (
+	"golang.org/x/sys/unix"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/internal/syscall"
+)
+
+// aclExtendedAttributeName is the name of the synthetic extended attribute used
+// to represent extended ACLs on macOS, which aren't otherwise exposed as
+// extended attributes. It matches the name used by copyfile.
+const aclExtendedAttributeName = "com.apple.acl.text"
+
+// readACL reads the textual representation of the extended ACL on the content
+// at the specified path without following symbolic links. It returns nil if the
+// content has no extended ACL.
+func readACL(path string) ([]byte, error) {
+	// Read the ACL. If there's no ACL, then acl_get_link_np returns ENOENT.
+	acl, err := syscall.Acl_get_link_np(path, syscall.ACL_TYPE_EXTENDED)
+	if err == unix.ENOENT {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer syscall.Acl_free(acl)
+
+	// Convert the ACL to text.
+	text, err := syscall.Acl_to_text(acl)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return []byte(text), nil
+}
+
+// setACL sets the extended ACL on the content at the specified path (without
+// following symbolic links) from its textual representation.
+func setACL(path string, value []byte) error {
+	// Parse the ACL.
+	acl, err := syscall.Acl_from_text(string(value))
+	if err != nil {
+		return err
+	}
+	defer syscall.Acl_free(acl)
+
+	// Set the ACL.
+	return syscall.Acl_set_link_np(path, syscall.ACL_TYPE_EXTENDED, acl)
+}
+
+// removeACL removes the extended ACL from the content at the specified path
+// without following symbolic links.
+func removeACL(path string) error {
+	// Create an empty ACL.
+	acl, err := syscall.Acl_init(0)
+	if err != nil {
+		return err
+	}
+	defer syscall.Acl_free(acl)
+
+	// Set the empty ACL.
+	return syscall.Acl_set_link_np(path, syscall.ACL_TYPE_EXTENDED, acl)
+}
@@ -0,0 +1,28 @@
+//go:build linux || freebsd || netbsd
+
+package filesystem
+
+import (
+	"errors"
+)
+
+// aclExtendedAttributeName is the name of the synthetic extended attribute used
+// to represent ACLs that aren't otherwise exposed as extended attributes. On
+// this platform, ACLs are either exposed as extended attributes or not
+// supported, so it's empty.
+const aclExtendedAttributeName = ""
+
+// readACL is unused on this platform.
+func readACL(_ string) ([]byte, error) {
+	return nil, errors.New("synthetic ACL attribute not supported on this platform")
+}
+
+// setACL is unused on this platform.
+func setACL(_ string, _ []byte) error {
+	return errors.New("synthetic ACL attribute not supported on this platform")
+}
+
+// removeACL is unused on this platform.
+func removeACL(_ string) error {
+	return errors.New("synthetic ACL attribute not supported on this platform")
+}
@@ -0,0 +1,9 @@
+package filesystem
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// errExtendedAttributeNotFound is the error returned when reading an extended
+// attribute that doesn't exist.
+const errExtendedAttributeNotFound = unix.ENODATA
@@ -0,0 +1,196 @@
+//go:build linux || darwin || freebsd || netbsd
+
+package filesystem
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// extendedAttributesUnsupported returns whether or not an error indicates that
+// extended attributes aren't supported by the underlying filesystem.
+func extendedAttributesUnsupported(err error) bool {
+	return err == unix.ENOTSUP || err == unix.EOPNOTSUPP
+}
+
+// listExtendedAttributes lists the names of the extended attributes present on
+// the content at the specified path without following symbolic links.
+func listExtendedAttributes(path string) ([]string, error) {
+	// Loop until we're able to read the full list. The list can change size
+	// between sizing and reading, in which case we'll see ERANGE.
+	for {
+		// Determine the size of the list.
+		size, err := unix.Llistxattr(path, nil)
+		if err == unix.EINTR {
+			continue
+		} else if err != nil {
+			return nil, err
+		} else if size == 0 {
+			return nil, nil
+		}
+
+		// Read the list.
+		buffer := make([]byte, size)
+		size, err = unix.Llistxattr(path, buffer)
+		if err == unix.EINTR || err == unix.ERANGE {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		// Split the list into its NUL-terminated names.
+		var names []string
+		for _, name := range bytes.Split(buffer[:size], []byte{0}) {
+			if len(name) > 0 {
+				names = append(names, string(name))
+			}
+		}
+		return names, nil
+	}
+}
+
+// getExtendedAttribute reads the value of an extended attribute on the content
+// at the specified path without following symbolic links.
+func getExtendedAttribute(path, name string) ([]byte, error) {
+	// Loop until we're able to read the full value. The value can change size
+	// between sizing and reading, in which case we'll see ERANGE.
+	for {
+		// Determine the size of the value.
+		size, err := unix.Lgetxattr(path, name, nil)
+		if err == unix.EINTR {
+			continue
+		} else if err != nil {
+			return nil, err
+		} else if size == 0 {
+			return []byte{}, nil
+		}
+
+		// Read the value.
+		value := make([]byte, size)
+		size, err = unix.Lgetxattr(path, name, value)
+		if err == unix.EINTR || err == unix.ERANGE {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		return value[:size], nil
+	}
+}
+
+// ReadExtendedAttributesByPath reads the extended attributes of the content at
+// the specified path, returning only those attributes whose names are accepted
+// by filter. Symbolic links are not followed. Attributes are read by path
+// rather than by descriptor so that content without read permissions can still
+// be queried. If the underlying filesystem doesn't support extended attributes,
+// then a nil map is returned. Errors indicating that the content doesn't exist
+// are returned in a form that can be checked using os.IsNotExist.
+//
+// On Linux, POSIX ACLs are exposed (and thus captured) as extended attributes
+// in the system namespace. On macOS, extended ACLs are exposed as a synthetic
+// extended attribute named "com.apple.acl.text" (the same name used by
+// copyfile) whose value is the ACL's textual representation.
+func ReadExtendedAttributesByPath(path string, filter func(string) bool) (map[string][]byte, error) {
+	// List the attribute names.
+	names, err := listExtendedAttributes(path)
+	if err != nil {
+		if extendedAttributesUnsupported(err) {
+			return nil, nil
+		}
+		return nil, &os.PathError{Op: "listxattr", Path: path, Err: err}
+	}
+
+	// Read the values of the accepted attributes. If an attribute disappears
+	// between listing and reading, then we simply skip it.
+	var result map[string][]byte
+	for _, name := range names {
+		if !filter(name) {
+			continue
+		}
+		value, err := getExtendedAttribute(path, name)
+		if err != nil {
+			if err == errExtendedAttributeNotFound {
+				continue
+			}
+			return nil, &os.PathError{Op: "getxattr", Path: path, Err: fmt.Errorf("%s: %w", name, err)}
+		}
+		if result == nil {
+			result = make(map[string][]byte)
+		}
+		result[name] = value
+	}
+
+	// Read the platform's ACL, if it's not exposed as an extended attribute
+	// and it's accepted.
+	if aclExtendedAttributeName != "" && filter(aclExtendedAttributeName) {
+		if value, err := readACL(path); err != nil {
+			return nil, &os.PathError{Op: "getacl", Path: path, Err: err}
+		} else if value != nil {
+			if result == nil {
+				result = make(map[string][]byte)
+			}
+			result[aclExtendedAttributeName] = value
+		}
+	}
+
+	// Success.
+	return result, nil
+}
+
+// SetExtendedAttributesByPath sets extended attributes on the content at the
+// specified path. Existing attributes not included in attributes are left
+// unmodified. Symbolic links are not followed.
+func SetExtendedAttributesByPath(path string, attributes map[string][]byte) error {
+	for name, value := range attributes {
+		// Handle the platform's ACL, if it's not exposed as an extended
+		// attribute.
+		if aclExtendedAttributeName != "" && name == aclExtendedAttributeName {
+			if err := setACL(path, value); err != nil {
+				return fmt.Errorf("unable to set access control list: %w", err)
+			}
+			continue
+		}
+
+		// Set the attribute.
+		for {
+			err := unix.Lsetxattr(path, name, value, 0)
+			if err == unix.EINTR {
+				continue
+			} else if err != nil {
+				return fmt.Errorf("unable to set extended attribute (%s): %w", name, err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// RemoveExtendedAttributesByPath removes the specified extended attributes from
+// the content at the specified path. Attributes that don't exist are ignored.
+// Symbolic links are not followed.
+func RemoveExtendedAttributesByPath(path string, names []string) error {
+	for _, name := range names {
+		// Handle the platform's ACL, if it's not exposed as an extended
+		// attribute.
+		if aclExtendedAttributeName != "" && name == aclExtendedAttributeName {
+			if err := removeACL(path); err != nil {
+				return fmt.Errorf("unable to remove access control list: %w", err)
+			}
+			continue
+		}
+
+		// Remove the attribute.
+		for {
+			err := unix.Lremovexattr(path, name)
+			if err == unix.EINTR {
+				continue
+			} else if err != nil && err != errExtendedAttributeNotFound {
+				return fmt.Errorf("unable to remove extended attribute (%s): %w", name, err)
+			}
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExtendedAttributesByPath tests setting, reading, and removing extended
+// attributes by path, including on content without read permissions.
+func TestExtendedAttributesByPath(t *testing.T) {
+	// Create a file to work with.
+	path := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(path, []byte("content"), 0600); err != nil {
+		t.Fatal("unable to create test file:", err)
+	}
+
+	// Set extended attributes. If the platform or filesystem doesn't support
+	// extended attributes, then skip the test.
+	attributes := map[string][]byte{
+		"user.mutagen_first":  []byte("first"),
+		"user.mutagen_second": []byte("second"),
+	}
+	if err := SetExtendedAttributesByPath(path, attributes); err != nil {
+		t.Skip("unable to set extended attributes:", err)
+	}
+
+	// Remove read permissions from the file to ensure that attributes are
+	// still readable.
+	if err := os.Chmod(path, 0); err != nil {
+		t.Fatal("unable to remove file permissions:", err)
+	}
+
+	// Read the attributes using a filter and verify that they match.
+	filter := func(name string) bool {
+		return name == "user.mutagen_first" || name == "user.mutagen_second"
+	}
+	if read, err := ReadExtendedAttributesByPath(path, filter); err != nil {
+		t.Fatal("unable to read extended attributes:", err)
+	} else if len(read) != 2 || string(read["user.mutagen_first"]) != "first" ||
+		string(read["user.mutagen_second"]) != "second" {
+		t.Error("read extended attributes do not match expected:", read)
+	}
+
+	// Remove an attribute (as well as one that doesn't exist) and verify that
+	// only the other attribute remains.
+	if err := RemoveExtendedAttributesByPath(path, []string{"user.mutagen_first", "user.mutagen_missing"}); err != nil {
+		t.Fatal("unable to remove extended attributes:", err)
+	}
+	if read, err := ReadExtendedAttributesByPath(path, filter); err != nil {
+		t.Fatal("unable to read extended attributes after removal:", err)
+	} else if len(read) != 1 || string(read["user.mutagen_second"]) != "second" {
+		t.Error("extended attributes after removal do not match expected:", read)
+	}
+
+	// Verify that reading attributes from non-existent content yields an error
+	// that can be identified as such.
+	if _, err := ReadExtendedAttributesByPath(path+"-missing", filter); !os.IsNotExist(err) {
+		t.Error("reading extended attributes from missing content did not yield non-existence error:", err)
+	}
+}
@@ -0,0 +1,35 @@
+//go:build !linux && !darwin && !freebsd && !netbsd
+
+package filesystem
+
+import (
+	"errors"
+)
+
+// ReadExtendedAttributesByPath reads the extended attributes of the content at
+// the specified path, returning only those attributes whose names are accepted
+// by filter. On this platform, extended attributes aren't supported, so a nil
+// map is always returned.
+func ReadExtendedAttributesByPath(_ string, _ func(string) bool) (map[string][]byte, error) {
+	return nil, nil
+}
+
+// SetExtendedAttributesByPath sets extended attributes on the content at the
+// specified path. On this platform, extended attributes aren't supported, so an
+// error is returned if attributes is non-empty.
+func SetExtendedAttributesByPath(_ string, attributes map[string][]byte) error {
+	if len(attributes) > 0 {
+		return errors.New("extended attributes not supported on this platform")
+	}
+	return nil
+}
+
+// RemoveExtendedAttributesByPath removes the specified extended attributes from
+// the content at the specified path. On this platform, extended attributes
+// aren't supported, so an error is returned if names is non-empty.
+func RemoveExtendedAttributesByPath(_ string, names []string) error {
+	if len(names) > 0 {
+		return errors.New("extended attributes not supported on this platform")
+	}
+	return nil
+}
@@ -44,12 +44,19 @@ const (
 	// RENAME_EXCL indicates that EEXIST should be returned if the rename target
 	// already exists.
 	RENAME_EXCL = 0x4
+	// ACL_TYPE_EXTENDED is the ACL type used for extended (NFSv4-style) access
+	// control lists on macOS.
+	ACL_TYPE_EXTENDED = 0x100
 )
 
 // Implemented in the runtime package (runtime/sys_darwin.go)
+func syscall_syscall(fn, a1, a2, a3 uintptr) (r1, r2 uintptr, err unix.Errno)
 func syscall_syscall6(fn, a1, a2, a3, a4, a5, a6 uintptr) (r1, r2 uintptr, err unix.Errno)
+func syscall_syscallPtr(fn, a1, a2, a3 uintptr) (r1, r2 uintptr, err unix.Errno)
 
+//go:linkname syscall_syscall syscall.syscall
 //go:linkname syscall_syscall6 syscall.syscall6
+//go:linkname syscall_syscallPtr syscall.syscallPtr
 
 // Renameatx_np exposes the renameatx_np function on macOS.
 func Renameatx_np(fromfd int, from string, tofd int, to string, flags uint) (err error) {
@@ -73,3 +80,107 @@ func Renameatx_np(fromfd int, from string, tofd int, to string, flags uint) (err
 var libc_renameatx_np_trampoline_addr uintptr
 
 //go:cgo_import_dynamic libc_renameatx_np renameatx_np "/usr/lib/libSystem.B.dylib"
+
+// Acl_get_link_np exposes the acl_get_link_np function on macOS. It does not
+// follow symbolic links. The resulting ACL must be released using Acl_free.
+func Acl_get_link_np(path string, aclType int) (acl uintptr, err error) {
+	var _p0 *byte
+	_p0, err = unix.BytePtrFromString(path)
+	if err != nil {
+		return
+	}
+	r0, _, e1 := syscall_syscallPtr(libc_acl_get_link_np_trampoline_addr, uintptr(unsafe.Pointer(_p0)), uintptr(aclType), 0)
+	acl = r0
+	if e1 != 0 {
+		err = e1
+	}
+	return
+}
+
+var libc_acl_get_link_np_trampoline_addr uintptr
+
+//go:cgo_import_dynamic libc_acl_get_link_np acl_get_link_np "/usr/lib/libSystem.B.dylib"
+
+// Acl_set_link_np exposes the acl_set_link_np function on macOS. It does not
+// follow symbolic links.
+func Acl_set_link_np(path string, aclType int, acl uintptr) (err error) {
+	var _p0 *byte
+	_p0, err = unix.BytePtrFromString(path)
+	if err != nil {
+		return
+	}
+	_, _, e1 := syscall_syscall(libc_acl_set_link_np_trampoline_addr, uintptr(unsafe.Pointer(_p0)), uintptr(aclType), acl)
+	if e1 != 0 {
+		err = e1
+	}
+	return
+}
+
+var libc_acl_set_link_np_trampoline_addr uintptr
+
+//go:cgo_import_dynamic libc_acl_set_link_np acl_set_link_np "/usr/lib/libSystem.B.dylib"
+
+// Acl_to_text exposes the acl_to_text function on macOS. Unlike the underlying
+// function, it returns a Go string and releases the C string representation.
+func Acl_to_text(acl uintptr) (text string, err error) {
+	r0, _, e1 := syscall_syscallPtr(libc_acl_to_text_trampoline_addr, acl, 0, 0)
+	if e1 != 0 {
+		err = e1
+		return
+	}
+	text = unix.BytePtrToString(*(**byte)(unsafe.Pointer(&r0)))
+	err = Acl_free(r0)
+	return
+}
+
+var libc_acl_to_text_trampoline_addr uintptr
+
+//go:cgo_import_dynamic libc_acl_to_text acl_to_text "/usr/lib/libSystem.B.dylib"
+
+// Acl_from_text exposes the acl_from_text function on macOS. The resulting ACL
+// must be released using Acl_free.
+func Acl_from_text(text string) (acl uintptr, err error) {
+	var _p0 *byte
+	_p0, err = unix.BytePtrFromString(text)
+	if err != nil {
+		return
+	}
+	r0, _, e1 := syscall_syscallPtr(libc_acl_from_text_trampoline_addr, uintptr(unsafe.Pointer(_p0)), 0, 0)
+	acl = r0
+	if e1 != 0 {
+		err = e1
+	}
+	return
+}
+
+var libc_acl_from_text_trampoline_addr uintptr
+
+//go:cgo_import_dynamic libc_acl_from_text acl_from_text "/usr/lib/libSystem.B.dylib"
+
+// Acl_init exposes the acl_init function on macOS. The resulting ACL must be
+// released using Acl_free.
+func Acl_init(count int) (acl uintptr, err error) {
+	r0, _, e1 := syscall_syscallPtr(libc_acl_init_trampoline_addr, uintptr(count), 0, 0)
+	acl = r0
+	if e1 != 0 {
+		err = e1
+	}
+	return
+}
+
+var libc_acl_init_trampoline_addr uintptr
+
+//go:cgo_import_dynamic libc_acl_init acl_init "/usr/lib/libSystem.B.dylib"
+
+// Acl_free exposes the acl_free function on macOS.
+func Acl_free(object uintptr) (err error) {
+	_, _, e1 := syscall_syscall(libc_acl_free_trampoline_addr, object, 0, 0)
+	if e1 != 0 {
+		err = e1
+	}
+	return
+}
+
+var libc_acl_free_trampoline_addr uintptr
+
+//go:cgo_import_dynamic libc_acl_free acl_free "/usr/lib/libSystem.B.dylib"
@@ -39,3 +39,39 @@ TEXT libc_renameatx_np_trampoline<>(SB),NOSPLIT,$0-0
 
 GLOBL	·libc_renameatx_np_trampoline_addr(SB), RODATA, $8
 DATA	·libc_renameatx_np_trampoline_addr(SB)/8, $libc_renameatx_np_trampoline<>(SB)
+
+TEXT libc_acl_get_link_np_trampoline<>(SB),NOSPLIT,$0-0
+	JMP	libc_acl_get_link_np(SB)
+
+GLOBL	·libc_acl_get_link_np_trampoline_addr(SB), RODATA, $8
+DATA	·libc_acl_get_link_np_trampoline_addr(SB)/8, $libc_acl_get_link_np_trampoline<>(SB)
+
+TEXT libc_acl_set_link_np_trampoline<>(SB),NOSPLIT,$0-0
+	JMP	libc_acl_set_link_np(SB)
+
+GLOBL	·libc_acl_set_link_np_trampoline_addr(SB), RODATA, $8
+DATA	·libc_acl_set_link_np_trampoline_addr(SB)/8, $libc_acl_set_link_np_trampoline<>(SB)
+
+TEXT libc_acl_to_text_trampoline<>(SB),NOSPLIT,$0-0
+	JMP	libc_acl_to_text(SB)
+
+GLOBL	·libc_acl_to_text_trampoline_addr(SB), RODATA, $8
+DATA	·libc_acl_to_text_trampoline_addr(SB)/8, $libc_acl_to_text_trampoline<>(SB)
+
+TEXT libc_acl_from_text_trampoline<>(SB),NOSPLIT,$0-0
+	JMP	libc_acl_from_text(SB)
+
+GLOBL	·libc_acl_from_text_trampoline_addr(SB), RODATA, $8
+DATA	·libc_acl_from_text_trampoline_addr(SB)/8, $libc_acl_from_text_trampoline<>(SB)
+
+TEXT libc_acl_init_trampoline<>(SB),NOSPLIT,$0-0
+	JMP	libc_acl_init(SB)
+
+GLOBL	·libc_acl_init_trampoline_addr(SB), RODATA, $8
+DATA	·libc_acl_init_trampoline_addr(SB)/8, $libc_acl_init_trampoline<>(SB)
+
+TEXT libc_acl_free_trampoline<>(SB),NOSPLIT,$0-0
+	JMP	libc_acl_free(SB)
+
+GLOBL	·libc_acl_free_trampoline_addr(SB), RODATA, $8
+DATA	·libc_acl_free_trampoline_addr(SB)/8, $libc_acl_free_trampoline<>(SB)
@@ -160,6 +160,19 @@ func fchownatRetryingOnEINTR(directory int, path string, uid int, gid int, flags
 	}
 }
 
+// utimensatRetryingOnEINTR is a wrapper around the utimensat system call that
+// retries on EINTR errors and returns on the first successful call or non-EINTR
+// error.
+func utimensatRetryingOnEINTR(directory int, path string, times []unix.Timespec, flags int) error {
+	for {
+		err := unix.UtimesNanoAt(directory, path, times, flags)
+		if err == unix.EINTR {
+			continue
+		}
+		return err
+	}
+}
+
 // symlinkatRetryingOnEINTR is a wrapper around the symlinkat system call that
 // retries on EINTR errors and returns on the first successful call or non-EINTR
 // error.
@@ -173,6 +186,18 @@ func symlinkatRetryingOnEINTR(target string, directory int, path string) error {
 	}
 }
 
+// linkatRetryingOnEINTR is a wrapper around the linkat system call that retries
+// on EINTR errors and returns on the first successful call or non-EINTR error.
+func linkatRetryingOnEINTR(oldDirectory int, oldPath string, newDirectory int, newPath string, flags int) error {
+	for {
+		err := unix.Linkat(oldDirectory, oldPath, newDirectory, newPath, flags)
+		if err == unix.EINTR {
+			continue
+		}
+		return err
+	}
+}
+
 // readlinkatRetryingOnEINTR is a wrapper around the readlinkat system call that
 // retries on EINTR errors and returns on the first successful call or non-EINTR
 // error.
@@ -51,6 +51,11 @@ const (
 	// directory.
 	MutagenSynchronizationStagingDirectoryName = "staging"
 
+	// MutagenSynchronizationSnapshotsDirectoryName is the name of the
+	// synchronization content snapshot storage directory within the Mutagen
+	// data directory.
+	MutagenSynchronizationSnapshotsDirectoryName = "snapshots"
+
 	// MutagenForwardingDirectoryName is the name of the forwarding data
 	// directory within the Mutagen data directory.
 	MutagenForwardingDirectoryName = "forwarding"
@@ -0,0 +1,142 @@
+package filesystem
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	// sparseBlockSize is the granularity at which SparseWriter detects
+	// zero-filled regions. It corresponds to the most common filesystem block
+	// size, below which holes can't be represented anyway.
+	sparseBlockSize = 4096
+)
+
+// sparseZeroBlock is a zero-filled block used for zero-filled region detection.
+var sparseZeroBlock [sparseBlockSize]byte
+
+// SparseFile is the interface required of files targeted by SparseWriter. It is
+// implemented by *os.File.
+type SparseFile interface {
+	io.WriterAt
+	// Truncate changes the size of the file.
+	Truncate(size int64) error
+}
+
+// SparseWriter is an io.Writer that writes sequentially to a file while
+// skipping over zero-filled regions, allowing block-aligned regions to be
+// represented as holes on filesystems that support sparse files. Since
+// zero-filled regions are detected independently of write boundaries, holes are
+// created even if data isn't written in block-aligned chunks. The target file
+// must be empty when the writer is created (so that skipped regions read as
+// zeros), and Finalize must be invoked once writing is complete in order to
+// establish the final file size.
+type SparseWriter struct {
+	// file is the target file.
+	file SparseFile
+	// offset is the current write offset.
+	offset int64
+}
+
+// NewSparseWriter creates a new sparse writer targeting the specified file,
+// which must be empty.
+func NewSparseWriter(file SparseFile) *SparseWriter {
+	return &SparseWriter{file: file}
+}
+
+// isZero determines whether or not a chunk of data (which can't be larger than
+// sparseBlockSize) is zero-filled.
+func isZero(chunk []byte) bool {
+	return bytes.Equal(chunk, sparseZeroBlock[:len(chunk)])
+}
+
+// Write implements io.Writer.Write. Consecutive blocks of data are coalesced
+// into single writes in order to minimize system call overhead.
+func (w *SparseWriter) Write(data []byte) (int, error) {
+	// Track the number of bytes that we've processed.
+	var processed int
+
+	// Loop until all data has been processed.
+	for len(data) > 0 {
+		// Determine the extent of the run of blocks (or partial blocks) that
+		// are all either zero-filled or data. We always split at block
+		// boundaries relative to the file offset so that holes are correctly
+		// aligned.
+		var run int
+		var zero bool
+		for run < len(data) {
+			// Compute the extent of the next (potentially partial) block.
+			extent := sparseBlockSize - int((w.offset+int64(run))%sparseBlockSize)
+			if extent > len(data)-run {
+				extent = len(data) - run
+			}
+
+			// Classify the block and stop if it differs from the current run.
+			blockIsZero := isZero(data[run : run+extent])
+			if run == 0 {
+				zero = blockIsZero
+			} else if blockIsZero != zero {
+				break
+			}
+			run += extent
+		}
+
+		// Process the run. Zero-filled regions are simply skipped.
+		if !zero {
+			if n, err := w.file.WriteAt(data[:run], w.offset); err != nil {
+				w.offset += int64(n)
+				return processed + n, err
+			}
+		}
+		w.offset += int64(run)
+		processed += run
+		data = data[run:]
+	}
+
+	// Success.
+	return processed, nil
+}
+
+// Skip advances the write offset by the specified number of bytes, leaving a
+// hole in the file. The amount must be non-negative.
+func (w *SparseWriter) Skip(amount int64) error {
+	// Validate the amount.
+	if amount < 0 {
+		return errors.New("negative skip amount")
+	}
+
+	// Advance the offset.
+	w.offset += amount
+
+	// Success.
+	return nil
+}
+
+// Finalize sets the file size to the total number of bytes written or skipped,
+// ensuring that any trailing hole is accounted for. It does not close the
+// underlying file.
+func (w *SparseWriter) Finalize() error {
+	return w.file.Truncate(w.offset)
+}
+
+// dataRegionWithoutHoleDetection is a fallback implementation of
+// NextDataRegion that treats the remainder of the file as data.
+func dataRegionWithoutHoleDetection(file *os.File, offset int64) (int64, int64, error) {
+	// Determine the file size.
+	metadata, err := file.Stat()
+	if err != nil {
+		return 0, 0, fmt.Errorf("unable to query file size: %w", err)
+	}
+	size := metadata.Size()
+
+	// Check whether or not any data remains.
+	if offset >= size {
+		return 0, 0, io.EOF
+	}
+
+	// Success.
+	return offset, size, nil
+}
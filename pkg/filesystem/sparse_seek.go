@@ -0,0 +1,40 @@
+//go:build linux || darwin || freebsd
+
+package filesystem
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// NextDataRegion returns the start and end offsets of the next region of data
+// in the file at or after the specified offset. Holes are detected using
+// SEEK_DATA and SEEK_HOLE. If the underlying filesystem doesn't support hole
+// detection, then the remainder of the file is treated as data. If there is no
+// data at or after the specified offset, then io.EOF is returned. This function
+// modifies the file's offset.
+func NextDataRegion(file *os.File, offset int64) (int64, int64, error) {
+	// Locate the start of the next data region.
+	start, err := file.Seek(offset, unix.SEEK_DATA)
+	if err != nil {
+		if errors.Is(err, unix.ENXIO) {
+			return 0, 0, io.EOF
+		} else if errors.Is(err, unix.EINVAL) {
+			return dataRegionWithoutHoleDetection(file, offset)
+		}
+		return 0, 0, err
+	}
+
+	// Locate the end of the data region. Every file has an implicit hole at
+	// its end, so this will always succeed for a valid data offset.
+	end, err := file.Seek(start, unix.SEEK_HOLE)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Success.
+	return start, end, nil
+}
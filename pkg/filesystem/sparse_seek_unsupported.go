@@ -0,0 +1,15 @@
+//go:build !linux && !darwin && !freebsd
+
+package filesystem
+
+import (
+	"os"
+)
+
+// NextDataRegion returns the start and end offsets of the next region of data
+// in the file at or after the specified offset. Hole detection isn't supported
+// on this platform, so the remainder of the file is always treated as data. If
+// there is no data at or after the specified offset, then io.EOF is returned.
+func NextDataRegion(file *os.File, offset int64) (int64, int64, error) {
+	return dataRegionWithoutHoleDetection(file, offset)
+}
@@ -0,0 +1,153 @@
+package filesystem
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testingHoleDetectionSupported determines whether or not the filesystem used
+// for temporary directories supports hole detection by checking whether or not
+// a file consisting of a single hole is reported as having no data.
+func testingHoleDetectionSupported(t *testing.T) bool {
+	// Mark this function as a test helper.
+	t.Helper()
+
+	// Create a file consisting of a single hole.
+	file, err := os.Create(filepath.Join(t.TempDir(), "hole"))
+	if err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	defer file.Close()
+	if err := file.Truncate(1 << 20); err != nil {
+		t.Fatal("unable to extend file:", err)
+	}
+
+	// Check whether or not any data is reported.
+	_, _, err = NextDataRegion(file, 0)
+	return err == io.EOF
+}
+
+// TestSparseWriter tests that SparseWriter reproduces written content exactly,
+// including zero-filled regions and trailing holes.
+func TestSparseWriter(t *testing.T) {
+	// Create content with leading, interior, and trailing zero-filled regions,
+	// as well as unaligned data.
+	content := make([]byte, 10*sparseBlockSize+123)
+	copy(content[3*sparseBlockSize+17:], []byte("interior data"))
+	copy(content[6*sparseBlockSize:], bytes.Repeat([]byte{1}, 2*sparseBlockSize))
+
+	// Determine whether or not the filesystem supports hole detection.
+	holeDetection := testingHoleDetectionSupported(t)
+
+	// Test a variety of write sizes, including ones that aren't aligned with
+	// the block size.
+	for _, chunkSize := range []int{1, 100, sparseBlockSize, sparseBlockSize + 1, len(content)} {
+		// Create the target file.
+		path := filepath.Join(t.TempDir(), "file")
+		file, err := os.Create(path)
+		if err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+
+		// Write the content in chunks.
+		writer := NewSparseWriter(file)
+		for remaining := content; len(remaining) > 0; {
+			n := chunkSize
+			if n > len(remaining) {
+				n = len(remaining)
+			}
+			if written, err := writer.Write(remaining[:n]); err != nil {
+				t.Fatal("unable to write content:", err)
+			} else if written != n {
+				t.Fatal("short write")
+			}
+			remaining = remaining[n:]
+		}
+
+		// Add a trailing hole.
+		if err := writer.Skip(sparseBlockSize); err != nil {
+			t.Fatal("unable to skip trailing hole:", err)
+		}
+
+		// Finalize the file.
+		if err := writer.Finalize(); err != nil {
+			t.Fatal("unable to finalize file:", err)
+		}
+
+		// Verify that the leading zero-filled region was converted to a hole,
+		// regardless of write alignment. We can only check this on filesystems
+		// that support hole detection.
+		if holeDetection {
+			if start, _, err := NextDataRegion(file, 0); err != nil {
+				t.Fatal("unable to locate data region:", err)
+			} else if start < 3*sparseBlockSize {
+				t.Error("leading hole not created for chunk size", chunkSize)
+			}
+		}
+
+		// Close the file.
+		if err := file.Close(); err != nil {
+			t.Fatal("unable to close file:", err)
+		}
+
+		// Verify the file contents.
+		expected := append(append([]byte{}, content...), make([]byte, sparseBlockSize)...)
+		if actual, err := os.ReadFile(path); err != nil {
+			t.Fatal("unable to read file:", err)
+		} else if !bytes.Equal(actual, expected) {
+			t.Error("file contents do not match expected for chunk size", chunkSize)
+		}
+	}
+}
+
+// TestSparseWriterNegativeSkip tests that SparseWriter rejects negative skips.
+func TestSparseWriterNegativeSkip(t *testing.T) {
+	if err := NewSparseWriter(nil).Skip(-1); err == nil {
+		t.Error("negative skip succeeded")
+	}
+}
+
+// TestNextDataRegion tests that NextDataRegion covers all data in a file.
+func TestNextDataRegion(t *testing.T) {
+	// Create a file with data surrounded by holes.
+	path := filepath.Join(t.TempDir(), "file")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	defer file.Close()
+	data := []byte("data")
+	const dataOffset = 1 << 20
+	if _, err := file.WriteAt(data, dataOffset); err != nil {
+		t.Fatal("unable to write data:", err)
+	}
+	if err := file.Truncate(2 << 20); err != nil {
+		t.Fatal("unable to extend file:", err)
+	}
+
+	// Iterate over data regions and ensure that the data is contained within
+	// them. We can't make assumptions about the precise boundaries since they
+	// depend on filesystem support and allocation granularity.
+	var offset int64
+	var found bool
+	for {
+		start, end, err := NextDataRegion(file, offset)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal("unable to locate data region:", err)
+		} else if start < offset || end <= start {
+			t.Fatal("invalid data region:", start, end)
+		}
+		if start <= dataOffset && dataOffset+int64(len(data)) <= end {
+			found = true
+		}
+		offset = end
+	}
+	if !found {
+		t.Error("data not contained in any data region")
+	}
+}
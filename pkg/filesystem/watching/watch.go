@@ -8,6 +8,9 @@ var (
 	// ErrWatchInternalOverflow indicates that a watcher saw an event buffering
 	// overflow in its underlying watching mechanism.
 	ErrWatchInternalOverflow = errors.New("internal event overflow")
+	// ErrWatchLimitReached indicates that a watcher was unable to establish a
+	// watch because a system limit on the number of watches was reached.
+	ErrWatchLimitReached = errors.New("watch limit reached")
 	// ErrWatchTerminated indicates that a watcher has been terminated.
 	ErrWatchTerminated = errors.New("watch terminated")
 )
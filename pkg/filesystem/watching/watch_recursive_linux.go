@@ -0,0 +1,387 @@
+//go:build linux && !(sspl && fanotify)
+
+package watching
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// RecursiveWatchingSupported indicates whether or not the current platform
+	// supports native recursive watching.
+	RecursiveWatchingSupported = true
+
+	// inotifyRecursiveWatchMask is the event mask used for watches established
+	// by the recursive inotify watcher.
+	inotifyRecursiveWatchMask = unix.IN_MODIFY | unix.IN_ATTRIB |
+		unix.IN_CLOSE_WRITE |
+		unix.IN_MOVED_FROM | unix.IN_MOVED_TO |
+		unix.IN_CREATE | unix.IN_DELETE |
+		unix.IN_DELETE_SELF | unix.IN_MOVE_SELF
+	// inotifyReadBufferSize is the size of the buffer used to read inotify
+	// events. It is large enough to hold many events, each of which is at most
+	// unix.SizeofInotifyEvent + unix.NAME_MAX + 1 bytes in size.
+	inotifyReadBufferSize = 64 * 1024
+	// inotifyMaximumUserWatchesPath is the path to the sysctl controlling the
+	// maximum number of inotify watches per user.
+	inotifyMaximumUserWatchesPath = "/proc/sys/fs/inotify/max_user_watches"
+)
+
+// inotifyWatchLimitError creates a descriptive error for cases where inotify
+// watch creation fails due to the per-user watch limit being reached.
+func inotifyWatchLimitError(established int) error {
+	// Attempt to read the current limit. If we can't, then we just omit it.
+	var limit string
+	if data, err := os.ReadFile(inotifyMaximumUserWatchesPath); err == nil {
+		limit = fmt.Sprintf(" (currently %s)", strings.TrimSpace(string(data)))
+	}
+
+	// Create the error.
+	return fmt.Errorf(
+		"%w after establishing %d inotify watches; consider raising fs.inotify.max_user_watches%s, e.g. with \"sysctl fs.inotify.max_user_watches=524288\"",
+		ErrWatchLimitReached, established, limit,
+	)
+}
+
+// recursiveWatcher implements RecursiveWatcher using inotify, establishing a
+// watch on each directory in the watched hierarchy.
+type recursiveWatcher struct {
+	// file is the inotify file descriptor, wrapped so that reads integrate with
+	// the Go runtime's poller and can be interrupted by closure.
+	file *os.File
+	// descriptor is the raw inotify file descriptor.
+	descriptor int
+	// target is the watch target path.
+	target string
+	// rootDescriptor is the watch descriptor for the watch target.
+	rootDescriptor int
+	// watches maps watch descriptors to target-relative directory paths.
+	watches map[int]string
+	// descriptors maps target-relative directory paths to watch descriptors.
+	descriptors map[string]int
+	// events is the event delivery channel.
+	events chan string
+	// errors is the error delivery channel.
+	errors chan error
+	// cancel is the run loop cancellation function.
+	cancel context.CancelFunc
+	// done is the run loop completion signaling mechanism.
+	done sync.WaitGroup
+}
+
+// NewRecursiveWatcher creates a new inotify-based recursive watcher using the
+// specified target path.
+func NewRecursiveWatcher(target string) (RecursiveWatcher, error) {
+	// Clean the target path so that we can compute target-relative paths by
+	// slicing.
+	target = filepath.Clean(target)
+
+	// Create the inotify instance.
+	descriptor, err := unix.InotifyInit1(unix.IN_CLOEXEC | unix.IN_NONBLOCK)
+	if err != nil {
+		if err == unix.EMFILE {
+			return nil, errors.New("unable to create inotify instance: per-user instance limit reached; consider raising fs.inotify.max_user_instances")
+		}
+		return nil, fmt.Errorf("unable to create inotify instance: %w", err)
+	}
+
+	// Create the watcher. Since the descriptor is non-blocking, wrapping it in
+	// an os.File will register it with the runtime poller.
+	watcher := &recursiveWatcher{
+		file:        os.NewFile(uintptr(descriptor), "inotify"),
+		descriptor:  descriptor,
+		target:      target,
+		watches:     make(map[int]string),
+		descriptors: make(map[string]int),
+		events:      make(chan string),
+		errors:      make(chan error, 1),
+	}
+
+	// Establish the watch on the target itself. We don't restrict this to
+	// directories because the target may be a file.
+	if rootDescriptor, err := unix.InotifyAddWatch(descriptor, target, inotifyRecursiveWatchMask); err != nil {
+		watcher.file.Close()
+		if err == unix.ENOSPC {
+			return nil, inotifyWatchLimitError(0)
+		}
+		return nil, fmt.Errorf("unable to watch target: %w", err)
+	} else {
+		watcher.rootDescriptor = rootDescriptor
+		watcher.watches[rootDescriptor] = ""
+		watcher.descriptors[""] = rootDescriptor
+	}
+
+	// Establish watches on the target's subdirectories. We don't need to send
+	// events for these directories since they're part of the initial state.
+	if err := watcher.walk("", nil); err != nil {
+		watcher.file.Close()
+		return nil, err
+	}
+
+	// Create a context to regulate the watcher's run loop.
+	ctx, cancel := context.WithCancel(context.Background())
+	watcher.cancel = cancel
+
+	// Track run loop termination.
+	watcher.done.Add(1)
+
+	// Start the run loop.
+	go func() {
+		watcher.errors <- watcher.run(ctx)
+		watcher.done.Done()
+	}()
+
+	// Success.
+	return watcher, nil
+}
+
+// watchDirectory establishes a watch on the directory at the specified
+// target-relative path. It returns false if the directory no longer exists or
+// can't be accessed, in which case it can be skipped, since it'll be picked up
+// (or reported) by scanning.
+func (w *recursiveWatcher) watchDirectory(path string) (bool, error) {
+	// Establish the watch.
+	descriptor, err := unix.InotifyAddWatch(w.descriptor,
+		filepath.Join(w.target, filepath.FromSlash(path)),
+		inotifyRecursiveWatchMask|unix.IN_ONLYDIR|unix.IN_DONT_FOLLOW,
+	)
+	if err != nil {
+		if err == unix.ENOSPC {
+			return false, inotifyWatchLimitError(len(w.watches))
+		} else if err == unix.ENOENT || err == unix.ENOTDIR || err == unix.EACCES {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to watch directory (%s): %w", path, err)
+	}
+
+	// Record the watch. If the directory was already watched (e.g. because
+	// we've already seen it via a different event), then inotify will have
+	// returned the existing descriptor.
+	w.watches[descriptor] = path
+	w.descriptors[path] = descriptor
+
+	// Success.
+	return true, nil
+}
+
+// walk establishes watches on the directory at the specified target-relative
+// path and its subdirectories, recursively. The watch target itself is assumed
+// to already be watched. If discovered is non-nil, then it's invoked with the
+// path of each directory whose watch is established.
+func (w *recursiveWatcher) walk(path string, discovered func(string)) error {
+	// Compute the absolute path at which to start.
+	start := w.target
+	if path != "" {
+		start = filepath.Join(w.target, path)
+	}
+
+	// Perform the walk.
+	return filepath.WalkDir(start, func(absolute string, entry fs.DirEntry, err error) error {
+		// Skip content that can't be read or no longer exists. If the start
+		// path itself is a file (e.g. a file target), then there's nothing to
+		// do.
+		if err != nil {
+			if entry != nil && entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		} else if !entry.IsDir() {
+			return nil
+		}
+
+		// Compute the target-relative path.
+		relative := path
+		if absolute != start {
+			relative = filepath.ToSlash(absolute[len(w.target)+1:])
+		}
+
+		// If this isn't the watch target (which is already watched), then
+		// establish a watch. We establish the watch before the directory is
+		// read by the walk to avoid missing content created concurrently.
+		if relative != "" {
+			if watched, err := w.watchDirectory(relative); err != nil {
+				return err
+			} else if !watched {
+				return fs.SkipDir
+			}
+		}
+
+		// Report discovery.
+		if discovered != nil {
+			discovered(relative)
+		}
+
+		// Continue the walk.
+		return nil
+	})
+}
+
+// unwatch removes the watches for the specified target-relative directory path
+// and any of its subdirectories.
+func (w *recursiveWatcher) unwatch(path string) {
+	prefix := path + "/"
+	for p, descriptor := range w.descriptors {
+		if p == path || strings.HasPrefix(p, prefix) {
+			unix.InotifyRmWatch(w.descriptor, uint32(descriptor))
+			delete(w.descriptors, p)
+			delete(w.watches, descriptor)
+		}
+	}
+}
+
+// run implements the event processing run loop for recursiveWatcher.
+func (w *recursiveWatcher) run(ctx context.Context) error {
+	// Create a buffer for reading events.
+	buffer := make([]byte, inotifyReadBufferSize)
+
+	// Create a queue for paths discovered while processing events.
+	var discovered []string
+	discover := func(path string) {
+		discovered = append(discovered, path)
+	}
+
+	// Perform event forwarding until cancellation or failure.
+	for {
+		// Read events. If the read fails due to closure, then we've been
+		// terminated.
+		n, err := w.file.Read(buffer)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ErrWatchTerminated
+			}
+			return fmt.Errorf("unable to read inotify events: %w", err)
+		} else if n < unix.SizeofInotifyEvent {
+			return errors.New("short inotify event read")
+		}
+
+		// Process events.
+		for offset := 0; offset+unix.SizeofInotifyEvent <= n; {
+			// Extract the event and its name.
+			event := (*unix.InotifyEvent)(unsafe.Pointer(&buffer[offset]))
+			nameStart := offset + unix.SizeofInotifyEvent
+			nameEnd := nameStart + int(event.Len)
+			if nameEnd > n {
+				return errors.New("truncated inotify event")
+			}
+			name := string(bytes.TrimRight(buffer[nameStart:nameEnd], "\x00"))
+			offset = nameEnd
+
+			// Watch for event overflows, which mean that we've missed events.
+			if event.Mask&unix.IN_Q_OVERFLOW != 0 {
+				return ErrWatchInternalOverflow
+			}
+
+			// Look up the directory path associated with the watch. If it's no
+			// longer tracked (e.g. because we've removed the watch), then
+			// ignore the event.
+			descriptor := int(event.Wd)
+			directory, ok := w.watches[descriptor]
+			if !ok {
+				continue
+			}
+
+			// Handle watch removal, which occurs when a directory is deleted
+			// (or the watch is otherwise invalidated). If this is the watch
+			// target, then the watch is no longer valid.
+			if event.Mask&unix.IN_IGNORED != 0 {
+				if descriptor == w.rootDescriptor {
+					return errors.New("watch target removed")
+				}
+				delete(w.watches, descriptor)
+				if w.descriptors[directory] == descriptor {
+					delete(w.descriptors, directory)
+				}
+				continue
+			}
+
+			// If the watch target itself has been moved or deleted, then the
+			// watch is no longer valid.
+			if descriptor == w.rootDescriptor && event.Mask&(unix.IN_MOVE_SELF|unix.IN_DELETE_SELF) != 0 {
+				return errors.New("watch target moved or deleted")
+			}
+
+			// Compute the target-relative event path.
+			path := directory
+			if name != "" {
+				if directory == "" {
+					path = name
+				} else {
+					path = directory + "/" + name
+				}
+			}
+
+			// Update watches for directories entering or leaving the watched
+			// hierarchy. Directories that are deleted have their watches
+			// removed automatically, but directories that are moved retain
+			// their watches (which would then have stale paths), so we remove
+			// those explicitly. Moves of subdirectories within the watched
+			// hierarchy are thus handled as removal and re-creation.
+			discovered = discovered[:0]
+			if event.Mask&unix.IN_ISDIR != 0 && name != "" {
+				if event.Mask&unix.IN_MOVED_FROM != 0 {
+					w.unwatch(path)
+				} else if event.Mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0 {
+					if err := w.walk(path, discover); err != nil {
+						return err
+					}
+				}
+			}
+
+			// Transmit the event path, followed by the paths of any
+			// directories discovered beneath it. Content may have been created
+			// within those directories before their watches were established,
+			// so they need to be rechecked.
+			select {
+			case w.events <- path:
+			case <-ctx.Done():
+				return ErrWatchTerminated
+			}
+			for _, d := range discovered {
+				if d == path {
+					continue
+				}
+				select {
+				case w.events <- d:
+				case <-ctx.Done():
+					return ErrWatchTerminated
+				}
+			}
+		}
+	}
+}
+
+// Events implements RecursiveWatcher.Events.
+func (w *recursiveWatcher) Events() <-chan string {
+	return w.events
+}
+
+// Errors implements RecursiveWatcher.Errors.
+func (w *recursiveWatcher) Errors() <-chan error {
+	return w.errors
+}
+
+// Terminate implements RecursiveWatcher.Terminate.
+func (w *recursiveWatcher) Terminate() error {
+	// Signal termination.
+	w.cancel()
+
+	// Close the inotify instance, which will unblock any pending read.
+	err := w.file.Close()
+
+	// Wait for the run loop to exit.
+	w.done.Wait()
+
+	// Done.
+	return err
+}
@@ -0,0 +1,49 @@
+//go:build linux && !(sspl && fanotify)
+
+package watching
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestRecursiveWatcherDirectoryTracking tests that the inotify-based recursive
+// watcher tracks directories created within and moved around the watched
+// hierarchy.
+func TestRecursiveWatcherDirectoryTracking(t *testing.T) {
+	// Create a temporary directory (that will be automatically removed).
+	directory := t.TempDir()
+
+	// Create the watcher and defer its termination.
+	watcher, err := NewRecursiveWatcher(directory)
+	if err != nil {
+		t.Fatal("unable to establish watch:", err)
+	}
+	defer watcher.Terminate()
+
+	// Create a nested directory hierarchy. Depending on timing, the deeper
+	// directory will be reported either due to its creation or due to its
+	// discovery when the watch for its parent is established.
+	if err := os.MkdirAll(filepath.Join(directory, "nested", "deeper"), 0700); err != nil {
+		t.Fatal("unable to create nested directories:", err)
+	}
+	verifyWatchEvent(t, watcher, map[string]bool{"nested": true, "nested/deeper": true})
+
+	// Create a file in the deeper directory.
+	if err := os.WriteFile(filepath.Join(directory, "nested", "deeper", "file"), nil, 0600); err != nil {
+		t.Fatal("unable to create test file:", err)
+	}
+	verifyWatchEvent(t, watcher, map[string]bool{"nested/deeper/file": true})
+
+	// Move the nested hierarchy and ensure that events within it are reported
+	// using its new location.
+	if err := os.Rename(filepath.Join(directory, "nested"), filepath.Join(directory, "moved")); err != nil {
+		t.Fatal("unable to move nested directories:", err)
+	}
+	verifyWatchEvent(t, watcher, map[string]bool{"nested": true, "moved": true})
+	if err := os.WriteFile(filepath.Join(directory, "moved", "deeper", "file"), []byte("data"), 0600); err != nil {
+		t.Fatal("unable to modify test file:", err)
+	}
+	verifyWatchEvent(t, watcher, map[string]bool{"moved/deeper/file": true})
+}
@@ -15,6 +15,28 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		return errors.New("nil configuration")
 	}
 
+	// Verify that the background prompting mode is unspecified or supported for
+	// usage.
+	if endpointSpecific {
+		if !c.BackgroundPromptingMode.IsDefault() {
+			return errors.New("background prompting mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.BackgroundPromptingMode.IsDefault() || c.BackgroundPromptingMode.Supported()) {
+			return errors.New("unknown or unsupported background prompting mode")
+		}
+	}
+
+	// Verify that disconnection policy parameters aren't specified on an
+	// endpoint-specific basis.
+	if endpointSpecific {
+		if c.DisconnectedTimeout != 0 {
+			return errors.New("disconnected timeout cannot be specified on an endpoint-specific basis")
+		} else if c.DisconnectedRetryInterval != 0 {
+			return errors.New("disconnected retry interval cannot be specified on an endpoint-specific basis")
+		}
+	}
+
 	// Verify that the socket overwrite mode is unspecified or supported for
 	// usage.
 	if !(c.SocketOverwriteMode.IsDefault() || c.SocketOverwriteMode.Supported()) {
@@ -51,7 +73,10 @@ func (c *Configuration) Equal(other *Configuration) bool {
 	}
 
 	// Perform an equivalence check.
-	return c.SocketOverwriteMode == other.SocketOverwriteMode &&
+	return c.BackgroundPromptingMode == other.BackgroundPromptingMode &&
+		c.DisconnectedTimeout == other.DisconnectedTimeout &&
+		c.DisconnectedRetryInterval == other.DisconnectedRetryInterval &&
+		c.SocketOverwriteMode == other.SocketOverwriteMode &&
 		c.SocketOwner == other.SocketOwner &&
 		c.SocketGroup == other.SocketGroup &&
 		c.SocketPermissionMode == other.SocketPermissionMode
@@ -63,6 +88,27 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 	// Create the resulting configuration.
 	result := &Configuration{}
 
+	// Merge background prompting mode.
+	if !higher.BackgroundPromptingMode.IsDefault() {
+		result.BackgroundPromptingMode = higher.BackgroundPromptingMode
+	} else {
+		result.BackgroundPromptingMode = lower.BackgroundPromptingMode
+	}
+
+	// Merge disconnected timeout.
+	if higher.DisconnectedTimeout != 0 {
+		result.DisconnectedTimeout = higher.DisconnectedTimeout
+	} else {
+		result.DisconnectedTimeout = lower.DisconnectedTimeout
+	}
+
+	// Merge disconnected retry interval.
+	if higher.DisconnectedRetryInterval != 0 {
+		result.DisconnectedRetryInterval = higher.DisconnectedRetryInterval
+	} else {
+		result.DisconnectedRetryInterval = lower.DisconnectedRetryInterval
+	}
+
 	// Merge socket overwrite mode.
 	if !higher.SocketOverwriteMode.IsDefault() {
 		result.SocketOverwriteMode = higher.SocketOverwriteMode
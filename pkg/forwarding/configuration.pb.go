@@ -7,6 +7,7 @@
 package forwarding
 
 import (
+	prompting "github.com/mutagen-io/mutagen/pkg/prompting"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	reflect "reflect"
@@ -29,6 +30,20 @@ type Configuration struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// BackgroundPromptingMode specifies whether or not automatic reconnections
+	// performed in the background may use the daemon's default prompter.
+	BackgroundPromptingMode prompting.BackgroundPromptingMode `protobuf:"varint,1,opt,name=backgroundPromptingMode,proto3,enum=prompting.BackgroundPromptingMode" json:"backgroundPromptingMode,omitempty"`
+	// DisconnectedTimeout specifies the duration (in seconds) for which the
+	// session can remain disconnected before it's suspended, after which
+	// reconnection is only attempted every DisconnectedRetryInterval. A value
+	// of 0 indicates that the session should never be suspended due to
+	// disconnection.
+	DisconnectedTimeout uint32 `protobuf:"varint,2,opt,name=disconnectedTimeout,proto3" json:"disconnectedTimeout,omitempty"`
+	// DisconnectedRetryInterval specifies the interval (in seconds) at which
+	// reconnection is attempted once the session has been suspended due to
+	// disconnection. A value of 0 specifies that the default interval should
+	// be used.
+	DisconnectedRetryInterval uint32 `protobuf:"varint,3,opt,name=disconnectedRetryInterval,proto3" json:"disconnectedRetryInterval,omitempty"`
 	// SocketOverwriteMode specifies whether or not existing Unix domain sockets
 	// should be overwritten when creating new listener sockets.
 	SocketOverwriteMode SocketOverwriteMode `protobuf:"varint,41,opt,name=socketOverwriteMode,proto3,enum=forwarding.SocketOverwriteMode" json:"socketOverwriteMode,omitempty"`
@@ -75,6 +90,27 @@ func (*Configuration) Descriptor() ([]byte, []int) {
 	return file_forwarding_configuration_proto_rawDescGZIP(), []int{0}
 }
 
+func (x *Configuration) GetBackgroundPromptingMode() prompting.BackgroundPromptingMode {
+	if x != nil {
+		return x.BackgroundPromptingMode
+	}
+	return prompting.BackgroundPromptingMode(0)
+}
+
+func (x *Configuration) GetDisconnectedTimeout() uint32 {
+	if x != nil {
+		return x.DisconnectedTimeout
+	}
+	return 0
+}
+
+func (x *Configuration) GetDisconnectedRetryInterval() uint32 {
+	if x != nil {
+		return x.DisconnectedRetryInterval
+	}
+	return 0
+}
+
 func (x *Configuration) GetSocketOverwriteMode() SocketOverwriteMode {
 	if x != nil {
 		return x.SocketOverwriteMode
@@ -111,24 +147,40 @@ var file_forwarding_configuration_proto_rawDesc = []byte{
 	0x12, 0x0a, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x1a, 0x26, 0x66, 0x6f,
 	0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x5f,
 	0x6f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xda, 0x01, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75,
-	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x51, 0x0a, 0x13, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
-	0x4f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x29, 0x20,
-	0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67,
-	0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65,
-	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x13, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72,
-	0x77, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x63,
-	0x6b, 0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
-	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x73,
-	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x32, 0x0a,
-	0x14, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f,
-	0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x2c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x73, 0x6f, 0x63,
-	0x6b, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64,
-	0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67,
-	0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
-	0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x29, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x2f,
+	0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0xa8, 0x03, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x5c, 0x0a, 0x17, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x50,
+	0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x42,
+	0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69,
+	0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x17, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x12,
+	0x30, 0x0a, 0x13, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x64, 0x69,
+	0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x12, 0x3c, 0x0a, 0x19, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65,
+	0x64, 0x52, 0x65, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74,
+	0x65, 0x64, 0x52, 0x65, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
+	0x51, 0x0a, 0x13, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69,
+	0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x29, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x66,
+	0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x4f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x13, 0x73,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x76, 0x65, 0x72, 0x77, 0x72, 0x69, 0x74, 0x65, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f, 0x77, 0x6e, 0x65,
+	0x72, 0x18, 0x2a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x4f,
+	0x77, 0x6e, 0x65, 0x72, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x18, 0x2b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x32, 0x0a, 0x14, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x2c,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x50, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e,
+	0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
 }
 
 var (
@@ -145,16 +197,18 @@ func file_forwarding_configuration_proto_rawDescGZIP() []byte {
 
 var file_forwarding_configuration_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_forwarding_configuration_proto_goTypes = []interface{}{
-	(*Configuration)(nil),    // 0: forwarding.Configuration
-	(SocketOverwriteMode)(0), // 1: forwarding.SocketOverwriteMode
+	(*Configuration)(nil),                  // 0: forwarding.Configuration
+	(prompting.BackgroundPromptingMode)(0), // 1: prompting.BackgroundPromptingMode
+	(SocketOverwriteMode)(0),               // 2: forwarding.SocketOverwriteMode
 }
 var file_forwarding_configuration_proto_depIdxs = []int32{
-	1, // 0: forwarding.Configuration.socketOverwriteMode:type_name -> forwarding.SocketOverwriteMode
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1, // 0: forwarding.Configuration.backgroundPromptingMode:type_name -> prompting.BackgroundPromptingMode
+	2, // 1: forwarding.Configuration.socketOverwriteMode:type_name -> forwarding.SocketOverwriteMode
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_forwarding_configuration_proto_init() }
@@ -15,6 +15,7 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/logging"
 	"github.com/mutagen-io/mutagen/pkg/mutagen"
 	"github.com/mutagen-io/mutagen/pkg/prompting"
+	"github.com/mutagen-io/mutagen/pkg/selection"
 	"github.com/mutagen-io/mutagen/pkg/state"
 	"github.com/mutagen-io/mutagen/pkg/url"
 )
@@ -23,12 +24,17 @@ const (
 	// autoReconnectInterval is the period of time to wait before attempting an
 	// automatic reconnect after disconnection or a failed reconnect.
 	autoReconnectInterval = 15 * time.Second
+	// logRingCapacity is the number of log entries retained in a session's log
+	// ring.
+	logRingCapacity = 1000
 )
 
 // controller manages and executes a single session.
 type controller struct {
 	// logger is the controller logger.
 	logger *logging.Logger
+	// logs is the session log ring attached to logger.
+	logs *logging.Ring
 	// sessionPath is the path to the serialized session.
 	sessionPath string
 	// stateLock guards and tracks changes to session's Paused field and state.
@@ -84,6 +90,10 @@ func newSession(
 	paused bool,
 	prompter string,
 ) (*controller, error) {
+	// Create the session log ring and attach it to the logger.
+	logs := logging.NewRing(logRingCapacity, logging.LevelInfo)
+	logger = logger.WithRing(logs)
+
 	// Update status.
 	prompting.Message(prompter, "Creating session...")
 
@@ -180,6 +190,7 @@ func newSession(
 	// Create the controller.
 	controller := &controller{
 		logger:                         logger,
+		logs:                           logs,
 		sessionPath:                    sessionPath,
 		stateLock:                      state.NewTrackingLock(tracker),
 		session:                        session,
@@ -211,6 +222,10 @@ func newSession(
 
 // loadSession loads an existing session and creates a corresponding controller.
 func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier string) (*controller, error) {
+	// Create the session log ring and attach it to the logger.
+	logs := logging.NewRing(logRingCapacity, logging.LevelInfo)
+	logger = logger.WithRing(logs)
+
 	// Compute the session path.
 	sessionPath, err := pathForSession(identifier)
 	if err != nil {
@@ -229,6 +244,7 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 	// Create the controller.
 	controller := &controller{
 		logger:      logger,
+		logs:        logs,
 		sessionPath: sessionPath,
 		stateLock:   state.NewTrackingLock(tracker),
 		session:     session,
@@ -272,6 +288,127 @@ func (c *controller) currentState() *State {
 	return proto.Clone(c.state).(*State)
 }
 
+// selectionCandidate creates a description of the session for the purpose of
+// matching it against session selectors.
+func (c *controller) selectionCandidate() *selection.Candidate {
+	// Lock the session state and defer its release. As with currentState, we
+	// unlock without a notification to avoid a list/notify cycle.
+	c.stateLock.Lock()
+	defer c.stateLock.UnlockWithoutNotify()
+
+	// Compute the statuses that currently apply to the session.
+	statuses := make(map[string]bool)
+	if c.session.Paused {
+		statuses[selection.SessionStatusPaused] = true
+	} else {
+		statuses[selection.SessionStatusRunning] = true
+		if c.state.SourceState.Connected && c.state.DestinationState.Connected {
+			statuses[selection.SessionStatusConnected] = true
+		} else {
+			statuses[selection.SessionStatusDisconnected] = true
+		}
+	}
+	if c.state.LastError != "" {
+		statuses[selection.SessionStatusHasErrors] = true
+	}
+
+	// Create the candidate.
+	return &selection.Candidate{
+		Name:      c.session.Name,
+		Endpoints: []*url.URL{c.session.Source, c.session.Destination},
+		Statuses:  statuses,
+	}
+}
+
+// export creates a portable copy of the session definition.
+func (c *controller) export() *Session {
+	c.stateLock.Lock()
+	defer c.stateLock.UnlockWithoutNotify()
+	return proto.Clone(c.session).(*Session)
+}
+
+// nameAndLabels returns the current session name and labels. The returned
+// label map must not be modified.
+func (c *controller) nameAndLabels() (string, map[string]string) {
+	c.stateLock.Lock()
+	defer c.stateLock.UnlockWithoutNotify()
+	return c.session.Name, c.session.Labels
+}
+
+// labeling computes the session name (if rename is true) and labels that would
+// result from a label operation, without applying them. Labels with keys in
+// removals are removed before labels are added. Unless overwrite is true,
+// adding a label whose key is already present with a different value is an
+// error. The returned label map may be stored by the caller.
+func (c *controller) labeling(rename bool, name string, labels map[string]string, removals []string, overwrite bool) (string, map[string]string, error) {
+	// Lock the session state and defer its release.
+	c.stateLock.Lock()
+	defer c.stateLock.UnlockWithoutNotify()
+
+	// Compute the new name.
+	newName := c.session.Name
+	if rename {
+		newName = name
+	}
+	if err := selection.EnsureNameValid(newName); err != nil {
+		return "", nil, fmt.Errorf("invalid name: %w", err)
+	}
+
+	// Compute the new labels. We create a new map (rather than modifying the
+	// existing one) since the existing one may have been handed out.
+	newLabels := make(map[string]string, len(c.session.Labels)+len(labels))
+	for k, v := range c.session.Labels {
+		newLabels[k] = v
+	}
+	for _, k := range removals {
+		delete(newLabels, k)
+	}
+	for k, v := range labels {
+		if existing, ok := newLabels[k]; ok && existing != v && !overwrite {
+			return "", nil, fmt.Errorf("label \"%s\" already has a value (overwrite not specified)", k)
+		} else if err := selection.EnsureLabelKeyValid(k); err != nil {
+			return "", nil, fmt.Errorf("invalid label key: %w", err)
+		} else if err = selection.EnsureLabelValueValid(v); err != nil {
+			return "", nil, fmt.Errorf("invalid label value: %w", err)
+		}
+		newLabels[k] = v
+	}
+	if len(newLabels) == 0 {
+		newLabels = nil
+	}
+
+	// Success.
+	return newName, newLabels, nil
+}
+
+// setNameAndLabels sets the session name and labels, saving the updated session
+// to disk. The label map must not be modified after this call.
+func (c *controller) setNameAndLabels(name string, labels map[string]string) error {
+	// Lock the session state and defer its release. We unlock with a
+	// notification so that listeners see the updated session.
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+
+	// Save an updated copy of the session to disk. We only update the
+	// in-memory session once the change has been persisted.
+	session := proto.Clone(c.session).(*Session)
+	session.Name = name
+	session.Labels = labels
+	if err := encoding.MarshalAndSaveProtobuf(c.sessionPath, session); err != nil {
+		return fmt.Errorf("unable to save session: %w", err)
+	}
+
+	// Update the in-memory session.
+	c.session.Name = name
+	c.session.Labels = labels
+
+	// Log the change.
+	c.logger.Info("Session name and labels updated")
+
+	// Success.
+	return nil
+}
+
 // resume attempts to reconnect and resume the session if it isn't currently
 // connected and forwarding.
 func (c *controller) resume(ctx context.Context, prompter string) error {
@@ -477,6 +614,26 @@ func (c *controller) halt(_ context.Context, mode controllerHaltMode, prompter s
 	return nil
 }
 
+// backgroundPrompter returns the identifier of the prompter to use for
+// connection operations performed in the background (i.e. automatic
+// reconnection). This is the default prompter (if any) if the session's
+// background prompting mode allows it, otherwise an empty string.
+func (c *controller) backgroundPrompter() string {
+	// Determine the effective background prompting mode.
+	mode := c.session.Configuration.BackgroundPromptingMode
+	if mode.IsDefault() {
+		mode = c.session.Version.DefaultBackgroundPromptingMode()
+	}
+
+	// If background prompting is disabled, then don't use any prompter.
+	if !mode.Enabled() {
+		return ""
+	}
+
+	// Use the default prompter, if any.
+	return prompting.DefaultPrompter()
+}
+
 // run is the main run loop for the controller, managing connectivity and
 // forwarding.
 func (c *controller) run(ctx context.Context, source, destination Endpoint) {
@@ -514,6 +671,10 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 	// Track the last time that forwarding failed.
 	var lastForwardingFailureTime time.Time
 
+	// Track the time at which the session became disconnected, if it's not
+	// connected.
+	var disconnectedSince time.Time
+
 	// Loop until cancelled.
 	for {
 		// Loop until we're connected to both endpoints. We do a non-blocking
@@ -531,7 +692,7 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 					ctx,
 					c.logger.Sublogger("source"),
 					c.session.Source,
-					"",
+					c.backgroundPrompter(),
 					c.session.Identifier,
 					c.session.Version,
 					c.mergedSourceConfiguration,
@@ -564,7 +725,7 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 					ctx,
 					c.logger.Sublogger("destination"),
 					c.session.Destination,
-					"",
+					c.backgroundPrompter(),
 					c.session.Identifier,
 					c.session.Version,
 					c.mergedDestinationConfiguration,
@@ -583,15 +744,37 @@ func (c *controller) run(ctx context.Context, source, destination Endpoint) {
 			// it in the loop condition we'd still need a check here to avoid a
 			// sleep every time (even if already successfully connected).
 			if source != nil && destination != nil {
+				disconnectedSince = time.Time{}
+				c.stateLock.Lock()
+				c.state.Suspension = ""
+				c.stateLock.Unlock()
 				break
 			}
 
+			// Determine the reconnection interval. If the session has been
+			// disconnected for long enough that its policies require
+			// suspension, then we reduce the frequency of reconnection.
+			if disconnectedSince.IsZero() {
+				disconnectedSince = time.Now()
+			}
+			reconnectInterval := autoReconnectInterval
+			disconnected := time.Since(disconnectedSince)
+			if interval, suspend := disconnectedRetryInterval(c.session.Version, c.session.Configuration, disconnected); suspend {
+				reconnectInterval = interval
+				c.stateLock.Lock()
+				c.state.Suspension = fmt.Sprintf(
+					"disconnected for %s (retrying every %s)",
+					disconnected.Round(time.Minute), interval,
+				)
+				c.stateLock.Unlock()
+			}
+
 			// If we failed to connect, wait and then retry. Watch for
 			// cancellation in the mean time.
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(autoReconnectInterval):
+			case <-time.After(reconnectInterval):
 			}
 		}
 
@@ -0,0 +1,24 @@
+package forwarding
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EnsureValid ensures that SessionExport's invariants are respected.
+func (e *SessionExport) EnsureValid() error {
+	// A nil session export is not valid.
+	if e == nil {
+		return errors.New("nil session export")
+	}
+
+	// Ensure that all exported sessions are valid.
+	for _, s := range e.Sessions {
+		if err := s.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid exported session: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
@@ -0,0 +1,156 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: forwarding/export.proto
+
+package forwarding
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SessionExport is a portable collection of session definitions that can be
+// used to recreate sessions on another daemon.
+type SessionExport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sessions are the exported session definitions. Their identifiers and
+	// other header fields are informational only and are regenerated on
+	// import.
+	Sessions []*Session `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *SessionExport) Reset() {
+	*x = SessionExport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_forwarding_export_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionExport) ProtoMessage() {}
+
+func (x *SessionExport) ProtoReflect() protoreflect.Message {
+	mi := &file_forwarding_export_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionExport.ProtoReflect.Descriptor instead.
+func (*SessionExport) Descriptor() ([]byte, []int) {
+	return file_forwarding_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SessionExport) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+var File_forwarding_export_proto protoreflect.FileDescriptor
+
+var file_forwarding_export_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2f, 0x65, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0a, 0x66, 0x6f, 0x72, 0x77, 0x61,
+	0x72, 0x64, 0x69, 0x6e, 0x67, 0x1a, 0x18, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x2f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22,
+	0x40, 0x0a, 0x0d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74,
+	0x12, 0x2f, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e, 0x67, 0x2e,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67,
+	0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x66, 0x6f, 0x72, 0x77, 0x61, 0x72, 0x64, 0x69, 0x6e,
+	0x67, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_forwarding_export_proto_rawDescOnce sync.Once
+	file_forwarding_export_proto_rawDescData = file_forwarding_export_proto_rawDesc
+)
+
+func file_forwarding_export_proto_rawDescGZIP() []byte {
+	file_forwarding_export_proto_rawDescOnce.Do(func() {
+		file_forwarding_export_proto_rawDescData = protoimpl.X.CompressGZIP(file_forwarding_export_proto_rawDescData)
+	})
+	return file_forwarding_export_proto_rawDescData
+}
+
+var file_forwarding_export_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_forwarding_export_proto_goTypes = []interface{}{
+	(*SessionExport)(nil), // 0: forwarding.SessionExport
+	(*Session)(nil),       // 1: forwarding.Session
+}
+var file_forwarding_export_proto_depIdxs = []int32{
+	1, // 0: forwarding.SessionExport.sessions:type_name -> forwarding.Session
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_forwarding_export_proto_init() }
+func file_forwarding_export_proto_init() {
+	if File_forwarding_export_proto != nil {
+		return
+	}
+	file_forwarding_session_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_forwarding_export_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionExport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_forwarding_export_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_forwarding_export_proto_goTypes,
+		DependencyIndexes: file_forwarding_export_proto_depIdxs,
+		MessageInfos:      file_forwarding_export_proto_msgTypes,
+	}.Build()
+	File_forwarding_export_proto = out.File
+	file_forwarding_export_proto_rawDesc = nil
+	file_forwarding_export_proto_goTypes = nil
+	file_forwarding_export_proto_depIdxs = nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/identifier"
@@ -25,6 +26,8 @@ type Manager struct {
 	sessionsLock *state.TrackingLock
 	// sessions maps sessions to their respective controllers.
 	sessions map[string]*controller
+	// labelLock serializes label operations.
+	labelLock sync.Mutex
 }
 
 // NewManager creates a new Manager instance.
@@ -53,7 +56,7 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 			continue
 		}
 		logger.Info("Loading session", id)
-		if controller, err := loadSession(logger.Sublogger(identifier.Truncated(id)), tracker, id); err != nil {
+		if controller, err := loadSession(logger.Sublogger(identifier.Truncated(id)).With("session", id), tracker, id); err != nil {
 			logger.Warnf("Failed to load session %s: %v", id, err)
 			continue
 		} else {
@@ -102,7 +105,8 @@ func (m *Manager) findControllersBySpecification(specifications []string) ([]*co
 	for _, specification := range specifications {
 		var matched bool
 		for _, controller := range m.sessions {
-			if controller.session.Identifier == specification || controller.session.Name == specification {
+			name, _ := controller.nameAndLabels()
+			if controller.session.Identifier == specification || name == specification {
 				controllerSet[controller] = true
 				matched = true
 			}
@@ -138,7 +142,32 @@ func (m *Manager) findControllersByLabelSelector(labelSelector string) ([]*contr
 	// Loop over controllers and look for matches.
 	var controllers []*controller
 	for _, controller := range m.sessions {
-		if selector.Matches(controller.session.Labels) {
+		if _, labels := controller.nameAndLabels(); selector.Matches(labels) {
+			controllers = append(controllers, controller)
+		}
+	}
+
+	// Done.
+	return controllers, nil
+}
+
+// findControllersBySelectors generates a list of controllers matching all of
+// the specified selectors.
+func (m *Manager) findControllersBySelectors(selectors []string) ([]*controller, error) {
+	// Parse the selectors.
+	parsed, err := selection.ParseSelectors(selectors)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse selectors: %w", err)
+	}
+
+	// Grab the registry lock and defer its release.
+	m.sessionsLock.Lock()
+	defer m.sessionsLock.UnlockWithoutNotify()
+
+	// Loop over controllers and look for matches.
+	var controllers []*controller
+	for _, controller := range m.sessions {
+		if parsed.Matches(controller.selectionCandidate()) {
 			controllers = append(controllers, controller)
 		}
 	}
@@ -157,6 +186,8 @@ func (m *Manager) selectControllers(selection *selection.Selection) ([]*controll
 		return m.findControllersBySpecification(selection.Specifications)
 	} else if selection.LabelSelector != "" {
 		return m.findControllersByLabelSelector(selection.LabelSelector)
+	} else if len(selection.Selectors) > 0 {
+		return m.findControllersBySelectors(selection.Selectors)
 	} else {
 		// TODO: Should we panic here instead?
 		return nil, errors.New("invalid session selection")
@@ -204,7 +235,7 @@ func (m *Manager) Create(
 	// Attempt to create a session.
 	controller, err := newSession(
 		ctx,
-		m.logger.Sublogger(identifier.Truncated(id)),
+		m.logger.Sublogger(identifier.Truncated(id)).With("session", id),
 		m.tracker,
 		id,
 		source, destination,
@@ -227,6 +258,69 @@ func (m *Manager) Create(
 	return controller.session.Identifier, nil
 }
 
+// Import tells the manager to create new sessions from exported session
+// definitions. Each session is assigned a new identifier. If paused is true,
+// then all sessions are created paused, otherwise sessions are only created
+// paused if they were paused at export time. The identifiers of the new
+// sessions are returned. If an error occurs, then sessions imported before the
+// failure are left in place.
+func (m *Manager) Import(ctx context.Context, export *SessionExport, paused bool, prompter string) ([]string, error) {
+	// Ensure that the export is valid.
+	if err := export.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid session export: %w", err)
+	}
+
+	// Create each session.
+	identifiers := make([]string, 0, len(export.Sessions))
+	for _, session := range export.Sessions {
+		identifier, err := m.Create(
+			ctx,
+			session.Source, session.Destination,
+			session.Configuration, session.ConfigurationSource, session.ConfigurationDestination,
+			session.Name,
+			session.Labels,
+			paused || session.Paused,
+			prompter,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to import session %s (%d session(s) imported): %w",
+				session.Identifier, len(identifiers), err,
+			)
+		}
+		identifiers = append(identifiers, identifier)
+	}
+
+	// Success.
+	return identifiers, nil
+}
+
+// Export creates a portable export of the sessions matching the specified
+// selection, ordered by creation time.
+func (m *Manager) Export(_ context.Context, selection *selection.Selection) (*SessionExport, error) {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate requested sessions: %w", err)
+	}
+
+	// Export each session.
+	export := &SessionExport{Sessions: make([]*Session, len(controllers))}
+	for c, controller := range controllers {
+		export.Sessions[c] = controller.export()
+	}
+
+	// Sort sessions by creation time.
+	sort.Slice(export.Sessions, func(i, j int) bool {
+		iTime := export.Sessions[i].CreationTime
+		jTime := export.Sessions[j].CreationTime
+		return iTime.Seconds < jTime.Seconds ||
+			(iTime.Seconds == jTime.Seconds && iTime.Nanos < jTime.Nanos)
+	})
+
+	// Success.
+	return export, nil
+}
+
 // List requests a state snapshot for the specified sessions.
 func (m *Manager) List(ctx context.Context, selection *selection.Selection, previousStateIndex uint64) (uint64, []*State, error) {
 	// Wait for a state change from the previous index.
@@ -259,6 +353,54 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 	return stateIndex, states, nil
 }
 
+// OverrideLogLevel temporarily raises the capture level of the log rings for
+// sessions matching the given selection. It returns a function that releases
+// the override, restoring the previous capture level.
+func (m *Manager) OverrideLogLevel(selection *selection.Selection, level logging.Level) (func(), error) {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate requested sessions: %w", err)
+	}
+
+	// Override the log ring capture levels.
+	releases := make([]func(), len(controllers))
+	for c, controller := range controllers {
+		releases[c] = controller.logs.Override(level)
+	}
+
+	// Success.
+	return func() {
+		for _, release := range releases {
+			release()
+		}
+	}, nil
+}
+
+// Logs returns captured log entries for the single session matching the given
+// selection. It returns the current log ring index and the entries recorded
+// since the previous index. If the previous index is 0, then all retained
+// entries are returned immediately, otherwise this method waits until new
+// entries are available or the provided context is cancelled.
+func (m *Manager) Logs(ctx context.Context, selection *selection.Selection, previousIndex uint64) (uint64, []*logging.Entry, error) {
+	// Extract the controller for the session of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to locate requested sessions: %w", err)
+	} else if len(controllers) != 1 {
+		return 0, nil, errors.New("selection must match exactly one session")
+	}
+
+	// Read log entries.
+	index, entries, err := controllers[0].logs.Entries(ctx, previousIndex)
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to read session logs: %w", err)
+	}
+
+	// Success.
+	return index, entries, nil
+}
+
 // Pause tells the manager to pause sessions matching the given specifications.
 func (m *Manager) Pause(ctx context.Context, selection *selection.Selection, prompter string) error {
 	// Extract the controllers for the sessions of interest.
@@ -298,6 +440,66 @@ func (m *Manager) Resume(ctx context.Context, selection *selection.Selection, pr
 	return nil
 }
 
+// Label tells the manager to change the names and labels of sessions matching
+// the given specifications. Renaming is only supported if a single session is
+// selected.
+func (m *Manager) Label(
+	_ context.Context,
+	selection *selection.Selection,
+	rename bool, name string,
+	labels map[string]string, removals []string, overwrite bool,
+) error {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return fmt.Errorf("unable to locate requested sessions: %w", err)
+	}
+
+	// Ensure that we're not renaming multiple sessions.
+	if rename && len(controllers) > 1 {
+		return errors.New("unable to rename multiple sessions")
+	}
+
+	// Lock label operations and defer their release. This ensures that the
+	// validation performed below remains accurate until changes are applied.
+	m.labelLock.Lock()
+	defer m.labelLock.Unlock()
+
+	// Compute and validate the new names and labels for all sessions before
+	// applying any changes, so that an invalid labeling of one session doesn't
+	// leave others modified. We also record the existing names and labels so
+	// that we can revert changes if necessary.
+	type labeling struct {
+		previousName, name     string
+		previousLabels, labels map[string]string
+	}
+	labelings := make([]labeling, len(controllers))
+	for c, controller := range controllers {
+		previousName, previousLabels := controller.nameAndLabels()
+		newName, newLabels, err := controller.labeling(rename, name, labels, removals, overwrite)
+		if err != nil {
+			return fmt.Errorf("unable to label session %s: %w", controller.session.Identifier, err)
+		}
+		labelings[c] = labeling{previousName, newName, previousLabels, newLabels}
+	}
+
+	// Apply the changes. If a change can't be applied (e.g. due to a failure
+	// to save the session), then revert any changes already applied.
+	for c, controller := range controllers {
+		if err := controller.setNameAndLabels(labelings[c].name, labelings[c].labels); err != nil {
+			for r, applied := range controllers[:c] {
+				if err := applied.setNameAndLabels(labelings[r].previousName, labelings[r].previousLabels); err != nil {
+					m.logger.Warnf("Unable to revert labeling for session %s: %v", applied.session.Identifier, err)
+				}
+			}
+			return fmt.Errorf("unable to label session %s: %w", controller.session.Identifier, err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // Terminate tells the manager to terminate sessions matching the given
 // specifications.
 func (m *Manager) Terminate(ctx context.Context, selection *selection.Selection, prompter string) error {
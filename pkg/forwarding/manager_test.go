@@ -1,3 +1,117 @@
 package forwarding
 
-// TODO: Implement.
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	"github.com/mutagen-io/mutagen/pkg/state"
+)
+
+// newTestLabelManager creates a manager with controllers for sessions with the
+// specified identifiers and labels. Session files are stored in directory. If
+// a session's identifier is in unwritable, then its session path will be in a
+// non-existent directory.
+func newTestLabelManager(directory string, sessions map[string]map[string]string, unwritable string) *Manager {
+	// Create the manager.
+	tracker := state.NewTracker()
+	manager := &Manager{
+		tracker:      tracker,
+		sessionsLock: state.NewTrackingLock(tracker),
+		sessions:     make(map[string]*controller, len(sessions)),
+	}
+
+	// Create controllers.
+	for identifier, labels := range sessions {
+		sessionPath := filepath.Join(directory, identifier)
+		if identifier == unwritable {
+			sessionPath = filepath.Join(directory, "missing", identifier)
+		}
+		manager.sessions[identifier] = &controller{
+			sessionPath: sessionPath,
+			stateLock:   state.NewTrackingLock(tracker),
+			session:     &Session{Identifier: identifier, Labels: labels},
+		}
+	}
+
+	// Done.
+	return manager
+}
+
+// TestManagerLabel tests that Manager.Label validates all sessions before
+// applying any changes and reverts applied changes on failure.
+func TestManagerLabel(t *testing.T) {
+	// Create a manager.
+	directory := t.TempDir()
+	manager := newTestLabelManager(directory, map[string]map[string]string{
+		"first":  {"env": "dev"},
+		"second": nil,
+	}, "")
+	both := &selection.Selection{Specifications: []string{"first", "second"}}
+
+	// Verify that a conflicting label prevents all changes.
+	conflicting := map[string]string{"env": "prod", "team": "core"}
+	if err := manager.Label(context.Background(), both, false, "", conflicting, nil, false); err == nil {
+		t.Fatal("conflicting labeling succeeded without overwrite")
+	}
+	for identifier, expected := range map[string]map[string]string{"first": {"env": "dev"}, "second": nil} {
+		if labels := manager.sessions[identifier].session.Labels; !reflect.DeepEqual(labels, expected) {
+			t.Errorf("session %s labels modified by failed labeling: %v", identifier, labels)
+		}
+	}
+
+	// Verify that multiple sessions can't be renamed.
+	if err := manager.Label(context.Background(), both, true, "name", nil, nil, false); err == nil {
+		t.Error("renaming of multiple sessions succeeded")
+	}
+
+	// Verify that labeling succeeds with overwriting and that changes are
+	// persisted.
+	if err := manager.Label(context.Background(), both, false, "", conflicting, nil, true); err != nil {
+		t.Fatal("labeling failed with overwrite:", err)
+	}
+	for _, identifier := range []string{"first", "second"} {
+		if labels := manager.sessions[identifier].session.Labels; !reflect.DeepEqual(labels, conflicting) {
+			t.Errorf("session %s labels not updated: %v", identifier, labels)
+		}
+		saved := &Session{}
+		if err := encoding.LoadAndUnmarshalProtobuf(filepath.Join(directory, identifier), saved); err != nil {
+			t.Errorf("unable to load saved session %s: %v", identifier, err)
+		} else if !reflect.DeepEqual(saved.Labels, conflicting) {
+			t.Errorf("saved session %s labels not updated: %v", identifier, saved.Labels)
+		}
+	}
+
+	// Verify that label removal works.
+	if err := manager.Label(context.Background(), both, false, "", nil, []string{"team"}, false); err != nil {
+		t.Fatal("label removal failed:", err)
+	} else if labels := manager.sessions["first"].session.Labels; !reflect.DeepEqual(labels, map[string]string{"env": "prod"}) {
+		t.Error("label not removed:", labels)
+	}
+}
+
+// TestManagerLabelRevert tests that Manager.Label reverts applied changes if a
+// change can't be saved.
+func TestManagerLabelRevert(t *testing.T) {
+	// Create a manager where one session can't be saved.
+	manager := newTestLabelManager(t.TempDir(), map[string]map[string]string{
+		"writable":   {"env": "dev"},
+		"unwritable": {"env": "dev"},
+	}, "unwritable")
+
+	// Attempt to label both sessions.
+	both := &selection.Selection{Specifications: []string{"writable", "unwritable"}}
+	if err := manager.Label(context.Background(), both, false, "", map[string]string{"team": "core"}, nil, false); err == nil {
+		t.Fatal("labeling succeeded with unwritable session")
+	}
+
+	// Verify that neither session was modified.
+	for _, identifier := range []string{"writable", "unwritable"} {
+		if labels := manager.sessions[identifier].session.Labels; !reflect.DeepEqual(labels, map[string]string{"env": "dev"}) {
+			t.Errorf("session %s labels not reverted: %v", identifier, labels)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package forwarding
+
+import (
+	"time"
+)
+
+// disconnectedRetryInterval determines whether or not a session's policies
+// require that a session that has been disconnected for the specified duration
+// be suspended. If so, it returns the reduced-frequency interval at which
+// reconnection should be attempted and true, otherwise it returns false.
+func disconnectedRetryInterval(version Version, configuration *Configuration, disconnected time.Duration) (time.Duration, bool) {
+	// Check whether or not the session has been disconnected long enough.
+	if configuration.DisconnectedTimeout == 0 ||
+		disconnected < time.Duration(configuration.DisconnectedTimeout)*time.Second {
+		return 0, false
+	}
+
+	// Compute the retry interval.
+	interval := configuration.DisconnectedRetryInterval
+	if interval == 0 {
+		interval = version.DefaultDisconnectedRetryInterval()
+	}
+	return time.Duration(interval) * time.Second, true
+}
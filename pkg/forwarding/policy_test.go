@@ -0,0 +1,36 @@
+package forwarding
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDisconnectedRetryInterval tests disconnectedRetryInterval.
+func TestDisconnectedRetryInterval(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		configuration    *Configuration
+		disconnected     time.Duration
+		expectedInterval time.Duration
+		expectSuspended  bool
+	}{
+		{&Configuration{}, 24 * time.Hour, 0, false},
+		{&Configuration{DisconnectedTimeout: 3600}, 59 * time.Minute, 0, false},
+		{&Configuration{DisconnectedTimeout: 3600}, time.Hour, 15 * time.Minute, true},
+		{&Configuration{DisconnectedTimeout: 3600, DisconnectedRetryInterval: 60}, 2 * time.Hour, time.Minute, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		interval, suspended := disconnectedRetryInterval(Version_Version1, testCase.configuration, testCase.disconnected)
+		if suspended != testCase.expectSuspended {
+			t.Errorf("test case %d: suspension status (%t) does not match expected (%t)",
+				i, suspended, testCase.expectSuspended,
+			)
+		} else if interval != testCase.expectedInterval {
+			t.Errorf("test case %d: retry interval (%s) does not match expected (%s)",
+				i, interval, testCase.expectedInterval,
+			)
+		}
+	}
+}
@@ -64,9 +64,9 @@ type Session struct {
 	ConfigurationDestination *Configuration `protobuf:"bytes,11,opt,name=configurationDestination,proto3" json:"configurationDestination,omitempty"`
 	// Name is a user-friendly name for the session. It may be empty and is not
 	// guaranteed to be unique across all sessions. It is only used as a simpler
-	// handle for specifying sessions. It is static.
+	// handle for specifying sessions. It may be changed via labeling.
 	Name string `protobuf:"bytes,12,opt,name=name,proto3" json:"name,omitempty"`
-	// Labels are the session labels. They are static.
+	// Labels are the session labels. They may be changed via labeling.
 	Labels map[string]string `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// Paused indicates whether or not the session is marked as paused.
 	Paused bool `protobuf:"varint,14,opt,name=paused,proto3" json:"paused,omitempty"`
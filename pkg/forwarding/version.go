@@ -2,6 +2,7 @@ package forwarding
 
 import (
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
 )
 
 // DefaultVersion is the default session version.
@@ -17,6 +18,29 @@ func (v Version) Supported() bool {
 	}
 }
 
+// DefaultBackgroundPromptingMode returns the default background prompting mode
+// for the session version.
+func (v Version) DefaultBackgroundPromptingMode() prompting.BackgroundPromptingMode {
+	switch v {
+	case Version_Version1:
+		return prompting.BackgroundPromptingMode_BackgroundPromptingModeEnabled
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultDisconnectedRetryInterval returns the default interval (in seconds) at
+// which reconnection is attempted once a session has been suspended due to
+// disconnection.
+func (v Version) DefaultDisconnectedRetryInterval() uint32 {
+	switch v {
+	case Version_Version1:
+		return 900
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultSocketOverwriteMode returns the default socket overwrite mode for the
 // session version.
 func (v Version) DefaultSocketOverwriteMode() SocketOverwriteMode {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/mutagen-io/extstat"
@@ -21,6 +22,8 @@ const (
 	maximumCacheAge = 7 * 24 * time.Hour
 	// maximumStagingRootAge is the maximum allowed staging root age.
 	maximumStagingRootAge = 7 * 24 * time.Hour
+	// maximumSnapshotStoreAge is the maximum allowed snapshot store age.
+	maximumSnapshotStoreAge = 30 * 24 * time.Hour
 )
 
 // Housekeep invokes housekeeping functions on the Mutagen data directory.
@@ -33,6 +36,9 @@ func Housekeep() {
 
 	// Perform housekeeping on staging roots.
 	housekeepStaging()
+
+	// Perform housekeeping on snapshot stores.
+	housekeepSnapshots()
 }
 
 // housekeepAgents performs housekeeping of agent binaries.
@@ -153,3 +159,66 @@ func housekeepStaging() {
 		}
 	}
 }
+
+// housekeepSnapshots performs housekeeping of snapshot stores.
+func housekeepSnapshots() {
+	// Compute the path to the snapshots directory (the top-level directory
+	// containing all snapshot stores). If we fail, just abort. We don't attempt
+	// to create the directory, because if it doesn't exist, then we don't need
+	// to do anything and we'll just bail when we fail to list the snapshots
+	// directory contents below.
+	snapshotsDirectoryPath, err := filesystem.Mutagen(false, filesystem.MutagenSynchronizationSnapshotsDirectoryName)
+	if err != nil {
+		return
+	}
+
+	// Get the list of snapshot stores. If we fail, just abort.
+	snapshotsDirectoryContents, err := filesystem.DirectoryContentsByPath(snapshotsDirectoryPath)
+	if err != nil {
+		return
+	}
+
+	// Compute the path to the sessions directory. If we fail, then we'll simply
+	// treat all sessions as unknown.
+	sessionsDirectoryPath, sessionsErr := filesystem.Mutagen(false, filesystem.MutagenSynchronizationSessionsDirectoryName)
+
+	// Grab the current time.
+	now := time.Now()
+
+	// Loop through each snapshot store and remove those whose sessions no
+	// longer exist. Ignore any failures. Stores for sessions registered with a
+	// daemon on this system are always retained. Stores for sessions that
+	// aren't registered on this system (either because they've been terminated
+	// or because the store belongs to a remote endpoint managed by a daemon on
+	// another system) are removed once they've gone unused for a certain
+	// period of time. A snapshot store's modification time is updated each
+	// time that an endpoint using it is created or preserves content.
+	for _, c := range snapshotsDirectoryContents {
+		name := c.Name()
+		if sessionsErr == nil {
+			if session := snapshotStoreSession(name); session != "" {
+				if _, err := os.Lstat(filepath.Join(sessionsDirectoryPath, session)); err == nil {
+					continue
+				}
+			}
+		}
+		fullPath := filepath.Join(snapshotsDirectoryPath, name)
+		if stat, err := os.Stat(fullPath); err != nil {
+			continue
+		} else if now.Sub(stat.ModTime()) > maximumSnapshotStoreAge {
+			os.RemoveAll(fullPath)
+		}
+	}
+}
+
+// snapshotStoreSession extracts the session identifier from a snapshot store
+// name, which takes the form <session>-<endpoint>. It returns an empty string
+// if the name isn't of that form.
+func snapshotStoreSession(name string) string {
+	if session := strings.TrimSuffix(name, "-alpha"); session != name {
+		return session
+	} else if session = strings.TrimSuffix(name, "-beta"); session != name {
+		return session
+	}
+	return ""
+}
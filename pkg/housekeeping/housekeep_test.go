@@ -23,3 +23,30 @@ func TestHousekeepCaches(_ *testing.T) {
 func TestHousekeepStaging(_ *testing.T) {
 	housekeepStaging()
 }
+
+// TestHousekeepSnapshots tests that housekeepSnapshots succeeds without
+// panicking.
+func TestHousekeepSnapshots(_ *testing.T) {
+	housekeepSnapshots()
+}
+
+// TestSnapshotStoreSession tests snapshotStoreSession.
+func TestSnapshotStoreSession(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"sync_abc-alpha", "sync_abc"},
+		{"sync_abc-beta", "sync_abc"},
+		{"sync_abc", ""},
+		{"sync_abc-gamma", ""},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		if session := snapshotStoreSession(test.name); session != test.expected {
+			t.Errorf("session for store %q does not match expected: %q != %q", test.name, session, test.expected)
+		}
+	}
+}
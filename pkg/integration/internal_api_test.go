@@ -142,6 +142,116 @@ func TestSynchronizationBothRootsNil(t *testing.T) {
 	}
 }
 
+func TestSynchronizationExportImport(t *testing.T) {
+	// Allow this test to run in parallel.
+	t.Parallel()
+
+	// Calculate alpha and beta paths and create alpha contents.
+	directory := t.TempDir()
+	alphaRoot := filepath.Join(directory, "alpha")
+	betaRoot := filepath.Join(directory, "beta")
+	if err := os.MkdirAll(filepath.Join(alphaRoot, "directory"), 0700); err != nil {
+		t.Fatal("unable to create alpha directory:", err)
+	} else if err = os.WriteFile(filepath.Join(alphaRoot, "file"), []byte("file"), 0600); err != nil {
+		t.Fatal("unable to create alpha file:", err)
+	} else if err = os.WriteFile(filepath.Join(alphaRoot, "directory", "nested"), []byte("nested"), 0600); err != nil {
+		t.Fatal("unable to create nested alpha file:", err)
+	}
+
+	// Compute alpha and beta URLs.
+	alphaURL := &url.URL{Path: alphaRoot}
+	betaURL := &url.URL{Path: betaRoot}
+
+	// Create a session and wait for it to synchronize.
+	ctx := context.Background()
+	sessionID, err := synchronizationManager.Create(
+		ctx,
+		alphaURL, betaURL,
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		&synchronization.Configuration{},
+		"testExportSession",
+		map[string]string{"test": "export"},
+		false,
+		"",
+	)
+	if err != nil {
+		t.Fatal("unable to create session:", err)
+	} else if err = waitForSuccessfulSynchronizationCycle(ctx, sessionID, false, false, false); err != nil {
+		t.Fatal("unable to wait for successful synchronization:", err)
+	}
+
+	// Export the session and verify that its archive was included.
+	export, err := synchronizationManager.Export(ctx, &selection.Selection{Specifications: []string{sessionID}}, true)
+	if err != nil {
+		t.Fatal("unable to export session:", err)
+	} else if len(export.Sessions) != 1 {
+		t.Fatal("unexpected number of exported sessions:", len(export.Sessions))
+	} else if export.Sessions[0].Archive == nil || export.Sessions[0].Archive.Content == nil {
+		t.Fatal("exported session archive missing or empty")
+	}
+
+	// Terminate the original session.
+	if err := synchronizationManager.Terminate(ctx, &selection.Selection{Specifications: []string{sessionID}}, ""); err != nil {
+		t.Fatal("unable to terminate session:", err)
+	}
+
+	// Import the session using its archive and wait for it to synchronize.
+	identifiers, err := synchronizationManager.Import(ctx, export, true, false, false, "")
+	if err != nil {
+		t.Fatal("unable to import session:", err)
+	} else if len(identifiers) != 1 {
+		t.Fatal("unexpected number of imported sessions:", len(identifiers))
+	} else if identifiers[0] == sessionID {
+		t.Error("imported session reused exported session identifier")
+	} else if err = waitForSuccessfulSynchronizationCycle(ctx, identifiers[0], false, false, false); err != nil {
+		t.Fatal("unable to wait for successful synchronization of imported session:", err)
+	}
+
+	// Verify the imported session's metadata.
+	importedSelection := &selection.Selection{Specifications: identifiers}
+	_, states, err := synchronizationManager.List(ctx, importedSelection, 0)
+	if err != nil {
+		t.Fatal("unable to list imported session:", err)
+	} else if len(states) != 1 {
+		t.Fatal("unexpected number of imported session states:", len(states))
+	} else if session := states[0].Session; session.Name != "testExportSession" || session.Labels["test"] != "export" {
+		t.Error("imported session name or labels mismatch:", session.Name, session.Labels)
+	}
+
+	// Terminate the imported session.
+	if err := synchronizationManager.Terminate(ctx, importedSelection, ""); err != nil {
+		t.Fatal("unable to terminate imported session:", err)
+	}
+
+	// Modify beta so that it no longer matches the archive. If the archive
+	// were used, then this would be treated as a deletion and propagated.
+	if err := os.Remove(filepath.Join(betaRoot, "file")); err != nil {
+		t.Fatal("unable to remove beta file:", err)
+	}
+
+	// Verify that importing with the archive fails without forcing.
+	if _, err := synchronizationManager.Import(ctx, export, true, false, false, ""); err == nil {
+		t.Error("import with mismatched archive succeeded without forcing")
+	}
+
+	// Verify that importing with the archive succeeds with forcing. We import
+	// the session paused to avoid propagating the deletion.
+	identifiers, err = synchronizationManager.Import(ctx, export, true, true, true, "")
+	if err != nil {
+		t.Fatal("unable to import session with forcing:", err)
+	} else if err = synchronizationManager.Terminate(ctx, &selection.Selection{Specifications: identifiers}, ""); err != nil {
+		t.Fatal("unable to terminate forcibly imported session:", err)
+	}
+
+	// Verify that alpha is unmodified.
+	if contents, err := os.ReadFile(filepath.Join(alphaRoot, "file")); err != nil {
+		t.Error("unable to read alpha file:", err)
+	} else if string(contents) != "file" {
+		t.Error("alpha file contents modified")
+	}
+}
+
 func TestSynchronizationGOROOTSrcToBeta(t *testing.T) {
 	// Check the end-to-end test mode and compute the source synchronization
 	// root accordingly. If no mode has been specified, then skip the test.
@@ -0,0 +1,39 @@
+package logging
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EnsureValid ensures that Entry's invariants are respected.
+func (e *Entry) EnsureValid() error {
+	// A nil entry is not valid.
+	if e == nil {
+		return errors.New("nil entry")
+	}
+
+	// Ensure that the timestamp is present and valid.
+	if err := e.Time.CheckValid(); err != nil {
+		return fmt.Errorf("invalid timestamp: %w", err)
+	}
+
+	// Ensure that the level is valid. Entries are never recorded at the
+	// disabled level.
+	if level := Level(e.Level); level == LevelDisabled || level > LevelTrace {
+		return errors.New("invalid level")
+	}
+
+	// Success.
+	return nil
+}
+
+// Format formats the entry in the text log output format, including a trailing
+// newline character.
+func (e *Entry) Format() string {
+	timestamp := e.Time.AsTime().Local().Format(timestampFormat)
+	level := Level(e.Level).abbreviation()
+	if e.Scope != "" {
+		return fmt.Sprintf("%s [%c] [%s] %s\n", timestamp, level, e.Scope, e.Message)
+	}
+	return fmt.Sprintf("%s [%c] %s\n", timestamp, level, e.Message)
+}
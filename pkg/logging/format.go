@@ -0,0 +1,39 @@
+package logging
+
+// Format represents a log output format.
+type Format uint8
+
+const (
+	// FormatText indicates that log lines should be rendered as human-readable
+	// text. Fields are not included in text output.
+	FormatText Format = iota
+	// FormatJSON indicates that log lines should be rendered as JSON objects,
+	// one per line, including any fields attached to the logger.
+	FormatJSON
+)
+
+// String provides a human-readable representation of a log format.
+func (f Format) String() string {
+	switch f {
+	case FormatText:
+		return "text"
+	case FormatJSON:
+		return "json"
+	default:
+		return "unknown"
+	}
+}
+
+// NameToFormat converts a string-based representation of a log format to the
+// appropriate Format value. It returns a boolean indicating whether or not the
+// conversion was valid. If the name is invalid, FormatText is returned.
+func NameToFormat(name string) (Format, bool) {
+	switch name {
+	case "text":
+		return FormatText, true
+	case "json":
+		return FormatJSON, true
+	default:
+		return FormatText, false
+	}
+}
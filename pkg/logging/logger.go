@@ -1,6 +1,8 @@
 package logging
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"regexp"
@@ -16,35 +18,70 @@ import (
 type Logger struct {
 	// level is the log level.
 	level Level
+	// format is the log output format.
+	format Format
 	// scope is the logger's scope.
 	scope string
+	// fields are the key/value fields attached to the logger.
+	fields []field
 	// writer is the underlying writer.
 	writer io.Writer
+	// ring is the log ring to which entries are also captured, if any.
+	ring *Ring
+}
+
+// field is a key/value pair attached to a logger.
+type field struct {
+	// key is the field key.
+	key string
+	// value is the field value.
+	value string
 }
 
 // NewLogger creates a new logger at the specified log level targeting the
-// specified writer. The writer must be non-nil. The logger and any derived
-// subloggers will coordinate access to the writer.
+// specified writer using the text output format. The writer must be non-nil.
+// The logger and any derived subloggers will coordinate access to the writer.
 func NewLogger(level Level, writer io.Writer) *Logger {
+	return NewLoggerWithFormat(level, FormatText, writer)
+}
+
+// NewLoggerWithFormat creates a new logger at the specified log level and
+// output format targeting the specified writer. The writer must be non-nil.
+// The logger and any derived subloggers will coordinate access to the writer.
+func NewLoggerWithFormat(level Level, format Format, writer io.Writer) *Logger {
 	return &Logger{
 		level:  level,
+		format: format,
 		writer: stream.NewConcurrentWriter(writer),
 	}
 }
 
 // Level returns the logger's log level. It can be used to restrict certain
 // computations to cases where their results will actually be used, for example
-// statistics that only need to be calculated when debugging.
+// statistics that only need to be calculated when debugging. If the logger has
+// an attached log ring with a higher capture level, then that level is
+// returned instead.
 func (l *Logger) Level() Level {
 	// If the logger is nil, then logging is disabled.
 	if l == nil {
 		return LevelDisabled
 	}
 
-	// Return the log level.
+	// Return the effective log level.
+	if l.ring != nil {
+		if ringLevel := l.ring.Level(); ringLevel > l.level {
+			return ringLevel
+		}
+	}
 	return l.level
 }
 
+// enabled returns whether or not messages at the specified level will be
+// written to either the underlying writer or the attached log ring.
+func (l *Logger) enabled(level Level) bool {
+	return l != nil && (l.level >= level || (l.ring != nil && l.ring.Level() >= level))
+}
+
 // nameMatcher is used to validate names passed to Sublogger.
 var nameMatcher = regexp.MustCompile("^[[:word:]]+$")
 
@@ -73,14 +110,125 @@ func (l *Logger) Sublogger(name string) *Logger {
 	// Create the new logger.
 	return &Logger{
 		level:  l.level,
+		format: l.format,
 		scope:  scope,
+		fields: l.fields,
+		writer: l.writer,
+		ring:   l.ring,
+	}
+}
+
+// reservedFieldKeys are the field keys used for the standard components of
+// structured log lines.
+var reservedFieldKeys = map[string]bool{
+	"time":    true,
+	"level":   true,
+	"logger":  true,
+	"message": true,
+}
+
+// With creates a new logger with the specified key/value field attached. The
+// field will be carried by any subloggers derived from the resulting logger and
+// included in structured output. Keys are subject to the same restrictions as
+// sublogger names and may not be one of "time", "level", "logger", or
+// "message". Attempts to use an invalid key will result in a nil logger and a
+// warning being issued on the current logger. If the key is already present,
+// then its value is replaced.
+func (l *Logger) With(key, value string) *Logger {
+	// If the logger is nil, then the derived logger will be as well.
+	if l == nil {
+		return nil
+	}
+
+	// Validate the key.
+	if !nameMatcher.MatchString(key) || reservedFieldKeys[key] {
+		l.Warn("attempt to attach field with invalid key")
+		return nil
+	}
+
+	// Compute the new logger's fields. We always create a new slice to avoid
+	// sharing backing storage with other loggers.
+	fields := make([]field, 0, len(l.fields)+1)
+	for _, f := range l.fields {
+		if f.key != key {
+			fields = append(fields, f)
+		}
+	}
+	fields = append(fields, field{key, value})
+
+	// Create the new logger.
+	return &Logger{
+		level:  l.level,
+		format: l.format,
+		scope:  l.scope,
+		fields: fields,
+		writer: l.writer,
+		ring:   l.ring,
+	}
+}
+
+// WithRing creates a new logger that also captures entries to the specified
+// log ring, subject to the ring's capture level. The ring will be carried by
+// any subloggers derived from the resulting logger. If the ring's capture level
+// exceeds the logger's level, then messages at the intervening levels will be
+// captured only by the ring.
+func (l *Logger) WithRing(ring *Ring) *Logger {
+	// If the logger is nil, then the derived logger will be as well.
+	if l == nil {
+		return nil
+	}
+
+	// Create the new logger.
+	return &Logger{
+		level:  l.level,
+		format: l.format,
+		scope:  l.scope,
+		fields: l.fields,
 		writer: l.writer,
+		ring:   ring,
 	}
 }
 
 // timestampFormat is the format in which timestamps should be rendered.
 const timestampFormat = "2006-01-02 15:04:05.000000"
 
+// formatJSON renders a log line in the JSON output format. The message should
+// not include a trailing newline character.
+func (l *Logger) formatJSON(timestamp time.Time, level Level, message string) string {
+	// Create a buffer to render the line.
+	buffer := &bytes.Buffer{}
+
+	// Define a helper to write individual key/value pairs. Marshaling a string
+	// value can't fail, so we don't bother checking for errors.
+	writePair := func(key, value string) {
+		if buffer.Len() > 0 {
+			buffer.WriteByte(',')
+		} else {
+			buffer.WriteByte('{')
+		}
+		encodedKey, _ := json.Marshal(key)
+		buffer.Write(encodedKey)
+		buffer.WriteByte(':')
+		encodedValue, _ := json.Marshal(value)
+		buffer.Write(encodedValue)
+	}
+
+	// Write the standard components and fields.
+	writePair("time", timestamp.Format(time.RFC3339Nano))
+	writePair("level", level.String())
+	if l.scope != "" {
+		writePair("logger", l.scope)
+	}
+	for _, f := range l.fields {
+		writePair(f.key, f.value)
+	}
+	writePair("message", message)
+	buffer.WriteString("}\n")
+
+	// Done.
+	return buffer.String()
+}
+
 // write writes a log message to the underlying writer.
 func (l *Logger) write(timestamp time.Time, level Level, message string) {
 	// If a carriage return is found, then truncate the message at that point.
@@ -97,9 +245,23 @@ func (l *Logger) write(timestamp time.Time, level Level, message string) {
 		message = message[:index] + "...\n"
 	}
 
+	// Capture the message to the log ring, if any.
+	if l.ring != nil {
+		l.ring.append(timestamp, level, l.scope, message[:len(message)-1])
+	}
+
+	// If the message is beyond the threshold of this logger's level (which
+	// can be the case if it was only destined for the log ring), then we're
+	// done.
+	if l.level < level {
+		return
+	}
+
 	// Compute the log line.
 	var line string
-	if l.scope != "" {
+	if l.format == FormatJSON {
+		line = l.formatJSON(timestamp, level, message[:len(message)-1])
+	} else if l.scope != "" {
 		line = fmt.Sprintf("%s [%c] [%s] %s",
 			timestamp.Format(timestampFormat), level.abbreviation(), l.scope, message,
 		)
@@ -122,7 +284,7 @@ func (l *Logger) write(timestamp time.Time, level Level, message string) {
 
 // log provides logging with formatting semantics equivalent to fmt.Sprintln.
 func (l *Logger) log(level Level, v ...any) {
-	if l != nil && l.level >= level {
+	if l.enabled(level) {
 		l.write(time.Now(), level, fmt.Sprintln(v...))
 	}
 }
@@ -130,7 +292,7 @@ func (l *Logger) log(level Level, v ...any) {
 // logf provides logging with formatting semantics equivalent to fmt.Sprintf. It
 // automatically appends a trailing newline to the format string.
 func (l *Logger) logf(level Level, format string, v ...any) {
-	if l != nil && l.level >= level {
+	if l.enabled(level) {
 		l.write(time.Now(), level, fmt.Sprintf(format+"\n", v...))
 	}
 }
@@ -198,14 +360,20 @@ func (l *Logger) Tracef(format string, v ...any) {
 }
 
 // linePrefixMatcher matches the timestamp and level prefix of logging lines.
-var linePrefixMatcher = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{6} \[([` + abbreviations + `])\] `)
+var linePrefixMatcher = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}\.\d{6}) \[([` + abbreviations + `])\] `)
+
+// lineScopeMatcher matches the scope prefix of logging line messages.
+var lineScopeMatcher = regexp.MustCompile(`^\[([[:word:].]+)\] `)
 
 // Writer returns an io.Writer that logs incoming lines. If an incoming line is
 // determined to be an output line from another logger, then it will be parsed
 // and gated against this logger's level, its scope will be merged with that of
 // this logger, and the combined line will be written. Otherwise, if an incoming
 // line is not determined to be from another logger, than it will be written as
-// a message with the specificed level.
+// a message with the specificed level. If this logger uses the JSON output
+// format, then lines from other loggers are converted to that format, with
+// their scope merged into the logger path. Lines are also captured to the
+// logger's log ring, if any, subject to the ring's capture level.
 //
 // Note that unlike the Logger itself, the writer returned from this method is
 // not safe for concurrent use by multiple Goroutines. An external locking
@@ -222,7 +390,7 @@ func (l *Logger) Writer(level Level) io.Writer {
 			// Check if the line is output from a logger. If it's not, then we
 			// just log it as if it were any other message.
 			matches := linePrefixMatcher.FindStringSubmatch(line)
-			if len(matches) != 2 {
+			if len(matches) != 3 {
 				l.log(level, line)
 				return
 			}
@@ -231,12 +399,58 @@ func (l *Logger) Writer(level Level) io.Writer {
 			// specifies is invalid, then just print an indicator that an
 			// invalid line was received. Otherwise, if the line level is beyond
 			// the threshold of this logger, then just ignore it.
-			if len(matches[1]) != 1 {
+			if len(matches[2]) != 1 {
 				panic("line prefix matcher returned invalid match")
-			} else if level, ok := abbreviationToLevel(matches[1][0]); !ok {
+			}
+			level, ok := abbreviationToLevel(matches[2][0])
+			if !ok {
 				l.Warn("<invalid incoming log line level>")
 				return
-			} else if l.level < level {
+			} else if !l.enabled(level) {
+				return
+			}
+
+			// Extract the line's timestamp, scope, and message, merging its
+			// scope with that of this logger.
+			timestamp, err := time.ParseInLocation(timestampFormat, matches[1], time.Local)
+			if err != nil {
+				timestamp = time.Now()
+			}
+			message := line[len(matches[0]):]
+			scope := l.scope
+			if scopeMatches := lineScopeMatcher.FindStringSubmatch(message); len(scopeMatches) == 2 {
+				message = message[len(scopeMatches[0]):]
+				if scope != "" {
+					scope = scope + "." + scopeMatches[1]
+				} else {
+					scope = scopeMatches[1]
+				}
+			}
+
+			// If we're using the JSON output format, then convert the line by
+			// writing it using a logger with the merged scope. This will also
+			// handle capture to the log ring.
+			if l.format == FormatJSON {
+				converter := &Logger{
+					level:  l.level,
+					format: l.format,
+					scope:  scope,
+					fields: l.fields,
+					writer: l.writer,
+					ring:   l.ring,
+				}
+				converter.write(timestamp, level, message+"\n")
+				return
+			}
+
+			// Capture the line to the log ring, if any.
+			if l.ring != nil {
+				l.ring.append(timestamp, level, scope, message)
+			}
+
+			// If the line level is beyond the threshold of this logger's level,
+			// then we're done.
+			if l.level < level {
 				return
 			}
 
@@ -1,3 +1,112 @@
 package logging
 
-// TODO: Implement.
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestLoggerTextFormat tests that text output is unaffected by fields.
+func TestLoggerTextFormat(t *testing.T) {
+	// Create a logger and log a message through a sublogger with fields.
+	buffer := &bytes.Buffer{}
+	logger := NewLogger(LevelInfo, buffer)
+	logger.Sublogger("sync").With("session", "sync_id").Info("message")
+
+	// Verify the output.
+	if output := buffer.String(); !strings.HasSuffix(output, " [I] [sync] message\n") {
+		t.Error("text output has unexpected format:", output)
+	}
+}
+
+// TestLoggerJSONFormat tests JSON output, including field propagation through
+// subloggers.
+func TestLoggerJSONFormat(t *testing.T) {
+	// Create a logger and log messages through subloggers with fields.
+	buffer := &bytes.Buffer{}
+	logger := NewLoggerWithFormat(LevelDebug, FormatJSON, buffer)
+	session := logger.Sublogger("sync").With("session", "sync_id")
+	session.Sublogger("alpha").Debugf("value: %d", 5)
+	session.Trace("ignored")
+	logger.Warn("root")
+
+	// Decode and verify the output.
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatal("unexpected number of lines:", len(lines))
+	}
+	expected := []map[string]string{
+		{"level": "debug", "logger": "sync.alpha", "session": "sync_id", "message": "value: 5"},
+		{"level": "warn", "message": "root"},
+	}
+	for i, line := range lines {
+		var decoded map[string]string
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("unable to decode line %d: %v", i, err)
+		}
+		if decoded["time"] == "" {
+			t.Errorf("line %d missing timestamp", i)
+		}
+		delete(decoded, "time")
+		if fmt.Sprint(decoded) != fmt.Sprint(expected[i]) {
+			t.Errorf("line %d does not match expected: %v != %v", i, decoded, expected[i])
+		}
+	}
+}
+
+// TestLoggerWithInvalidKey tests that invalid field keys are rejected.
+func TestLoggerWithInvalidKey(t *testing.T) {
+	logger := NewLogger(LevelDisabled, &bytes.Buffer{})
+	for _, key := range []string{"", "a-b", "message", "level"} {
+		if logger.With(key, "value") != nil {
+			t.Errorf("field key %q accepted", key)
+		}
+	}
+}
+
+// TestLoggerWithReplacesField tests that attaching an existing field key
+// replaces its value without affecting the parent logger.
+func TestLoggerWithReplacesField(t *testing.T) {
+	logger := NewLogger(LevelInfo, &bytes.Buffer{}).With("key", "a")
+	derived := logger.With("key", "b")
+	if len(derived.fields) != 1 || derived.fields[0].value != "b" {
+		t.Error("field value not replaced in derived logger")
+	}
+	if logger.fields[0].value != "a" {
+		t.Error("parent logger fields modified")
+	}
+}
+
+// TestLoggerWriterJSONConversion tests that Writer converts text lines from
+// other loggers when using the JSON output format.
+func TestLoggerWriterJSONConversion(t *testing.T) {
+	// Create a JSON logger and write a line from a remote text logger.
+	buffer := &bytes.Buffer{}
+	logger := NewLoggerWithFormat(LevelInfo, FormatJSON, buffer).Sublogger("remote").With("session", "sync_id")
+	writer := logger.Writer(LevelInfo)
+	fmt.Fprint(writer, "2023-01-02 03:04:05.000006 [W] [agent] remote message\n")
+	fmt.Fprint(writer, "2023-01-02 03:04:05.000006 [D] [agent] filtered message\n")
+
+	// Decode and verify the output.
+	var decoded map[string]string
+	if err := json.Unmarshal(buffer.Bytes(), &decoded); err != nil {
+		t.Fatal("unable to decode output:", err)
+	}
+	if decoded["level"] != "warn" {
+		t.Error("converted line has incorrect level:", decoded["level"])
+	}
+	if decoded["logger"] != "remote.agent" {
+		t.Error("converted line has incorrect logger:", decoded["logger"])
+	}
+	if decoded["session"] != "sync_id" {
+		t.Error("converted line has incorrect session:", decoded["session"])
+	}
+	if decoded["message"] != "remote message" {
+		t.Error("converted line has incorrect message:", decoded["message"])
+	}
+	if !strings.HasPrefix(decoded["time"], "2023-01-02T03:04:05.000006") {
+		t.Error("converted line has incorrect timestamp:", decoded["time"])
+	}
+}
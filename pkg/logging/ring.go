@@ -0,0 +1,178 @@
+package logging
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// overrideReleaseDelay is the delay between the release of a capture level
+	// override and its removal. It allows clients polling for entries to
+	// re-establish an override with each request without any gap in capture.
+	overrideReleaseDelay = 2 * time.Second
+)
+
+// Ring is a bounded in-memory log capture buffer with its own log level. Once
+// the ring reaches capacity, the oldest entries are discarded to make room for
+// new ones. It is safe for concurrent usage.
+type Ring struct {
+	// lock serializes access to the ring.
+	lock sync.Mutex
+	// level is the base capture log level.
+	level Level
+	// overrides are the active capture level overrides, keyed by override
+	// identifier.
+	overrides map[uint64]Level
+	// nextOverride is the identifier to use for the next override.
+	nextOverride uint64
+	// entries is the circular entry buffer.
+	entries []*Entry
+	// start is the position of the oldest entry in entries.
+	start int
+	// count is the number of entries currently stored in entries.
+	count int
+	// index is the current ring index. It starts at 1 and is incremented with
+	// each appended entry.
+	index uint64
+	// changed is closed (and replaced) each time an entry is appended.
+	changed chan struct{}
+}
+
+// NewRing creates a new log ring with the specified capacity and capture level.
+// The capacity must be greater than 0.
+func NewRing(capacity int, level Level) *Ring {
+	return &Ring{
+		level:     level,
+		overrides: make(map[uint64]Level),
+		entries:   make([]*Entry, capacity),
+		index:     1,
+		changed:   make(chan struct{}),
+	}
+}
+
+// effectiveLevel computes the ring's effective capture log level, which is the
+// highest of its base level and any active override levels. It must be called
+// with the ring's lock held.
+func (r *Ring) effectiveLevel() Level {
+	result := r.level
+	for _, level := range r.overrides {
+		if level > result {
+			result = level
+		}
+	}
+	return result
+}
+
+// Level returns the ring's effective capture log level.
+func (r *Ring) Level() Level {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.effectiveLevel()
+}
+
+// Override temporarily raises the ring's capture log level to at least the
+// specified level. It returns a function that releases the override. Released
+// overrides remain in effect for a short delay so that a subsequent override
+// can be established without a gap in capture.
+func (r *Ring) Override(level Level) func() {
+	// Register the override.
+	r.lock.Lock()
+	identifier := r.nextOverride
+	r.nextOverride++
+	r.overrides[identifier] = level
+	r.lock.Unlock()
+
+	// Create the release function.
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			time.AfterFunc(overrideReleaseDelay, func() {
+				r.lock.Lock()
+				delete(r.overrides, identifier)
+				r.lock.Unlock()
+			})
+		})
+	}
+}
+
+// append records an entry in the ring if the ring's level permits it. The
+// message should not include a trailing newline character.
+func (r *Ring) append(timestamp time.Time, level Level, scope, message string) {
+	// Lock the ring and defer its release.
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	// Check that the entry should be recorded.
+	if r.effectiveLevel() < level {
+		return
+	}
+
+	// Create the entry.
+	entry := &Entry{
+		Time:    timestamppb.New(timestamp),
+		Level:   uint32(level),
+		Scope:   scope,
+		Message: message,
+	}
+
+	// Store the entry, overwriting the oldest entry if necessary.
+	capacity := len(r.entries)
+	if r.count < capacity {
+		r.entries[(r.start+r.count)%capacity] = entry
+		r.count++
+	} else {
+		r.entries[r.start] = entry
+		r.start = (r.start + 1) % capacity
+	}
+
+	// Update the index and notify any waiters.
+	r.index++
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// Entries returns the ring's current index and the entries recorded since the
+// specified previous index, ordered from oldest to newest. If the previous
+// index is 0, then all retained entries are returned immediately. Otherwise,
+// this method waits until at least one new entry has been recorded (or until
+// the provided context is cancelled, in which case the context's error is
+// returned). If entries recorded after the previous index have already been
+// discarded, then only the retained entries are returned.
+func (r *Ring) Entries(ctx context.Context, previousIndex uint64) (uint64, []*Entry, error) {
+	// Lock the ring and wait for a change from the previous index.
+	r.lock.Lock()
+	for previousIndex != 0 && r.index == previousIndex {
+		changed := r.changed
+		r.lock.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return 0, nil, ctx.Err()
+		}
+		r.lock.Lock()
+	}
+	defer r.lock.Unlock()
+
+	// Compute the number of retained entries to return. If the previous index
+	// is invalid for this ring (i.e. it's from the future), then treat it as
+	// a request for all entries.
+	count := r.count
+	if previousIndex != 0 && previousIndex < r.index {
+		if missed := r.index - previousIndex; missed < uint64(count) {
+			count = int(missed)
+		}
+	}
+
+	// Extract the entries.
+	capacity := len(r.entries)
+	entries := make([]*Entry, count)
+	for i := 0; i < count; i++ {
+		entries[i] = r.entries[(r.start+r.count-count+i)%capacity]
+	}
+
+	// Success.
+	return r.index, entries, nil
+}
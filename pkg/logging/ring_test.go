@@ -0,0 +1,113 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRingEntries tests Ring.Entries, including truncation and change tracking.
+func TestRingEntries(t *testing.T) {
+	// Create a ring and populate it beyond capacity.
+	ring := NewRing(3, LevelInfo)
+	for _, message := range []string{"a", "b", "c", "d"} {
+		ring.append(time.Now(), LevelInfo, "", message)
+	}
+	ring.append(time.Now(), LevelDebug, "", "ignored")
+
+	// Verify that only the newest entries are retained.
+	index, entries, err := ring.Entries(context.Background(), 0)
+	if err != nil {
+		t.Fatal("unable to read entries:", err)
+	} else if index != 5 {
+		t.Error("unexpected ring index:", index)
+	} else if len(entries) != 3 || entries[0].Message != "b" || entries[2].Message != "d" {
+		t.Fatal("unexpected retained entries")
+	}
+
+	// Verify that waiting for changes times out appropriately.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := ring.Entries(ctx, index); err == nil {
+		t.Error("waiting for entries succeeded without changes")
+	}
+
+	// Verify that only new entries are returned after a change.
+	ring.append(time.Now(), LevelInfo, "", "e")
+	if newIndex, entries, err := ring.Entries(context.Background(), index); err != nil {
+		t.Fatal("unable to read new entries:", err)
+	} else if newIndex != index+1 {
+		t.Error("unexpected new ring index:", newIndex)
+	} else if len(entries) != 1 || entries[0].Message != "e" {
+		t.Error("unexpected new entries")
+	}
+}
+
+// TestRingOverride tests Ring.Override.
+func TestRingOverride(t *testing.T) {
+	// Create a ring and override its level.
+	ring := NewRing(3, LevelInfo)
+	release := ring.Override(LevelDebug)
+	if ring.Level() != LevelDebug {
+		t.Fatal("override did not raise ring level")
+	}
+
+	// Verify that a lower override doesn't reduce the level.
+	releaseLower := ring.Override(LevelWarn)
+	if ring.Level() != LevelDebug {
+		t.Error("lower override reduced ring level")
+	}
+	releaseLower()
+
+	// Release the override and verify that it remains in effect until the
+	// release delay has elapsed.
+	release()
+	if ring.Level() != LevelDebug {
+		t.Error("override removed before release delay elapsed")
+	}
+	time.Sleep(overrideReleaseDelay + 250*time.Millisecond)
+	if ring.Level() != LevelInfo {
+		t.Error("ring level not restored after override release")
+	}
+}
+
+// TestLoggerWithRing tests that a log ring captures messages according to its
+// own level without affecting the logger's output.
+func TestLoggerWithRing(t *testing.T) {
+	// Create a logger with an attached ring at a higher level.
+	buffer := &bytes.Buffer{}
+	ring := NewRing(10, LevelDebug)
+	logger := NewLogger(LevelInfo, buffer).WithRing(ring).Sublogger("session")
+	if logger.Level() != LevelDebug {
+		t.Error("logger level does not reflect ring level")
+	}
+
+	// Log messages and verify the output.
+	logger.Info("info")
+	logger.Debug("debug")
+	logger.Trace("trace")
+	if bytes.Contains(buffer.Bytes(), []byte("debug")) {
+		t.Error("debug message written to logger output")
+	}
+	_, entries, _ := ring.Entries(context.Background(), 0)
+	if len(entries) != 2 {
+		t.Fatal("unexpected number of captured entries:", len(entries))
+	} else if entries[1].Message != "debug" || entries[1].Scope != "session" {
+		t.Error("captured entry does not match expected")
+	}
+	if err := entries[1].EnsureValid(); err != nil {
+		t.Error("captured entry invalid:", err)
+	}
+
+	// Verify that lines written via Writer are captured.
+	writer := logger.Writer(LevelInfo)
+	writer.Write([]byte("2023-01-02 03:04:05.000006 [D] [remote] remote message\n"))
+	_, entries, _ = ring.Entries(context.Background(), 0)
+	if len(entries) != 3 || entries[2].Scope != "session.remote" || entries[2].Message != "remote message" {
+		t.Error("remote line not captured as expected")
+	}
+	if bytes.Contains(buffer.Bytes(), []byte("remote message")) {
+		t.Error("debug remote line written to logger output")
+	}
+}
@@ -0,0 +1,282 @@
+package logging
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// rotatedSegmentTimestampFormat is the format used for timestamps in the
+	// names of rotated segments. It sorts lexicographically.
+	rotatedSegmentTimestampFormat = "20060102T150405.000000"
+	// compressedSegmentExtension is the extension added to compressed segments.
+	compressedSegmentExtension = ".gz"
+	// rotatingFilePermissions are the permissions used for log files.
+	rotatingFilePermissions = 0600
+)
+
+// RotationPolicy specifies rotation and retention behavior for a RotatingFile.
+type RotationPolicy struct {
+	// MaximumSize is the size (in bytes) beyond which the active segment will
+	// be rotated. A value of 0 disables size-based rotation.
+	MaximumSize uint64
+	// MaximumAge is the maximum age of rotated segments before they're
+	// removed. A value of 0 disables age-based removal.
+	MaximumAge time.Duration
+	// MaximumSegments is the maximum number of rotated segments to retain. A
+	// value of 0 disables count-based removal.
+	MaximumSegments int
+	// Compress indicates whether or not rotated segments should be compressed.
+	Compress bool
+}
+
+// RotatingFile is an io.WriteCloser that writes to a file and rotates it once
+// it exceeds a maximum size. Rotated segments are stored alongside the active
+// segment with a timestamp suffix, optionally compressed, and pruned according
+// to age and count limits. It is safe for concurrent usage.
+type RotatingFile struct {
+	// path is the path to the active segment.
+	path string
+	// policy is the rotation policy.
+	policy RotationPolicy
+	// lock serializes access to file and size.
+	lock sync.Mutex
+	// file is the active segment. It is nil if the file has been closed.
+	file *os.File
+	// size is the size of the active segment.
+	size uint64
+}
+
+// NewRotatingFile opens (or creates) a rotating file at the specified path
+// with the specified rotation policy. The parent directory must exist. Any
+// expired rotated segments are pruned immediately.
+func NewRotatingFile(path string, policy RotationPolicy) (*RotatingFile, error) {
+	// Create the rotating file.
+	result := &RotatingFile{
+		path:   path,
+		policy: policy,
+	}
+
+	// Open the active segment.
+	if err := result.open(); err != nil {
+		return nil, err
+	}
+
+	// Prune existing segments.
+	if err := result.prune(); err != nil {
+		result.file.Close()
+		return nil, fmt.Errorf("unable to prune rotated segments: %w", err)
+	}
+
+	// Success.
+	return result, nil
+}
+
+// open opens the active segment for appending and records its size.
+func (f *RotatingFile) open() error {
+	// Open the file.
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, rotatingFilePermissions)
+	if err != nil {
+		return fmt.Errorf("unable to open log file: %w", err)
+	}
+
+	// Determine its current size.
+	metadata, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("unable to query log file metadata: %w", err)
+	}
+
+	// Success.
+	f.file = file
+	f.size = uint64(metadata.Size())
+	return nil
+}
+
+// rotatedSegments returns the paths of rotated segments, sorted from oldest to
+// newest.
+func (f *RotatingFile) rotatedSegments() ([]string, error) {
+	// Read the contents of the parent directory.
+	directory := filepath.Dir(f.path)
+	contents, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read log directory: %w", err)
+	}
+
+	// Identify rotated segments.
+	prefix := filepath.Base(f.path) + "."
+	var segments []string
+	for _, c := range contents {
+		if name := c.Name(); strings.HasPrefix(name, prefix) && !c.IsDir() {
+			segments = append(segments, filepath.Join(directory, name))
+		}
+	}
+
+	// Sort the segments. Because the suffixes are timestamps that sort
+	// lexicographically, this will sort the segments chronologically.
+	sort.Strings(segments)
+
+	// Done.
+	return segments, nil
+}
+
+// prune removes rotated segments that exceed the policy's age and count
+// limits.
+func (f *RotatingFile) prune() error {
+	// Grab the list of rotated segments.
+	segments, err := f.rotatedSegments()
+	if err != nil {
+		return err
+	}
+
+	// Remove segments that exceed the count limit.
+	if f.policy.MaximumSegments > 0 && len(segments) > f.policy.MaximumSegments {
+		excess := len(segments) - f.policy.MaximumSegments
+		for _, segment := range segments[:excess] {
+			if err := os.Remove(segment); err != nil {
+				return fmt.Errorf("unable to remove excess segment: %w", err)
+			}
+		}
+		segments = segments[excess:]
+	}
+
+	// Remove segments that exceed the age limit.
+	if f.policy.MaximumAge > 0 {
+		cutoff := time.Now().Add(-f.policy.MaximumAge)
+		for _, segment := range segments {
+			if metadata, err := os.Stat(segment); err != nil {
+				return fmt.Errorf("unable to query segment metadata: %w", err)
+			} else if metadata.ModTime().Before(cutoff) {
+				if err := os.Remove(segment); err != nil {
+					return fmt.Errorf("unable to remove expired segment: %w", err)
+				}
+			}
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// compress compresses a rotated segment and removes the uncompressed copy.
+func compress(path string) error {
+	// Open the uncompressed segment.
+	source, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open segment: %w", err)
+	}
+	defer source.Close()
+
+	// Create the compressed segment.
+	compressedPath := path + compressedSegmentExtension
+	target, err := os.OpenFile(compressedPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, rotatingFilePermissions)
+	if err != nil {
+		return fmt.Errorf("unable to create compressed segment: %w", err)
+	}
+
+	// Perform compression.
+	compressor := gzip.NewWriter(target)
+	_, err = io.Copy(compressor, source)
+	if err == nil {
+		err = compressor.Close()
+	}
+	if closeErr := target.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(compressedPath)
+		return fmt.Errorf("unable to compress segment: %w", err)
+	}
+
+	// Remove the uncompressed segment.
+	source.Close()
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("unable to remove uncompressed segment: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// rotate rotates the active segment.
+func (f *RotatingFile) rotate() error {
+	// Close the active segment.
+	if err := f.file.Close(); err != nil {
+		return fmt.Errorf("unable to close active segment: %w", err)
+	}
+	f.file = nil
+
+	// Move the active segment aside.
+	rotatedPath := f.path + "." + time.Now().UTC().Format(rotatedSegmentTimestampFormat)
+	if err := os.Rename(f.path, rotatedPath); err != nil {
+		return fmt.Errorf("unable to rename active segment: %w", err)
+	}
+
+	// Open a new active segment.
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	// Compress the rotated segment, if requested.
+	if f.policy.Compress {
+		if err := compress(rotatedPath); err != nil {
+			return err
+		}
+	}
+
+	// Prune rotated segments.
+	return f.prune()
+}
+
+// Write implements io.Writer.Write. If the write would cause the active segment
+// to exceed the maximum size (and the active segment is non-empty), then the
+// active segment is rotated before writing.
+func (f *RotatingFile) Write(data []byte) (int, error) {
+	// Lock the file and defer its release.
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// Ensure that the file hasn't been closed.
+	if f.file == nil {
+		return 0, errors.New("file closed")
+	}
+
+	// Rotate if necessary. If rotation fails but we still have an active
+	// segment (e.g. if compression or pruning failed), then we continue with
+	// the write rather than losing data.
+	if f.policy.MaximumSize > 0 && f.size > 0 && f.size+uint64(len(data)) > f.policy.MaximumSize {
+		if err := f.rotate(); err != nil && f.file == nil {
+			return 0, fmt.Errorf("unable to rotate log file: %w", err)
+		}
+	}
+
+	// Perform the write.
+	n, err := f.file.Write(data)
+	f.size += uint64(n)
+	return n, err
+}
+
+// Close implements io.Closer.Close.
+func (f *RotatingFile) Close() error {
+	// Lock the file and defer its release.
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	// Ensure that the file hasn't already been closed.
+	if f.file == nil {
+		return errors.New("file already closed")
+	}
+
+	// Close the file.
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
@@ -0,0 +1,106 @@
+package logging
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestRotatingFileRotation tests size-based rotation, compression, and
+// count-based pruning for RotatingFile.
+func TestRotatingFileRotation(t *testing.T) {
+	// Create a rotating file with a small maximum size.
+	directory := t.TempDir()
+	path := filepath.Join(directory, "test.log")
+	file, err := NewRotatingFile(path, RotationPolicy{
+		MaximumSize:     10,
+		MaximumSegments: 2,
+		Compress:        true,
+	})
+	if err != nil {
+		t.Fatal("unable to create rotating file:", err)
+	}
+	defer file.Close()
+
+	// Perform writes that will trigger several rotations.
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatal("unable to write to rotating file:", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Verify the contents of the active segment.
+	if data, err := os.ReadFile(path); err != nil {
+		t.Fatal("unable to read active segment:", err)
+	} else if string(data) != "fourth\n" {
+		t.Error("active segment has unexpected contents:", string(data))
+	}
+
+	// Verify that only the newest rotated segments were retained and that
+	// they're compressed.
+	segments, err := file.rotatedSegments()
+	if err != nil {
+		t.Fatal("unable to list rotated segments:", err)
+	} else if len(segments) != 2 {
+		t.Fatal("unexpected number of rotated segments:", len(segments))
+	}
+	for i, expected := range []string{"second\n", "third\n"} {
+		if !strings.HasSuffix(segments[i], compressedSegmentExtension) {
+			t.Fatal("rotated segment not compressed:", segments[i])
+		}
+		compressed, err := os.Open(segments[i])
+		if err != nil {
+			t.Fatal("unable to open rotated segment:", err)
+		}
+		decompressor, err := gzip.NewReader(compressed)
+		if err != nil {
+			compressed.Close()
+			t.Fatal("unable to create decompressor:", err)
+		}
+		data, err := io.ReadAll(decompressor)
+		compressed.Close()
+		if err != nil {
+			t.Fatal("unable to decompress rotated segment:", err)
+		} else if string(data) != expected {
+			t.Error("rotated segment has unexpected contents:", string(data))
+		}
+	}
+}
+
+// TestRotatingFileAgePruning tests age-based pruning for RotatingFile.
+func TestRotatingFileAgePruning(t *testing.T) {
+	// Create an expired rotated segment and a recent rotated segment.
+	directory := t.TempDir()
+	path := filepath.Join(directory, "test.log")
+	expired := path + ".20000101T000000.000000"
+	recent := path + ".20000101T000001.000000"
+	for _, segment := range []string{expired, recent} {
+		if err := os.WriteFile(segment, []byte("content"), 0600); err != nil {
+			t.Fatal("unable to create segment:", err)
+		}
+	}
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(expired, old, old); err != nil {
+		t.Fatal("unable to set segment modification time:", err)
+	}
+
+	// Open a rotating file, which should prune the expired segment.
+	file, err := NewRotatingFile(path, RotationPolicy{MaximumAge: time.Hour})
+	if err != nil {
+		t.Fatal("unable to create rotating file:", err)
+	}
+	defer file.Close()
+
+	// Verify pruning.
+	if _, err := os.Stat(expired); !os.IsNotExist(err) {
+		t.Error("expired segment not pruned")
+	}
+	if _, err := os.Stat(recent); err != nil {
+		t.Error("recent segment pruned")
+	}
+}
@@ -0,0 +1,3 @@
+// Package metrics provides an exporter for session metrics in the Prometheus
+// text exposition format.
+package metrics
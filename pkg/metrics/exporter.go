@@ -0,0 +1,218 @@
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/selection"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+const (
+	// contentType is the content type for the Prometheus text exposition
+	// format.
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Exporter is an http.Handler that serves per-session metrics for
+// synchronization and forwarding sessions in the Prometheus text exposition
+// format.
+type Exporter struct {
+	// synchronizationManager is the synchronization session manager.
+	synchronizationManager *synchronization.Manager
+	// forwardingManager is the forwarding session manager.
+	forwardingManager *forwarding.Manager
+}
+
+// NewExporter creates a new metrics exporter for the specified session
+// managers.
+func NewExporter(synchronizationManager *synchronization.Manager, forwardingManager *forwarding.Manager) *Exporter {
+	return &Exporter{
+		synchronizationManager: synchronizationManager,
+		forwardingManager:      forwardingManager,
+	}
+}
+
+// ServeHTTP implements http.Handler.ServeHTTP.
+func (e *Exporter) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	// Only allow read requests.
+	if request.Method != http.MethodGet && request.Method != http.MethodHead {
+		writer.Header().Set("Allow", "GET, HEAD")
+		http.Error(writer, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Grab immediate state snapshots for all sessions.
+	all := &selection.Selection{All: true}
+	_, synchronizationStates, err := e.synchronizationManager.List(request.Context(), all, 0)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("unable to list synchronization sessions: %v", err), http.StatusInternalServerError)
+		return
+	}
+	_, forwardingStates, err := e.forwardingManager.List(request.Context(), all, 0)
+	if err != nil {
+		http.Error(writer, fmt.Sprintf("unable to list forwarding sessions: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Render metrics into a buffer so that errors can be reported cleanly.
+	buffer := &bytes.Buffer{}
+	if err := writeSynchronizationMetrics(buffer, synchronizationStates); err != nil {
+		http.Error(writer, fmt.Sprintf("unable to render synchronization metrics: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if err := writeForwardingMetrics(buffer, forwardingStates); err != nil {
+		http.Error(writer, fmt.Sprintf("unable to render forwarding metrics: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	// Transmit the response.
+	writer.Header().Set("Content-Type", contentType)
+	writer.Write(buffer.Bytes())
+}
+
+// sortedStatusValues returns the sorted values of a Protocol Buffers
+// enumeration name map.
+func sortedStatusValues(names map[int32]string) []int32 {
+	values := make([]int32, 0, len(names))
+	for value := range names {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return values[i] < values[j]
+	})
+	return values
+}
+
+// writeSynchronizationMetrics writes metrics for the specified synchronization
+// session states.
+func writeSynchronizationMetrics(writer io.Writer, states []*synchronization.State) error {
+	// Create metric families.
+	status := newFamily("mutagen_sync_status", kindGauge, "Whether or not the synchronization session is in the labeled status.")
+	paused := newFamily("mutagen_sync_paused", kindGauge, "Whether or not the synchronization session is paused.")
+	erroring := newFamily("mutagen_sync_error", kindGauge, "Whether or not the synchronization session has a recorded error.")
+	cycles := newFamily("mutagen_sync_successful_cycles_total", kindCounter, "Number of successful synchronization cycles since connecting to the endpoints.")
+	conflicts := newFamily("mutagen_sync_conflicts", kindGauge, "Number of unresolved synchronization conflicts.")
+	connected := newFamily("mutagen_sync_connected", kindGauge, "Whether or not the endpoint is connected.")
+	scanProblems := newFamily("mutagen_sync_scan_problems", kindGauge, "Number of problems encountered during the last scan of the endpoint.")
+	transitionProblems := newFamily("mutagen_sync_transition_problems", kindGauge, "Number of problems encountered during the last transition on the endpoint.")
+	scanDuration := newFamily("mutagen_sync_scan_duration_seconds", kindGauge, "Duration of the last scan of the endpoint.")
+	stagedBytes := newFamily("mutagen_sync_staged_bytes_total", kindCounter, "Number of bytes staged on the endpoint since connecting to the endpoints.")
+	stagingBytes := newFamily("mutagen_sync_staging_bytes", kindGauge, "Number of bytes received by the endpoint during the current staging operation.")
+	directories := newFamily("mutagen_sync_directories", kindGauge, "Number of synchronizable directories on the endpoint.")
+	files := newFamily("mutagen_sync_files", kindGauge, "Number of synchronizable files on the endpoint.")
+	symbolicLinks := newFamily("mutagen_sync_symbolic_links", kindGauge, "Number of synchronizable symbolic links on the endpoint.")
+	fileSize := newFamily("mutagen_sync_file_size_bytes", kindGauge, "Total size of synchronizable files on the endpoint.")
+
+	// Record samples for each session.
+	statusValues := sortedStatusValues(synchronization.Status_name)
+	for _, state := range states {
+		// Compute session labels.
+		session := state.Session
+		labels := sessionLabels(session.Identifier, session.Name, session.Labels)
+
+		// Record session-level metrics.
+		for _, value := range statusValues {
+			s := synchronization.Status(value)
+			text, _ := s.MarshalText()
+			status.addBool(withLabels(labels, label{"status", string(text)}), state.Status == s)
+		}
+		paused.addBool(labels, session.Paused)
+		erroring.addBool(labels, state.LastError != "")
+		cycles.add(labels, float64(state.SuccessfulCycles))
+		conflicts.add(labels, float64(uint64(len(state.Conflicts))+state.ExcludedConflicts))
+
+		// Record endpoint-level metrics.
+		for _, endpoint := range []struct {
+			name  string
+			state *synchronization.EndpointState
+		}{
+			{"alpha", state.AlphaState},
+			{"beta", state.BetaState},
+		} {
+			if endpoint.state == nil {
+				continue
+			}
+			endpointLabels := withLabels(labels, label{"endpoint", endpoint.name})
+			connected.addBool(endpointLabels, endpoint.state.Connected)
+			scanProblems.add(endpointLabels, float64(uint64(len(endpoint.state.ScanProblems))+endpoint.state.ExcludedScanProblems))
+			transitionProblems.add(endpointLabels, float64(uint64(len(endpoint.state.TransitionProblems))+endpoint.state.ExcludedTransitionProblems))
+			if endpoint.state.LastScanDuration != nil {
+				scanDuration.add(endpointLabels, endpoint.state.LastScanDuration.AsDuration().Seconds())
+			}
+			var staging uint64
+			if endpoint.state.StagingProgress != nil {
+				staging = endpoint.state.StagingProgress.TotalReceivedSize
+			}
+			stagedBytes.add(endpointLabels, float64(endpoint.state.TotalStagedSize))
+			stagingBytes.add(endpointLabels, float64(staging))
+			if endpoint.state.Scanned {
+				directories.add(endpointLabels, float64(endpoint.state.Directories))
+				files.add(endpointLabels, float64(endpoint.state.Files))
+				symbolicLinks.add(endpointLabels, float64(endpoint.state.SymbolicLinks))
+				fileSize.add(endpointLabels, float64(endpoint.state.TotalFileSize))
+			}
+		}
+	}
+
+	// Write metric families.
+	return writeFamilies(writer, []*family{
+		status, paused, erroring, cycles, conflicts,
+		connected, scanProblems, transitionProblems, scanDuration,
+		stagedBytes, stagingBytes,
+		directories, files, symbolicLinks, fileSize,
+	})
+}
+
+// writeForwardingMetrics writes metrics for the specified forwarding session
+// states.
+func writeForwardingMetrics(writer io.Writer, states []*forwarding.State) error {
+	// Create metric families.
+	status := newFamily("mutagen_forward_status", kindGauge, "Whether or not the forwarding session is in the labeled status.")
+	paused := newFamily("mutagen_forward_paused", kindGauge, "Whether or not the forwarding session is paused.")
+	erroring := newFamily("mutagen_forward_error", kindGauge, "Whether or not the forwarding session has a recorded error.")
+	connected := newFamily("mutagen_forward_connected", kindGauge, "Whether or not the endpoint is connected.")
+	openConnections := newFamily("mutagen_forward_open_connections", kindGauge, "Number of connections currently being forwarded.")
+	totalConnections := newFamily("mutagen_forward_connections_total", kindCounter, "Number of connections that have been forwarded.")
+	outboundBytes := newFamily("mutagen_forward_outbound_bytes_total", kindCounter, "Number of bytes forwarded from source to destination.")
+	inboundBytes := newFamily("mutagen_forward_inbound_bytes_total", kindCounter, "Number of bytes forwarded from destination to source.")
+
+	// Record samples for each session.
+	statusValues := sortedStatusValues(forwarding.Status_name)
+	for _, state := range states {
+		// Compute session labels.
+		session := state.Session
+		labels := sessionLabels(session.Identifier, session.Name, session.Labels)
+
+		// Record session-level metrics.
+		for _, value := range statusValues {
+			s := forwarding.Status(value)
+			text, _ := s.MarshalText()
+			status.addBool(withLabels(labels, label{"status", string(text)}), state.Status == s)
+		}
+		paused.addBool(labels, session.Paused)
+		erroring.addBool(labels, state.LastError != "")
+		openConnections.add(labels, float64(state.OpenConnections))
+		totalConnections.add(labels, float64(state.TotalConnections))
+		outboundBytes.add(labels, float64(state.TotalOutboundData))
+		inboundBytes.add(labels, float64(state.TotalInboundData))
+
+		// Record endpoint-level metrics.
+		if state.SourceState != nil {
+			connected.addBool(withLabels(labels, label{"endpoint", "source"}), state.SourceState.Connected)
+		}
+		if state.DestinationState != nil {
+			connected.addBool(withLabels(labels, label{"endpoint", "destination"}), state.DestinationState.Connected)
+		}
+	}
+
+	// Write metric families.
+	return writeFamilies(writer, []*family{
+		status, paused, erroring, connected,
+		openConnections, totalConnections, outboundBytes, inboundBytes,
+	})
+}
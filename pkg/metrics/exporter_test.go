@@ -0,0 +1,157 @@
+package metrics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
+)
+
+// TestSessionLabels tests sessionLabels.
+func TestSessionLabels(t *testing.T) {
+	// Compute and format labels for a session with custom labels.
+	labels := sessionLabels("sync_id", "web", map[string]string{
+		"team":            "a\"b",
+		"example.com/env": "dev",
+	})
+	expected := `{identifier="sync_id",name="web",label_example_com_env="dev",label_team="a\"b"}`
+	if formatted := formatLabels(labels); formatted != expected {
+		t.Errorf("formatted labels do not match expected: %s != %s", formatted, expected)
+	}
+
+	// Verify that labels whose keys sanitize to the same name are omitted.
+	labels = sessionLabels("sync_id", "web", map[string]string{
+		"a-b":  "1",
+		"a.b":  "2",
+		"team": "c",
+	})
+	expected = `{identifier="sync_id",name="web",label_team="c"}`
+	if formatted := formatLabels(labels); formatted != expected {
+		t.Errorf("formatted labels with collisions do not match expected: %s != %s", formatted, expected)
+	}
+}
+
+// TestFamilyWriteEmpty tests that empty metric families are omitted.
+func TestFamilyWriteEmpty(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	if err := newFamily("empty", kindGauge, "Empty family.").write(buffer); err != nil {
+		t.Fatal("unable to write family:", err)
+	} else if buffer.Len() != 0 {
+		t.Error("empty family produced output")
+	}
+}
+
+// TestWriteSynchronizationMetrics tests writeSynchronizationMetrics.
+func TestWriteSynchronizationMetrics(t *testing.T) {
+	// Create a synthetic session state.
+	states := []*synchronization.State{{
+		Session: &synchronization.Session{
+			Identifier: "sync_id",
+			Name:       "web",
+		},
+		Status:            synchronization.Status_Watching,
+		SuccessfulCycles:  3,
+		Conflicts:         []*core.Conflict{{}},
+		ExcludedConflicts: 2,
+		AlphaState: &synchronization.EndpointState{
+			Connected:        true,
+			Scanned:          true,
+			Files:            10,
+			ScanProblems:     []*core.Problem{{Path: "a", Error: "error"}},
+			TotalStagedSize:  1024,
+			LastScanDuration: durationpb.New(1500 * time.Millisecond),
+			StagingProgress:  &rsync.ReceiverState{TotalReceivedSize: 512},
+		},
+		BetaState: &synchronization.EndpointState{},
+	}}
+
+	// Render metrics.
+	buffer := &bytes.Buffer{}
+	if err := writeSynchronizationMetrics(buffer, states); err != nil {
+		t.Fatal("unable to write metrics:", err)
+	}
+	output := buffer.String()
+
+	// Verify that expected samples are present.
+	expected := []string{
+		"# TYPE mutagen_sync_status gauge\n",
+		`mutagen_sync_status{identifier="sync_id",name="web",status="watching"} 1` + "\n",
+		`mutagen_sync_status{identifier="sync_id",name="web",status="disconnected"} 0` + "\n",
+		"# TYPE mutagen_sync_successful_cycles_total counter\n",
+		`mutagen_sync_successful_cycles_total{identifier="sync_id",name="web"} 3` + "\n",
+		`mutagen_sync_conflicts{identifier="sync_id",name="web"} 3` + "\n",
+		`mutagen_sync_connected{identifier="sync_id",name="web",endpoint="alpha"} 1` + "\n",
+		`mutagen_sync_connected{identifier="sync_id",name="web",endpoint="beta"} 0` + "\n",
+		`mutagen_sync_scan_problems{identifier="sync_id",name="web",endpoint="alpha"} 1` + "\n",
+		`mutagen_sync_scan_duration_seconds{identifier="sync_id",name="web",endpoint="alpha"} 1.5` + "\n",
+		`mutagen_sync_staged_bytes_total{identifier="sync_id",name="web",endpoint="alpha"} 1024` + "\n",
+		`mutagen_sync_staging_bytes{identifier="sync_id",name="web",endpoint="alpha"} 512` + "\n",
+		`mutagen_sync_files{identifier="sync_id",name="web",endpoint="alpha"} 10` + "\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(output, e) {
+			t.Errorf("output missing expected content: %q", e)
+		}
+	}
+
+	// Verify that unscanned and unmeasured endpoints don't report scan
+	// metrics.
+	unexpected := []string{
+		`mutagen_sync_files{identifier="sync_id",name="web",endpoint="beta"}`,
+		`mutagen_sync_scan_duration_seconds{identifier="sync_id",name="web",endpoint="beta"}`,
+	}
+	for _, u := range unexpected {
+		if strings.Contains(output, u) {
+			t.Errorf("output contains unexpected content: %q", u)
+		}
+	}
+}
+
+// TestWriteForwardingMetrics tests writeForwardingMetrics.
+func TestWriteForwardingMetrics(t *testing.T) {
+	// Create a synthetic session state.
+	states := []*forwarding.State{{
+		Session: &forwarding.Session{
+			Identifier: "fwrd_id",
+			Labels:     map[string]string{"env": "dev"},
+			Paused:     true,
+		},
+		OpenConnections:   2,
+		TotalConnections:  5,
+		TotalOutboundData: 100,
+		TotalInboundData:  200,
+		SourceState:       &forwarding.EndpointState{Connected: true},
+		DestinationState:  &forwarding.EndpointState{},
+	}}
+
+	// Render metrics.
+	buffer := &bytes.Buffer{}
+	if err := writeForwardingMetrics(buffer, states); err != nil {
+		t.Fatal("unable to write metrics:", err)
+	}
+	output := buffer.String()
+
+	// Verify that expected samples are present.
+	expected := []string{
+		`mutagen_forward_status{identifier="fwrd_id",name="",label_env="dev",status="disconnected"} 1` + "\n",
+		`mutagen_forward_paused{identifier="fwrd_id",name="",label_env="dev"} 1` + "\n",
+		`mutagen_forward_open_connections{identifier="fwrd_id",name="",label_env="dev"} 2` + "\n",
+		`mutagen_forward_connections_total{identifier="fwrd_id",name="",label_env="dev"} 5` + "\n",
+		`mutagen_forward_outbound_bytes_total{identifier="fwrd_id",name="",label_env="dev"} 100` + "\n",
+		`mutagen_forward_inbound_bytes_total{identifier="fwrd_id",name="",label_env="dev"} 200` + "\n",
+		`mutagen_forward_connected{identifier="fwrd_id",name="",label_env="dev",endpoint="source"} 1` + "\n",
+		`mutagen_forward_connected{identifier="fwrd_id",name="",label_env="dev",endpoint="destination"} 0` + "\n",
+	}
+	for _, e := range expected {
+		if !strings.Contains(output, e) {
+			t.Errorf("output missing expected content: %q", e)
+		}
+	}
+}
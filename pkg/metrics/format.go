@@ -0,0 +1,194 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// kindGauge is the metric type for gauges.
+	kindGauge = "gauge"
+	// kindCounter is the metric type for counters.
+	kindCounter = "counter"
+)
+
+// label represents a single metric label.
+type label struct {
+	// name is the label name.
+	name string
+	// value is the label value.
+	value string
+}
+
+// sample represents a single sample within a metric family.
+type sample struct {
+	// labels are the sample labels.
+	labels []label
+	// value is the sample value.
+	value float64
+}
+
+// family represents a metric family.
+type family struct {
+	// name is the metric family name.
+	name string
+	// help is the help text for the metric family.
+	help string
+	// kind is the metric type for the metric family.
+	kind string
+	// samples are the samples for the metric family.
+	samples []sample
+}
+
+// newFamily creates a new metric family.
+func newFamily(name, kind, help string) *family {
+	return &family{name: name, help: help, kind: kind}
+}
+
+// add adds a sample to the metric family.
+func (f *family) add(labels []label, value float64) {
+	f.samples = append(f.samples, sample{labels, value})
+}
+
+// addBool adds a sample to the metric family with a value of 1 if the
+// specified condition is true and 0 otherwise.
+func (f *family) addBool(labels []label, condition bool) {
+	if condition {
+		f.add(labels, 1)
+	} else {
+		f.add(labels, 0)
+	}
+}
+
+// write writes the metric family to the specified writer. Families without any
+// samples are omitted.
+func (f *family) write(writer io.Writer) error {
+	// Skip empty families.
+	if len(f.samples) == 0 {
+		return nil
+	}
+
+	// Write the family metadata.
+	if _, err := fmt.Fprintf(writer, "# HELP %s %s\n# TYPE %s %s\n", f.name, escapeHelp(f.help), f.name, f.kind); err != nil {
+		return err
+	}
+
+	// Write the samples.
+	for _, s := range f.samples {
+		if _, err := fmt.Fprintf(writer, "%s%s %s\n", f.name, formatLabels(s.labels), formatValue(s.value)); err != nil {
+			return err
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// writeFamilies writes the specified metric families to the writer.
+func writeFamilies(writer io.Writer, families []*family) error {
+	for _, f := range families {
+		if err := f.write(writer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withLabels returns a new label set composed of base and the additional
+// labels. It never modifies base.
+func withLabels(base []label, additional ...label) []label {
+	result := make([]label, 0, len(base)+len(additional))
+	result = append(result, base...)
+	return append(result, additional...)
+}
+
+// sessionLabels computes the label set identifying a session. Session labels
+// are included with their keys sanitized and prefixed with "label_". Session
+// labels whose keys sanitize to the same name (e.g. "a-b" and "a.b") are
+// omitted, since their values can't be unambiguously attributed and duplicate
+// label names would render the sample invalid.
+func sessionLabels(identifier, name string, labels map[string]string) []label {
+	// Create the base label set.
+	result := []label{
+		{"identifier", identifier},
+		{"name", name},
+	}
+
+	// Sanitize session label keys and identify collisions.
+	sanitized := make(map[string]string, len(labels))
+	occurrences := make(map[string]int, len(labels))
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		sanitizedKey := sanitizeLabelName(key)
+		sanitized[key] = sanitizedKey
+		occurrences[sanitizedKey]++
+		keys = append(keys, key)
+	}
+
+	// Add non-colliding session labels in a deterministic order.
+	sort.Strings(keys)
+	for _, key := range keys {
+		if occurrences[sanitized[key]] > 1 {
+			continue
+		}
+		result = append(result, label{"label_" + sanitized[key], labels[key]})
+	}
+
+	// Done.
+	return result
+}
+
+// sanitizeLabelName converts a session label key into a valid metric label name
+// by replacing all characters outside of [a-zA-Z0-9_] with underscores.
+func sanitizeLabelName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// labelValueEscaper escapes metric label values.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// helpEscaper escapes metric help text.
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// escapeHelp escapes help text.
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+// formatLabels formats a label set for inclusion in a sample line.
+func formatLabels(labels []label) string {
+	// Handle the empty case.
+	if len(labels) == 0 {
+		return ""
+	}
+
+	// Format the labels.
+	var builder strings.Builder
+	builder.WriteByte('{')
+	for i, l := range labels {
+		if i > 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteString(l.name)
+		builder.WriteString(`="`)
+		builder.WriteString(labelValueEscaper.Replace(l.value))
+		builder.WriteByte('"')
+	}
+	builder.WriteByte('}')
+
+	// Done.
+	return builder.String()
+}
+
+// formatValue formats a sample value.
+func formatValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,238 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// ChangeOrigin is a custom YAML type that encodes the endpoint on which
+// changes must originate in order to trigger a change hook, including a lack
+// of specification.
+type ChangeOrigin uint8
+
+const (
+	// ChangeOriginDefault indicates that the change origin is unspecified,
+	// which is treated as ChangeOriginAny.
+	ChangeOriginDefault ChangeOrigin = iota
+	// ChangeOriginAny indicates that changes originating on either endpoint
+	// will trigger a change hook.
+	ChangeOriginAny
+	// ChangeOriginAlpha indicates that only changes originating on alpha will
+	// trigger a change hook.
+	ChangeOriginAlpha
+	// ChangeOriginBeta indicates that only changes originating on beta will
+	// trigger a change hook.
+	ChangeOriginBeta
+)
+
+// String returns a human-readable representation of the change origin.
+func (o ChangeOrigin) String() string {
+	switch o {
+	case ChangeOriginDefault:
+		return "default"
+	case ChangeOriginAny:
+		return "any"
+	case ChangeOriginAlpha:
+		return "alpha"
+	case ChangeOriginBeta:
+		return "beta"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalYAML implements Unmarshaler.UnmarshalYAML.
+func (o *ChangeOrigin) UnmarshalYAML(unmarshal func(any) error) error {
+	// Call the underlying unmarshaling function.
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+
+	// Convert to a change origin.
+	switch text {
+	case "any":
+		*o = ChangeOriginAny
+	case "alpha":
+		*o = ChangeOriginAlpha
+	case "beta":
+		*o = ChangeOriginBeta
+	default:
+		return fmt.Errorf("unknown change origin specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// HookLocation is a custom YAML type that encodes where a change hook command
+// is run, including a lack of specification.
+type HookLocation uint8
+
+const (
+	// HookLocationDefault indicates that the hook location is unspecified,
+	// which is treated as HookLocationLocal.
+	HookLocationDefault HookLocation = iota
+	// HookLocationLocal indicates that a hook command is run locally, in the
+	// same manner as other project commands.
+	HookLocationLocal
+	// HookLocationAlpha indicates that a hook command is run on the alpha
+	// endpoint, within the synchronization root.
+	HookLocationAlpha
+	// HookLocationBeta indicates that a hook command is run on the beta
+	// endpoint, within the synchronization root.
+	HookLocationBeta
+)
+
+// String returns a human-readable representation of the hook location.
+func (l HookLocation) String() string {
+	switch l {
+	case HookLocationDefault:
+		return "default"
+	case HookLocationLocal:
+		return "local"
+	case HookLocationAlpha:
+		return "alpha"
+	case HookLocationBeta:
+		return "beta"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalYAML implements Unmarshaler.UnmarshalYAML.
+func (l *HookLocation) UnmarshalYAML(unmarshal func(any) error) error {
+	// Call the underlying unmarshaling function.
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+
+	// Convert to a hook location.
+	switch text {
+	case "local":
+		*l = HookLocationLocal
+	case "alpha":
+		*l = HookLocationAlpha
+	case "beta":
+		*l = HookLocationBeta
+	default:
+		return fmt.Errorf("unknown hook location specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// ChangeHook encodes a command that is run when a synchronization cycle
+// modifies paths matching one or more patterns.
+type ChangeHook struct {
+	// Session is the name of the synchronization session to watch.
+	Session string `yaml:"session"`
+	// Paths are the doublestar-style patterns against which changed paths are
+	// matched. Patterns are relative to the synchronization root.
+	Paths []string `yaml:"paths"`
+	// Origin is the endpoint on which changes must originate in order to
+	// trigger the hook.
+	Origin ChangeOrigin `yaml:"origin"`
+	// Command is the command to run.
+	Command string `yaml:"command"`
+	// RunOn is the location where the command is run.
+	RunOn HookLocation `yaml:"runOn"`
+}
+
+// ensureValid ensures that the change hook is valid with respect to the
+// specified configuration.
+func (h *ChangeHook) ensureValid(configuration *Configuration) error {
+	// Verify that the session refers to a synchronization session.
+	if h.Session == "" {
+		return errors.New("session not specified")
+	} else if _, ok := configuration.Synchronization[h.Session]; !ok || h.Session == "defaults" {
+		return fmt.Errorf("unknown or disabled synchronization session: %s", h.Session)
+	}
+
+	// Verify that the patterns are valid.
+	if len(h.Paths) == 0 {
+		return errors.New("no path patterns specified")
+	}
+	for _, pattern := range h.Paths {
+		if pattern == "" {
+			return errors.New("empty path pattern")
+		} else if strings.HasPrefix(pattern, "/") {
+			return fmt.Errorf("path pattern is not relative: %s", pattern)
+		} else if _, err := doublestar.Match(pattern, "a"); err != nil {
+			return fmt.Errorf("invalid path pattern (%s): %w", pattern, err)
+		}
+	}
+
+	// Verify that a command has been specified.
+	if h.Command == "" {
+		return errors.New("command not specified")
+	}
+
+	// Success.
+	return nil
+}
+
+// matchesPath determines whether or not a changed path matches any of the
+// hook's patterns. Because synchronization changes are recorded at the
+// top-most modified path, a change to a directory is also considered a match
+// if any pattern could match content within that directory (based on the
+// pattern's leading path components).
+func (h *ChangeHook) matchesPath(path string) bool {
+	for _, pattern := range h.Paths {
+		if path == "" || strings.HasPrefix(pattern, path+"/") {
+			return true
+		} else if match, _ := doublestar.Match(pattern, path); match {
+			return true
+		}
+	}
+	return false
+}
+
+// Triggered determines whether or not the hook is triggered by a set of
+// changes. The alpha and beta arguments are the changed paths that originated
+// on each endpoint. If the truncated flag for an endpoint is set, then the
+// corresponding path list is incomplete and the hook is conservatively
+// considered triggered by changes on that endpoint.
+func (h *ChangeHook) Triggered(alpha []string, alphaTruncated bool, beta []string, betaTruncated bool) bool {
+	// Check alpha changes.
+	if h.Origin != ChangeOriginBeta {
+		if alphaTruncated {
+			return true
+		}
+		for _, path := range alpha {
+			if h.matchesPath(path) {
+				return true
+			}
+		}
+	}
+
+	// Check beta changes.
+	if h.Origin != ChangeOriginAlpha {
+		if betaTruncated {
+			return true
+		}
+		for _, path := range beta {
+			if h.matchesPath(path) {
+				return true
+			}
+		}
+	}
+
+	// No match.
+	return false
+}
+
+// EnsureChangeHooksValid ensures that the project's change hooks are valid.
+func (c *Configuration) EnsureChangeHooksValid() error {
+	for name, hook := range c.OnChange {
+		if err := hook.ensureValid(c); err != nil {
+			return fmt.Errorf("invalid change hook (%s): %w", name, err)
+		}
+	}
+	return nil
+}
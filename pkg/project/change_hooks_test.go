@@ -0,0 +1,96 @@
+package project
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TestChangeHookUnmarshal tests that change hooks can be unmarshaled and that
+// invalid origins and locations are rejected.
+func TestChangeHookUnmarshal(t *testing.T) {
+	// Unmarshal a change hook.
+	var hook ChangeHook
+	data := "session: code\npaths: [\"proto/**\"]\norigin: alpha\ncommand: go generate ./...\nrunOn: beta\n"
+	if err := yaml.UnmarshalStrict([]byte(data), &hook); err != nil {
+		t.Fatal("unable to unmarshal change hook:", err)
+	}
+
+	// Verify the result.
+	if hook.Session != "code" || len(hook.Paths) != 1 || hook.Paths[0] != "proto/**" {
+		t.Error("change hook mismatch:", hook)
+	} else if hook.Origin != ChangeOriginAlpha || hook.RunOn != HookLocationBeta {
+		t.Error("change hook origin or location mismatch:", hook.Origin, hook.RunOn)
+	}
+
+	// Verify that invalid origins and locations are rejected.
+	if err := yaml.UnmarshalStrict([]byte("origin: gamma\n"), &hook); err == nil {
+		t.Error("invalid change origin unmarshaled successfully")
+	}
+	if err := yaml.UnmarshalStrict([]byte("runOn: gamma\n"), &hook); err == nil {
+		t.Error("invalid hook location unmarshaled successfully")
+	}
+}
+
+// TestChangeHookTriggered tests ChangeHook.Triggered.
+func TestChangeHookTriggered(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		hook           ChangeHook
+		alpha          []string
+		alphaTruncated bool
+		beta           []string
+		expected       bool
+	}{
+		{ChangeHook{Paths: []string{"proto/**"}}, []string{"proto/a.proto"}, false, nil, true},
+		{ChangeHook{Paths: []string{"proto/**"}}, nil, false, []string{"proto/sub/b.proto"}, true},
+		{ChangeHook{Paths: []string{"proto/**"}}, []string{"protocol.go"}, false, nil, false},
+		{ChangeHook{Paths: []string{"proto/*.proto"}}, []string{"proto"}, false, nil, true},
+		{ChangeHook{Paths: []string{"proto/*.proto"}}, []string{""}, false, nil, true},
+		{ChangeHook{Paths: []string{"**/*.go"}}, []string{"cmd/main.go"}, false, nil, true},
+		{ChangeHook{Paths: []string{"proto/**"}, Origin: ChangeOriginBeta}, []string{"proto/a.proto"}, false, nil, false},
+		{ChangeHook{Paths: []string{"proto/**"}, Origin: ChangeOriginAlpha}, nil, false, []string{"proto/a.proto"}, false},
+		{ChangeHook{Paths: []string{"proto/**"}, Origin: ChangeOriginAlpha}, []string{"other"}, true, nil, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		triggered := testCase.hook.Triggered(testCase.alpha, testCase.alphaTruncated, testCase.beta, false)
+		if triggered != testCase.expected {
+			t.Errorf("test case %d: triggered mismatch: %t != %t", i, triggered, testCase.expected)
+		}
+	}
+}
+
+// TestEnsureChangeHooksValid tests Configuration.EnsureChangeHooksValid.
+func TestEnsureChangeHooksValid(t *testing.T) {
+	// Define test cases.
+	valid := ChangeHook{Session: "code", Paths: []string{"proto/**"}, Command: "go generate"}
+	testCases := []struct {
+		hook     ChangeHook
+		expected bool
+	}{
+		{valid, true},
+		{ChangeHook{Paths: valid.Paths, Command: valid.Command}, false},
+		{ChangeHook{Session: "defaults", Paths: valid.Paths, Command: valid.Command}, false},
+		{ChangeHook{Session: "assets", Paths: valid.Paths, Command: valid.Command}, false},
+		{ChangeHook{Session: "code", Command: valid.Command}, false},
+		{ChangeHook{Session: "code", Paths: []string{"/proto/**"}, Command: valid.Command}, false},
+		{ChangeHook{Session: "code", Paths: []string{"proto/["}, Command: valid.Command}, false},
+		{ChangeHook{Session: "code", Paths: valid.Paths}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		configuration := &Configuration{
+			OnChange: map[string]ChangeHook{"hook": testCase.hook},
+			Synchronization: map[string]SynchronizationConfiguration{
+				"defaults": {},
+				"code":     {},
+			},
+		}
+		if err := configuration.EnsureChangeHooksValid(); (err == nil) != testCase.expected {
+			t.Errorf("test case %d: validity mismatch: %v", i, err)
+		}
+	}
+}
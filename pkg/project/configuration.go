@@ -1,9 +1,21 @@
 package project
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
 	"github.com/mutagen-io/mutagen/pkg/api/models/forwarding"
 	"github.com/mutagen-io/mutagen/pkg/api/models/synchronization"
-	"github.com/mutagen-io/mutagen/pkg/encoding"
+)
+
+const (
+	// includeKey is the top-level configuration key used to specify fragment
+	// files to include.
+	includeKey = "include"
 )
 
 // ForwardingConfiguration encodes a forwarding session specification.
@@ -19,6 +31,14 @@ type ForwardingConfiguration struct {
 	// ConfigurationDestination is the destination-specific configuration for
 	// the session.
 	ConfigurationDestination forwarding.Configuration `yaml:"configurationDestination"`
+	// Profiles are the profiles in which the session is enabled. If empty, the
+	// session is always enabled.
+	Profiles []string `yaml:"profiles"`
+	// DependsOn are the sessions that must be ready before the session is
+	// created.
+	DependsOn []string `yaml:"dependsOn"`
+	// Readiness are the criteria used to determine when the session is ready.
+	Readiness Readiness `yaml:"readiness"`
 }
 
 // FlushOnCreateBehavior is a custom YAML type that can encode various
@@ -91,14 +111,23 @@ type SynchronizationConfiguration struct {
 	ConfigurationAlpha synchronization.Configuration `yaml:"configurationAlpha"`
 	// ConfigurationBeta is the beta-specific configuration for the session.
 	ConfigurationBeta synchronization.Configuration `yaml:"configurationBeta"`
+	// Profiles are the profiles in which the session is enabled. If empty, the
+	// session is always enabled.
+	Profiles []string `yaml:"profiles"`
+	// DependsOn are the sessions that must be ready before the session is
+	// created.
+	DependsOn []string `yaml:"dependsOn"`
+	// Readiness are the criteria used to determine when the session is ready.
+	Readiness Readiness `yaml:"readiness"`
 }
 
 // Configuration is the orchestration configuration object type.
 type Configuration struct {
 	// BeforeCreate are setup commands to be run before session creation.
 	BeforeCreate []string `yaml:"beforeCreate"`
-	// AfterCreate are setup commands to be run after session creation.
-	AfterCreate []string `yaml:"afterCreate"`
+	// AfterCreate are setup commands to be run after session creation. Each
+	// command may specify sessions that must be ready before it's run.
+	AfterCreate []Hook `yaml:"afterCreate"`
 	// BeforePause are setup commands to be run before session pausing.
 	BeforePause []string `yaml:"beforePause"`
 	// AfterPause are setup commands to be run after session pausing.
@@ -114,6 +143,9 @@ type Configuration struct {
 	AfterTerminate []string `yaml:"afterTerminate"`
 	// Commands are commands that can be invoked while a project is running.
 	Commands map[string]string `yaml:"commands"`
+	// OnChange are change hooks that can be run while a project is running.
+	// They are keyed by name and run by watching the project.
+	OnChange map[string]ChangeHook `yaml:"onChange"`
 	// Forwarding represents the forwarding sessions to be created. If a
 	// "defaults" key is present, it is treated as a template upon which other
 	// configurations are layered, thus keeping syntactic compatibility with the
@@ -126,17 +158,228 @@ type Configuration struct {
 	Synchronization map[string]SynchronizationConfiguration `yaml:"sync"`
 }
 
+// loadConfigurationTree loads a project configuration file as a generic YAML
+// tree, performing variable interpolation and recursively processing included
+// fragments. The stack argument tracks the absolute paths of files currently
+// being loaded in order to detect inclusion cycles.
+func loadConfigurationTree(path string, vars variables, stack []string) (map[any]any, error) {
+	// Compute the absolute path and check for inclusion cycles.
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to compute absolute path: %w", err)
+	}
+	for _, p := range stack {
+		if p == absolutePath {
+			return nil, fmt.Errorf("inclusion cycle detected at %s", path)
+		}
+	}
+	stack = append(stack, absolutePath)
+
+	// Read and decode the file. We pass-through os.IsNotExist errors.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("unable to load file: %w", err)
+	}
+	var decoded any
+	if err := yaml.Unmarshal(data, &decoded); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal data: %w", err)
+	}
+	tree, ok := decoded.(map[any]any)
+	if decoded == nil {
+		tree = make(map[any]any)
+	} else if !ok {
+		return nil, errors.New("configuration is not a mapping")
+	}
+
+	// Perform variable interpolation.
+	if err := interpolateConfigurationTree(tree, vars.lookup); err != nil {
+		return nil, fmt.Errorf("unable to interpolate variables: %w", err)
+	}
+
+	// Extract the list of included files.
+	var includes []string
+	switch i := tree[includeKey].(type) {
+	case nil:
+	case string:
+		includes = []string{i}
+	case []any:
+		for _, include := range i {
+			if s, ok := include.(string); !ok {
+				return nil, errors.New("invalid include specification")
+			} else {
+				includes = append(includes, s)
+			}
+		}
+	default:
+		return nil, errors.New("invalid include specification")
+	}
+	delete(tree, includeKey)
+
+	// If there are no included files, then we're done.
+	if len(includes) == 0 {
+		return tree, nil
+	}
+
+	// Load included files (resolving their paths relative to the including
+	// file) and merge them in order, with the including file merged last.
+	directory := filepath.Dir(path)
+	result := make(map[any]any)
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(directory, include)
+		}
+		included, err := loadConfigurationTree(include, vars, stack)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load included file (%s): %w", include, err)
+		}
+		mergeConfigurationTrees(result, included)
+	}
+	mergeConfigurationTrees(result, tree)
+
+	// Success.
+	return result, nil
+}
+
+// mergeConfigurationTrees merges the top-level entries of an overlay
+// configuration tree into a base configuration tree. If both trees specify a
+// mapping for a key (e.g. sync or forward), then the mappings are merged
+// recursively (see mergeConfigurationMappings). If both trees specify a
+// sequence for a key (e.g. beforeCreate), then the sequences are concatenated.
+// Otherwise, the overlay value replaces the base value.
+func mergeConfigurationTrees(base, overlay map[any]any) {
+	for key, value := range overlay {
+		if v, ok := value.([]any); ok {
+			if existing, ok := base[key].([]any); ok {
+				base[key] = append(existing, v...)
+				continue
+			}
+		}
+		mergeConfigurationEntry(base, key, value)
+	}
+}
+
+// mergeConfigurationMappings recursively merges an overlay mapping into a base
+// mapping. Nested mappings are merged recursively, while all other overlay
+// values (including sequences) replace the corresponding base values.
+func mergeConfigurationMappings(base, overlay map[any]any) {
+	for key, value := range overlay {
+		mergeConfigurationEntry(base, key, value)
+	}
+}
+
+// mergeConfigurationEntry merges an overlay value into a base mapping at the
+// specified key. If both the overlay value and the existing base value are
+// mappings, then they're merged recursively. Otherwise, the overlay value
+// replaces the base value.
+func mergeConfigurationEntry(base map[any]any, key, value any) {
+	if v, ok := value.(map[any]any); ok {
+		if existing, ok := base[key].(map[any]any); ok {
+			mergeConfigurationMappings(existing, v)
+			return
+		}
+	}
+	base[key] = value
+}
+
 // LoadConfiguration attempts to load a YAML-based Mutagen orchestration
-// configuration file from the specified path.
+// configuration file from the specified path. String values in the file may
+// reference variables (see interpolate for syntax), which are resolved from the
+// environment or, failing that, from an environment file named .env residing
+// in the same directory as the configuration file. Shell commands (i.e. hooks,
+// project commands, and readiness commands) aren't interpolated and are instead
+// passed to the shell verbatim (see interpolateConfigurationTree). The file may
+// also specify a top-level include key containing a path (or a list of paths)
+// of fragment files to include. Included paths are resolved relative to the
+// including file, and fragments are merged in order, with the including file
+// merged last (see mergeConfigurationTrees for merge semantics).
 func LoadConfiguration(path string) (*Configuration, error) {
-	// Create the target configuration object.
-	result := &Configuration{}
+	// Load variables from the environment file.
+	vars, err := loadEnvironmentFile(filepath.Join(filepath.Dir(path), EnvironmentFileName))
+	if err != nil {
+		return nil, err
+	}
 
-	// Attempt to load. We pass-through os.IsNotExist errors.
-	if err := encoding.LoadAndUnmarshalYAML(path, result); err != nil {
+	// Load the configuration tree. We pass-through os.IsNotExist errors.
+	tree, err := loadConfigurationTree(path, vars, nil)
+	if err != nil {
 		return nil, err
 	}
 
+	// Convert the tree to the target configuration object. We re-encode the
+	// tree so that we can perform strict decoding.
+	data, err := yaml.Marshal(tree)
+	if err != nil {
+		return nil, fmt.Errorf("unable to re-encode configuration: %w", err)
+	}
+	result := &Configuration{}
+	if err := yaml.UnmarshalStrict(data, result); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal data: %w", err)
+	}
+
 	// Success.
 	return result, nil
 }
+
+// SelectProfiles removes any synchronization and forwarding sessions that
+// aren't enabled in at least one of the specified profiles. Sessions that
+// don't specify any profiles are always retained. It returns an error if any
+// of the specified profiles isn't used by any session or if the defaults
+// entries specify profiles.
+func (c *Configuration) SelectProfiles(profiles []string) error {
+	// Ensure that defaults don't specify profiles.
+	if len(c.Forwarding["defaults"].Profiles) > 0 {
+		return errors.New("forwarding defaults may not specify profiles")
+	} else if len(c.Synchronization["defaults"].Profiles) > 0 {
+		return errors.New("synchronization defaults may not specify profiles")
+	}
+
+	// Compute the set of selected profiles and track their usage.
+	selected := make(map[string]bool, len(profiles))
+	for _, profile := range profiles {
+		if profile == "" {
+			return errors.New("empty profile name")
+		}
+		selected[profile] = false
+	}
+
+	// Create a closure to determine whether or not a session is enabled and to
+	// record profile usage.
+	enabled := func(sessionProfiles []string) bool {
+		if len(sessionProfiles) == 0 {
+			return true
+		}
+		var result bool
+		for _, profile := range sessionProfiles {
+			if _, ok := selected[profile]; ok {
+				selected[profile] = true
+				result = true
+			}
+		}
+		return result
+	}
+
+	// Remove sessions that aren't enabled.
+	for name, session := range c.Forwarding {
+		if !enabled(session.Profiles) {
+			delete(c.Forwarding, name)
+		}
+	}
+	for name, session := range c.Synchronization {
+		if !enabled(session.Profiles) {
+			delete(c.Synchronization, name)
+		}
+	}
+
+	// Ensure that all selected profiles were used.
+	for _, profile := range profiles {
+		if !selected[profile] {
+			return fmt.Errorf("unknown profile: %s", profile)
+		}
+	}
+
+	// Success.
+	return nil
+}
@@ -1,3 +1,205 @@
 package project
 
-// TODO: Implement.
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeTestFile writes a test file with the specified contents.
+func writeTestFile(t *testing.T, path, contents string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal("unable to write test file:", err)
+	}
+}
+
+// TestLoadConfigurationInterpolationAndIncludes tests that LoadConfiguration
+// performs variable interpolation and processes included fragments.
+func TestLoadConfigurationInterpolationAndIncludes(t *testing.T) {
+	// Set up a mock environment and defer its removal.
+	lookupEnv = func(name string) (string, bool) {
+		if name == "OVERRIDDEN" {
+			return "environment", true
+		}
+		return "", false
+	}
+	defer func() {
+		lookupEnv = os.LookupEnv
+	}()
+
+	// Create the project files.
+	directory := t.TempDir()
+	writeTestFile(t, filepath.Join(directory, EnvironmentFileName), "PLATFORM=linux\nOVERRIDDEN=file\nTIMEOUT=30\n")
+	writeTestFile(t, filepath.Join(directory, "common.yml"), `
+beforeCreate:
+  - echo common
+sync:
+  code:
+    alpha: "."
+    beta: "docker://common/code"
+    mode: "two-way-resolved"
+    ignore:
+      paths:
+        - "common"
+  data:
+    alpha: "./data"
+    beta: "docker://common/data"
+`)
+	writeTestFile(t, filepath.Join(directory, "linux.yml"), `
+commands:
+  platform: echo linux
+`)
+	writeTestFile(t, filepath.Join(directory, DefaultConfigurationFileName), `
+include:
+  - common.yml
+  - ${PLATFORM}.yml
+beforeCreate:
+  - echo $OVERRIDDEN $$HOME
+sync:
+  code:
+    beta: "docker://${PLATFORM}/code"
+    ignore:
+      paths:
+        - "${OVERRIDDEN}"
+    readiness:
+      timeout: ${TIMEOUT}
+`)
+
+	// Load the configuration.
+	configuration, err := LoadConfiguration(filepath.Join(directory, DefaultConfigurationFileName))
+	if err != nil {
+		t.Fatal("unable to load configuration:", err)
+	}
+
+	// Verify hook concatenation and that hook commands aren't interpolated.
+	if len(configuration.BeforeCreate) != 2 {
+		t.Fatal("unexpected number of pre-create commands:", len(configuration.BeforeCreate))
+	} else if configuration.BeforeCreate[0] != "echo common" {
+		t.Error("included pre-create command mismatch:", configuration.BeforeCreate[0])
+	} else if configuration.BeforeCreate[1] != "echo $OVERRIDDEN $$HOME" {
+		t.Error("uninterpolated pre-create command mismatch:", configuration.BeforeCreate[1])
+	}
+
+	// Verify session merging.
+	if len(configuration.Synchronization) != 2 {
+		t.Fatal("unexpected number of synchronization sessions:", len(configuration.Synchronization))
+	} else if beta := configuration.Synchronization["code"].Beta; beta != "docker://linux/code" {
+		t.Error("overridden session beta mismatch:", beta)
+	} else if beta := configuration.Synchronization["data"].Beta; beta != "docker://common/data" {
+		t.Error("included session beta mismatch:", beta)
+	}
+
+	// Verify that session configurations were merged recursively, with nested
+	// sequences replaced.
+	code := configuration.Synchronization["code"]
+	if code.Alpha != "." {
+		t.Error("included session alpha not merged:", code.Alpha)
+	} else if code.Configuration.Mode.IsDefault() {
+		t.Error("included session mode not merged")
+	} else if len(code.Configuration.Ignore.Paths) != 1 || code.Configuration.Ignore.Paths[0] != "environment" {
+		t.Error("overridden session ignores mismatch:", code.Configuration.Ignore.Paths)
+	}
+
+	// Verify that interpolated numeric values were decoded.
+	if code.Readiness.Timeout != 30 {
+		t.Error("interpolated readiness timeout mismatch:", code.Readiness.Timeout)
+	}
+
+	// Verify that the variable-specified include was processed.
+	if configuration.Commands["platform"] != "echo linux" {
+		t.Error("platform-specific command not included")
+	}
+}
+
+// TestLoadConfigurationErrors tests that LoadConfiguration rejects invalid
+// configurations.
+func TestLoadConfigurationErrors(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		description string
+		files       map[string]string
+	}{
+		{"undefined variable", map[string]string{
+			DefaultConfigurationFileName: "sync:\n  code:\n    alpha: $MUTAGEN_TEST_UNDEFINED\n",
+		}},
+		{"inclusion cycle", map[string]string{
+			DefaultConfigurationFileName: "include: a.yml\n",
+			"a.yml":                      "include: " + DefaultConfigurationFileName + "\n",
+		}},
+		{"missing include", map[string]string{
+			DefaultConfigurationFileName: "include: missing.yml\n",
+		}},
+		{"unknown field", map[string]string{
+			DefaultConfigurationFileName: "unknown: true\n",
+		}},
+		{"non-mapping", map[string]string{
+			DefaultConfigurationFileName: "- item\n",
+		}},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		directory := t.TempDir()
+		for name, contents := range test.files {
+			writeTestFile(t, filepath.Join(directory, name), contents)
+		}
+		if _, err := LoadConfiguration(filepath.Join(directory, DefaultConfigurationFileName)); err == nil {
+			t.Errorf("%s: configuration loaded successfully", test.description)
+		}
+	}
+
+	// Verify that missing files pass through os.IsNotExist errors.
+	if _, err := LoadConfiguration(filepath.Join(t.TempDir(), DefaultConfigurationFileName)); !os.IsNotExist(err) {
+		t.Error("missing configuration did not yield os.IsNotExist error:", err)
+	}
+}
+
+// TestSelectProfiles tests Configuration.SelectProfiles.
+func TestSelectProfiles(t *testing.T) {
+	// Create a configuration generator.
+	create := func() *Configuration {
+		return &Configuration{
+			Forwarding: map[string]ForwardingConfiguration{
+				"database": {Profiles: []string{"full"}},
+			},
+			Synchronization: map[string]SynchronizationConfiguration{
+				"defaults": {},
+				"frontend": {},
+				"backend":  {Profiles: []string{"full", "backend"}},
+			},
+		}
+	}
+
+	// Verify that sessions with profiles are disabled by default.
+	configuration := create()
+	if err := configuration.SelectProfiles(nil); err != nil {
+		t.Fatal("unable to select no profiles:", err)
+	} else if len(configuration.Forwarding) != 0 {
+		t.Error("forwarding session not removed")
+	} else if len(configuration.Synchronization) != 2 {
+		t.Error("unexpected number of synchronization sessions:", len(configuration.Synchronization))
+	}
+
+	// Verify profile selection.
+	configuration = create()
+	if err := configuration.SelectProfiles([]string{"backend"}); err != nil {
+		t.Fatal("unable to select profile:", err)
+	} else if len(configuration.Forwarding) != 0 {
+		t.Error("forwarding session not removed")
+	} else if _, ok := configuration.Synchronization["backend"]; !ok {
+		t.Error("synchronization session removed")
+	}
+
+	// Verify that unknown profiles are rejected.
+	if err := create().SelectProfiles([]string{"unknown"}); err == nil {
+		t.Error("unknown profile selected successfully")
+	}
+
+	// Verify that defaults can't specify profiles.
+	configuration = create()
+	configuration.Synchronization["defaults"] = SynchronizationConfiguration{Profiles: []string{"full"}}
+	if err := configuration.SelectProfiles(nil); err == nil {
+		t.Error("defaults with profiles accepted")
+	}
+}
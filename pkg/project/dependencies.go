@@ -0,0 +1,237 @@
+package project
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// forwardingReferencePrefix is the prefix that can be used in dependency
+	// specifications to refer explicitly to a forwarding session.
+	forwardingReferencePrefix = "forward:"
+	// synchronizationReferencePrefix is the prefix that can be used in
+	// dependency specifications to refer explicitly to a synchronization
+	// session.
+	synchronizationReferencePrefix = "sync:"
+)
+
+// SessionKind identifies the type of a project session.
+type SessionKind uint8
+
+const (
+	// SessionKindForwarding indicates a forwarding session.
+	SessionKindForwarding SessionKind = iota
+	// SessionKindSynchronization indicates a synchronization session.
+	SessionKindSynchronization
+)
+
+// String returns a human-readable representation of the session kind.
+func (k SessionKind) String() string {
+	switch k {
+	case SessionKindForwarding:
+		return "forwarding"
+	case SessionKindSynchronization:
+		return "synchronization"
+	default:
+		return "unknown"
+	}
+}
+
+// SessionReference identifies a session within a project.
+type SessionReference struct {
+	// Kind is the session kind.
+	Kind SessionKind
+	// Name is the session name.
+	Name string
+}
+
+// Hook encodes a hook command. In YAML, a hook may be specified either as a
+// plain command string or as a mapping with command and dependsOn keys.
+type Hook struct {
+	// Command is the command to run.
+	Command string `yaml:"command"`
+	// DependsOn are the sessions that must be ready before the command is run.
+	DependsOn []string `yaml:"dependsOn"`
+}
+
+// UnmarshalYAML implements Unmarshaler.UnmarshalYAML.
+func (h *Hook) UnmarshalYAML(unmarshal func(any) error) error {
+	// Attempt to unmarshal a plain command string.
+	var command string
+	if err := unmarshal(&command); err == nil {
+		*h = Hook{Command: command}
+		return nil
+	}
+
+	// Otherwise unmarshal the full hook specification. We use an alias type to
+	// avoid recursion.
+	type hook Hook
+	var result hook
+	if err := unmarshal(&result); err != nil {
+		return err
+	} else if result.Command == "" {
+		return errors.New("hook command not specified")
+	}
+	*h = Hook(result)
+
+	// Success.
+	return nil
+}
+
+// resolveDependency resolves a dependency specification to a session
+// reference. Specifications may be prefixed with "forward:" or "sync:" to
+// disambiguate between sessions of different kinds with the same name.
+func (c *Configuration) resolveDependency(specification string) (SessionReference, error) {
+	// Handle explicit references.
+	if strings.HasPrefix(specification, forwardingReferencePrefix) {
+		name := specification[len(forwardingReferencePrefix):]
+		if _, ok := c.Forwarding[name]; !ok || name == "defaults" {
+			return SessionReference{}, fmt.Errorf("unknown or disabled forwarding session: %s", name)
+		}
+		return SessionReference{SessionKindForwarding, name}, nil
+	} else if strings.HasPrefix(specification, synchronizationReferencePrefix) {
+		name := specification[len(synchronizationReferencePrefix):]
+		if _, ok := c.Synchronization[name]; !ok || name == "defaults" {
+			return SessionReference{}, fmt.Errorf("unknown or disabled synchronization session: %s", name)
+		}
+		return SessionReference{SessionKindSynchronization, name}, nil
+	}
+
+	// Handle implicit references.
+	if specification == "defaults" {
+		return SessionReference{}, errors.New("defaults can't be used as a dependency")
+	}
+	_, forwarding := c.Forwarding[specification]
+	_, synchronization := c.Synchronization[specification]
+	if forwarding && synchronization {
+		return SessionReference{}, fmt.Errorf(
+			"ambiguous session name (use %s or %s prefix): %s",
+			forwardingReferencePrefix, synchronizationReferencePrefix, specification,
+		)
+	} else if forwarding {
+		return SessionReference{SessionKindForwarding, specification}, nil
+	} else if synchronization {
+		return SessionReference{SessionKindSynchronization, specification}, nil
+	}
+	return SessionReference{}, fmt.Errorf("unknown or disabled session: %s", specification)
+}
+
+// ResolveDependencies resolves a list of dependency specifications.
+func (c *Configuration) ResolveDependencies(specifications []string) ([]SessionReference, error) {
+	result := make([]SessionReference, 0, len(specifications))
+	for _, specification := range specifications {
+		reference, err := c.resolveDependency(specification)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, reference)
+	}
+	return result, nil
+}
+
+// DependencyOrder computes an order in which the project's sessions (excluding
+// defaults) can be created such that each session is created after the
+// sessions on which it depends. Independent sessions are ordered with
+// forwarding sessions first and then by name. It also validates the
+// dependencies specified by hooks. It returns an error if any dependency can't
+// be resolved or if a dependency cycle exists.
+func (c *Configuration) DependencyOrder() ([]SessionReference, error) {
+	// Ensure that defaults don't specify dependencies.
+	if len(c.Forwarding["defaults"].DependsOn) > 0 {
+		return nil, errors.New("forwarding defaults may not specify dependencies")
+	} else if len(c.Synchronization["defaults"].DependsOn) > 0 {
+		return nil, errors.New("synchronization defaults may not specify dependencies")
+	}
+
+	// Build the dependency graph, tracking the number of unsatisfied
+	// dependencies for each session and the dependents of each session.
+	pending := make(map[SessionReference]int)
+	dependents := make(map[SessionReference][]SessionReference)
+	add := func(session SessionReference, specifications []string) error {
+		dependencies, err := c.ResolveDependencies(specifications)
+		if err != nil {
+			return fmt.Errorf("invalid dependencies for %s session %s: %w", session.Kind, session.Name, err)
+		}
+		unique := make(map[SessionReference]bool, len(dependencies))
+		for _, dependency := range dependencies {
+			if dependency == session {
+				return fmt.Errorf("%s session %s depends on itself", session.Kind, session.Name)
+			} else if unique[dependency] {
+				continue
+			}
+			unique[dependency] = true
+			dependents[dependency] = append(dependents[dependency], session)
+		}
+		pending[session] = len(unique)
+		return nil
+	}
+	for name, session := range c.Forwarding {
+		if name == "defaults" {
+			continue
+		}
+		if err := add(SessionReference{SessionKindForwarding, name}, session.DependsOn); err != nil {
+			return nil, err
+		}
+	}
+	for name, session := range c.Synchronization {
+		if name == "defaults" {
+			continue
+		}
+		if err := add(SessionReference{SessionKindSynchronization, name}, session.DependsOn); err != nil {
+			return nil, err
+		}
+	}
+
+	// Validate hook dependencies.
+	for _, hook := range c.AfterCreate {
+		if _, err := c.ResolveDependencies(hook.DependsOn); err != nil {
+			return nil, fmt.Errorf("invalid dependencies for hook (%s): %w", hook.Command, err)
+		}
+	}
+
+	// Create a comparison function for ordering independent sessions.
+	less := func(a, b SessionReference) bool {
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.Name < b.Name
+	}
+
+	// Perform a topological sort.
+	var ready []SessionReference
+	for session, count := range pending {
+		if count == 0 {
+			ready = append(ready, session)
+		}
+	}
+	result := make([]SessionReference, 0, len(pending))
+	for len(ready) > 0 {
+		sort.Slice(ready, func(i, j int) bool { return less(ready[i], ready[j]) })
+		session := ready[0]
+		ready = ready[1:]
+		result = append(result, session)
+		for _, dependent := range dependents[session] {
+			pending[dependent]--
+			if pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	// If any sessions remain unsorted, then there's a cycle.
+	if len(result) != len(pending) {
+		var cyclic []string
+		for session, count := range pending {
+			if count > 0 {
+				cyclic = append(cyclic, session.Name)
+			}
+		}
+		sort.Strings(cyclic)
+		return nil, fmt.Errorf("dependency cycle detected among sessions: %s", strings.Join(cyclic, ", "))
+	}
+
+	// Success.
+	return result, nil
+}
@@ -0,0 +1,97 @@
+package project
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+// TestHookUnmarshal tests that hooks can be unmarshaled from both plain command
+// strings and full hook specifications.
+func TestHookUnmarshal(t *testing.T) {
+	// Unmarshal hooks.
+	var hooks []Hook
+	data := "- echo plain\n- command: echo full\n  dependsOn: [code]\n"
+	if err := yaml.UnmarshalStrict([]byte(data), &hooks); err != nil {
+		t.Fatal("unable to unmarshal hooks:", err)
+	}
+
+	// Verify the results.
+	if len(hooks) != 2 {
+		t.Fatal("unexpected number of hooks:", len(hooks))
+	} else if hooks[0].Command != "echo plain" || len(hooks[0].DependsOn) != 0 {
+		t.Error("plain hook mismatch:", hooks[0])
+	} else if hooks[1].Command != "echo full" || len(hooks[1].DependsOn) != 1 || hooks[1].DependsOn[0] != "code" {
+		t.Error("full hook mismatch:", hooks[1])
+	}
+
+	// Verify that hooks without commands are rejected.
+	if err := yaml.UnmarshalStrict([]byte("- dependsOn: [code]\n"), &hooks); err == nil {
+		t.Error("hook without command unmarshaled successfully")
+	}
+}
+
+// TestDependencyOrder tests Configuration.DependencyOrder.
+func TestDependencyOrder(t *testing.T) {
+	// Create a configuration with dependencies.
+	configuration := &Configuration{
+		AfterCreate: []Hook{{Command: "echo ready", DependsOn: []string{"web"}}},
+		Forwarding: map[string]ForwardingConfiguration{
+			"defaults": {},
+			"web":      {DependsOn: []string{"code"}},
+			"database": {},
+		},
+		Synchronization: map[string]SynchronizationConfiguration{
+			"code":   {},
+			"assets": {DependsOn: []string{"sync:code", "forward:database"}},
+		},
+	}
+
+	// Compute and verify the order.
+	order, err := configuration.DependencyOrder()
+	if err != nil {
+		t.Fatal("unable to compute dependency order:", err)
+	}
+	expected := []SessionReference{
+		{SessionKindForwarding, "database"},
+		{SessionKindSynchronization, "code"},
+		{SessionKindForwarding, "web"},
+		{SessionKindSynchronization, "assets"},
+	}
+	if len(order) != len(expected) {
+		t.Fatal("unexpected order length:", len(order), "!=", len(expected))
+	}
+	for i, reference := range order {
+		if reference != expected[i] {
+			t.Errorf("order mismatch at index %d: %v != %v", i, reference, expected[i])
+		}
+	}
+
+	// Verify that cycles are detected.
+	configuration.Synchronization["code"] = SynchronizationConfiguration{DependsOn: []string{"assets"}}
+	if _, err := configuration.DependencyOrder(); err == nil {
+		t.Error("dependency cycle not detected")
+	}
+
+	// Verify that ambiguous, unknown, and self-referential dependencies are
+	// rejected.
+	invalid := []map[string]SynchronizationConfiguration{
+		{"web": {}, "other": {DependsOn: []string{"web"}}},
+		{"other": {DependsOn: []string{"unknown"}}},
+		{"other": {DependsOn: []string{"other"}}},
+		{"other": {DependsOn: []string{"defaults"}}},
+	}
+	for i, synchronization := range invalid {
+		configuration.Synchronization = synchronization
+		if _, err := configuration.DependencyOrder(); err == nil {
+			t.Errorf("invalid dependencies at index %d accepted", i)
+		}
+	}
+
+	// Verify that invalid hook dependencies are rejected.
+	configuration.Synchronization = nil
+	configuration.AfterCreate = []Hook{{Command: "echo", DependsOn: []string{"unknown"}}}
+	if _, err := configuration.DependencyOrder(); err == nil {
+		t.Error("invalid hook dependencies accepted")
+	}
+}
@@ -0,0 +1,327 @@
+package project
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// EnvironmentFileName is the name of the file (residing alongside the
+	// project configuration file) from which project variables are loaded.
+	EnvironmentFileName = ".env"
+)
+
+// uninterpolatedKeys are the top-level configuration keys whose values are
+// shell commands (or structures containing shell commands) and thus aren't
+// interpolated. Shell commands are passed to the shell verbatim so that they
+// can use the shell's own variable expansion.
+var uninterpolatedKeys = map[string]bool{
+	"beforeCreate":    true,
+	"afterCreate":     true,
+	"beforePause":     true,
+	"afterPause":      true,
+	"beforeResume":    true,
+	"afterResume":     true,
+	"beforeTerminate": true,
+	"afterTerminate":  true,
+	"commands":        true,
+}
+
+// commandKey is the key used for shell commands within nested configuration
+// mappings (e.g. readiness commands and change hook commands). Values for this
+// key aren't interpolated.
+const commandKey = "command"
+
+// lookupEnv is the environment variable lookup function to use. It is a
+// variable so that it can be swapped out during testing.
+var lookupEnv = os.LookupEnv
+
+// variables provides variable lookup for project configuration interpolation.
+// Environment variables take precedence over those loaded from the environment
+// file.
+type variables map[string]string
+
+// lookup returns the value for the specified variable, as well as whether or
+// not it was found.
+func (v variables) lookup(name string) (string, bool) {
+	if value, ok := lookupEnv(name); ok {
+		return value, true
+	}
+	value, ok := v[name]
+	return value, ok
+}
+
+// isVariableNameByte determines whether or not a byte is valid within a
+// variable name.
+func isVariableNameByte(b byte, first bool) bool {
+	return b == '_' ||
+		(b >= 'A' && b <= 'Z') ||
+		(b >= 'a' && b <= 'z') ||
+		(!first && b >= '0' && b <= '9')
+}
+
+// isValidVariableName determines whether or not a string is a valid variable
+// name.
+func isValidVariableName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isVariableNameByte(name[i], i == 0) {
+			return false
+		}
+	}
+	return true
+}
+
+// loadEnvironmentFile loads variables from an environment file. Each non-empty
+// line that isn't a comment (i.e. that doesn't start with '#') must take the
+// form NAME=VALUE, optionally prefixed by "export ". Values may be enclosed in
+// single or double quotes, which are removed. If the file doesn't exist, then
+// an empty set of variables is returned.
+func loadEnvironmentFile(path string) (variables, error) {
+	// Read the file contents.
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return variables{}, nil
+		}
+		return nil, fmt.Errorf("unable to read environment file: %w", err)
+	}
+
+	// Parse variables.
+	result := make(variables)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for l := 1; scanner.Scan(); l++ {
+		// Ignore empty lines and comments.
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+
+		// Split the line into its name and value.
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("missing '=' separator on line %d", l)
+		}
+		name = strings.TrimSpace(name)
+		if !isValidVariableName(name) {
+			return nil, fmt.Errorf("invalid variable name on line %d: %s", l, name)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+
+		// Record the variable.
+		result[name] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to scan environment file: %w", err)
+	}
+
+	// Success.
+	return result, nil
+}
+
+// interpolate performs variable substitution on a string. It supports $NAME
+// and ${NAME} references, ${NAME:-default} and ${NAME-default} references
+// (which use the default if the variable is unset or empty and unset,
+// respectively), and ${NAME:?message} and ${NAME?message} references (which
+// fail with the specified message under the same conditions). A literal '$'
+// can be specified using "$$". References to unset variables without a default
+// are treated as errors.
+func interpolate(value string, lookup func(string) (string, bool)) (string, error) {
+	// Fast path for strings without references.
+	if strings.IndexByte(value, '$') < 0 {
+		return value, nil
+	}
+
+	// Perform substitution.
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		// Copy through anything that isn't a reference.
+		if value[i] != '$' {
+			result.WriteByte(value[i])
+			continue
+		}
+
+		// Handle escaped dollar signs.
+		if i+1 < len(value) && value[i+1] == '$' {
+			result.WriteByte('$')
+			i++
+			continue
+		}
+
+		// Handle unbraced references.
+		if i+1 < len(value) && value[i+1] != '{' {
+			end := i + 1
+			for end < len(value) && isVariableNameByte(value[end], end == i+1) {
+				end++
+			}
+			if end == i+1 {
+				return "", fmt.Errorf("invalid variable reference at offset %d", i)
+			}
+			name := value[i+1 : end]
+			substitution, ok := lookup(name)
+			if !ok {
+				return "", fmt.Errorf("undefined variable: %s", name)
+			}
+			result.WriteString(substitution)
+			i = end - 1
+			continue
+		} else if i+1 == len(value) {
+			return "", errors.New("trailing '$' (use \"$$\" for a literal '$')")
+		}
+
+		// Handle braced references by first extracting the reference body.
+		closing := strings.IndexByte(value[i+2:], '}')
+		if closing < 0 {
+			return "", fmt.Errorf("unterminated variable reference at offset %d", i)
+		}
+		body := value[i+2 : i+2+closing]
+		i += 2 + closing
+
+		// Split the body into its name, operator, and argument.
+		nameEnd := 0
+		for nameEnd < len(body) && isVariableNameByte(body[nameEnd], nameEnd == 0) {
+			nameEnd++
+		}
+		name, operator := body[:nameEnd], body[nameEnd:]
+		if name == "" {
+			return "", fmt.Errorf("invalid variable reference: ${%s}", body)
+		}
+		var argument string
+		var requireNonEmpty bool
+		if strings.HasPrefix(operator, ":") {
+			requireNonEmpty = true
+			operator = operator[1:]
+		}
+		if operator != "" {
+			argument = operator[1:]
+			operator = operator[:1]
+		} else if requireNonEmpty {
+			return "", fmt.Errorf("invalid variable reference: ${%s}", body)
+		}
+
+		// Perform the substitution.
+		substitution, ok := lookup(name)
+		unset := !ok || (requireNonEmpty && substitution == "")
+		switch operator {
+		case "":
+			if !ok {
+				return "", fmt.Errorf("undefined variable: %s", name)
+			}
+		case "-":
+			if unset {
+				substitution = argument
+			}
+		case "?":
+			if unset {
+				if argument == "" {
+					argument = "variable not set"
+				}
+				return "", fmt.Errorf("%s: %s", name, argument)
+			}
+		default:
+			return "", fmt.Errorf("invalid variable reference: ${%s}", body)
+		}
+		result.WriteString(substitution)
+	}
+
+	// Success.
+	return result.String(), nil
+}
+
+// resolveScalar converts an interpolated string to a boolean or numeric value
+// if it represents one, so that interpolated values can be used for non-string
+// fields. Values are only converted if their canonical encoding is identical to
+// the interpolated string, so values such as "1.10" or "007" (which would lose
+// information in conversion) remain strings.
+func resolveScalar(value string) any {
+	// Attempt to decode the value as a YAML scalar.
+	var resolved any
+	if err := yaml.Unmarshal([]byte(value), &resolved); err != nil {
+		return value
+	}
+
+	// Only convert boolean and numeric values.
+	switch resolved.(type) {
+	case bool, int, int64, uint64, float64:
+	default:
+		return value
+	}
+
+	// Ensure that the conversion is lossless.
+	if encoded, err := yaml.Marshal(resolved); err != nil || strings.TrimSpace(string(encoded)) != value {
+		return value
+	}
+
+	// Success.
+	return resolved
+}
+
+// interpolateTree performs variable substitution on all string scalar values
+// within a decoded YAML tree, except for shell commands, which are identified
+// by commandKey. Mapping keys aren't modified. Strings that contain references
+// are converted to boolean or numeric values if their interpolated values
+// represent such values (see resolveScalar).
+func interpolateTree(node any, lookup func(string) (string, bool)) (any, error) {
+	switch n := node.(type) {
+	case string:
+		substituted, err := interpolate(n, lookup)
+		if err != nil {
+			return nil, err
+		} else if substituted != n {
+			return resolveScalar(substituted), nil
+		}
+		return n, nil
+	case []any:
+		for i, element := range n {
+			substituted, err := interpolateTree(element, lookup)
+			if err != nil {
+				return nil, err
+			}
+			n[i] = substituted
+		}
+		return n, nil
+	case map[any]any:
+		for key, element := range n {
+			if key == commandKey {
+				continue
+			}
+			substituted, err := interpolateTree(element, lookup)
+			if err != nil {
+				return nil, fmt.Errorf("%v: %w", key, err)
+			}
+			n[key] = substituted
+		}
+		return n, nil
+	default:
+		return node, nil
+	}
+}
+
+// interpolateConfigurationTree performs variable substitution on a decoded
+// configuration tree, excluding the top-level entries identified by
+// uninterpolatedKeys (see interpolateTree for details).
+func interpolateConfigurationTree(tree map[any]any, lookup func(string) (string, bool)) error {
+	for key, value := range tree {
+		if name, ok := key.(string); ok && uninterpolatedKeys[name] {
+			continue
+		}
+		substituted, err := interpolateTree(value, lookup)
+		if err != nil {
+			return fmt.Errorf("%v: %w", key, err)
+		}
+		tree[key] = substituted
+	}
+	return nil
+}
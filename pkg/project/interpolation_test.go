@@ -0,0 +1,170 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testVariables are the variables used for interpolation tests.
+var testVariables = variables{
+	"NAME":  "value",
+	"EMPTY": "",
+}
+
+// TestInterpolate tests interpolate.
+func TestInterpolate(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value       string
+		expected    string
+		expectError bool
+	}{
+		{"plain", "plain", false},
+		{"$NAME", "value", false},
+		{"a-${NAME}-b", "a-value-b", false},
+		{"$NAME/$NAME", "value/value", false},
+		{"$$NAME", "$NAME", false},
+		{"cost: $$5", "cost: $5", false},
+		{"${MISSING:-fallback}", "fallback", false},
+		{"${EMPTY:-fallback}", "fallback", false},
+		{"${EMPTY-fallback}", "", false},
+		{"${NAME:-fallback}", "value", false},
+		{"${NAME:?required}", "value", false},
+		{"${EMPTY:?required}", "", true},
+		{"${MISSING?required}", "", true},
+		{"$MISSING", "", true},
+		{"${MISSING}", "", true},
+		{"${NAME", "", true},
+		{"${}", "", true},
+		{"${NAME:}", "", true},
+		{"${NAME+x}", "", true},
+		{"$1", "", true},
+		{"trailing$", "", true},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		result, err := interpolate(test.value, testVariables.lookup)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("interpolation of %q unexpectedly succeeded", test.value)
+			}
+		} else if err != nil {
+			t.Errorf("interpolation of %q failed: %v", test.value, err)
+		} else if result != test.expected {
+			t.Errorf("interpolation of %q result mismatch: %q != %q", test.value, result, test.expected)
+		}
+	}
+}
+
+// TestResolveScalar tests resolveScalar.
+func TestResolveScalar(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    string
+		expected any
+	}{
+		{"30", 30},
+		{"-5", -5},
+		{"2.5", 2.5},
+		{"true", true},
+		{"false", false},
+		{"1.10", "1.10"},
+		{"007", "007"},
+		{"yes", "yes"},
+		{"value", "value"},
+		{"", ""},
+		{"[a]", "[a]"},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		if result := resolveScalar(test.value); result != test.expected {
+			t.Errorf("resolution of %q mismatch: %#v != %#v", test.value, result, test.expected)
+		}
+	}
+}
+
+// TestInterpolateConfigurationTree tests that interpolateConfigurationTree
+// doesn't interpolate shell commands.
+func TestInterpolateConfigurationTree(t *testing.T) {
+	// Create a configuration tree.
+	tree := map[any]any{
+		"beforeCreate": []any{"echo $MISSING"},
+		"commands":     map[any]any{"show": "echo ${MISSING}"},
+		"onChange": map[any]any{
+			"generate": map[any]any{"session": "$NAME", "command": "echo $MISSING"},
+		},
+		"sync": map[any]any{
+			"code": map[any]any{
+				"alpha":     "./$NAME",
+				"readiness": map[any]any{"command": "test -n \"$MISSING\""},
+			},
+		},
+	}
+
+	// Perform interpolation.
+	if err := interpolateConfigurationTree(tree, testVariables.lookup); err != nil {
+		t.Fatal("unable to interpolate configuration tree:", err)
+	}
+
+	// Verify that commands weren't interpolated but other values were.
+	if command := tree["beforeCreate"].([]any)[0]; command != "echo $MISSING" {
+		t.Error("hook command modified:", command)
+	}
+	if command := tree["commands"].(map[any]any)["show"]; command != "echo ${MISSING}" {
+		t.Error("project command modified:", command)
+	}
+	hook := tree["onChange"].(map[any]any)["generate"].(map[any]any)
+	if hook["session"] != "value" {
+		t.Error("change hook session not interpolated:", hook["session"])
+	} else if hook["command"] != "echo $MISSING" {
+		t.Error("change hook command modified:", hook["command"])
+	}
+	session := tree["sync"].(map[any]any)["code"].(map[any]any)
+	if session["alpha"] != "./value" {
+		t.Error("session alpha not interpolated:", session["alpha"])
+	} else if command := session["readiness"].(map[any]any)["command"]; command != "test -n \"$MISSING\"" {
+		t.Error("readiness command modified:", command)
+	}
+}
+
+// TestLoadEnvironmentFile tests loadEnvironmentFile.
+func TestLoadEnvironmentFile(t *testing.T) {
+	// Create an environment file.
+	path := filepath.Join(t.TempDir(), EnvironmentFileName)
+	contents := "# Comment\n\nA=1\nexport B = two\nC=\"quoted value\"\nD='single'\nE=\n"
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatal("unable to write environment file:", err)
+	}
+
+	// Load the file and verify its contents.
+	vars, err := loadEnvironmentFile(path)
+	if err != nil {
+		t.Fatal("unable to load environment file:", err)
+	}
+	expected := variables{"A": "1", "B": "two", "C": "quoted value", "D": "single", "E": ""}
+	if len(vars) != len(expected) {
+		t.Fatal("variable count mismatch:", len(vars), "!=", len(expected))
+	}
+	for name, value := range expected {
+		if vars[name] != value {
+			t.Errorf("variable %s mismatch: %q != %q", name, vars[name], value)
+		}
+	}
+
+	// Verify that a missing file yields no variables.
+	if vars, err := loadEnvironmentFile(path + ".missing"); err != nil {
+		t.Error("missing environment file treated as error:", err)
+	} else if len(vars) != 0 {
+		t.Error("missing environment file yielded variables")
+	}
+
+	// Verify that invalid lines are rejected.
+	if err := os.WriteFile(path, []byte("INVALID\n"), 0600); err != nil {
+		t.Fatal("unable to write environment file:", err)
+	} else if _, err := loadEnvironmentFile(path); err == nil {
+		t.Error("environment file with invalid line loaded successfully")
+	}
+}
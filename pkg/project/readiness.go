@@ -0,0 +1,127 @@
+package project
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultReadinessTimeout is the readiness timeout used if none is
+	// specified.
+	DefaultReadinessTimeout = 5 * time.Minute
+)
+
+// ReadinessCondition is a custom YAML type that encodes the session state that
+// must be reached before a session is considered ready, including a lack of
+// specification.
+type ReadinessCondition uint8
+
+const (
+	// ReadinessConditionDefault indicates that the readiness condition is
+	// unspecified.
+	ReadinessConditionDefault ReadinessCondition = iota
+	// ReadinessConditionNone indicates that a session is ready as soon as it's
+	// created.
+	ReadinessConditionNone
+	// ReadinessConditionConnected indicates that a session is ready once it's
+	// connected to both of its endpoints (and, for forwarding sessions, once
+	// it's forwarding connections). For forwarding sessions, this doesn't
+	// indicate that the destination is accepting connections, since the
+	// destination isn't dialed until a connection is forwarded, so a readiness
+	// command is required to verify that the forwarded service is available.
+	ReadinessConditionConnected
+	// ReadinessConditionSynchronized indicates that a synchronization session
+	// is ready once it's completed its first successful synchronization cycle.
+	// It is only valid for synchronization sessions.
+	ReadinessConditionSynchronized
+)
+
+// IsDefault indicates whether or not the readiness condition is
+// ReadinessConditionDefault.
+func (c ReadinessCondition) IsDefault() bool {
+	return c == ReadinessConditionDefault
+}
+
+// String returns a human-readable representation of the readiness condition.
+func (c ReadinessCondition) String() string {
+	switch c {
+	case ReadinessConditionDefault:
+		return "default"
+	case ReadinessConditionNone:
+		return "none"
+	case ReadinessConditionConnected:
+		return "connected"
+	case ReadinessConditionSynchronized:
+		return "synchronized"
+	default:
+		return "unknown"
+	}
+}
+
+// UnmarshalYAML implements Unmarshaler.UnmarshalYAML.
+func (c *ReadinessCondition) UnmarshalYAML(unmarshal func(any) error) error {
+	// Call the underlying unmarshaling function.
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+
+	// Convert to a readiness condition.
+	switch text {
+	case "none":
+		*c = ReadinessConditionNone
+	case "connected":
+		*c = ReadinessConditionConnected
+	case "synchronized":
+		*c = ReadinessConditionSynchronized
+	default:
+		return fmt.Errorf("unknown readiness condition specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Readiness encodes the criteria used to determine when a session is ready.
+type Readiness struct {
+	// Condition is the session state that must be reached before the session
+	// is considered ready.
+	Condition ReadinessCondition `yaml:"condition"`
+	// Command is a command that must succeed (after Condition is satisfied)
+	// before the session is considered ready. It is retried until it succeeds.
+	Command string `yaml:"command"`
+	// Timeout is the maximum amount of time (in seconds) to wait for the
+	// session to become ready. A value of 0 indicates that
+	// DefaultReadinessTimeout should be used.
+	Timeout uint32 `yaml:"timeout"`
+}
+
+// Merge returns a copy of the readiness criteria with any unspecified fields
+// taken from the specified defaults.
+func (r Readiness) Merge(defaults Readiness) Readiness {
+	if r.Condition.IsDefault() {
+		r.Condition = defaults.Condition
+	}
+	if r.Command == "" {
+		r.Command = defaults.Command
+	}
+	if r.Timeout == 0 {
+		r.Timeout = defaults.Timeout
+	}
+	return r
+}
+
+// EffectiveTimeout returns the maximum amount of time to wait for the session
+// to become ready, taking the default timeout into account.
+func (r Readiness) EffectiveTimeout() time.Duration {
+	if r.Timeout == 0 {
+		return DefaultReadinessTimeout
+	}
+	return time.Duration(r.Timeout) * time.Second
+}
+
+// IsTrivial indicates whether or not the readiness criteria are satisfied as
+// soon as a session is created.
+func (r Readiness) IsTrivial() bool {
+	return (r.Condition.IsDefault() || r.Condition == ReadinessConditionNone) && r.Command == ""
+}
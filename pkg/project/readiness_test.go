@@ -0,0 +1,60 @@
+package project
+
+import (
+	"testing"
+	"time"
+)
+
+// TestReadinessMerge tests Readiness.Merge.
+func TestReadinessMerge(t *testing.T) {
+	// Merge partially specified criteria with defaults.
+	defaults := Readiness{Condition: ReadinessConditionConnected, Command: "true", Timeout: 30}
+	merged := Readiness{Timeout: 10}.Merge(defaults)
+
+	// Verify the result.
+	expected := Readiness{Condition: ReadinessConditionConnected, Command: "true", Timeout: 10}
+	if merged != expected {
+		t.Errorf("merged readiness mismatch: %+v != %+v", merged, expected)
+	}
+}
+
+// TestReadinessEffectiveTimeout tests Readiness.EffectiveTimeout.
+func TestReadinessEffectiveTimeout(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		readiness Readiness
+		expected  time.Duration
+	}{
+		{Readiness{}, DefaultReadinessTimeout},
+		{Readiness{Timeout: 1}, time.Second},
+		{Readiness{Timeout: 600}, 10 * time.Minute},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if timeout := testCase.readiness.EffectiveTimeout(); timeout != testCase.expected {
+			t.Errorf("test case %d: timeout mismatch: %v != %v", i, timeout, testCase.expected)
+		}
+	}
+}
+
+// TestReadinessIsTrivial tests Readiness.IsTrivial.
+func TestReadinessIsTrivial(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		readiness Readiness
+		expected  bool
+	}{
+		{Readiness{}, true},
+		{Readiness{Condition: ReadinessConditionNone, Timeout: 10}, true},
+		{Readiness{Condition: ReadinessConditionConnected}, false},
+		{Readiness{Command: "true"}, false},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if trivial := testCase.readiness.IsTrivial(); trivial != testCase.expected {
+			t.Errorf("test case %d: triviality mismatch: %t != %t", i, trivial, testCase.expected)
+		}
+	}
+}
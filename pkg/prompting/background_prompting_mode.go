@@ -0,0 +1,79 @@
+package prompting
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the background prompting mode is
+// BackgroundPromptingMode_BackgroundPromptingModeDefault.
+func (m BackgroundPromptingMode) IsDefault() bool {
+	return m == BackgroundPromptingMode_BackgroundPromptingModeDefault
+}
+
+// Enabled indicates whether or not the background prompting mode is
+// BackgroundPromptingMode_BackgroundPromptingModeEnabled.
+func (m BackgroundPromptingMode) Enabled() bool {
+	return m == BackgroundPromptingMode_BackgroundPromptingModeEnabled
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m BackgroundPromptingMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case BackgroundPromptingMode_BackgroundPromptingModeDefault:
+	case BackgroundPromptingMode_BackgroundPromptingModeDisabled:
+		result = "disabled"
+	case BackgroundPromptingMode_BackgroundPromptingModeEnabled:
+		result = "enabled"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *BackgroundPromptingMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a background prompting mode.
+	switch text {
+	case "disabled":
+		*m = BackgroundPromptingMode_BackgroundPromptingModeDisabled
+	case "enabled":
+		*m = BackgroundPromptingMode_BackgroundPromptingModeEnabled
+	default:
+		return fmt.Errorf("unknown background prompting mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular background prompting mode is
+// a valid, non-default value.
+func (m BackgroundPromptingMode) Supported() bool {
+	switch m {
+	case BackgroundPromptingMode_BackgroundPromptingModeDisabled:
+		return true
+	case BackgroundPromptingMode_BackgroundPromptingModeEnabled:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a background prompting
+// mode.
+func (m BackgroundPromptingMode) Description() string {
+	switch m {
+	case BackgroundPromptingMode_BackgroundPromptingModeDefault:
+		return "Default"
+	case BackgroundPromptingMode_BackgroundPromptingModeDisabled:
+		return "Disabled"
+	case BackgroundPromptingMode_BackgroundPromptingModeEnabled:
+		return "Enabled"
+	default:
+		return "Unknown"
+	}
+}
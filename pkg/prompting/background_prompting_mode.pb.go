@@ -0,0 +1,150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: prompting/background_prompting_mode.proto
+
+package prompting
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// BackgroundPromptingMode specifies whether or not operations performed in the
+// background (such as automatic reconnection) may use the default prompter.
+type BackgroundPromptingMode int32
+
+const (
+	// BackgroundPromptingMode_BackgroundPromptingModeDefault represents an
+	// unspecified background prompting mode. It should be converted to one of
+	// the following values based on the desired default behavior.
+	BackgroundPromptingMode_BackgroundPromptingModeDefault BackgroundPromptingMode = 0
+	// BackgroundPromptingMode_BackgroundPromptingModeDisabled specifies that
+	// background operations should never perform prompting or messaging.
+	BackgroundPromptingMode_BackgroundPromptingModeDisabled BackgroundPromptingMode = 1
+	// BackgroundPromptingMode_BackgroundPromptingModeEnabled specifies that
+	// background operations should use the default prompter, if one is
+	// registered.
+	BackgroundPromptingMode_BackgroundPromptingModeEnabled BackgroundPromptingMode = 2
+)
+
+// Enum value maps for BackgroundPromptingMode.
+var (
+	BackgroundPromptingMode_name = map[int32]string{
+		0: "BackgroundPromptingModeDefault",
+		1: "BackgroundPromptingModeDisabled",
+		2: "BackgroundPromptingModeEnabled",
+	}
+	BackgroundPromptingMode_value = map[string]int32{
+		"BackgroundPromptingModeDefault":  0,
+		"BackgroundPromptingModeDisabled": 1,
+		"BackgroundPromptingModeEnabled":  2,
+	}
+)
+
+func (x BackgroundPromptingMode) Enum() *BackgroundPromptingMode {
+	p := new(BackgroundPromptingMode)
+	*p = x
+	return p
+}
+
+func (x BackgroundPromptingMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BackgroundPromptingMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_prompting_background_prompting_mode_proto_enumTypes[0].Descriptor()
+}
+
+func (BackgroundPromptingMode) Type() protoreflect.EnumType {
+	return &file_prompting_background_prompting_mode_proto_enumTypes[0]
+}
+
+func (x BackgroundPromptingMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BackgroundPromptingMode.Descriptor instead.
+func (BackgroundPromptingMode) EnumDescriptor() ([]byte, []int) {
+	return file_prompting_background_prompting_mode_proto_rawDescGZIP(), []int{0}
+}
+
+var File_prompting_background_prompting_mode_proto protoreflect.FileDescriptor
+
+var file_prompting_background_prompting_mode_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x61, 0x63, 0x6b,
+	0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x70, 0x72, 0x6f,
+	0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x2a, 0x86, 0x01, 0x0a, 0x17, 0x42, 0x61, 0x63, 0x6b, 0x67,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f,
+	0x64, 0x65, 0x12, 0x22, 0x0a, 0x1e, 0x42, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x10, 0x00, 0x12, 0x23, 0x0a, 0x1f, 0x42, 0x61, 0x63, 0x6b, 0x67, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64,
+	0x65, 0x44, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x10, 0x01, 0x12, 0x22, 0x0a, 0x1e, 0x42,
+	0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69,
+	0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x10, 0x02, 0x42,
+	0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75,
+	0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e,
+	0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_prompting_background_prompting_mode_proto_rawDescOnce sync.Once
+	file_prompting_background_prompting_mode_proto_rawDescData = file_prompting_background_prompting_mode_proto_rawDesc
+)
+
+func file_prompting_background_prompting_mode_proto_rawDescGZIP() []byte {
+	file_prompting_background_prompting_mode_proto_rawDescOnce.Do(func() {
+		file_prompting_background_prompting_mode_proto_rawDescData = protoimpl.X.CompressGZIP(file_prompting_background_prompting_mode_proto_rawDescData)
+	})
+	return file_prompting_background_prompting_mode_proto_rawDescData
+}
+
+var file_prompting_background_prompting_mode_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_prompting_background_prompting_mode_proto_goTypes = []interface{}{
+	(BackgroundPromptingMode)(0), // 0: prompting.BackgroundPromptingMode
+}
+var file_prompting_background_prompting_mode_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_prompting_background_prompting_mode_proto_init() }
+func file_prompting_background_prompting_mode_proto_init() {
+	if File_prompting_background_prompting_mode_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_prompting_background_prompting_mode_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_prompting_background_prompting_mode_proto_goTypes,
+		DependencyIndexes: file_prompting_background_prompting_mode_proto_depIdxs,
+		EnumInfos:         file_prompting_background_prompting_mode_proto_enumTypes,
+	}.Build()
+	File_prompting_background_prompting_mode_proto = out.File
+	file_prompting_background_prompting_mode_proto_rawDesc = nil
+	file_prompting_background_prompting_mode_proto_goTypes = nil
+	file_prompting_background_prompting_mode_proto_depIdxs = nil
+}
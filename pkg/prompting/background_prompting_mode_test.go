@@ -0,0 +1,91 @@
+package prompting
+
+import (
+	"testing"
+)
+
+// TestBackgroundPromptingModeUnmarshal tests that unmarshaling from a string
+// specification succeeeds for BackgroundPromptingMode.
+func TestBackgroundPromptingModeUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text          string
+		expectedMode  BackgroundPromptingMode
+		expectFailure bool
+	}{
+		{"", BackgroundPromptingMode_BackgroundPromptingModeDefault, true},
+		{"asdf", BackgroundPromptingMode_BackgroundPromptingModeDefault, true},
+		{"disabled", BackgroundPromptingMode_BackgroundPromptingModeDisabled, false},
+		{"enabled", BackgroundPromptingMode_BackgroundPromptingModeEnabled, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var mode BackgroundPromptingMode
+		if err := mode.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if mode != testCase.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				testCase.expectedMode,
+			)
+		}
+	}
+}
+
+// TestBackgroundPromptingModeSupported tests that BackgroundPromptingMode
+// support detection works as expected.
+func TestBackgroundPromptingModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            BackgroundPromptingMode
+		expectSupported bool
+	}{
+		{BackgroundPromptingMode_BackgroundPromptingModeDefault, false},
+		{BackgroundPromptingMode_BackgroundPromptingModeDisabled, true},
+		{BackgroundPromptingMode_BackgroundPromptingModeEnabled, true},
+		{(BackgroundPromptingMode_BackgroundPromptingModeEnabled + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestBackgroundPromptingModeDescription tests that BackgroundPromptingMode
+// description generation works as expected.
+func TestBackgroundPromptingModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                BackgroundPromptingMode
+		expectedDescription string
+	}{
+		{BackgroundPromptingMode_BackgroundPromptingModeDefault, "Default"},
+		{BackgroundPromptingMode_BackgroundPromptingModeDisabled, "Disabled"},
+		{BackgroundPromptingMode_BackgroundPromptingModeEnabled, "Enabled"},
+		{(BackgroundPromptingMode_BackgroundPromptingModeEnabled + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -14,6 +14,12 @@ var registryLock sync.RWMutex
 // registry is the global prompter registry.
 var registry = make(map[string]chan Prompter)
 
+// defaultPrompter is the identifier of the default prompter, if any. It is the
+// prompter used by operations performed in the background (e.g. automatic
+// reconnection) that lack a prompter of their own. It is protected by
+// registryLock.
+var defaultPrompter string
+
 // RegisterPrompter registers a prompter with the global registry. It
 // automatically generates a unique identifier for the prompter.
 func RegisterPrompter(prompter Prompter) (string, error) {
@@ -74,6 +80,9 @@ func UnregisterPrompter(identifier string) {
 		panic("deregistration requested for unregistered prompter")
 	}
 	delete(registry, identifier)
+	if defaultPrompter == identifier {
+		defaultPrompter = ""
+	}
 	registryLock.Unlock()
 
 	// Get the prompter back and close the holder to let anyone else who has it
@@ -82,6 +91,40 @@ func UnregisterPrompter(identifier string) {
 	close(holder)
 }
 
+// SetDefaultPrompter designates a registered prompter as the default prompter.
+// Only one default prompter may be designated at a time. The designation is
+// automatically removed when the prompter is unregistered.
+func SetDefaultPrompter(identifier string) error {
+	// Lock the registry for writing and defer its release.
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	// Ensure that the prompter is registered.
+	if _, ok := registry[identifier]; !ok {
+		return errors.New("prompter not found")
+	}
+
+	// Ensure that no other default prompter is designated.
+	if defaultPrompter != "" {
+		return errors.New("default prompter already registered")
+	}
+
+	// Perform the designation.
+	defaultPrompter = identifier
+
+	// Success.
+	return nil
+}
+
+// DefaultPrompter returns the identifier of the default prompter, if any. If no
+// default prompter is designated, then an empty string is returned, which (as
+// a prompter identifier) disables messaging and prompting.
+func DefaultPrompter() string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+	return defaultPrompter
+}
+
 // Message invokes the Message method on a prompter in the global registry. If
 // the prompter identifier provided is an empty string, this method is a no-op
 // and returns a nil error.
@@ -1,3 +1,59 @@
 package prompting
 
-// TODO: Implement.
+import (
+	"testing"
+)
+
+// testPrompter is a no-op prompter implementation for testing.
+type testPrompter struct{}
+
+// Message implements Prompter.Message.
+func (p *testPrompter) Message(_ string) error {
+	return nil
+}
+
+// Prompt implements Prompter.Prompt.
+func (p *testPrompter) Prompt(_ string) (string, error) {
+	return "", nil
+}
+
+// TestDefaultPrompter tests default prompter designation and removal.
+func TestDefaultPrompter(t *testing.T) {
+	// Ensure that designation of an unregistered prompter fails.
+	if err := SetDefaultPrompter("unregistered"); err == nil {
+		t.Error("designation of unregistered prompter succeeded")
+	}
+
+	// Register two prompters.
+	first, err := RegisterPrompter(&testPrompter{})
+	if err != nil {
+		t.Fatal("unable to register first prompter:", err)
+	}
+	second, err := RegisterPrompter(&testPrompter{})
+	if err != nil {
+		t.Fatal("unable to register second prompter:", err)
+	}
+	defer UnregisterPrompter(second)
+
+	// Designate the first as the default and ensure that a second designation
+	// is rejected.
+	if err := SetDefaultPrompter(first); err != nil {
+		t.Fatal("unable to designate default prompter:", err)
+	} else if DefaultPrompter() != first {
+		t.Error("default prompter does not match designated prompter")
+	}
+	if err := SetDefaultPrompter(second); err == nil {
+		t.Error("designation of second default prompter succeeded")
+	}
+
+	// Unregister the first prompter and ensure that the designation is removed.
+	UnregisterPrompter(first)
+	if DefaultPrompter() != "" {
+		t.Error("default prompter designation not removed on unregistration")
+	}
+
+	// Ensure that the second prompter can now be designated.
+	if err := SetDefaultPrompter(second); err != nil {
+		t.Error("unable to designate default prompter after removal:", err)
+	}
+}
@@ -22,6 +22,9 @@ func (s *Selection) EnsureValid() error {
 	if s.LabelSelector != "" {
 		mechanismsPresent++
 	}
+	if len(s.Selectors) > 0 {
+		mechanismsPresent++
+	}
 
 	// Enforce that exactly one selection mechanism is present.
 	if mechanismsPresent > 1 {
@@ -41,6 +44,14 @@ func (s *Selection) EnsureValid() error {
 	// pose a risk to parse unvalidated and it would only be possible to
 	// validate by parsing, so we'll catch any format errors later.
 
+	// Validate selectors, if present. Unlike label selectors, these are cheap
+	// to parse and are most usefully validated early.
+	if len(s.Selectors) > 0 {
+		if _, err := ParseSelectors(s.Selectors); err != nil {
+			return err
+		}
+	}
+
 	// Success.
 	return nil
 }
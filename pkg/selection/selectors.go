@@ -0,0 +1,264 @@
+package selection
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+const (
+	// SessionStatusPaused indicates that a session has been paused.
+	SessionStatusPaused = "paused"
+	// SessionStatusRunning indicates that a session has not been paused.
+	SessionStatusRunning = "running"
+	// SessionStatusHalted indicates that a session has been halted for safety
+	// reasons (e.g. root deletion).
+	SessionStatusHalted = "halted"
+	// SessionStatusDisconnected indicates that a running session is not
+	// connected to all of its endpoints.
+	SessionStatusDisconnected = "disconnected"
+	// SessionStatusConnected indicates that a running session is connected to
+	// all of its endpoints.
+	SessionStatusConnected = "connected"
+	// SessionStatusHasConflicts indicates that a session has conflicts.
+	SessionStatusHasConflicts = "has-conflicts"
+	// SessionStatusHasProblems indicates that a session has scan or transition
+	// problems on at least one endpoint.
+	SessionStatusHasProblems = "has-problems"
+	// SessionStatusHasErrors indicates that a session has recorded an error.
+	SessionStatusHasErrors = "has-errors"
+)
+
+// sessionStatuses is the set of session statuses supported by selectors.
+var sessionStatuses = map[string]bool{
+	SessionStatusPaused:       true,
+	SessionStatusRunning:      true,
+	SessionStatusHalted:       true,
+	SessionStatusDisconnected: true,
+	SessionStatusConnected:    true,
+	SessionStatusHasConflicts: true,
+	SessionStatusHasProblems:  true,
+	SessionStatusHasErrors:    true,
+}
+
+// Candidate describes the attributes of a session that can be matched by
+// selectors.
+type Candidate struct {
+	// Name is the session name.
+	Name string
+	// Endpoints are the session endpoint URLs.
+	Endpoints []*url.URL
+	// Statuses is the set of session statuses (using the SessionStatus*
+	// constants) that currently apply to the session.
+	Statuses map[string]bool
+}
+
+// selectorKey identifies the session attribute matched by a selector clause.
+type selectorKey uint8
+
+const (
+	// selectorKeyName matches session names using glob patterns.
+	selectorKeyName selectorKey = iota
+	// selectorKeyProtocol matches endpoint protocols.
+	selectorKeyProtocol
+	// selectorKeyHost matches endpoint hosts using glob patterns.
+	selectorKeyHost
+	// selectorKeyEndpoint matches endpoint protocol and host pairs.
+	selectorKeyEndpoint
+	// selectorKeyStatus matches session statuses.
+	selectorKeyStatus
+)
+
+// endpointPattern is a protocol and host glob pair that must both match the
+// same endpoint.
+type endpointPattern struct {
+	// protocol is the endpoint protocol.
+	protocol url.Protocol
+	// host is the endpoint host glob pattern. An empty pattern matches any
+	// host.
+	host string
+}
+
+// matches determines whether or not the endpoint pattern matches a URL.
+func (p endpointPattern) matches(u *url.URL) bool {
+	if u.Protocol != p.protocol {
+		return false
+	} else if p.host == "" {
+		return true
+	}
+	matched, _ := path.Match(p.host, u.Host)
+	return matched
+}
+
+// selectorClause is a single parsed selector. It matches a session if any of
+// its values match.
+type selectorClause struct {
+	// key is the session attribute that the clause matches.
+	key selectorKey
+	// patterns are the name or host glob patterns or status names.
+	patterns []string
+	// protocols are the endpoint protocols.
+	protocols []url.Protocol
+	// endpoints are the endpoint patterns.
+	endpoints []endpointPattern
+}
+
+// matches determines whether or not the clause matches a candidate.
+func (c *selectorClause) matches(candidate *Candidate) bool {
+	switch c.key {
+	case selectorKeyName:
+		for _, pattern := range c.patterns {
+			if matched, _ := path.Match(pattern, candidate.Name); matched {
+				return true
+			}
+		}
+	case selectorKeyProtocol:
+		for _, protocol := range c.protocols {
+			for _, endpoint := range candidate.Endpoints {
+				if endpoint.Protocol == protocol {
+					return true
+				}
+			}
+		}
+	case selectorKeyHost:
+		for _, pattern := range c.patterns {
+			for _, endpoint := range candidate.Endpoints {
+				if endpoint.Protocol == url.Protocol_Local {
+					continue
+				} else if matched, _ := path.Match(pattern, endpoint.Host); matched {
+					return true
+				}
+			}
+		}
+	case selectorKeyEndpoint:
+		for _, pattern := range c.endpoints {
+			for _, endpoint := range candidate.Endpoints {
+				if pattern.matches(endpoint) {
+					return true
+				}
+			}
+		}
+	case selectorKeyStatus:
+		for _, status := range c.patterns {
+			if candidate.Statuses[status] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Selectors performs matching of sessions against a list of selector
+// specifications.
+type Selectors struct {
+	// clauses are the parsed selector clauses.
+	clauses []*selectorClause
+}
+
+// Matches determines whether or not a candidate session is matched by all of
+// the selectors.
+func (s *Selectors) Matches(candidate *Candidate) bool {
+	for _, clause := range s.clauses {
+		if !clause.matches(candidate) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseSelector parses a single selector specification.
+func parseSelector(specification string) (*selectorClause, error) {
+	// Split the specification into its key and value list.
+	key, value, ok := strings.Cut(specification, "=")
+	if !ok {
+		return nil, errors.New("missing '=' separator")
+	} else if value == "" {
+		return nil, errors.New("empty value list")
+	}
+	values := strings.Split(value, ",")
+	for _, v := range values {
+		if v == "" {
+			return nil, errors.New("empty value")
+		}
+	}
+
+	// Parse values based on the key.
+	clause := &selectorClause{}
+	switch key {
+	case "name", "host":
+		if key == "name" {
+			clause.key = selectorKeyName
+		} else {
+			clause.key = selectorKeyHost
+		}
+		for _, pattern := range values {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern: %s", pattern)
+			}
+		}
+		clause.patterns = values
+	case "protocol":
+		clause.key = selectorKeyProtocol
+		for _, v := range values {
+			var protocol url.Protocol
+			if err := protocol.UnmarshalText([]byte(v)); err != nil {
+				return nil, err
+			}
+			clause.protocols = append(clause.protocols, protocol)
+		}
+	case "endpoint":
+		clause.key = selectorKeyEndpoint
+		for _, v := range values {
+			protocolName, host, ok := strings.Cut(v, "://")
+			if !ok {
+				return nil, fmt.Errorf("invalid endpoint specification: %s", v)
+			}
+			var protocol url.Protocol
+			if err := protocol.UnmarshalText([]byte(protocolName)); err != nil {
+				return nil, err
+			} else if _, err := path.Match(host, ""); err != nil {
+				return nil, fmt.Errorf("invalid host pattern: %s", host)
+			}
+			clause.endpoints = append(clause.endpoints, endpointPattern{protocol, host})
+		}
+	case "status":
+		clause.key = selectorKeyStatus
+		for _, status := range values {
+			if !sessionStatuses[status] {
+				return nil, fmt.Errorf("unknown status: %s", status)
+			}
+		}
+		clause.patterns = values
+	default:
+		return nil, fmt.Errorf("unknown selector key: %s", key)
+	}
+
+	// Success.
+	return clause, nil
+}
+
+// ParseSelectors parses a list of selector specifications. Each specification
+// takes the form key=value[,value...], where key is one of "name" (matching
+// session names against glob patterns), "protocol" (matching endpoint
+// protocols), "host" (matching remote endpoint hosts against glob patterns),
+// "endpoint" (matching protocol://host-pattern pairs against individual
+// endpoints), or "status" (matching the SessionStatus* values). A specification
+// matches a session if any of its values match, and a session is selected only
+// if it matches all specifications.
+func ParseSelectors(specifications []string) (*Selectors, error) {
+	// Parse each specification.
+	clauses := make([]*selectorClause, len(specifications))
+	for s, specification := range specifications {
+		clause, err := parseSelector(specification)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector (%s): %w", specification, err)
+		}
+		clauses[s] = clause
+	}
+
+	// Success.
+	return &Selectors{clauses}, nil
+}
@@ -0,0 +1,96 @@
+package selection
+
+import (
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/url"
+)
+
+// TestParseSelectors tests that ParseSelectors behaves as expected for a
+// variety of test cases.
+func TestParseSelectors(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		specification string
+		expectFailure bool
+	}{
+		{"", true},
+		{"name", true},
+		{"name=", true},
+		{"name=web-*", false},
+		{"name=web,,api", true},
+		{"name=[", true},
+		{"protocol=docker", false},
+		{"protocol=docker,ssh", false},
+		{"protocol=ftp", true},
+		{"host=web*", false},
+		{"endpoint=docker://web", false},
+		{"endpoint=ssh://", false},
+		{"endpoint=web", true},
+		{"status=halted,has-conflicts", false},
+		{"status=sleeping", true},
+		{"color=blue", true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		_, err := ParseSelectors([]string{testCase.specification})
+		if err != nil && !testCase.expectFailure {
+			t.Errorf("selector (%s) failed to parse unexpectedly: %v", testCase.specification, err)
+		} else if err == nil && testCase.expectFailure {
+			t.Errorf("selector (%s) parsed unexpectedly", testCase.specification)
+		}
+	}
+}
+
+// TestSelectorsMatches tests that Selectors.Matches behaves as expected for a
+// variety of test cases.
+func TestSelectorsMatches(t *testing.T) {
+	// Create a candidate session.
+	candidate := &Candidate{
+		Name: "web-code",
+		Endpoints: []*url.URL{
+			{Protocol: url.Protocol_Local, Path: "/home/user/web"},
+			{Protocol: url.Protocol_Docker, Host: "web", Path: "/code"},
+		},
+		Statuses: map[string]bool{
+			SessionStatusRunning: true,
+			SessionStatusHalted:  true,
+		},
+	}
+
+	// Set up test cases.
+	testCases := []struct {
+		selectors []string
+		expected  bool
+	}{
+		{[]string{"name=web-*"}, true},
+		{[]string{"name=api-*"}, false},
+		{[]string{"name=api-*,web-*"}, true},
+		{[]string{"protocol=docker"}, true},
+		{[]string{"protocol=ssh"}, false},
+		{[]string{"host=web"}, true},
+		{[]string{"host=*"}, true},
+		{[]string{"host=api"}, false},
+		{[]string{"endpoint=docker://web"}, true},
+		{[]string{"endpoint=ssh://web"}, false},
+		{[]string{"status=halted"}, true},
+		{[]string{"status=paused,disconnected"}, false},
+		{[]string{"status=halted", "host=web"}, true},
+		{[]string{"status=halted", "host=api"}, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		selectors, err := ParseSelectors(testCase.selectors)
+		if err != nil {
+			t.Errorf("unable to parse selectors (%v): %v", testCase.selectors, err)
+			continue
+		}
+		if matched := selectors.Matches(candidate); matched != testCase.expected {
+			t.Errorf("selectors (%v) match result (%t) does not match expected (%t)",
+				testCase.selectors, matched, testCase.expected,
+			)
+		}
+	}
+}
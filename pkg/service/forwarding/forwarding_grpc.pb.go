@@ -26,10 +26,18 @@ type ForwardingClient interface {
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
 	// List returns metadata for existing sessions.
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Logs returns captured logs for a session.
+	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error)
 	// Pause pauses sessions.
 	Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error)
 	// Resume resumes paused or disconnected sessions.
 	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
+	// Label changes the names and labels of sessions.
+	Label(ctx context.Context, in *LabelRequest, opts ...grpc.CallOption) (*LabelResponse, error)
+	// Export exports session definitions.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error)
+	// Import creates sessions from exported session definitions.
+	Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportResponse, error)
 	// Terminate terminates sessions.
 	Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResponse, error)
 }
@@ -60,6 +68,15 @@ func (c *forwardingClient) List(ctx context.Context, in *ListRequest, opts ...gr
 	return out, nil
 }
 
+func (c *forwardingClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error) {
+	out := new(LogsResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Logs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *forwardingClient) Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error) {
 	out := new(PauseResponse)
 	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Pause", in, out, opts...)
@@ -78,6 +95,33 @@ func (c *forwardingClient) Resume(ctx context.Context, in *ResumeRequest, opts .
 	return out, nil
 }
 
+func (c *forwardingClient) Label(ctx context.Context, in *LabelRequest, opts ...grpc.CallOption) (*LabelResponse, error) {
+	out := new(LabelResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Label", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forwardingClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error) {
+	out := new(ExportResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Export", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forwardingClient) Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportResponse, error) {
+	out := new(ImportResponse)
+	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Import", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *forwardingClient) Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResponse, error) {
 	out := new(TerminateResponse)
 	err := c.cc.Invoke(ctx, "/forwarding.Forwarding/Terminate", in, out, opts...)
@@ -95,10 +139,18 @@ type ForwardingServer interface {
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
 	// List returns metadata for existing sessions.
 	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Logs returns captured logs for a session.
+	Logs(context.Context, *LogsRequest) (*LogsResponse, error)
 	// Pause pauses sessions.
 	Pause(context.Context, *PauseRequest) (*PauseResponse, error)
 	// Resume resumes paused or disconnected sessions.
 	Resume(context.Context, *ResumeRequest) (*ResumeResponse, error)
+	// Label changes the names and labels of sessions.
+	Label(context.Context, *LabelRequest) (*LabelResponse, error)
+	// Export exports session definitions.
+	Export(context.Context, *ExportRequest) (*ExportResponse, error)
+	// Import creates sessions from exported session definitions.
+	Import(context.Context, *ImportRequest) (*ImportResponse, error)
 	// Terminate terminates sessions.
 	Terminate(context.Context, *TerminateRequest) (*TerminateResponse, error)
 	mustEmbedUnimplementedForwardingServer()
@@ -114,12 +166,24 @@ func (UnimplementedForwardingServer) Create(context.Context, *CreateRequest) (*C
 func (UnimplementedForwardingServer) List(context.Context, *ListRequest) (*ListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedForwardingServer) Logs(context.Context, *LogsRequest) (*LogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logs not implemented")
+}
 func (UnimplementedForwardingServer) Pause(context.Context, *PauseRequest) (*PauseResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
 }
 func (UnimplementedForwardingServer) Resume(context.Context, *ResumeRequest) (*ResumeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
 }
+func (UnimplementedForwardingServer) Label(context.Context, *LabelRequest) (*LabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Label not implemented")
+}
+func (UnimplementedForwardingServer) Export(context.Context, *ExportRequest) (*ExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedForwardingServer) Import(context.Context, *ImportRequest) (*ImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
 func (UnimplementedForwardingServer) Terminate(context.Context, *TerminateRequest) (*TerminateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Terminate not implemented")
 }
@@ -172,6 +236,24 @@ func _Forwarding_List_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Forwarding_Logs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).Logs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/Logs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).Logs(ctx, req.(*LogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Forwarding_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(PauseRequest)
 	if err := dec(in); err != nil {
@@ -208,6 +290,60 @@ func _Forwarding_Resume_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Forwarding_Label_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).Label(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/Label",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).Label(ctx, req.(*LabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Forwarding_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).Export(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Forwarding_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForwardingServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/forwarding.Forwarding/Import",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForwardingServer).Import(ctx, req.(*ImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Forwarding_Terminate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TerminateRequest)
 	if err := dec(in); err != nil {
@@ -241,6 +377,10 @@ var Forwarding_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _Forwarding_List_Handler,
 		},
+		{
+			MethodName: "Logs",
+			Handler:    _Forwarding_Logs_Handler,
+		},
 		{
 			MethodName: "Pause",
 			Handler:    _Forwarding_Pause_Handler,
@@ -249,6 +389,18 @@ var Forwarding_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Resume",
 			Handler:    _Forwarding_Resume_Handler,
 		},
+		{
+			MethodName: "Label",
+			Handler:    _Forwarding_Label_Handler,
+		},
+		{
+			MethodName: "Export",
+			Handler:    _Forwarding_Export_Handler,
+		},
+		{
+			MethodName: "Import",
+			Handler:    _Forwarding_Import_Handler,
+		},
 		{
 			MethodName: "Terminate",
 			Handler:    _Forwarding_Terminate_Handler,
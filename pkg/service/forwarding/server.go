@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/mutagen-io/mutagen/pkg/forwarding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
 )
 
 // Server provides an implementation of the Forwarding service.
@@ -70,6 +71,38 @@ func (s *Server) List(ctx context.Context, request *ListRequest) (*ListResponse,
 	}, nil
 }
 
+// Logs returns captured logs for a session.
+func (s *Server) Logs(ctx context.Context, request *LogsRequest) (*LogsResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid logs request: %w", err)
+	}
+
+	// Override the log capture level for the duration of the request, if
+	// requested. Clients following logs re-establish the override with each
+	// request, so the previous level is restored once they stop.
+	if request.Level != "" {
+		level, _ := logging.NameToLevel(request.Level)
+		release, err := s.manager.OverrideLogLevel(request.Selection, level)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	// Read log entries.
+	index, entries, err := s.manager.Logs(ctx, request.Selection, request.PreviousIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &LogsResponse{
+		Index:   index,
+		Entries: entries,
+	}, nil
+}
+
 // Pause pauses existing sessions.
 func (s *Server) Pause(ctx context.Context, request *PauseRequest) (*PauseResponse, error) {
 	// Validate the request.
@@ -102,6 +135,60 @@ func (s *Server) Resume(ctx context.Context, request *ResumeRequest) (*ResumeRes
 	return &ResumeResponse{}, nil
 }
 
+// Label changes the names and labels of existing sessions.
+func (s *Server) Label(ctx context.Context, request *LabelRequest) (*LabelResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid label request: %w", err)
+	}
+
+	// Perform labeling.
+	if err := s.manager.Label(
+		ctx, request.Selection,
+		request.Rename, request.Name,
+		request.Labels, request.RemovedLabels, request.Overwrite,
+	); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &LabelResponse{}, nil
+}
+
+// Export exports session definitions.
+func (s *Server) Export(ctx context.Context, request *ExportRequest) (*ExportResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid export request: %w", err)
+	}
+
+	// Perform export.
+	export, err := s.manager.Export(ctx, request.Selection)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &ExportResponse{Export: export}, nil
+}
+
+// Import creates sessions from exported session definitions.
+func (s *Server) Import(ctx context.Context, request *ImportRequest) (*ImportResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid import request: %w", err)
+	}
+
+	// Perform import.
+	sessions, err := s.manager.Import(ctx, request.Export, request.Paused, request.Prompter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &ImportResponse{Sessions: sessions}, nil
+}
+
 // Terminate terminates existing sessions.
 func (s *Server) Terminate(ctx context.Context, request *TerminateRequest) (*TerminateResponse, error) {
 	// Validate the request.
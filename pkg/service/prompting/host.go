@@ -20,6 +20,27 @@ import (
 func Host(
 	ctx context.Context, client PromptingClient,
 	prompter prompting.Prompter, allowPrompts bool,
+) (string, <-chan error, error) {
+	return host(ctx, client, prompter, allowPrompts, false)
+}
+
+// HostDefault is a variant of Host that requests that the hosted prompter be
+// designated as the daemon's default prompter, which is used by operations
+// performed in the background (such as automatic reconnection). Prompts are
+// always allowed for default prompters. Only one default prompter may be hosted
+// at a time, and a conflicting registration will be reported as a hosting
+// error.
+func HostDefault(
+	ctx context.Context, client PromptingClient,
+	prompter prompting.Prompter,
+) (string, <-chan error, error) {
+	return host(ctx, client, prompter, true, true)
+}
+
+// host implements Host and HostDefault.
+func host(
+	ctx context.Context, client PromptingClient,
+	prompter prompting.Prompter, allowPrompts, isDefault bool,
 ) (string, <-chan error, error) {
 	// Create a subcontext that we can use to perform cancellation in case of a
 	// client-side messaging or prompting error.
@@ -35,6 +56,7 @@ func Host(
 	// Send the initialization request.
 	request := &HostRequest{
 		AllowPrompts: allowPrompts,
+		Default:      isDefault,
 	}
 	if err := stream.Send(request); err != nil {
 		cancel()
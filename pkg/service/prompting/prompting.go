@@ -27,6 +27,11 @@ func (r *HostRequest) ensureValid(mode hostRequestMode) error {
 	if mode == hostRequestModeInitial {
 		// Any setting for prompt allowance is valid.
 
+		// Ensure that default prompters allow prompts.
+		if r.Default && !r.AllowPrompts {
+			return errors.New("default prompter must allow prompts")
+		}
+
 		// Ensure that the response is empty.
 		if r.Response != "" {
 			return errors.New("unexpected response value on initial request")
@@ -37,6 +42,11 @@ func (r *HostRequest) ensureValid(mode hostRequestMode) error {
 			return errors.New("unexpected prompt allowance specification")
 		}
 
+		// Ensure that default designation hasn't been re-specified.
+		if r.Default {
+			return errors.New("unexpected default prompter specification")
+		}
+
 		// If responding to a message, ensure that the response is empty. For
 		// prompt responses, any value is allowed.
 		if mode == hostRequestModeMessageResponse && r.Response != "" {
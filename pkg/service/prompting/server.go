@@ -55,6 +55,14 @@ func (s *Server) Host(stream Prompting_HostServer) error {
 		return fmt.Errorf("unable to register prompter: %w", err)
 	}
 
+	// If requested, designate the prompter as the default prompter.
+	if request.Default {
+		if err := prompting.SetDefaultPrompter(identifier); err != nil {
+			prompting.UnregisterPrompter(identifier)
+			return fmt.Errorf("unable to designate default prompter: %w", err)
+		}
+	}
+
 	// Wait for the request or connection to be terminated.
 	<-ctx.Done()
 
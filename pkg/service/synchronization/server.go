@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/logging"
 	"github.com/mutagen-io/mutagen/pkg/synchronization"
 )
 
@@ -70,6 +72,38 @@ func (s *Server) List(ctx context.Context, request *ListRequest) (*ListResponse,
 	}, nil
 }
 
+// Logs returns captured logs for a session.
+func (s *Server) Logs(ctx context.Context, request *LogsRequest) (*LogsResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid logs request: %w", err)
+	}
+
+	// Override the log capture level for the duration of the request, if
+	// requested. Clients following logs re-establish the override with each
+	// request, so the previous level is restored once they stop.
+	if request.Level != "" {
+		level, _ := logging.NameToLevel(request.Level)
+		release, err := s.manager.OverrideLogLevel(request.Selection, level)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+	}
+
+	// Read log entries.
+	index, entries, err := s.manager.Logs(ctx, request.Selection, request.PreviousIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &LogsResponse{
+		Index:   index,
+		Entries: entries,
+	}, nil
+}
+
 // Flush flushes sessions.
 func (s *Server) Flush(ctx context.Context, request *FlushRequest) (*FlushResponse, error) {
 	// Validate the request.
@@ -134,6 +168,88 @@ func (s *Server) Reset(ctx context.Context, request *ResetRequest) (*ResetRespon
 	return &ResetResponse{}, nil
 }
 
+// Restore restores snapshotted content in sessions.
+func (s *Server) Restore(ctx context.Context, request *RestoreRequest) (*RestoreResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid restore request: %w", err)
+	}
+
+	// Perform restoration.
+	results, err := s.manager.Restore(ctx, request.Selection, request.Prompter, request.Before.AsTime(), request.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &RestoreResponse{Results: results}, nil
+}
+
+// Label changes the names and labels of sessions.
+func (s *Server) Label(ctx context.Context, request *LabelRequest) (*LabelResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid label request: %w", err)
+	}
+
+	// Perform labeling.
+	if err := s.manager.Label(
+		ctx, request.Selection,
+		request.Rename, request.Name,
+		request.Labels, request.RemovedLabels, request.Overwrite,
+	); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &LabelResponse{}, nil
+}
+
+// Export exports session definitions to a file.
+func (s *Server) Export(ctx context.Context, request *ExportRequest) (*ExportResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid export request: %w", err)
+	}
+
+	// Perform export.
+	export, err := s.manager.Export(ctx, request.Selection, request.IncludeArchives)
+	if err != nil {
+		return nil, err
+	}
+
+	// Save the export.
+	if err := encoding.MarshalAndSaveProtobuf(request.Path, export); err != nil {
+		return nil, fmt.Errorf("unable to save export: %w", err)
+	}
+
+	// Success.
+	return &ExportResponse{Sessions: uint64(len(export.Sessions))}, nil
+}
+
+// Import creates sessions from exported session definitions in a file.
+func (s *Server) Import(ctx context.Context, request *ImportRequest) (*ImportResponse, error) {
+	// Validate the request.
+	if err := request.ensureValid(); err != nil {
+		return nil, fmt.Errorf("invalid import request: %w", err)
+	}
+
+	// Load the export. It will be validated by the manager.
+	export := &synchronization.SessionExport{}
+	if err := encoding.LoadAndUnmarshalProtobuf(request.Path, export); err != nil {
+		return nil, fmt.Errorf("unable to load export: %w", err)
+	}
+
+	// Perform import.
+	sessions, err := s.manager.Import(ctx, export, request.UseArchives, request.Paused, request.Force, request.Prompter)
+	if err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return &ImportResponse{Sessions: sessions}, nil
+}
+
 // Terminate terminates sessions.
 func (s *Server) Terminate(ctx context.Context, request *TerminateRequest) (*TerminateResponse, error) {
 	// Validate the request.
@@ -3,7 +3,9 @@ package synchronization
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 
+	"github.com/mutagen-io/mutagen/pkg/logging"
 	"github.com/mutagen-io/mutagen/pkg/selection"
 	"github.com/mutagen-io/mutagen/pkg/url"
 )
@@ -137,6 +139,49 @@ func (r *ListResponse) EnsureValid() error {
 	return nil
 }
 
+// ensureValid verifies that a LogsRequest is valid.
+func (r *LogsRequest) ensureValid() error {
+	// A nil logs request is not valid.
+	if r == nil {
+		return errors.New("nil logs request")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// Ensure that the log level, if specified, is valid.
+	if r.Level != "" {
+		if _, ok := logging.NameToLevel(r.Level); !ok {
+			return fmt.Errorf("invalid log level: %s", r.Level)
+		}
+	}
+
+	// There's no need to validate the index - any value is valid.
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a LogsResponse is valid.
+func (r *LogsResponse) EnsureValid() error {
+	// A nil logs response is not valid.
+	if r == nil {
+		return errors.New("nil logs response")
+	}
+
+	// Ensure that all entries are valid.
+	for _, e := range r.Entries {
+		if err := e.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid log entry: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid verifies that a FlushRequest is valid.
 func (r *FlushRequest) ensureValid() error {
 	// A nil flush request is not valid.
@@ -267,6 +312,180 @@ func (r *ResetResponse) EnsureValid() error {
 	return nil
 }
 
+// ensureValid verifies that a RestoreRequest is valid.
+func (r *RestoreRequest) ensureValid() error {
+	// A nil restore request is not valid.
+	if r == nil {
+		return errors.New("nil restore request")
+	}
+
+	// Ensure that a prompter has been specified.
+	if r.Prompter == "" {
+		return errors.New("no prompter specified")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// Ensure that the restoration time is valid.
+	if err := r.Before.CheckValid(); err != nil {
+		return fmt.Errorf("invalid restoration time: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a RestoreResponse is valid.
+func (r *RestoreResponse) EnsureValid() error {
+	// A nil restore response is not valid.
+	if r == nil {
+		return errors.New("nil restore response")
+	}
+
+	// Ensure that all results are valid.
+	for _, result := range r.Results {
+		if err := result.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid restore result: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that a LabelRequest is valid.
+func (r *LabelRequest) ensureValid() error {
+	// A nil label request is not valid.
+	if r == nil {
+		return errors.New("nil label request")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// Ensure that a name is only specified when renaming and that it's valid.
+	if r.Rename {
+		if err := selection.EnsureNameValid(r.Name); err != nil {
+			return fmt.Errorf("invalid name: %w", err)
+		}
+	} else if r.Name != "" {
+		return errors.New("name specified without rename")
+	}
+
+	// Verify that labels are valid.
+	for k, v := range r.Labels {
+		if err := selection.EnsureLabelKeyValid(k); err != nil {
+			return fmt.Errorf("invalid label key: %w", err)
+		} else if err = selection.EnsureLabelValueValid(v); err != nil {
+			return fmt.Errorf("invalid label value: %w", err)
+		}
+	}
+
+	// Verify that removed label keys are valid.
+	for _, k := range r.RemovedLabels {
+		if err := selection.EnsureLabelKeyValid(k); err != nil {
+			return fmt.Errorf("invalid removed label key: %w", err)
+		}
+	}
+
+	// Ensure that some change has been requested.
+	if !r.Rename && len(r.Labels) == 0 && len(r.RemovedLabels) == 0 {
+		return errors.New("no name or label changes specified")
+	}
+
+	// Any value of Overwrite is considered valid.
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that a LabelResponse is valid.
+func (r *LabelResponse) EnsureValid() error {
+	// A nil label response is not valid.
+	if r == nil {
+		return errors.New("nil label response")
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that an ExportRequest is valid.
+func (r *ExportRequest) ensureValid() error {
+	// A nil export request is not valid.
+	if r == nil {
+		return errors.New("nil export request")
+	}
+
+	// Ensure that the session selection is valid.
+	if err := r.Selection.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid selection specification: %w", err)
+	}
+
+	// Ensure that the export path is absolute.
+	if !filepath.IsAbs(r.Path) {
+		return errors.New("export path is not absolute")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that an ExportResponse is valid.
+func (r *ExportResponse) EnsureValid() error {
+	// A nil export response is not valid.
+	if r == nil {
+		return errors.New("nil export response")
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid verifies that an ImportRequest is valid.
+func (r *ImportRequest) ensureValid() error {
+	// A nil import request is not valid.
+	if r == nil {
+		return errors.New("nil import request")
+	}
+
+	// Ensure that a prompter has been specified.
+	if r.Prompter == "" {
+		return errors.New("no prompter specified")
+	}
+
+	// Ensure that the export path is absolute.
+	if !filepath.IsAbs(r.Path) {
+		return errors.New("export path is not absolute")
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid verifies that an ImportResponse is valid.
+func (r *ImportResponse) EnsureValid() error {
+	// A nil import response is not valid.
+	if r == nil {
+		return errors.New("nil import response")
+	}
+
+	// Ensure that all session identifiers are non-empty.
+	for _, session := range r.Sessions {
+		if session == "" {
+			return errors.New("empty session identifier")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid verifies that a TerminateRequest is valid.
 func (r *TerminateRequest) ensureValid() error {
 	// A nil terminate request is not valid.
@@ -26,6 +26,8 @@ type SynchronizationClient interface {
 	Create(ctx context.Context, in *CreateRequest, opts ...grpc.CallOption) (*CreateResponse, error)
 	// List returns metadata for existing sessions.
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	// Logs returns captured logs for a session.
+	Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error)
 	// Flush flushes sessions.
 	Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error)
 	// Pause pauses sessions.
@@ -34,6 +36,14 @@ type SynchronizationClient interface {
 	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
 	// Reset resets sessions' histories.
 	Reset(ctx context.Context, in *ResetRequest, opts ...grpc.CallOption) (*ResetResponse, error)
+	// Restore restores snapshotted content in sessions.
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	// Label changes the names and labels of sessions.
+	Label(ctx context.Context, in *LabelRequest, opts ...grpc.CallOption) (*LabelResponse, error)
+	// Export exports session definitions.
+	Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error)
+	// Import creates sessions from exported session definitions.
+	Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportResponse, error)
 	// Terminate terminates sessions.
 	Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResponse, error)
 }
@@ -64,6 +74,15 @@ func (c *synchronizationClient) List(ctx context.Context, in *ListRequest, opts
 	return out, nil
 }
 
+func (c *synchronizationClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (*LogsResponse, error) {
+	out := new(LogsResponse)
+	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Logs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *synchronizationClient) Flush(ctx context.Context, in *FlushRequest, opts ...grpc.CallOption) (*FlushResponse, error) {
 	out := new(FlushResponse)
 	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Flush", in, out, opts...)
@@ -100,6 +119,42 @@ func (c *synchronizationClient) Reset(ctx context.Context, in *ResetRequest, opt
 	return out, nil
 }
 
+func (c *synchronizationClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synchronizationClient) Label(ctx context.Context, in *LabelRequest, opts ...grpc.CallOption) (*LabelResponse, error) {
+	out := new(LabelResponse)
+	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Label", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synchronizationClient) Export(ctx context.Context, in *ExportRequest, opts ...grpc.CallOption) (*ExportResponse, error) {
+	out := new(ExportResponse)
+	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Export", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synchronizationClient) Import(ctx context.Context, in *ImportRequest, opts ...grpc.CallOption) (*ImportResponse, error) {
+	out := new(ImportResponse)
+	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Import", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *synchronizationClient) Terminate(ctx context.Context, in *TerminateRequest, opts ...grpc.CallOption) (*TerminateResponse, error) {
 	out := new(TerminateResponse)
 	err := c.cc.Invoke(ctx, "/synchronization.Synchronization/Terminate", in, out, opts...)
@@ -117,6 +172,8 @@ type SynchronizationServer interface {
 	Create(context.Context, *CreateRequest) (*CreateResponse, error)
 	// List returns metadata for existing sessions.
 	List(context.Context, *ListRequest) (*ListResponse, error)
+	// Logs returns captured logs for a session.
+	Logs(context.Context, *LogsRequest) (*LogsResponse, error)
 	// Flush flushes sessions.
 	Flush(context.Context, *FlushRequest) (*FlushResponse, error)
 	// Pause pauses sessions.
@@ -125,6 +182,14 @@ type SynchronizationServer interface {
 	Resume(context.Context, *ResumeRequest) (*ResumeResponse, error)
 	// Reset resets sessions' histories.
 	Reset(context.Context, *ResetRequest) (*ResetResponse, error)
+	// Restore restores snapshotted content in sessions.
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	// Label changes the names and labels of sessions.
+	Label(context.Context, *LabelRequest) (*LabelResponse, error)
+	// Export exports session definitions.
+	Export(context.Context, *ExportRequest) (*ExportResponse, error)
+	// Import creates sessions from exported session definitions.
+	Import(context.Context, *ImportRequest) (*ImportResponse, error)
 	// Terminate terminates sessions.
 	Terminate(context.Context, *TerminateRequest) (*TerminateResponse, error)
 	mustEmbedUnimplementedSynchronizationServer()
@@ -140,6 +205,9 @@ func (UnimplementedSynchronizationServer) Create(context.Context, *CreateRequest
 func (UnimplementedSynchronizationServer) List(context.Context, *ListRequest) (*ListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedSynchronizationServer) Logs(context.Context, *LogsRequest) (*LogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logs not implemented")
+}
 func (UnimplementedSynchronizationServer) Flush(context.Context, *FlushRequest) (*FlushResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Flush not implemented")
 }
@@ -152,6 +220,18 @@ func (UnimplementedSynchronizationServer) Resume(context.Context, *ResumeRequest
 func (UnimplementedSynchronizationServer) Reset(context.Context, *ResetRequest) (*ResetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Reset not implemented")
 }
+func (UnimplementedSynchronizationServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedSynchronizationServer) Label(context.Context, *LabelRequest) (*LabelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Label not implemented")
+}
+func (UnimplementedSynchronizationServer) Export(context.Context, *ExportRequest) (*ExportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Export not implemented")
+}
+func (UnimplementedSynchronizationServer) Import(context.Context, *ImportRequest) (*ImportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Import not implemented")
+}
 func (UnimplementedSynchronizationServer) Terminate(context.Context, *TerminateRequest) (*TerminateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Terminate not implemented")
 }
@@ -204,6 +284,24 @@ func _Synchronization_List_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Synchronization_Logs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).Logs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.Synchronization/Logs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).Logs(ctx, req.(*LogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Synchronization_Flush_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FlushRequest)
 	if err := dec(in); err != nil {
@@ -276,6 +374,78 @@ func _Synchronization_Reset_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Synchronization_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.Synchronization/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Synchronization_Label_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).Label(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.Synchronization/Label",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).Label(ctx, req.(*LabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Synchronization_Export_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).Export(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.Synchronization/Export",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).Export(ctx, req.(*ExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Synchronization_Import_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynchronizationServer).Import(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/synchronization.Synchronization/Import",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynchronizationServer).Import(ctx, req.(*ImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Synchronization_Terminate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TerminateRequest)
 	if err := dec(in); err != nil {
@@ -309,6 +479,10 @@ var Synchronization_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _Synchronization_List_Handler,
 		},
+		{
+			MethodName: "Logs",
+			Handler:    _Synchronization_Logs_Handler,
+		},
 		{
 			MethodName: "Flush",
 			Handler:    _Synchronization_Flush_Handler,
@@ -325,6 +499,22 @@ var Synchronization_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Reset",
 			Handler:    _Synchronization_Reset_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _Synchronization_Restore_Handler,
+		},
+		{
+			MethodName: "Label",
+			Handler:    _Synchronization_Label_Handler,
+		},
+		{
+			MethodName: "Export",
+			Handler:    _Synchronization_Export_Handler,
+		},
+		{
+			MethodName: "Import",
+			Handler:    _Synchronization_Import_Handler,
+		},
 		{
 			MethodName: "Terminate",
 			Handler:    _Synchronization_Terminate_Handler,
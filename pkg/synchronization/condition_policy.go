@@ -0,0 +1,78 @@
+package synchronization
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the condition policy is
+// ConditionPolicy_ConditionPolicyDefault.
+func (p ConditionPolicy) IsDefault() bool {
+	return p == ConditionPolicy_ConditionPolicyDefault
+}
+
+// Pauses indicates whether or not the condition policy is
+// ConditionPolicy_ConditionPolicyPause.
+func (p ConditionPolicy) Pauses() bool {
+	return p == ConditionPolicy_ConditionPolicyPause
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (p ConditionPolicy) MarshalText() ([]byte, error) {
+	var result string
+	switch p {
+	case ConditionPolicy_ConditionPolicyDefault:
+	case ConditionPolicy_ConditionPolicyIgnore:
+		result = "ignore"
+	case ConditionPolicy_ConditionPolicyPause:
+		result = "pause"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (p *ConditionPolicy) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a condition policy.
+	switch text {
+	case "ignore":
+		*p = ConditionPolicy_ConditionPolicyIgnore
+	case "pause":
+		*p = ConditionPolicy_ConditionPolicyPause
+	default:
+		return fmt.Errorf("unknown condition policy specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular condition policy is a valid,
+// non-default value.
+func (p ConditionPolicy) Supported() bool {
+	switch p {
+	case ConditionPolicy_ConditionPolicyIgnore:
+		return true
+	case ConditionPolicy_ConditionPolicyPause:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a condition policy.
+func (p ConditionPolicy) Description() string {
+	switch p {
+	case ConditionPolicy_ConditionPolicyDefault:
+		return "Default"
+	case ConditionPolicy_ConditionPolicyIgnore:
+		return "Ignore"
+	case ConditionPolicy_ConditionPolicyPause:
+		return "Pause"
+	default:
+		return "Unknown"
+	}
+}
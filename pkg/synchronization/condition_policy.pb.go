@@ -0,0 +1,147 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: synchronization/condition_policy.proto
+
+package synchronization
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ConditionPolicy specifies how a session should respond to a host condition
+// (such as running on battery power or using a metered network connection).
+type ConditionPolicy int32
+
+const (
+	// ConditionPolicy_ConditionPolicyDefault represents an unspecified
+	// condition policy. It should be converted to one of the following values
+	// based on the desired default behavior.
+	ConditionPolicy_ConditionPolicyDefault ConditionPolicy = 0
+	// ConditionPolicy_ConditionPolicyIgnore specifies that the condition should
+	// not affect synchronization.
+	ConditionPolicy_ConditionPolicyIgnore ConditionPolicy = 1
+	// ConditionPolicy_ConditionPolicyPause specifies that synchronization
+	// should be suspended while the condition holds.
+	ConditionPolicy_ConditionPolicyPause ConditionPolicy = 2
+)
+
+// Enum value maps for ConditionPolicy.
+var (
+	ConditionPolicy_name = map[int32]string{
+		0: "ConditionPolicyDefault",
+		1: "ConditionPolicyIgnore",
+		2: "ConditionPolicyPause",
+	}
+	ConditionPolicy_value = map[string]int32{
+		"ConditionPolicyDefault": 0,
+		"ConditionPolicyIgnore":  1,
+		"ConditionPolicyPause":   2,
+	}
+)
+
+func (x ConditionPolicy) Enum() *ConditionPolicy {
+	p := new(ConditionPolicy)
+	*p = x
+	return p
+}
+
+func (x ConditionPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConditionPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_synchronization_condition_policy_proto_enumTypes[0].Descriptor()
+}
+
+func (ConditionPolicy) Type() protoreflect.EnumType {
+	return &file_synchronization_condition_policy_proto_enumTypes[0]
+}
+
+func (x ConditionPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConditionPolicy.Descriptor instead.
+func (ConditionPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_synchronization_condition_policy_proto_rawDescGZIP(), []int{0}
+}
+
+var File_synchronization_condition_policy_proto protoreflect.FileDescriptor
+
+var file_synchronization_condition_policy_proto_rawDesc = []byte{
+	0x0a, 0x26, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72,
+	0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2a, 0x62, 0x0a, 0x0f, 0x43, 0x6f, 0x6e,
+	0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1a, 0x0a, 0x16,
+	0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x43, 0x6f, 0x6e, 0x64,
+	0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x49, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x50, 0x61, 0x75, 0x73, 0x65, 0x10, 0x02, 0x42, 0x33, 0x5a,
+	0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61,
+	0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70,
+	0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_synchronization_condition_policy_proto_rawDescOnce sync.Once
+	file_synchronization_condition_policy_proto_rawDescData = file_synchronization_condition_policy_proto_rawDesc
+)
+
+func file_synchronization_condition_policy_proto_rawDescGZIP() []byte {
+	file_synchronization_condition_policy_proto_rawDescOnce.Do(func() {
+		file_synchronization_condition_policy_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_condition_policy_proto_rawDescData)
+	})
+	return file_synchronization_condition_policy_proto_rawDescData
+}
+
+var file_synchronization_condition_policy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_synchronization_condition_policy_proto_goTypes = []interface{}{
+	(ConditionPolicy)(0), // 0: synchronization.ConditionPolicy
+}
+var file_synchronization_condition_policy_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_condition_policy_proto_init() }
+func file_synchronization_condition_policy_proto_init() {
+	if File_synchronization_condition_policy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_condition_policy_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_condition_policy_proto_goTypes,
+		DependencyIndexes: file_synchronization_condition_policy_proto_depIdxs,
+		EnumInfos:         file_synchronization_condition_policy_proto_enumTypes,
+	}.Build()
+	File_synchronization_condition_policy_proto = out.File
+	file_synchronization_condition_policy_proto_rawDesc = nil
+	file_synchronization_condition_policy_proto_goTypes = nil
+	file_synchronization_condition_policy_proto_depIdxs = nil
+}
@@ -0,0 +1,91 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestConditionPolicyUnmarshal tests that unmarshaling from a string
+// specification succeeeds for ConditionPolicy.
+func TestConditionPolicyUnmarshal(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		text           string
+		expectedPolicy ConditionPolicy
+		expectFailure  bool
+	}{
+		{"", ConditionPolicy_ConditionPolicyDefault, true},
+		{"asdf", ConditionPolicy_ConditionPolicyDefault, true},
+		{"ignore", ConditionPolicy_ConditionPolicyIgnore, false},
+		{"pause", ConditionPolicy_ConditionPolicyPause, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		var policy ConditionPolicy
+		if err := policy.UnmarshalText([]byte(testCase.text)); err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", testCase.text, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", testCase.text)
+		} else if policy != testCase.expectedPolicy {
+			t.Errorf(
+				"unmarshaled policy (%s) does not match expected (%s)",
+				policy,
+				testCase.expectedPolicy,
+			)
+		}
+	}
+}
+
+// TestConditionPolicySupported tests that ConditionPolicy support detection
+// works as expected.
+func TestConditionPolicySupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		policy          ConditionPolicy
+		expectSupported bool
+	}{
+		{ConditionPolicy_ConditionPolicyDefault, false},
+		{ConditionPolicy_ConditionPolicyIgnore, true},
+		{ConditionPolicy_ConditionPolicyPause, true},
+		{(ConditionPolicy_ConditionPolicyPause + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.policy.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"policy support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestConditionPolicyDescription tests that ConditionPolicy description
+// generation works as expected.
+func TestConditionPolicyDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		policy              ConditionPolicy
+		expectedDescription string
+	}{
+		{ConditionPolicy_ConditionPolicyDefault, "Default"},
+		{ConditionPolicy_ConditionPolicyIgnore, "Ignore"},
+		{ConditionPolicy_ConditionPolicyPause, "Pause"},
+		{(ConditionPolicy_ConditionPolicyPause + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.policy.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"policy description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -9,6 +9,12 @@ import (
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 )
 
+// MaximumScanParallelism is the maximum scan parallelism that can be specified
+// in a configuration. Each unit of parallelism allocates a scanning worker with
+// its own hasher and buffers, so this bounds the resources that a configuration
+// (possibly received from another endpoint) can cause a scan to allocate.
+const MaximumScanParallelism = 256
+
 // EnsureValid ensures that Configuration's invariants are respected. The
 // validation of the configuration depends on whether or not it is
 // endpoint-specific.
@@ -35,6 +41,9 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 	// The maximum staging file size doesn't need to be validated - any of its
 	// values are technically valid regardless of the source.
 
+	// The in-place update threshold doesn't need to be validated - any of its
+	// values are technically valid regardless of the source.
+
 	// Verify that the probe mode is unspecified or supported for usage.
 	if !(c.ProbeMode.IsDefault() || c.ProbeMode.Supported()) {
 		return errors.New("unknown or unsupported probe mode")
@@ -45,6 +54,11 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		return errors.New("unknown or unsupported scan mode")
 	}
 
+	// Verify that the scan parallelism is within bounds.
+	if c.ScanParallelism > MaximumScanParallelism {
+		return fmt.Errorf("scan parallelism exceeds maximum (%d)", MaximumScanParallelism)
+	}
+
 	// Verify that the staging mode is unspecified or supported for usage.
 	if !(c.StageMode.IsDefault() || c.StageMode.Supported()) {
 		return errors.New("unknown or unsupported staging mode")
@@ -105,6 +119,28 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		}
 	}
 
+	// Verify that ignore file names are unset for endpoint-specific
+	// configurations and that any specified names are valid.
+	if endpointSpecific && len(c.IgnoreFiles) > 0 {
+		return errors.New("ignore files cannot be specified on an endpoint-specific basis")
+	}
+	for _, name := range c.IgnoreFiles {
+		if !core.ValidIgnoreFileName(name) {
+			return fmt.Errorf("invalid ignore file name: %s", name)
+		}
+	}
+
+	// Verify that include paths are unset for endpoint-specific configurations
+	// and that any specified paths are valid.
+	if endpointSpecific && len(c.Includes) > 0 {
+		return errors.New("include paths cannot be specified on an endpoint-specific basis")
+	}
+	for _, include := range c.Includes {
+		if !core.ValidIncludePath(include) {
+			return fmt.Errorf("invalid include path: %s", include)
+		}
+	}
+
 	// Verify that the permissions mode is unspecified or supported for usage.
 	// Also determine the effective permissions mode for validating file and
 	// directory modes.
@@ -164,6 +200,113 @@ func (c *Configuration) EnsureValid(endpointSpecific bool) error {
 		}
 	}
 
+	// Verify that the hard link mode is unspecified or supported for usage.
+	if endpointSpecific {
+		if !c.HardLinkMode.IsDefault() {
+			return errors.New("hard link mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.HardLinkMode.IsDefault() || c.HardLinkMode.Supported()) {
+			return errors.New("unknown or unsupported hard link mode")
+		}
+	}
+
+	// Verify that the name collision policy is unspecified or supported for
+	// usage.
+	if endpointSpecific {
+		if !c.NameCollisionPolicy.IsDefault() {
+			return errors.New("name collision policy cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.NameCollisionPolicy.IsDefault() || c.NameCollisionPolicy.Supported()) {
+			return errors.New("unknown or unsupported name collision policy")
+		}
+	}
+
+	// The maximum snapshot size doesn't need to be validated - any of its
+	// values are technically valid regardless of the source.
+
+	// Verify that the modification time mode is unspecified or supported for
+	// usage.
+	if endpointSpecific {
+		if !c.ModificationTimeMode.IsDefault() {
+			return errors.New("modification time mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.ModificationTimeMode.IsDefault() || c.ModificationTimeMode.Supported()) {
+			return errors.New("unknown or unsupported modification time mode")
+		}
+	}
+
+	// Verify that extended attribute patterns are unspecified or valid.
+	if endpointSpecific {
+		if len(c.ExtendedAttributes) > 0 {
+			return errors.New("extended attributes cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		for _, pattern := range c.ExtendedAttributes {
+			if !core.ValidExtendedAttributePattern(pattern) {
+				return fmt.Errorf("invalid extended attribute pattern: %s", pattern)
+			}
+		}
+	}
+
+	// Verify that the background prompting mode is unspecified or supported for
+	// usage.
+	if endpointSpecific {
+		if !c.BackgroundPromptingMode.IsDefault() {
+			return errors.New("background prompting mode cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.BackgroundPromptingMode.IsDefault() || c.BackgroundPromptingMode.Supported()) {
+			return errors.New("unknown or unsupported background prompting mode")
+		}
+	}
+
+	// Verify that the battery policy is unspecified or supported for usage.
+	if endpointSpecific {
+		if !c.BatteryPolicy.IsDefault() {
+			return errors.New("battery policy cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.BatteryPolicy.IsDefault() || c.BatteryPolicy.Supported()) {
+			return errors.New("unknown or unsupported battery policy")
+		}
+	}
+
+	// Verify that the metered connection policy is unspecified or supported
+	// for usage.
+	if endpointSpecific {
+		if !c.MeteredPolicy.IsDefault() {
+			return errors.New("metered connection policy cannot be specified on an endpoint-specific basis")
+		}
+	} else {
+		if !(c.MeteredPolicy.IsDefault() || c.MeteredPolicy.Supported()) {
+			return errors.New("unknown or unsupported metered connection policy")
+		}
+	}
+
+	// Verify that active hours are unspecified or valid.
+	if endpointSpecific {
+		if c.ActiveHours != "" {
+			return errors.New("active hours cannot be specified on an endpoint-specific basis")
+		}
+	} else if c.ActiveHours != "" {
+		if _, err := ParseActiveHours(c.ActiveHours); err != nil {
+			return fmt.Errorf("invalid active hours: %w", err)
+		}
+	}
+
+	// Verify that disconnection policy parameters are unspecified. Any of
+	// their values are otherwise valid.
+	if endpointSpecific {
+		if c.DisconnectedTimeout != 0 {
+			return errors.New("disconnected timeout cannot be specified on an endpoint-specific basis")
+		} else if c.DisconnectedRetryInterval != 0 {
+			return errors.New("disconnected retry interval cannot be specified on an endpoint-specific basis")
+		}
+	}
+
 	// Success.
 	return nil
 }
@@ -182,6 +325,10 @@ func (c *Configuration) Equal(other *Configuration) bool {
 		c.MaximumStagingFileSize == other.MaximumStagingFileSize &&
 		c.ProbeMode == other.ProbeMode &&
 		c.ScanMode == other.ScanMode &&
+		c.ScanParallelism == other.ScanParallelism &&
+		c.HardLinkMode == other.HardLinkMode &&
+		c.InPlaceUpdateThreshold == other.InPlaceUpdateThreshold &&
+		c.NameCollisionPolicy == other.NameCollisionPolicy &&
 		c.StageMode == other.StageMode &&
 		c.SymbolicLinkMode == other.SymbolicLinkMode &&
 		c.WatchMode == other.WatchMode &&
@@ -189,11 +336,22 @@ func (c *Configuration) Equal(other *Configuration) bool {
 		comparison.StringSlicesEqual(c.DefaultIgnores, other.DefaultIgnores) &&
 		comparison.StringSlicesEqual(c.Ignores, other.Ignores) &&
 		c.IgnoreVCSMode == other.IgnoreVCSMode &&
+		comparison.StringSlicesEqual(c.IgnoreFiles, other.IgnoreFiles) &&
+		comparison.StringSlicesEqual(c.Includes, other.Includes) &&
 		c.PermissionsMode == other.PermissionsMode &&
 		c.DefaultFileMode == other.DefaultFileMode &&
 		c.DefaultDirectoryMode == other.DefaultDirectoryMode &&
 		c.DefaultOwner == other.DefaultOwner &&
-		c.DefaultGroup == other.DefaultGroup
+		c.DefaultGroup == other.DefaultGroup &&
+		c.MaximumSnapshotSize == other.MaximumSnapshotSize &&
+		c.ModificationTimeMode == other.ModificationTimeMode &&
+		comparison.StringSlicesEqual(c.ExtendedAttributes, other.ExtendedAttributes) &&
+		c.BackgroundPromptingMode == other.BackgroundPromptingMode &&
+		c.BatteryPolicy == other.BatteryPolicy &&
+		c.MeteredPolicy == other.MeteredPolicy &&
+		c.ActiveHours == other.ActiveHours &&
+		c.DisconnectedTimeout == other.DisconnectedTimeout &&
+		c.DisconnectedRetryInterval == other.DisconnectedRetryInterval
 }
 
 // MergeConfigurations merges two configurations of differing priorities. Both
@@ -237,6 +395,13 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.ScanMode = lower.ScanMode
 	}
 
+	// Merge scan parallelism.
+	if higher.ScanParallelism != 0 {
+		result.ScanParallelism = higher.ScanParallelism
+	} else {
+		result.ScanParallelism = lower.ScanParallelism
+	}
+
 	// Merge staging mode.
 	if !higher.StageMode.IsDefault() {
 		result.StageMode = higher.StageMode
@@ -244,6 +409,13 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.StageMode = lower.StageMode
 	}
 
+	// Merge in-place update threshold.
+	if higher.InPlaceUpdateThreshold != 0 {
+		result.InPlaceUpdateThreshold = higher.InPlaceUpdateThreshold
+	} else {
+		result.InPlaceUpdateThreshold = lower.InPlaceUpdateThreshold
+	}
+
 	// Merge symbolic link mode.
 	if !higher.SymbolicLinkMode.IsDefault() {
 		result.SymbolicLinkMode = higher.SymbolicLinkMode
@@ -282,6 +454,21 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.IgnoreVCSMode = lower.IgnoreVCSMode
 	}
 
+	// Merge ignore files.
+	if len(higher.IgnoreFiles) > 0 {
+		result.IgnoreFiles = higher.IgnoreFiles
+	} else {
+		result.IgnoreFiles = lower.IgnoreFiles
+	}
+
+	// Merge include paths. We don't combine these since doing so would widen
+	// the scope of synchronization rather than overriding it.
+	if len(higher.Includes) > 0 {
+		result.Includes = higher.Includes
+	} else {
+		result.Includes = lower.Includes
+	}
+
 	// Merge permissions mode.
 	if !higher.PermissionsMode.IsDefault() {
 		result.PermissionsMode = higher.PermissionsMode
@@ -317,6 +504,83 @@ func MergeConfigurations(lower, higher *Configuration) *Configuration {
 		result.DefaultGroup = lower.DefaultGroup
 	}
 
+	// Merge maximum snapshot size.
+	if higher.MaximumSnapshotSize != 0 {
+		result.MaximumSnapshotSize = higher.MaximumSnapshotSize
+	} else {
+		result.MaximumSnapshotSize = lower.MaximumSnapshotSize
+	}
+
+	// Merge hard link mode.
+	if !higher.HardLinkMode.IsDefault() {
+		result.HardLinkMode = higher.HardLinkMode
+	} else {
+		result.HardLinkMode = lower.HardLinkMode
+	}
+
+	// Merge name collision policy.
+	if !higher.NameCollisionPolicy.IsDefault() {
+		result.NameCollisionPolicy = higher.NameCollisionPolicy
+	} else {
+		result.NameCollisionPolicy = lower.NameCollisionPolicy
+	}
+
+	// Merge modification time mode.
+	if !higher.ModificationTimeMode.IsDefault() {
+		result.ModificationTimeMode = higher.ModificationTimeMode
+	} else {
+		result.ModificationTimeMode = lower.ModificationTimeMode
+	}
+
+	// Merge extended attribute patterns.
+	if len(higher.ExtendedAttributes) > 0 {
+		result.ExtendedAttributes = higher.ExtendedAttributes
+	} else {
+		result.ExtendedAttributes = lower.ExtendedAttributes
+	}
+
+	// Merge background prompting mode.
+	if !higher.BackgroundPromptingMode.IsDefault() {
+		result.BackgroundPromptingMode = higher.BackgroundPromptingMode
+	} else {
+		result.BackgroundPromptingMode = lower.BackgroundPromptingMode
+	}
+
+	// Merge battery policy.
+	if !higher.BatteryPolicy.IsDefault() {
+		result.BatteryPolicy = higher.BatteryPolicy
+	} else {
+		result.BatteryPolicy = lower.BatteryPolicy
+	}
+
+	// Merge metered connection policy.
+	if !higher.MeteredPolicy.IsDefault() {
+		result.MeteredPolicy = higher.MeteredPolicy
+	} else {
+		result.MeteredPolicy = lower.MeteredPolicy
+	}
+
+	// Merge active hours.
+	if higher.ActiveHours != "" {
+		result.ActiveHours = higher.ActiveHours
+	} else {
+		result.ActiveHours = lower.ActiveHours
+	}
+
+	// Merge disconnected timeout.
+	if higher.DisconnectedTimeout != 0 {
+		result.DisconnectedTimeout = higher.DisconnectedTimeout
+	} else {
+		result.DisconnectedTimeout = lower.DisconnectedTimeout
+	}
+
+	// Merge disconnected retry interval.
+	if higher.DisconnectedRetryInterval != 0 {
+		result.DisconnectedRetryInterval = higher.DisconnectedRetryInterval
+	} else {
+		result.DisconnectedRetryInterval = lower.DisconnectedRetryInterval
+	}
+
 	// Done.
 	return result
 }
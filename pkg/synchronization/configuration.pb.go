@@ -8,6 +8,7 @@ package synchronization
 
 import (
 	behavior "github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
+	prompting "github.com/mutagen-io/mutagen/pkg/prompting"
 	core "github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -46,6 +47,24 @@ type Configuration struct {
 	ScanMode ScanMode `protobuf:"varint,15,opt,name=scanMode,proto3,enum=synchronization.ScanMode" json:"scanMode,omitempty"`
 	// StageMode specifies the file staging mode.
 	StageMode StageMode `protobuf:"varint,16,opt,name=stageMode,proto3,enum=synchronization.StageMode" json:"stageMode,omitempty"`
+	// ScanParallelism specifies the number of workers that endpoints will use
+	// to traverse directories and compute file digests when scanning. A value
+	// of 0 specifies that the default parallelism should be used.
+	ScanParallelism uint32 `protobuf:"varint,17,opt,name=scanParallelism,proto3" json:"scanParallelism,omitempty"`
+	// HardLinkMode specifies the manner in which hard links should be handled
+	// during scanning, staging, and transitioning.
+	HardLinkMode core.HardLinkMode `protobuf:"varint,18,opt,name=hardLinkMode,proto3,enum=core.HardLinkMode" json:"hardLinkMode,omitempty"`
+	// InPlaceUpdateThreshold specifies the minimum size of existing files that
+	// endpoints will update in place. Updated content for such files is staged
+	// as a block-level delta against the existing file and then applied
+	// directly to it, avoiding the need to store an additional copy of large
+	// files. In-place updates are not atomic, though files are restored to
+	// their original content if an update fails. A zero value disables in-place
+	// updates.
+	InPlaceUpdateThreshold uint64 `protobuf:"varint,19,opt,name=inPlaceUpdateThreshold,proto3" json:"inPlaceUpdateThreshold,omitempty"`
+	// NameCollisionPolicy specifies the policy for handling names that would
+	// collide on an endpoint that ignores case or Unicode normalization.
+	NameCollisionPolicy core.NameCollisionPolicy `protobuf:"varint,20,opt,name=nameCollisionPolicy,proto3,enum=core.NameCollisionPolicy" json:"nameCollisionPolicy,omitempty"`
 	// SymbolicLinkMode specifies the symbolic link mode.
 	SymbolicLinkMode core.SymbolicLinkMode `protobuf:"varint,1,opt,name=symbolicLinkMode,proto3,enum=core.SymbolicLinkMode" json:"symbolicLinkMode,omitempty"`
 	// WatchMode specifies the filesystem watching mode.
@@ -66,6 +85,18 @@ type Configuration struct {
 	// IgnoreVCSMode specifies the VCS ignore mode that should be used in
 	// synchronization.
 	IgnoreVCSMode core.IgnoreVCSMode `protobuf:"varint,33,opt,name=ignoreVCSMode,proto3,enum=core.IgnoreVCSMode" json:"ignoreVCSMode,omitempty"`
+	// IgnoreFiles specifies the names of per-directory ignore files (e.g.
+	// .gitignore) whose patterns should be loaded during scanning and applied
+	// to the subtree rooted at the directory containing them. These patterns
+	// take precedence over ignores specified in configuration, with patterns
+	// from more deeply nested files taking precedence over those from their
+	// ancestors.
+	IgnoreFiles []string `protobuf:"bytes,34,rep,name=ignoreFiles,proto3" json:"ignoreFiles,omitempty"`
+	// Includes specifies synchronization root-relative paths to which
+	// synchronization should be limited. If non-empty, then only content at or
+	// beneath these paths is synchronized and content not along the path to
+	// them is neither traversed nor modified.
+	Includes []string `protobuf:"bytes,35,rep,name=includes,proto3" json:"includes,omitempty"`
 	// PermissionsMode species the manner in which permissions should be
 	// propagated between endpoints.
 	PermissionsMode core.PermissionsMode `protobuf:"varint,61,opt,name=permissionsMode,proto3,enum=core.PermissionsMode" json:"permissionsMode,omitempty"`
@@ -83,6 +114,44 @@ type Configuration struct {
 	// ownership of new files and directories in "portable" permission
 	// propagation mode.
 	DefaultGroup string `protobuf:"bytes,66,opt,name=defaultGroup,proto3" json:"defaultGroup,omitempty"`
+	// MaximumSnapshotSize specifies the maximum total size of file contents
+	// that an endpoint will retain in its snapshot store for point-in-time
+	// restoration. A zero value indicates that snapshots are disabled.
+	MaximumSnapshotSize uint64 `protobuf:"varint,81,opt,name=maximumSnapshotSize,proto3" json:"maximumSnapshotSize,omitempty"`
+	// ModificationTimeMode specifies the manner in which file modification
+	// times should be propagated between endpoints.
+	ModificationTimeMode core.ModificationTimeMode `protobuf:"varint,91,opt,name=modificationTimeMode,proto3,enum=core.ModificationTimeMode" json:"modificationTimeMode,omitempty"`
+	// ExtendedAttributes specifies name patterns for extended attributes that
+	// should be propagated between endpoints. POSIX ACLs can be propagated by
+	// including their corresponding extended attribute names (e.g.
+	// "system.posix_acl_access"). An empty list disables extended attribute
+	// propagation.
+	ExtendedAttributes []string `protobuf:"bytes,92,rep,name=extendedAttributes,proto3" json:"extendedAttributes,omitempty"`
+	// BackgroundPromptingMode specifies whether or not automatic reconnections
+	// performed in the background may use the daemon's default prompter.
+	BackgroundPromptingMode prompting.BackgroundPromptingMode `protobuf:"varint,101,opt,name=backgroundPromptingMode,proto3,enum=prompting.BackgroundPromptingMode" json:"backgroundPromptingMode,omitempty"`
+	// BatteryPolicy specifies how the session should respond to the daemon's
+	// host running on battery power.
+	BatteryPolicy ConditionPolicy `protobuf:"varint,111,opt,name=batteryPolicy,proto3,enum=synchronization.ConditionPolicy" json:"batteryPolicy,omitempty"`
+	// MeteredPolicy specifies how the session should respond to the daemon's
+	// host using a metered network connection.
+	MeteredPolicy ConditionPolicy `protobuf:"varint,112,opt,name=meteredPolicy,proto3,enum=synchronization.ConditionPolicy" json:"meteredPolicy,omitempty"`
+	// ActiveHours specifies the daily time range (in the daemon's local time)
+	// during which synchronization is allowed, in the form "HH:MM-HH:MM". The
+	// range may wrap around midnight. An empty value indicates that
+	// synchronization is allowed at all times.
+	ActiveHours string `protobuf:"bytes,113,opt,name=activeHours,proto3" json:"activeHours,omitempty"`
+	// DisconnectedTimeout specifies the duration (in seconds) for which the
+	// session can remain disconnected before it's suspended, after which
+	// reconnection is only attempted every DisconnectedRetryInterval. A value
+	// of 0 indicates that the session should never be suspended due to
+	// disconnection.
+	DisconnectedTimeout uint32 `protobuf:"varint,114,opt,name=disconnectedTimeout,proto3" json:"disconnectedTimeout,omitempty"`
+	// DisconnectedRetryInterval specifies the interval (in seconds) at which
+	// reconnection is attempted once the session has been suspended due to
+	// disconnection. A value of 0 specifies that the default interval should
+	// be used.
+	DisconnectedRetryInterval uint32 `protobuf:"varint,115,opt,name=disconnectedRetryInterval,proto3" json:"disconnectedRetryInterval,omitempty"`
 }
 
 func (x *Configuration) Reset() {
@@ -159,6 +228,34 @@ func (x *Configuration) GetStageMode() StageMode {
 	return StageMode_StageModeDefault
 }
 
+func (x *Configuration) GetScanParallelism() uint32 {
+	if x != nil {
+		return x.ScanParallelism
+	}
+	return 0
+}
+
+func (x *Configuration) GetHardLinkMode() core.HardLinkMode {
+	if x != nil {
+		return x.HardLinkMode
+	}
+	return core.HardLinkMode(0)
+}
+
+func (x *Configuration) GetInPlaceUpdateThreshold() uint64 {
+	if x != nil {
+		return x.InPlaceUpdateThreshold
+	}
+	return 0
+}
+
+func (x *Configuration) GetNameCollisionPolicy() core.NameCollisionPolicy {
+	if x != nil {
+		return x.NameCollisionPolicy
+	}
+	return core.NameCollisionPolicy(0)
+}
+
 func (x *Configuration) GetSymbolicLinkMode() core.SymbolicLinkMode {
 	if x != nil {
 		return x.SymbolicLinkMode
@@ -201,6 +298,20 @@ func (x *Configuration) GetIgnoreVCSMode() core.IgnoreVCSMode {
 	return core.IgnoreVCSMode(0)
 }
 
+func (x *Configuration) GetIgnoreFiles() []string {
+	if x != nil {
+		return x.IgnoreFiles
+	}
+	return nil
+}
+
+func (x *Configuration) GetIncludes() []string {
+	if x != nil {
+		return x.Includes
+	}
+	return nil
+}
+
 func (x *Configuration) GetPermissionsMode() core.PermissionsMode {
 	if x != nil {
 		return x.PermissionsMode
@@ -236,6 +347,69 @@ func (x *Configuration) GetDefaultGroup() string {
 	return ""
 }
 
+func (x *Configuration) GetMaximumSnapshotSize() uint64 {
+	if x != nil {
+		return x.MaximumSnapshotSize
+	}
+	return 0
+}
+
+func (x *Configuration) GetModificationTimeMode() core.ModificationTimeMode {
+	if x != nil {
+		return x.ModificationTimeMode
+	}
+	return core.ModificationTimeMode(0)
+}
+
+func (x *Configuration) GetExtendedAttributes() []string {
+	if x != nil {
+		return x.ExtendedAttributes
+	}
+	return nil
+}
+
+func (x *Configuration) GetBackgroundPromptingMode() prompting.BackgroundPromptingMode {
+	if x != nil {
+		return x.BackgroundPromptingMode
+	}
+	return prompting.BackgroundPromptingMode(0)
+}
+
+func (x *Configuration) GetBatteryPolicy() ConditionPolicy {
+	if x != nil {
+		return x.BatteryPolicy
+	}
+	return ConditionPolicy_ConditionPolicyDefault
+}
+
+func (x *Configuration) GetMeteredPolicy() ConditionPolicy {
+	if x != nil {
+		return x.MeteredPolicy
+	}
+	return ConditionPolicy_ConditionPolicyDefault
+}
+
+func (x *Configuration) GetActiveHours() string {
+	if x != nil {
+		return x.ActiveHours
+	}
+	return ""
+}
+
+func (x *Configuration) GetDisconnectedTimeout() uint32 {
+	if x != nil {
+		return x.DisconnectedTimeout
+	}
+	return 0
+}
+
+func (x *Configuration) GetDisconnectedRetryInterval() uint32 {
+	if x != nil {
+		return x.DisconnectedRetryInterval
+	}
+	return 0
+}
+
 var File_synchronization_configuration_proto protoreflect.FileDescriptor
 
 var file_synchronization_configuration_proto_rawDesc = []byte{
@@ -244,84 +418,151 @@ var file_synchronization_configuration_proto_rawDesc = []byte{
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
 	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x24, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x79, 0x73, 0x74,
 	0x65, 0x6d, 0x2f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x62,
-	0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x73, 0x79,
-	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x73, 0x63,
-	0x61, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x20, 0x73,
-	0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x73,
-	0x74, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
-	0x20, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x1a, 0x2a, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x76,
-	0x63, 0x73, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x73,
-	0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63,
-	0x6f, 0x72, 0x65, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2b,
-	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
-	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2d, 0x73, 0x79, 0x6e,
+	0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x29, 0x70, 0x72,
+	0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x5f, 0x6d, 0x6f, 0x64,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x26, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x1f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2f, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x20, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x73, 0x74, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x20, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x2f, 0x77, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x29, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x5f,
+	0x6c, 0x69, 0x6e, 0x6b, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x2a, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x76, 0x63, 0x73,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x73, 0x79, 0x6e,
 	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72,
-	0x65, 0x2f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x5f, 0x6c, 0x69, 0x6e, 0x6b, 0x5f,
-	0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfc, 0x06, 0x0a, 0x0d, 0x43,
-	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x4b, 0x0a, 0x13,
-	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d,
-	0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x72, 0x65,
-	0x2e, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x4d, 0x6f, 0x64, 0x65, 0x52, 0x13, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a, 0x11, 0x6d, 0x61, 0x78,
-	0x69, 0x6d, 0x75, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0c,
-	0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x16, 0x6d, 0x61, 0x78, 0x69, 0x6d,
-	0x75, 0x6d, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a,
-	0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
-	0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12,
-	0x31, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x0e, 0x20, 0x01,
-	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x50, 0x72,
-	0x6f, 0x62, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x4d, 0x6f,
-	0x64, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x0f,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
-	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52,
-	0x08, 0x73, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x73, 0x74, 0x61,
-	0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x73,
-	0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53,
-	0x74, 0x61, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x73, 0x74, 0x61, 0x67, 0x65, 0x4d,
-	0x6f, 0x64, 0x65, 0x12, 0x42, 0x0a, 0x10, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c,
-	0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
-	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e,
-	0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x10, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c,
-	0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a, 0x09, 0x77, 0x61, 0x74, 0x63, 0x68,
-	0x4d, 0x6f, 0x64, 0x65, 0x18, 0x15, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x73, 0x79, 0x6e,
-	0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x57, 0x61, 0x74,
-	0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x77, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64,
-	0x65, 0x12, 0x32, 0x0a, 0x14, 0x77, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e,
-	0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x14, 0x77, 0x61, 0x74, 0x63, 0x68, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74,
-	0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
-	0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x1f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64,
-	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07,
-	0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x39, 0x0a, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72,
-	0x65, 0x56, 0x43, 0x53, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
-	0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d,
-	0x6f, 0x64, 0x65, 0x52, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d, 0x6f,
-	0x64, 0x65, 0x12, 0x3f, 0x0a, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
-	0x73, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x3d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f,
-	0x72, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f,
-	0x64, 0x65, 0x52, 0x0f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d,
-	0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69,
-	0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x3f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65,
-	0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a,
-	0x14, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72,
-	0x79, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x40, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x64, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f, 0x64,
-	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4f, 0x77, 0x6e, 0x65,
-	0x72, 0x18, 0x41, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
-	0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
-	0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x42, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66,
-	0x61, 0x75, 0x6c, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d,
-	0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73,
-	0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x31, 0x73, 0x79,
+	0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f,
+	0x72, 0x65, 0x2f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x30, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x69,
+	0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x2b, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x73, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x2d,
+	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x5f, 0x6c, 0x69,
+	0x6e, 0x6b, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd3, 0x0d,
+	0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x4b, 0x0a, 0x13, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63,
+	0x6f, 0x72, 0x65, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x13, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a, 0x11,
+	0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x16, 0x6d, 0x61,
+	0x78, 0x69, 0x6d, 0x75, 0x6d, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x6d, 0x61, 0x78, 0x69,
+	0x6d, 0x75, 0x6d, 0x53, 0x74, 0x61, 0x67, 0x69, 0x6e, 0x67, 0x46, 0x69, 0x6c, 0x65, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x31, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x62, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72,
+	0x2e, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x70, 0x72, 0x6f, 0x62,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x35, 0x0a, 0x08, 0x73, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64,
+	0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72,
+	0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x63, 0x61, 0x6e, 0x4d, 0x6f,
+	0x64, 0x65, 0x52, 0x08, 0x73, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a, 0x09,
+	0x73, 0x74, 0x61, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x1a, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2e, 0x53, 0x74, 0x61, 0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x09, 0x73, 0x74, 0x61,
+	0x67, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x73, 0x63, 0x61, 0x6e, 0x50, 0x61,
+	0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0f, 0x73, 0x63, 0x61, 0x6e, 0x50, 0x61, 0x72, 0x61, 0x6c, 0x6c, 0x65, 0x6c, 0x69, 0x73, 0x6d,
+	0x12, 0x36, 0x0a, 0x0c, 0x68, 0x61, 0x72, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x12, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x48, 0x61,
+	0x72, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0c, 0x68, 0x61, 0x72, 0x64,
+	0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x36, 0x0a, 0x16, 0x69, 0x6e, 0x50, 0x6c,
+	0x61, 0x63, 0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f,
+	0x6c, 0x64, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x69, 0x6e, 0x50, 0x6c, 0x61, 0x63,
+	0x65, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x12, 0x4b, 0x0a, 0x13, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x13, 0x6e, 0x61, 0x6d, 0x65, 0x43, 0x6f,
+	0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x42, 0x0a,
+	0x10, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x52,
+	0x10, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x69, 0x63, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x38, 0x0a, 0x09, 0x77, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65,
+	0x52, 0x09, 0x77, 0x61, 0x74, 0x63, 0x68, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x77,
+	0x61, 0x74, 0x63, 0x68, 0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x77, 0x61, 0x74, 0x63, 0x68,
+	0x50, 0x6f, 0x6c, 0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12,
+	0x26, 0x0a, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x73, 0x18, 0x1f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x69, 0x67, 0x6e, 0x6f, 0x72,
+	0x65, 0x73, 0x18, 0x20, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x73, 0x12, 0x39, 0x0a, 0x0d, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d, 0x6f,
+	0x64, 0x65, 0x18, 0x21, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e,
+	0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0d, 0x69,
+	0x67, 0x6e, 0x6f, 0x72, 0x65, 0x56, 0x43, 0x53, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x0b,
+	0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x22, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0b, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x73, 0x18, 0x23, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x08, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x73, 0x12, 0x3f, 0x0a, 0x0f, 0x70, 0x65,
+	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x3d, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x65, 0x72, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x0f, 0x70, 0x65, 0x72, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x28, 0x0a, 0x0f, 0x64,
+	0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x6c, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x3f,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x46, 0x69, 0x6c,
+	0x65, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x14, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x40, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x14, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x79, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x66,
+	0x61, 0x75, 0x6c, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x18, 0x41, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4f, 0x77, 0x6e, 0x65, 0x72, 0x12, 0x22, 0x0a,
+	0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x42, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x12, 0x30, 0x0a, 0x13, 0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x53, 0x6e, 0x61, 0x70,
+	0x73, 0x68, 0x6f, 0x74, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x51, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13,
+	0x6d, 0x61, 0x78, 0x69, 0x6d, 0x75, 0x6d, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x53,
+	0x69, 0x7a, 0x65, 0x12, 0x4e, 0x0a, 0x14, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x5b, 0x20, 0x01, 0x28,
+	0x0e, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x14, 0x6d,
+	0x6f, 0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d,
+	0x6f, 0x64, 0x65, 0x12, 0x2e, 0x0a, 0x12, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x5c, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x12, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x65, 0x64, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x73, 0x12, 0x5c, 0x0a, 0x17, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x18, 0x65,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x70, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67,
+	0x2e, 0x42, 0x61, 0x63, 0x6b, 0x67, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6d, 0x70,
+	0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x17, 0x62, 0x61, 0x63, 0x6b, 0x67, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x50, 0x72, 0x6f, 0x6d, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x46, 0x0a, 0x0d, 0x62, 0x61, 0x74, 0x74, 0x65, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x18, 0x6f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x20, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68,
+	0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69,
+	0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x0d, 0x62, 0x61, 0x74, 0x74,
+	0x65, 0x72, 0x79, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x46, 0x0a, 0x0d, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x65, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x70, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x20, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6e, 0x64, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x52, 0x0d, 0x6d, 0x65, 0x74, 0x65, 0x72, 0x65, 0x64, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x12, 0x20, 0x0a, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x48, 0x6f, 0x75, 0x72, 0x73,
+	0x18, 0x71, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x48, 0x6f,
+	0x75, 0x72, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63,
+	0x74, 0x65, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x72, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x13, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x3c, 0x0a, 0x19, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e, 0x6e,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x76,
+	0x61, 0x6c, 0x18, 0x73, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x64, 0x69, 0x73, 0x63, 0x6f, 0x6e,
+	0x6e, 0x65, 0x63, 0x74, 0x65, 0x64, 0x52, 0x65, 0x74, 0x72, 0x79, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74,
+	0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -338,30 +579,41 @@ func file_synchronization_configuration_proto_rawDescGZIP() []byte {
 
 var file_synchronization_configuration_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_synchronization_configuration_proto_goTypes = []interface{}{
-	(*Configuration)(nil),         // 0: synchronization.Configuration
-	(core.SynchronizationMode)(0), // 1: core.SynchronizationMode
-	(behavior.ProbeMode)(0),       // 2: behavior.ProbeMode
-	(ScanMode)(0),                 // 3: synchronization.ScanMode
-	(StageMode)(0),                // 4: synchronization.StageMode
-	(core.SymbolicLinkMode)(0),    // 5: core.SymbolicLinkMode
-	(WatchMode)(0),                // 6: synchronization.WatchMode
-	(core.IgnoreVCSMode)(0),       // 7: core.IgnoreVCSMode
-	(core.PermissionsMode)(0),     // 8: core.PermissionsMode
+	(*Configuration)(nil),                  // 0: synchronization.Configuration
+	(core.SynchronizationMode)(0),          // 1: core.SynchronizationMode
+	(behavior.ProbeMode)(0),                // 2: behavior.ProbeMode
+	(ScanMode)(0),                          // 3: synchronization.ScanMode
+	(StageMode)(0),                         // 4: synchronization.StageMode
+	(core.HardLinkMode)(0),                 // 5: core.HardLinkMode
+	(core.NameCollisionPolicy)(0),          // 6: core.NameCollisionPolicy
+	(core.SymbolicLinkMode)(0),             // 7: core.SymbolicLinkMode
+	(WatchMode)(0),                         // 8: synchronization.WatchMode
+	(core.IgnoreVCSMode)(0),                // 9: core.IgnoreVCSMode
+	(core.PermissionsMode)(0),              // 10: core.PermissionsMode
+	(core.ModificationTimeMode)(0),         // 11: core.ModificationTimeMode
+	(prompting.BackgroundPromptingMode)(0), // 12: prompting.BackgroundPromptingMode
+	(ConditionPolicy)(0),                   // 13: synchronization.ConditionPolicy
 }
 var file_synchronization_configuration_proto_depIdxs = []int32{
-	1, // 0: synchronization.Configuration.synchronizationMode:type_name -> core.SynchronizationMode
-	2, // 1: synchronization.Configuration.probeMode:type_name -> behavior.ProbeMode
-	3, // 2: synchronization.Configuration.scanMode:type_name -> synchronization.ScanMode
-	4, // 3: synchronization.Configuration.stageMode:type_name -> synchronization.StageMode
-	5, // 4: synchronization.Configuration.symbolicLinkMode:type_name -> core.SymbolicLinkMode
-	6, // 5: synchronization.Configuration.watchMode:type_name -> synchronization.WatchMode
-	7, // 6: synchronization.Configuration.ignoreVCSMode:type_name -> core.IgnoreVCSMode
-	8, // 7: synchronization.Configuration.permissionsMode:type_name -> core.PermissionsMode
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	1,  // 0: synchronization.Configuration.synchronizationMode:type_name -> core.SynchronizationMode
+	2,  // 1: synchronization.Configuration.probeMode:type_name -> behavior.ProbeMode
+	3,  // 2: synchronization.Configuration.scanMode:type_name -> synchronization.ScanMode
+	4,  // 3: synchronization.Configuration.stageMode:type_name -> synchronization.StageMode
+	5,  // 4: synchronization.Configuration.hardLinkMode:type_name -> core.HardLinkMode
+	6,  // 5: synchronization.Configuration.nameCollisionPolicy:type_name -> core.NameCollisionPolicy
+	7,  // 6: synchronization.Configuration.symbolicLinkMode:type_name -> core.SymbolicLinkMode
+	8,  // 7: synchronization.Configuration.watchMode:type_name -> synchronization.WatchMode
+	9,  // 8: synchronization.Configuration.ignoreVCSMode:type_name -> core.IgnoreVCSMode
+	10, // 9: synchronization.Configuration.permissionsMode:type_name -> core.PermissionsMode
+	11, // 10: synchronization.Configuration.modificationTimeMode:type_name -> core.ModificationTimeMode
+	12, // 11: synchronization.Configuration.backgroundPromptingMode:type_name -> prompting.BackgroundPromptingMode
+	13, // 12: synchronization.Configuration.batteryPolicy:type_name -> synchronization.ConditionPolicy
+	13, // 13: synchronization.Configuration.meteredPolicy:type_name -> synchronization.ConditionPolicy
+	14, // [14:14] is the sub-list for method output_type
+	14, // [14:14] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
 }
 
 func init() { file_synchronization_configuration_proto_init() }
@@ -369,6 +621,7 @@ func file_synchronization_configuration_proto_init() {
 	if File_synchronization_configuration_proto != nil {
 		return
 	}
+	file_synchronization_condition_policy_proto_init()
 	file_synchronization_scan_mode_proto_init()
 	file_synchronization_stage_mode_proto_init()
 	file_synchronization_watch_mode_proto_init()
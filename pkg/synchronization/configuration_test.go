@@ -1,3 +1,35 @@
 package synchronization
 
-// TODO: Implement.
+import (
+	"testing"
+)
+
+// TestConfigurationScanParallelismBound tests that Configuration.EnsureValid
+// enforces the maximum scan parallelism.
+func TestConfigurationScanParallelismBound(t *testing.T) {
+	// Define test cases.
+	testCases := []struct {
+		scanParallelism uint32
+		expectValid     bool
+	}{
+		{0, true},
+		{1, true},
+		{MaximumScanParallelism, true},
+		{MaximumScanParallelism + 1, false},
+		{^uint32(0), false},
+	}
+
+	// Process test cases, checking both general and endpoint-specific
+	// validation.
+	for _, testCase := range testCases {
+		configuration := &Configuration{ScanParallelism: testCase.scanParallelism}
+		for _, endpointSpecific := range []bool{false, true} {
+			err := configuration.EnsureValid(endpointSpecific)
+			if testCase.expectValid && err != nil {
+				t.Errorf("scan parallelism %d unexpectedly invalid: %v", testCase.scanParallelism, err)
+			} else if !testCase.expectValid && err == nil {
+				t.Errorf("scan parallelism %d unexpectedly valid", testCase.scanParallelism)
+			}
+		}
+	}
+}
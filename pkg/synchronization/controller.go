@@ -9,12 +9,15 @@ import (
 	"time"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/mutagen-io/mutagen/pkg/conditions"
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/logging"
 	"github.com/mutagen-io/mutagen/pkg/mutagen"
 	"github.com/mutagen-io/mutagen/pkg/prompting"
+	"github.com/mutagen-io/mutagen/pkg/selection"
 	"github.com/mutagen-io/mutagen/pkg/state"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
@@ -28,12 +31,56 @@ const (
 	// rescanWaitDuration is the period of time to wait before attempting to
 	// rescan after an ephemeral scan failure.
 	rescanWaitDuration = 5 * time.Second
+	// logRingCapacity is the number of log entries retained in a session's log
+	// ring.
+	logRingCapacity = 1000
+	// maximumRecentChangePaths is the maximum number of paths recorded for each
+	// endpoint in each cycle's changes.
+	maximumRecentChangePaths = 100
 )
 
+// recordedChangePaths extracts the paths from a list of transitions,
+// truncating the list if necessary. It returns the (potentially truncated)
+// paths and the number of paths excluded due to truncation.
+func recordedChangePaths(transitions []*core.Change) ([]string, uint64) {
+	var excluded uint64
+	if len(transitions) > maximumRecentChangePaths {
+		excluded = uint64(len(transitions) - maximumRecentChangePaths)
+		transitions = transitions[:maximumRecentChangePaths]
+	}
+	paths := make([]string, len(transitions))
+	for t, transition := range transitions {
+		paths[t] = transition.Path
+	}
+	return paths, excluded
+}
+
+// restoreResponse encodes the response to a restore request.
+type restoreResponse struct {
+	// result is the restoration result. It is nil if err is non-nil.
+	result *RestoreResult
+	// err is any error that occurred during restoration.
+	err error
+}
+
+// restoreRequest encodes a request for point-in-time content restoration that
+// is passed to the synchronization loop.
+type restoreRequest struct {
+	// before is the point in time before which content should be restored.
+	before time.Time
+	// paths are the paths to which restoration should be restricted.
+	paths []string
+	// response is the channel to which the response should be sent. It must be
+	// buffered with a capacity of at least one.
+	response chan restoreResponse
+}
+
 // controller manages and executes a single session.
 type controller struct {
 	// logger is the controller logger.
 	logger *logging.Logger
+	// logs is the session log ring attached to logger.
+	logs *logging.Ring
 	// sessionPath is the path to the serialized session.
 	sessionPath string
 	// archivePath is the path to the serialized archive.
@@ -65,15 +112,17 @@ type controller struct {
 	// a state where it can perform synchronization. It is closed when
 	// synchronization fails due to an error.
 	synchronizing chan struct{}
-	// lifecycleLock guards access to disabled, cancel, flushRequests, and done.
-	// Only the current holder of the lifecycle lock may set any of these fields
-	// or invoke cancel. The synchronization loop may close close done or
-	// receive from flushRequests without holding the lifecycle lock. Moreover,
-	// previous lifecycle lock holders may continue to send to flushRequests and
-	// poll on done after storing them in separate variables and releasing the
-	// lifecycle lock. Any code wishing to set these fields must first acquire
-	// the lock, then cancel the synchronization loop and wait for it to
-	// complete before making any changes.
+	// lifecycleLock guards access to disabled, suspended, policySuspension,
+	// cancel, flushRequests, restoreRequests, and done. Only the current
+	// holder of the lifecycle lock may set any of these fields or invoke
+	// cancel. The synchronization loop may close done or receive from
+	// flushRequests and restoreRequests without holding the lifecycle lock.
+	// Moreover, previous lifecycle lock holders may continue to send to
+	// flushRequests and restoreRequests and poll on done after storing them in
+	// separate variables and releasing the lifecycle lock. Any code wishing to
+	// set these fields must first acquire the lock, then cancel the
+	// synchronization loop and wait for it to complete before making any
+	// changes.
 	lifecycleLock sync.Mutex
 	// disabled indicates that no more changes to the synchronization loop
 	// lifecycle are allowed (i.e. no more synchronization loops can be started
@@ -81,6 +130,15 @@ type controller struct {
 	// only be set to true once any existing synchronization loop has been
 	// stopped.
 	disabled bool
+	// suspended indicates that the synchronization loop has been stopped due
+	// to the session's policies (rather than the session being paused). It is
+	// cleared by any explicit pause or resume operation.
+	suspended bool
+	// policySuspension is the result of the most recent policy evaluation for
+	// the session. Policies are only applied when this value changes between
+	// empty and non-empty, allowing explicit pause and resume operations to
+	// override policies until the relevant conditions next change.
+	policySuspension string
 	// cancel cancels the synchronization loop execution context. It is nil if
 	// and only if there is no synchronization loop running.
 	cancel context.CancelFunc
@@ -88,11 +146,20 @@ type controller struct {
 	// is buffered, allowing a single request to be queued. All requests passed
 	// via this channel must be buffered and contain room for one error.
 	flushRequests chan chan error
+	// restoreRequests is used to pass restore requests to the synchronization
+	// loop. It is buffered, allowing a single request to be queued. All
+	// requests passed via this channel must have a response channel that is
+	// buffered and contains room for one response.
+	restoreRequests chan *restoreRequest
 	// done will be closed by the current synchronization loop when it exits.
 	done chan struct{}
 }
 
-// newSession creates a new session and corresponding controller.
+// newSession creates a new session and corresponding controller. If archive is
+// non-nil, then it is used as the session's initial ancestor archive. If
+// verifyArchive is also true, then the endpoints are connected (even if the
+// session is being created paused) and the archive is only accepted if the
+// contents of both endpoints match it.
 func newSession(
 	ctx context.Context,
 	logger *logging.Logger,
@@ -103,8 +170,14 @@ func newSession(
 	name string,
 	labels map[string]string,
 	paused bool,
+	archive *core.Archive,
+	verifyArchive bool,
 	prompter string,
 ) (*controller, error) {
+	// Create the session log ring and attach it to the logger.
+	logs := logging.NewRing(logRingCapacity, logging.LevelInfo)
+	logger = logger.WithRing(logs)
+
 	// Update status.
 	prompting.Message(prompter, "Creating session...")
 
@@ -121,9 +194,13 @@ func newSession(
 	mergedAlphaConfiguration := MergeConfigurations(configuration, configurationAlpha)
 	mergedBetaConfiguration := MergeConfigurations(configuration, configurationBeta)
 
-	// If the session isn't being created paused, then try to connect to the
-	// endpoints. Before doing so, set up a deferred handler that will shut down
-	// any endpoints that aren't handed off to the run loop due to errors.
+	// Determine whether or not archive verification is required.
+	verifyArchive = verifyArchive && archive != nil
+
+	// If the session isn't being created paused (or if archive verification is
+	// required), then try to connect to the endpoints. Before doing so, set up
+	// a deferred handler that will shut down any endpoints that aren't handed
+	// off to the run loop (due to errors or the session being paused).
 	var alphaEndpoint, betaEndpoint Endpoint
 	var err error
 	defer func() {
@@ -136,7 +213,7 @@ func newSession(
 			betaEndpoint = nil
 		}
 	}()
-	if !paused {
+	if !paused || verifyArchive {
 		logger.Info("Connecting to alpha endpoint")
 		alphaEndpoint, err = connect(
 			ctx,
@@ -169,6 +246,16 @@ func newSession(
 		}
 	}
 
+	// Verify that the endpoint contents match the archive, if required.
+	if verifyArchive {
+		prompting.Message(prompter, "Verifying endpoint contents against archive...")
+		if err := verifyEndpointContents(ctx, alphaEndpoint, archive); err != nil {
+			return nil, fmt.Errorf("unable to verify alpha contents: %w", err)
+		} else if err = verifyEndpointContents(ctx, betaEndpoint, archive); err != nil {
+			return nil, fmt.Errorf("unable to verify beta contents: %w", err)
+		}
+	}
+
 	// Create the session and initial archive.
 	session := &Session{
 		Identifier:           identifier,
@@ -186,7 +273,9 @@ func newSession(
 		Labels:               labels,
 		Paused:               paused,
 	}
-	archive := &core.Archive{}
+	if archive == nil {
+		archive = &core.Archive{}
+	}
 
 	// Compute the session and archive paths.
 	sessionPath, err := pathForSession(session.Identifier)
@@ -210,6 +299,7 @@ func newSession(
 	// Create the controller.
 	controller := &controller{
 		logger:                   logger,
+		logs:                     logs,
 		sessionPath:              sessionPath,
 		archivePath:              archivePath,
 		stateLock:                state.NewTrackingLock(tracker),
@@ -230,6 +320,7 @@ func newSession(
 		ctx, cancel := context.WithCancel(context.Background())
 		controller.cancel = cancel
 		controller.flushRequests = make(chan chan error, 1)
+		controller.restoreRequests = make(chan *restoreRequest, 1)
 		controller.done = make(chan struct{})
 		go controller.run(ctx, alphaEndpoint, betaEndpoint)
 		alphaEndpoint = nil
@@ -243,6 +334,10 @@ func newSession(
 
 // loadSession loads an existing session and creates a corresponding controller.
 func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier string) (*controller, error) {
+	// Create the session log ring and attach it to the logger.
+	logs := logging.NewRing(logRingCapacity, logging.LevelInfo)
+	logger = logger.WithRing(logs)
+
 	// Compute session and archive paths.
 	sessionPath, err := pathForSession(identifier)
 	if err != nil {
@@ -274,6 +369,7 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 	// Create the controller.
 	controller := &controller{
 		logger:      logger,
+		logs:        logs,
 		sessionPath: sessionPath,
 		archivePath: archivePath,
 		stateLock:   state.NewTrackingLock(tracker),
@@ -298,6 +394,7 @@ func loadSession(logger *logging.Logger, tracker *state.Tracker, identifier stri
 		ctx, cancel := context.WithCancel(context.Background())
 		controller.cancel = cancel
 		controller.flushRequests = make(chan chan error, 1)
+		controller.restoreRequests = make(chan *restoreRequest, 1)
 		controller.done = make(chan struct{})
 		go controller.run(ctx, nil, nil)
 	}
@@ -319,6 +416,154 @@ func (c *controller) currentState() *State {
 	return proto.Clone(c.state).(*State)
 }
 
+// selectionCandidate creates a description of the session for the purpose of
+// matching it against session selectors.
+func (c *controller) selectionCandidate() *selection.Candidate {
+	// Lock the session state and defer its release. As with currentState, we
+	// unlock without a notification to avoid a list/notify cycle.
+	c.stateLock.Lock()
+	defer c.stateLock.UnlockWithoutNotify()
+
+	// Compute the statuses that currently apply to the session.
+	statuses := make(map[string]bool)
+	if c.session.Paused {
+		statuses[selection.SessionStatusPaused] = true
+	} else {
+		statuses[selection.SessionStatusRunning] = true
+		if c.state.Status.IsHalted() {
+			statuses[selection.SessionStatusHalted] = true
+		}
+		if c.state.AlphaState.Connected && c.state.BetaState.Connected {
+			statuses[selection.SessionStatusConnected] = true
+		} else {
+			statuses[selection.SessionStatusDisconnected] = true
+		}
+	}
+	if len(c.state.Conflicts) > 0 {
+		statuses[selection.SessionStatusHasConflicts] = true
+	}
+	if len(c.state.AlphaState.ScanProblems) > 0 || len(c.state.AlphaState.TransitionProblems) > 0 ||
+		len(c.state.BetaState.ScanProblems) > 0 || len(c.state.BetaState.TransitionProblems) > 0 {
+		statuses[selection.SessionStatusHasProblems] = true
+	}
+	if c.state.LastError != "" {
+		statuses[selection.SessionStatusHasErrors] = true
+	}
+
+	// Create the candidate.
+	return &selection.Candidate{
+		Name:      c.session.Name,
+		Endpoints: []*url.URL{c.session.Alpha, c.session.Beta},
+		Statuses:  statuses,
+	}
+}
+
+// export creates a portable representation of the session, optionally
+// including its ancestor archive.
+func (c *controller) export(includeArchive bool) (*ExportedSession, error) {
+	// Create a static copy of the session.
+	c.stateLock.Lock()
+	session := proto.Clone(c.session).(*Session)
+	c.stateLock.UnlockWithoutNotify()
+
+	// Load the archive, if requested. Archives are saved atomically, so we
+	// don't need to coordinate with the synchronization loop to read it.
+	var archive *core.Archive
+	if includeArchive {
+		archive = &core.Archive{}
+		if err := encoding.LoadAndUnmarshalProtobuf(c.archivePath, archive); err != nil {
+			return nil, fmt.Errorf("unable to load archive: %w", err)
+		} else if err = archive.EnsureValid(true); err != nil {
+			return nil, fmt.Errorf("invalid archive found on disk: %w", err)
+		}
+	}
+
+	// Done.
+	return &ExportedSession{Session: session, Archive: archive}, nil
+}
+
+// nameAndLabels returns the current session name and labels. The returned
+// label map must not be modified.
+func (c *controller) nameAndLabels() (string, map[string]string) {
+	c.stateLock.Lock()
+	defer c.stateLock.UnlockWithoutNotify()
+	return c.session.Name, c.session.Labels
+}
+
+// labeling computes the session name (if rename is true) and labels that would
+// result from a label operation, without applying them. Labels with keys in
+// removals are removed before labels are added. Unless overwrite is true,
+// adding a label whose key is already present with a different value is an
+// error. The returned label map may be stored by the caller.
+func (c *controller) labeling(rename bool, name string, labels map[string]string, removals []string, overwrite bool) (string, map[string]string, error) {
+	// Lock the session state and defer its release.
+	c.stateLock.Lock()
+	defer c.stateLock.UnlockWithoutNotify()
+
+	// Compute the new name.
+	newName := c.session.Name
+	if rename {
+		newName = name
+	}
+	if err := selection.EnsureNameValid(newName); err != nil {
+		return "", nil, fmt.Errorf("invalid name: %w", err)
+	}
+
+	// Compute the new labels. We create a new map (rather than modifying the
+	// existing one) since the existing one may have been handed out.
+	newLabels := make(map[string]string, len(c.session.Labels)+len(labels))
+	for k, v := range c.session.Labels {
+		newLabels[k] = v
+	}
+	for _, k := range removals {
+		delete(newLabels, k)
+	}
+	for k, v := range labels {
+		if existing, ok := newLabels[k]; ok && existing != v && !overwrite {
+			return "", nil, fmt.Errorf("label \"%s\" already has a value (overwrite not specified)", k)
+		} else if err := selection.EnsureLabelKeyValid(k); err != nil {
+			return "", nil, fmt.Errorf("invalid label key: %w", err)
+		} else if err = selection.EnsureLabelValueValid(v); err != nil {
+			return "", nil, fmt.Errorf("invalid label value: %w", err)
+		}
+		newLabels[k] = v
+	}
+	if len(newLabels) == 0 {
+		newLabels = nil
+	}
+
+	// Success.
+	return newName, newLabels, nil
+}
+
+// setNameAndLabels sets the session name and labels, saving the updated session
+// to disk. The label map must not be modified after this call.
+func (c *controller) setNameAndLabels(name string, labels map[string]string) error {
+	// Lock the session state and defer its release. We unlock with a
+	// notification so that listeners see the updated session.
+	c.stateLock.Lock()
+	defer c.stateLock.Unlock()
+
+	// Save an updated copy of the session to disk. We only update the
+	// in-memory session once the change has been persisted.
+	session := proto.Clone(c.session).(*Session)
+	session.Name = name
+	session.Labels = labels
+	if err := encoding.MarshalAndSaveProtobuf(c.sessionPath, session); err != nil {
+		return fmt.Errorf("unable to save session: %w", err)
+	}
+
+	// Update the in-memory session.
+	c.session.Name = name
+	c.session.Labels = labels
+
+	// Log the change.
+	c.logger.Info("Session name and labels updated")
+
+	// Success.
+	return nil
+}
+
 // flush attempts to force a synchronization cycle for the session. If wait is
 // specified, then the method will wait until a post-flush synchronization cycle
 // has completed. The provided context (which must be non-nil) can terminate
@@ -336,8 +581,11 @@ func (c *controller) flush(ctx context.Context, prompter string, skipWait bool)
 		return errors.New("controller disabled")
 	}
 
-	// Check if the session is paused.
-	if c.cancel == nil {
+	// Check if the session is paused or suspended.
+	if c.suspended {
+		c.lifecycleLock.Unlock()
+		return errors.New("session is suspended by policy")
+	} else if c.cancel == nil {
 		c.lifecycleLock.Unlock()
 		return errors.New("session is paused")
 	}
@@ -410,6 +658,86 @@ func (c *controller) flush(ctx context.Context, prompter string, skipWait bool)
 	}
 }
 
+// restore restores snapshotted content on both endpoints of the session that
+// existed at the specified point in time, restricted to the specified paths (if
+// any). The restoration is performed by the synchronization loop, after which
+// a synchronization cycle will propagate restored content. The provided context
+// (which must be non-nil) can terminate the wait for a response.
+func (c *controller) restore(ctx context.Context, prompter string, before time.Time, paths []string) (*RestoreResult, error) {
+	// Update status.
+	prompting.Message(prompter, fmt.Sprintf("Restoring content for session %s...", c.session.Identifier))
+
+	// Lock the controller's lifecycle.
+	c.lifecycleLock.Lock()
+
+	// Don't allow any operations if the controller is disabled.
+	if c.disabled {
+		c.lifecycleLock.Unlock()
+		return nil, errors.New("controller disabled")
+	}
+
+	// Check if the session is paused or suspended.
+	if c.suspended {
+		c.lifecycleLock.Unlock()
+		return nil, errors.New("session is suspended by policy")
+	} else if c.cancel == nil {
+		c.lifecycleLock.Unlock()
+		return nil, errors.New("session is paused")
+	}
+
+	// Perform logging.
+	c.logger.Infof("Restoring content from before %s", before.Format(time.RFC3339))
+
+	// Check if the session is currently synchronizing and store the channel
+	// that we'll use to track synchronizability.
+	c.stateLock.Lock()
+	synchronizing := c.synchronizing
+	c.stateLock.UnlockWithoutNotify()
+	if synchronizing == nil {
+		c.lifecycleLock.Unlock()
+		return nil, errors.New("session is not currently able to synchronize")
+	}
+
+	// Store the channels that we'll need to submit restore requests and track
+	// synchronization termination.
+	restoreRequests := c.restoreRequests
+	done := c.done
+
+	// Release the lifecycle lock.
+	c.lifecycleLock.Unlock()
+
+	// Create a restore request.
+	request := &restoreRequest{
+		before:   before,
+		paths:    paths,
+		response: make(chan restoreResponse, 1),
+	}
+
+	// Send the request, watching for cancellation, failure, or termination.
+	select {
+	case restoreRequests <- request:
+	case <-ctx.Done():
+		return nil, errors.New("restore cancelled before request could be sent")
+	case <-synchronizing:
+		return nil, errors.New("synchronization failed before restore request could be sent")
+	case <-done:
+		return nil, errors.New("synchronization terminated before restore request could be sent")
+	}
+
+	// Wait for a response to the request, again watching for cancellation,
+	// failure, or termination.
+	select {
+	case response := <-request.response:
+		return response.result, response.err
+	case <-ctx.Done():
+		return nil, errors.New("restore cancelled while waiting for response")
+	case <-synchronizing:
+		return nil, errors.New("synchronization failed while waiting for restore response")
+	case <-done:
+		return nil, errors.New("synchronization terminated while waiting for restore response")
+	}
+}
+
 // resume attempts to reconnect and resume the session if it isn't currently
 // connected and synchronizing. If lifecycleLockHeld is true, then halt will
 // assume that the lifecycle lock is held by the caller and will not attempt to
@@ -464,12 +792,15 @@ func (c *controller) resume(ctx context.Context, prompter string, lifecycleLockH
 		// Nil out any lifecycle state.
 		c.cancel = nil
 		c.flushRequests = nil
+		c.restoreRequests = nil
 		c.done = nil
 	}
 
-	// Mark the session as unpaused and save it to disk.
+	// Mark the session as unpaused (and not suspended) and save it to disk.
+	c.suspended = false
 	c.stateLock.Lock()
 	c.session.Paused = false
+	c.state.Suspension = ""
 	saveErr := encoding.MarshalAndSaveProtobuf(c.sessionPath, c.session)
 	c.stateLock.Unlock()
 
@@ -515,6 +846,7 @@ func (c *controller) resume(ctx context.Context, prompter string, lifecycleLockH
 	ctx, cancel := context.WithCancel(context.Background())
 	c.cancel = cancel
 	c.flushRequests = make(chan chan error, 1)
+	c.restoreRequests = make(chan *restoreRequest, 1)
 	c.done = make(chan struct{})
 	go c.run(ctx, alpha, beta)
 
@@ -593,14 +925,17 @@ func (c *controller) halt(_ context.Context, mode controllerHaltMode, prompter s
 		// Nil out any lifecycle state.
 		c.cancel = nil
 		c.flushRequests = nil
+		c.restoreRequests = nil
 		c.done = nil
 	}
 
 	// Handle based on the halt mode.
 	if mode == controllerHaltModePause {
-		// Mark the session as paused and save it.
+		// Mark the session as paused (and not suspended) and save it.
+		c.suspended = false
 		c.stateLock.Lock()
 		c.session.Paused = true
+		c.state.Suspension = ""
 		saveErr := encoding.MarshalAndSaveProtobuf(c.sessionPath, c.session)
 		c.stateLock.Unlock()
 		if saveErr != nil {
@@ -665,12 +1000,122 @@ func (c *controller) reset(ctx context.Context, prompter string) error {
 	return nil
 }
 
+// applyPolicies evaluates the session's policies under the specified host
+// conditions at the specified time, suspending or unsuspending the session as
+// necessary. Policies are only applied when their evaluation changes between
+// requiring and not requiring suspension, so explicit pause and resume
+// operations take precedence until the relevant conditions next change. Paused
+// sessions are never affected. If the controller's lifecycle is busy (e.g. due
+// to a resume operation waiting on a prompt), then policy application is
+// skipped until the next evaluation, rather than stalling policy evaluation
+// for other sessions.
+func (c *controller) applyPolicies(hostConditions conditions.Conditions, now time.Time) {
+	// Evaluate the session's policies.
+	suspension := policySuspension(c.session.Version, c.session.Configuration, hostConditions, now)
+
+	// Attempt to lock the controller's lifecycle and defer its release.
+	if !c.lifecycleLock.TryLock() {
+		c.logger.Debug("Skipping policy application for busy session")
+		return
+	}
+	defer c.lifecycleLock.Unlock()
+
+	// Don't allow any changes if the controller is disabled.
+	if c.disabled {
+		return
+	}
+
+	// Record the evaluation and check whether or not it requires any change.
+	// If the session is already suspended and the reason for suspension has
+	// changed, then we update the reported reason.
+	previous := c.policySuspension
+	c.policySuspension = suspension
+	if (suspension == "") == (previous == "") {
+		if c.suspended && suspension != previous {
+			c.stateLock.Lock()
+			c.state.Suspension = suspension
+			c.stateLock.Unlock()
+		}
+		return
+	}
+
+	// Handle suspension.
+	if suspension != "" {
+		// If there's no synchronization loop running, then the session is
+		// paused and there's nothing to suspend.
+		if c.cancel == nil {
+			return
+		}
+
+		// Perform logging.
+		c.logger.Infof("Suspending synchronization: %s", suspension)
+
+		// Cancel the synchronization loop and wait for it to finish.
+		c.cancel()
+		<-c.done
+
+		// Nil out any lifecycle state.
+		c.cancel = nil
+		c.flushRequests = nil
+		c.restoreRequests = nil
+		c.done = nil
+
+		// Mark the session as suspended.
+		c.suspended = true
+		c.stateLock.Lock()
+		c.state.Suspension = suspension
+		c.stateLock.Unlock()
+		return
+	}
+
+	// If the session was suspended, then start a new synchronization loop. It
+	// will connect to the endpoints on its own.
+	if c.suspended {
+		// Perform logging.
+		c.logger.Info("Resuming synchronization after policy suspension")
+
+		// Mark the session as no longer suspended.
+		c.suspended = false
+		c.stateLock.Lock()
+		c.state.Suspension = ""
+		c.stateLock.Unlock()
+
+		// Start the synchronization loop.
+		ctx, cancel := context.WithCancel(context.Background())
+		c.cancel = cancel
+		c.flushRequests = make(chan chan error, 1)
+		c.restoreRequests = make(chan *restoreRequest, 1)
+		c.done = make(chan struct{})
+		go c.run(ctx, nil, nil)
+	}
+}
+
 var (
 	// errHaltedForSafety is a sentinel error indicating that a safety check
 	// wants the synchronization loop to be halted until manually resumed.
 	errHaltedForSafety = errors.New("synchronization halted")
 )
 
+// backgroundPrompter returns the identifier of the prompter to use for
+// connection operations performed in the background (i.e. automatic
+// reconnection). This is the default prompter (if any) if the session's
+// background prompting mode allows it, otherwise an empty string.
+func (c *controller) backgroundPrompter() string {
+	// Determine the effective background prompting mode.
+	mode := c.session.Configuration.BackgroundPromptingMode
+	if mode.IsDefault() {
+		mode = c.session.Version.DefaultBackgroundPromptingMode()
+	}
+
+	// If background prompting is disabled, then don't use any prompter.
+	if !mode.Enabled() {
+		return ""
+	}
+
+	// Use the default prompter, if any.
+	return prompting.DefaultPrompter()
+}
+
 // run is the main run loop for the controller, managing connectivity and
 // synchronization.
 func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
@@ -707,6 +1152,10 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 	// Track the last time that synchronization failed.
 	var lastSynchronizationFailureTime time.Time
 
+	// Track the time at which the session became disconnected, if it's not
+	// connected.
+	var disconnectedSince time.Time
+
 	// Loop until cancelled.
 	for {
 		// Loop until we're connected to both endpoints. We do a non-blocking
@@ -723,7 +1172,7 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 					ctx,
 					c.logger.Sublogger("alpha"),
 					c.session.Alpha,
-					"",
+					c.backgroundPrompter(),
 					c.session.Identifier,
 					c.session.Version,
 					c.mergedAlphaConfiguration,
@@ -751,7 +1200,7 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 					ctx,
 					c.logger.Sublogger("beta"),
 					c.session.Beta,
-					"",
+					c.backgroundPrompter(),
 					c.session.Identifier,
 					c.session.Version,
 					c.mergedBetaConfiguration,
@@ -767,15 +1216,37 @@ func (c *controller) run(ctx context.Context, alpha, beta Endpoint) {
 			// it in the loop condition we'd still need a check here to avoid a
 			// sleep every time (even if already successfully connected).
 			if alpha != nil && beta != nil {
+				disconnectedSince = time.Time{}
+				c.stateLock.Lock()
+				c.state.Suspension = ""
+				c.stateLock.Unlock()
 				break
 			}
 
+			// Determine the reconnection interval. If the session has been
+			// disconnected for long enough that its policies require
+			// suspension, then we reduce the frequency of reconnection.
+			if disconnectedSince.IsZero() {
+				disconnectedSince = time.Now()
+			}
+			reconnectInterval := autoReconnectInterval
+			disconnected := time.Since(disconnectedSince)
+			if interval, suspend := disconnectedRetryInterval(c.session.Version, c.session.Configuration, disconnected); suspend {
+				reconnectInterval = interval
+				c.stateLock.Lock()
+				c.state.Suspension = fmt.Sprintf(
+					"disconnected for %s (retrying every %s)",
+					disconnected.Round(time.Minute), interval,
+				)
+				c.stateLock.Unlock()
+			}
+
 			// If we failed to connect, wait and then retry. Watch for
 			// cancellation in the mean time.
 			select {
 			case <-ctx.Done():
 				return
-			case <-time.After(autoReconnectInterval):
+			case <-time.After(reconnectInterval):
 			}
 		}
 
@@ -861,6 +1332,9 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 	// Track whether or not a flush request triggered the synchronization loop.
 	var flushRequest chan error
 
+	// Track any restore request that triggered the synchronization loop.
+	var restoreRequest *restoreRequest
+
 	// Load the archive and extract the ancestor. We enforce that the archive
 	// contains only synchronizable content.
 	archive := &core.Archive{}
@@ -883,6 +1357,12 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		permissionsMode = c.session.Version.DefaultPermissionsMode()
 	}
 
+	// Compute the effective name collision policy.
+	nameCollisionPolicy := c.session.Configuration.NameCollisionPolicy
+	if nameCollisionPolicy.IsDefault() {
+		nameCollisionPolicy = c.session.Version.DefaultNameCollisionPolicy()
+	}
+
 	// Compute, on a per-endpoint basis, whether or not polling should be
 	// disabled.
 	αWatchMode := c.mergedAlphaConfiguration.WatchMode
@@ -969,8 +1449,9 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			}()
 
 			// Wait for either poll to return an event or an error, for a flush
-			// request, or for cancellation. In any of these cases, cancel
-			// polling and ensure that both polling operations have completed.
+			// or restore request, or for cancellation. In any of these cases,
+			// cancel polling and ensure that both polling operations have
+			// completed.
 			var αPollErr, βPollErr error
 			cancelled := false
 			select {
@@ -990,6 +1471,14 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				pollCancel()
 				αPollErr = <-αPollResults
 				βPollErr = <-βPollResults
+			case restoreRequest = <-c.restoreRequests:
+				if cap(restoreRequest.response) < 1 {
+					panic("unbuffered restore response")
+				}
+				c.logger.Debug("Triggered by restore request")
+				pollCancel()
+				αPollErr = <-αPollResults
+				βPollErr = <-βPollResults
 			case <-ctx.Done():
 				cancelled = true
 				pollCancel()
@@ -1010,6 +1499,31 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			skipPolling = false
 		}
 
+		// If a restore request is present, then perform restoration on both
+		// endpoints before scanning, that way restored content is picked up by
+		// the synchronization cycle that follows. Restoration is performed
+		// sequentially since it's expected to be rare and relatively small.
+		if restoreRequest != nil {
+			c.logger.Debug("Restoring content")
+			result := &RestoreResult{Session: c.session.Identifier}
+			var αRestoreErr, βRestoreErr error
+			result.AlphaRestored, result.AlphaProblems, αRestoreErr = alpha.Restore(restoreRequest.before, restoreRequest.paths)
+			if αRestoreErr == nil {
+				result.BetaRestored, result.BetaProblems, βRestoreErr = beta.Restore(restoreRequest.before, restoreRequest.paths)
+			}
+			if αRestoreErr != nil {
+				αRestoreErr = fmt.Errorf("alpha restore error: %w", αRestoreErr)
+				restoreRequest.response <- restoreResponse{err: αRestoreErr}
+				return αRestoreErr
+			} else if βRestoreErr != nil {
+				βRestoreErr = fmt.Errorf("beta restore error: %w", βRestoreErr)
+				restoreRequest.response <- restoreResponse{err: βRestoreErr}
+				return βRestoreErr
+			}
+			restoreRequest.response <- restoreResponse{result: result}
+			restoreRequest = nil
+		}
+
 		// Scan both endpoints in parallel and check for errors. If a flush
 		// request is present, then force both endpoints to perform a full
 		// (warm) re-scan rather than using acceleration.
@@ -1021,14 +1535,19 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		var αSnapshot, βSnapshot *core.Snapshot
 		var αScanErr, βScanErr error
 		var αTryAgain, βTryAgain bool
+		var αScanDuration, βScanDuration time.Duration
 		scanDone := &sync.WaitGroup{}
 		scanDone.Add(2)
 		go func() {
+			start := time.Now()
 			αSnapshot, αScanErr, αTryAgain = alpha.Scan(ctx, ancestor, forceFullScan)
+			αScanDuration = time.Since(start)
 			scanDone.Done()
 		}()
 		go func() {
+			start := time.Now()
 			βSnapshot, βScanErr, βTryAgain = beta.Scan(ctx, ancestor, forceFullScan)
+			βScanDuration = time.Since(start)
 			scanDone.Done()
 		}()
 		scanDone.Wait()
@@ -1121,12 +1640,14 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 		c.state.AlphaState.SymbolicLinks = αSnapshot.SymbolicLinks
 		c.state.AlphaState.TotalFileSize = αSnapshot.TotalFileSize
 		c.state.AlphaState.ScanProblems = αContent.Problems()
+		c.state.AlphaState.LastScanDuration = durationpb.New(αScanDuration)
 		c.state.BetaState.Scanned = true
 		c.state.BetaState.Directories = βSnapshot.Directories
 		c.state.BetaState.Files = βSnapshot.Files
 		c.state.BetaState.SymbolicLinks = βSnapshot.SymbolicLinks
 		c.state.BetaState.TotalFileSize = βSnapshot.TotalFileSize
 		c.state.BetaState.ScanProblems = βContent.Problems()
+		c.state.BetaState.LastScanDuration = durationpb.New(βScanDuration)
 		c.state.Status = Status_Reconciling
 		c.stateLock.Unlock()
 
@@ -1162,6 +1683,27 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			return errHaltedForSafety
 		}
 
+		// If either endpoint ignores case or normalizes Unicode names, then
+		// identify names that would collide on that endpoint and exclude them
+		// from reconciliation as dictated by the name collision policy. We
+		// conservatively treat case-insensitive filesystems as also being
+		// normalization-insensitive, which is true of the most common such
+		// filesystems.
+		var nameCollisions []*core.NameCollision
+		ignoresCase := αSnapshot.IgnoresCase || βSnapshot.IgnoresCase
+		if ignoresCase || αSnapshot.DecomposesUnicode || βSnapshot.DecomposesUnicode {
+			αContent, βContent, nameCollisions = core.ResolveNameCollisions(
+				ancestor,
+				αContent,
+				βContent,
+				ignoresCase,
+				nameCollisionPolicy,
+			)
+			if len(nameCollisions) > 0 {
+				c.logger.Debugf("Identified %d name collision(s)", len(nameCollisions))
+			}
+		}
+
 		// Perform reconciliation.
 		c.logger.Debug("Performing reconciliation")
 		ancestorChanges, αTransitions, βTransitions, conflicts := core.Reconcile(
@@ -1198,9 +1740,11 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			}
 		}
 
-		// Store conflicts that arose during reconciliation.
+		// Store conflicts that arose during reconciliation, as well as any name
+		// collisions.
 		c.stateLock.Lock()
 		c.state.Conflicts = conflicts
+		c.state.NameCollisions = nameCollisions
 		c.stateLock.Unlock()
 
 		// Check if a root deletion operation is being propagated. This can be
@@ -1247,6 +1791,9 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				monitor := func(state *rsync.ReceiverState) error {
 					c.stateLock.Lock()
 					if state == nil {
+						if progress := c.state.AlphaState.StagingProgress; progress != nil {
+							c.state.AlphaState.TotalStagedSize += progress.TotalReceivedSize
+						}
 						c.state.AlphaState.StagingProgress = nil
 					} else {
 						if c.state.AlphaState.StagingProgress == nil {
@@ -1285,6 +1832,9 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				monitor := func(state *rsync.ReceiverState) error {
 					c.stateLock.Lock()
 					if state == nil {
+						if progress := c.state.BetaState.StagingProgress; progress != nil {
+							c.state.BetaState.TotalStagedSize += progress.TotalReceivedSize
+						}
 						c.state.BetaState.StagingProgress = nil
 					} else {
 						if c.state.BetaState.StagingProgress == nil {
@@ -1305,8 +1855,10 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 
 		// Perform transitions on both endpoints in parallel. For each side that
 		// doesn't completely error out, convert its results to ancestor
-		// changes. Transition errors are checked later, once the ancestor has
-		// been updated.
+		// changes. We include the old entry from each transition so that Apply
+		// can identify directory metadata updates (which don't include
+		// directory contents). Transition errors are checked later, once the
+		// ancestor has been updated.
 		c.stateLock.Lock()
 		c.state.Status = Status_Transitioning
 		c.stateLock.Unlock()
@@ -1328,7 +1880,7 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				αResults, αProblems, αMissingFiles, αTransitionErr = alpha.Transition(ctx, αTransitions)
 				if αTransitionErr == nil {
 					for t, transition := range αTransitions {
-						αChanges = append(αChanges, &core.Change{Path: transition.Path, New: αResults[t]})
+						αChanges = append(αChanges, &core.Change{Path: transition.Path, Old: transition.Old, New: αResults[t]})
 					}
 				}
 				transitionDone.Done()
@@ -1340,7 +1892,7 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 				βResults, βProblems, βMissingFiles, βTransitionErr = beta.Transition(ctx, βTransitions)
 				if βTransitionErr == nil {
 					for t, transition := range βTransitions {
-						βChanges = append(βChanges, &core.Change{Path: transition.Path, New: βResults[t]})
+						βChanges = append(βChanges, &core.Change{Path: transition.Path, Old: transition.Old, New: βResults[t]})
 					}
 				}
 				transitionDone.Done()
@@ -1409,9 +1961,20 @@ func (c *controller) synchronize(ctx context.Context, alpha, beta Endpoint) erro
 			skippingPollingDueToMissingFiles = false
 		}
 
-		// Increment the synchronization cycle count.
+		// Increment the synchronization cycle count and record the changes made
+		// by the cycle (if any). Changes applied to beta originated on alpha,
+		// and vice versa.
 		c.stateLock.Lock()
 		c.state.SuccessfulCycles++
+		if len(αTransitions) > 0 || len(βTransitions) > 0 {
+			changes := &CycleChanges{Cycle: c.state.SuccessfulCycles}
+			changes.AlphaChanges, changes.ExcludedAlphaChanges = recordedChangePaths(βTransitions)
+			changes.BetaChanges, changes.ExcludedBetaChanges = recordedChangePaths(αTransitions)
+			c.state.RecentChanges = append(c.state.RecentChanges, changes)
+			if len(c.state.RecentChanges) > MaximumRecentChangeCycles {
+				c.state.RecentChanges = c.state.RecentChanges[1:]
+			}
+		}
 		c.stateLock.Unlock()
 
 		// If a flush request triggered this synchronization cycle, then tell it
@@ -1,3 +1,57 @@
 package synchronization
 
-// TODO: Implement.
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/conditions"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/state"
+)
+
+// TestControllerApplyPoliciesBusy tests that controller.applyPolicies skips
+// policy application (rather than blocking) when the controller's lifecycle
+// lock is held.
+func TestControllerApplyPoliciesBusy(t *testing.T) {
+	// Create a controller for a running session whose policies require
+	// suspension.
+	c := &controller{
+		logger: logging.NewLogger(logging.LevelDisabled, io.Discard),
+		session: &Session{
+			Version:       Version_Version1,
+			Configuration: &Configuration{MeteredPolicy: ConditionPolicy_ConditionPolicyPause},
+		},
+		stateLock: state.NewTrackingLock(state.NewTracker()),
+		state:     &State{},
+		cancel:    func() {},
+		done:      make(chan struct{}),
+	}
+	hostConditions := conditions.Conditions{Metered: true}
+
+	// Hold the lifecycle lock (as a long-running lifecycle operation would)
+	// and ensure that policy application returns without blocking or changing
+	// any state.
+	c.lifecycleLock.Lock()
+	applied := make(chan struct{})
+	go func() {
+		c.applyPolicies(hostConditions, time.Now())
+		close(applied)
+	}()
+	select {
+	case <-applied:
+	case <-time.After(10 * time.Second):
+		t.Fatal("policy application blocked on busy controller")
+	}
+	c.lifecycleLock.Unlock()
+	if c.policySuspension != "" || c.suspended {
+		t.Error("policies applied to busy controller")
+	}
+
+	// Ensure that policies are applied once the controller is no longer busy.
+	close(c.done)
+	c.applyPolicies(hostConditions, time.Now())
+	if c.policySuspension == "" || !c.suspended {
+		t.Error("policies not applied to idle controller")
+	}
+}
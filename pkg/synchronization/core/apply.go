@@ -5,8 +5,21 @@ import (
 	"strings"
 )
 
+// applyMetadata applies the metadata from a directory metadata change to an
+// existing (mutable) entry. If the existing entry isn't a directory, then the
+// new entry is returned (copied) in its place.
+func applyMetadata(existing *Entry, change *Change) *Entry {
+	if existing == nil || existing.Kind != EntryKind_Directory {
+		return change.New.Copy(true)
+	}
+	existing.ExtendedAttributes = change.New.ExtendedAttributes
+	return existing
+}
+
 // Apply applies a series of changes to a base entry. It ignores the Old value
-// for changes and only fails if the path to a change can't be resolved.
+// for changes (except to identify directory metadata changes, which update
+// only the properties of an existing directory and retain its contents) and
+// only fails if the path to a change can't be resolved.
 func Apply(base *Entry, changes []*Change) (*Entry, error) {
 	// If there are no changes, then we can just return the base unmodified.
 	if len(changes) == 0 {
@@ -15,7 +28,7 @@ func Apply(base *Entry, changes []*Change) (*Entry, error) {
 
 	// If there's only a single change and it's a root replacement, then we can
 	// just return the new entry.
-	if len(changes) == 1 && changes[0].Path == "" {
+	if len(changes) == 1 && changes[0].Path == "" && !changes[0].directoryMetadataOnly() {
 		return changes[0].New, nil
 	}
 
@@ -28,7 +41,11 @@ func Apply(base *Entry, changes []*Change) (*Entry, error) {
 		// occur mid-change-list, so we don't optimize for this case here in the
 		// same way that we do above.
 		if change.Path == "" {
-			result = change.New.Copy(true)
+			if change.directoryMetadataOnly() {
+				result = applyMetadata(result, change)
+			} else {
+				result = change.New.Copy(true)
+			}
 			continue
 		}
 
@@ -49,6 +66,11 @@ func Apply(base *Entry, changes []*Change) (*Entry, error) {
 		// case any subsequent changes affect it.
 		if change.New == nil {
 			delete(parent.Contents, components[0])
+		} else if change.directoryMetadataOnly() {
+			if parent.Contents == nil {
+				parent.Contents = make(map[string]*Entry)
+			}
+			parent.Contents[components[0]] = applyMetadata(parent.Contents[components[0]], change)
 		} else {
 			if parent.Contents == nil {
 				parent.Contents = make(map[string]*Entry)
@@ -37,3 +37,35 @@ func TestApply(t *testing.T) {
 		}
 	}
 }
+
+// TestApplyDirectoryMetadata tests that Apply treats directory-to-directory
+// changes as metadata-only updates that retain directory contents.
+func TestApplyDirectoryMetadata(t *testing.T) {
+	// Create a slim directory entry with extended attributes.
+	attributes := map[string][]byte{"user.mutagen": []byte("value")}
+	updated := &Entry{Kind: EntryKind_Directory, ExtendedAttributes: attributes}
+
+	// Process test cases for root and non-root paths.
+	for i, path := range []string{"", "child"} {
+		base := tD1
+		if path != "" {
+			base = nested(path, tD1)
+		}
+		change := &Change{Path: path, Old: &Entry{Kind: EntryKind_Directory}, New: updated}
+		if result, err := Apply(base, []*Change{change}); err != nil {
+			t.Errorf("test index %d: unable to apply changes: %v", i, err)
+		} else if !result.Equal(base, true) {
+			t.Errorf("test index %d: directory contents not retained", i)
+		} else {
+			target := result
+			if path != "" {
+				target = result.Contents[path]
+			}
+			if !ExtendedAttributesEqual(target.ExtendedAttributes, attributes) {
+				t.Errorf("test index %d: extended attributes not applied", i)
+			} else if tD1.ExtendedAttributes != nil {
+				t.Errorf("test index %d: base entry modified", i)
+			}
+		}
+	}
+}
@@ -29,13 +29,26 @@ func (c *Cache) EnsureValid() error {
 		}
 	}
 
+	// Perform the same validation for directory cache entries.
+	for _, e := range c.Directories {
+		if e == nil {
+			return errors.New("nil directory cache entry detected")
+		} else if e.ModificationTime == nil {
+			return errors.New("directory cache entry with nil modification time detected")
+		} else if err := e.ModificationTime.CheckValid(); err != nil {
+			return fmt.Errorf("directory cache entry modification time invalid: %w", err)
+		}
+	}
+
 	// Success.
 	return nil
 }
 
 // Equal determines whether or not another cache is equal to this one. It is
 // designed specifically for tests, though it is exported so that it can be used
-// by scan_bench.
+// by scan_bench. Directory cache entries aren't compared because they're only
+// recorded once they're no longer subject to modification time races, meaning
+// that they can legitimately differ between otherwise equivalent scans.
 func (c *Cache) Equal(other *Cache) bool {
 	// Verify non-nilness. We don't consider nil caches valid, so we don't
 	// consider them equal.
@@ -84,6 +97,34 @@ func (c *Cache) Equal(other *Cache) bool {
 	return true
 }
 
+// WithoutDirectories creates a shallow copy of the cache that omits directory
+// metadata for the specified paths and all of their parent directories. The
+// original cache is not modified.
+func (c *Cache) WithoutDirectories(paths map[string]bool) *Cache {
+	// Copy the directory metadata.
+	directories := make(map[string]*CacheEntry, len(c.Directories))
+	for path, entry := range c.Directories {
+		directories[path] = entry
+	}
+
+	// Remove directory metadata for the specified paths and their parents.
+	for path := range paths {
+		for {
+			delete(directories, path)
+			if path == "" {
+				break
+			}
+			path = pathDir(path)
+		}
+	}
+
+	// Done.
+	return &Cache{
+		Entries:     c.Entries,
+		Directories: directories,
+	}
+}
+
 // ReverseLookupMap provides facilities for doing reverse lookups to avoid
 // expensive staging operations in the case of renames and copies.
 type ReverseLookupMap struct {
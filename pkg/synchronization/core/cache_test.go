@@ -63,6 +63,43 @@ func TestCacheEnsureValid(t *testing.T) {
 	}
 }
 
+// TestCacheWithoutDirectories tests Cache.WithoutDirectories.
+func TestCacheWithoutDirectories(t *testing.T) {
+	// Create a cache with directory metadata.
+	entry := &CacheEntry{Mode: 0700, ModificationTime: timestamppb.Now()}
+	cache := &Cache{
+		Entries: map[string]*CacheEntry{"a/b/file": {}},
+		Directories: map[string]*CacheEntry{
+			"":      entry,
+			"a":     entry,
+			"a/b":   entry,
+			"a/b/c": entry,
+			"d":     entry,
+		},
+	}
+
+	// Remove metadata for a path and its parents.
+	result := cache.WithoutDirectories(map[string]bool{"a/b/file": true})
+
+	// Verify the result.
+	if len(result.Entries) != 1 {
+		t.Error("file entries not preserved")
+	}
+	if len(result.Directories) != 2 {
+		t.Error("directory metadata count does not match expected:", len(result.Directories), "!=", 2)
+	}
+	for _, path := range []string{"a/b/c", "d"} {
+		if _, ok := result.Directories[path]; !ok {
+			t.Error("directory metadata unexpectedly removed for path:", path)
+		}
+	}
+
+	// Verify that the original cache wasn't modified.
+	if len(cache.Directories) != 5 {
+		t.Error("original cache modified")
+	}
+}
+
 // TODO: Implement TestCacheEqual. This is purely an internal testing method,
 // but it's worth testing for completeness.
 
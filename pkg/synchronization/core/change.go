@@ -1,8 +1,10 @@
 package core
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // EnsureValid ensures that Change's invariants are respected. If synchronizable
@@ -52,3 +54,94 @@ func (c *Change) IsRootDeletion() bool {
 func (c *Change) IsRootTypeChange() bool {
 	return c.Path == "" && c.Old != nil && c.New != nil && c.Old.Kind != c.New.Kind
 }
+
+// directoryMetadataOnly indicates whether or not the change represents a
+// metadata-only update of an existing directory, which is the case if both the
+// old and new entries are directories. Reconciliation only generates such
+// changes for extended attribute updates, in which case both entries are
+// shallow and the directory contents aren't affected.
+func (c *Change) directoryMetadataOnly() bool {
+	return c.Old != nil && c.New != nil &&
+		c.Old.Kind == EntryKind_Directory &&
+		c.New.Kind == EntryKind_Directory
+}
+
+// DisplacedFiles returns the file entries within the change's old entry
+// hierarchy whose content will be replaced or removed when the change is
+// applied, keyed by their root-relative paths. Files that will be recreated
+// with identical content at the same path are not included.
+func (c *Change) DisplacedFiles() map[string]*Entry {
+	// Walk the old entry hierarchy and identify displaced files.
+	var result map[string]*Entry
+	c.Old.walk(c.Path, func(path string, entry *Entry) {
+		// Ignore anything that isn't a file.
+		if entry == nil || entry.Kind != EntryKind_File {
+			return
+		}
+
+		// Locate the corresponding entry in the new hierarchy.
+		replacement := c.locate(c.New, path)
+
+		// If the content is being preserved, then the file isn't displaced.
+		if replacement != nil && replacement.Kind == EntryKind_File &&
+			bytes.Equal(replacement.Digest, entry.Digest) {
+			return
+		}
+
+		// Record the displaced file.
+		if result == nil {
+			result = make(map[string]*Entry)
+		}
+		result[path] = entry
+	}, false)
+
+	// Done.
+	return result
+}
+
+// CreatedEntries returns the entries within the change's new entry hierarchy
+// that don't exist (with the same kind) in the change's old entry hierarchy and
+// will thus be created when the change is applied, keyed by their root-relative
+// paths. Only files, directories, and symbolic links are included.
+func (c *Change) CreatedEntries() map[string]*Entry {
+	// Walk the new entry hierarchy and identify created entries.
+	var result map[string]*Entry
+	c.New.walk(c.Path, func(path string, entry *Entry) {
+		// Ignore anything that won't be created on disk.
+		if entry == nil || !(entry.Kind == EntryKind_Directory ||
+			entry.Kind == EntryKind_File ||
+			entry.Kind == EntryKind_SymbolicLink) {
+			return
+		}
+
+		// If an entry of the same kind already exists, then it isn't created.
+		if existing := c.locate(c.Old, path); existing != nil && existing.Kind == entry.Kind {
+			return
+		}
+
+		// Record the created entry.
+		if result == nil {
+			result = make(map[string]*Entry)
+		}
+		result[path] = entry
+	}, false)
+
+	// Done.
+	return result
+}
+
+// locate finds the entry at the specified root-relative path within the
+// specified entry hierarchy, which must be rooted at the change's path. It
+// returns nil if no entry exists at the path.
+func (c *Change) locate(hierarchy *Entry, path string) *Entry {
+	if path == c.Path {
+		return hierarchy
+	}
+	for _, component := range strings.Split(path[len(pathJoinable(c.Path)):], "/") {
+		if hierarchy == nil {
+			return nil
+		}
+		hierarchy = hierarchy.Contents[component]
+	}
+	return hierarchy
+}
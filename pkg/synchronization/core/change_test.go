@@ -145,3 +145,76 @@ func TestIsRootTypeChange(t *testing.T) {
 		}
 	}
 }
+
+// TestChangeDisplacedFiles tests Change.DisplacedFiles.
+func TestChangeDisplacedFiles(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		change   *Change
+		expected map[string]*Entry
+	}{
+		{&Change{}, nil},
+		{&Change{New: tF1}, nil},
+		{&Change{Old: tF1, New: tF1}, nil},
+		{&Change{Old: tF1}, map[string]*Entry{"": tF1}},
+		{&Change{Path: "content", Old: tF1, New: tF2}, map[string]*Entry{"content": tF1}},
+		{&Change{Path: "content", Old: tF1, New: tD1}, map[string]*Entry{"content": tF1}},
+		{&Change{Path: "content", Old: tD1, New: tD1}, nil},
+		{&Change{Path: "content", Old: tD1, New: tD2}, map[string]*Entry{"content/file": tF1}},
+		{&Change{Path: "content", Old: tD1, New: tSA}, map[string]*Entry{"content/file": tF1}},
+		{&Change{Old: tDCC, New: tD1}, map[string]*Entry{"FILE": tF2}},
+		{&Change{Old: tSA}, nil},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		displaced := test.change.DisplacedFiles()
+		if len(displaced) != len(test.expected) {
+			t.Errorf("test index %d: displaced file count does not match expected: %d != %d",
+				i, len(displaced), len(test.expected),
+			)
+			continue
+		}
+		for path, entry := range test.expected {
+			if !displaced[path].Equal(entry, true) {
+				t.Errorf("test index %d: displaced file at path %q does not match expected", i, path)
+			}
+		}
+	}
+}
+
+// TestChangeCreatedEntries tests Change.CreatedEntries.
+func TestChangeCreatedEntries(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		change   *Change
+		expected map[string]*Entry
+	}{
+		{&Change{}, nil},
+		{&Change{Old: tF1}, nil},
+		{&Change{Old: tF1, New: tF2}, nil},
+		{&Change{New: tF1}, map[string]*Entry{"": tF1}},
+		{&Change{Path: "content", Old: tF1, New: tD1}, map[string]*Entry{"content": tD1, "content/file": tF1}},
+		{&Change{Path: "content", Old: tD0, New: tD1}, map[string]*Entry{"content/file": tF1}},
+		{&Change{Path: "content", Old: tD1, New: tD2}, nil},
+		{&Change{Path: "content", Old: tF1, New: tSA}, map[string]*Entry{"content": tSA}},
+		{&Change{Old: tD1, New: tDCC}, map[string]*Entry{"FILE": tF2}},
+		{&Change{New: tPInvalidUTF8}, nil},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		created := test.change.CreatedEntries()
+		if len(created) != len(test.expected) {
+			t.Errorf("test index %d: created entry count does not match expected: %d != %d",
+				i, len(created), len(test.expected),
+			)
+			continue
+		}
+		for path, entry := range test.expected {
+			if !created[path].Equal(entry, true) {
+				t.Errorf("test index %d: created entry at path %q does not match expected", i, path)
+			}
+		}
+	}
+}
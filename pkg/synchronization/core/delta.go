@@ -0,0 +1,263 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// deltaBlockSize is the granularity at which DeltaWriter compares content
+	// against its base. It also bounds the length of individual delta records.
+	deltaBlockSize = 64 * 1024
+	// deltaHeaderSize is the size of the delta header, which consists of the
+	// base size and the base modification time (in nanoseconds since the Unix
+	// epoch), both encoded as 64-bit big-endian integers.
+	deltaHeaderSize = 16
+	// deltaRecordHeaderSize is the size of the header preceding each delta
+	// record, which consists of a 64-bit big-endian offset and a 32-bit
+	// big-endian length.
+	deltaRecordHeaderSize = 12
+)
+
+// deltaZeroBlock is a zero-filled block used for detecting zero-filled content
+// that extends beyond the end of the base.
+var deltaZeroBlock [deltaBlockSize]byte
+
+// DeltaWriter is an io.Writer that encodes content as a block-level delta
+// against an existing base file. Blocks that are identical to the base at the
+// same offset are omitted, as are zero-filled blocks that extend beyond the end
+// of the base (since these will be implicitly zero-filled when the file is
+// extended). Differing blocks are recorded along with their offsets, allowing
+// the delta to be applied directly to the base without storing a complete copy
+// of the new content. The base's size and modification time are recorded so
+// that the base can be verified before the delta is applied. Close must be
+// invoked once writing is complete in order to record the final content size.
+type DeltaWriter struct {
+	// destination is the destination for the encoded delta.
+	destination io.Writer
+	// base is the base content. It is set to nil once it has been exhausted or
+	// has failed to read.
+	base io.Reader
+	// baseSize is the size of the base.
+	baseSize int64
+	// offset is the offset of the current block.
+	offset int64
+	// block is the buffer for the current block.
+	block []byte
+	// buffered is the number of bytes buffered in the current block.
+	buffered int
+	// baseBlock is the buffer for the corresponding block of the base.
+	baseBlock []byte
+}
+
+// NewDeltaWriter creates a new delta writer that encodes content against the
+// specified base, which must be positioned at its start and must have the
+// specified size and modification time. The delta header is written to the
+// destination immediately.
+func NewDeltaWriter(destination io.Writer, base io.Reader, baseSize int64, baseModificationTime time.Time) (*DeltaWriter, error) {
+	// Write the header.
+	var header [deltaHeaderSize]byte
+	binary.BigEndian.PutUint64(header[:8], uint64(baseSize))
+	binary.BigEndian.PutUint64(header[8:], uint64(baseModificationTime.UnixNano()))
+	if _, err := destination.Write(header[:]); err != nil {
+		return nil, fmt.Errorf("unable to write delta header: %w", err)
+	}
+
+	// Success.
+	return &DeltaWriter{
+		destination: destination,
+		base:        base,
+		baseSize:    baseSize,
+		block:       make([]byte, deltaBlockSize),
+		baseBlock:   make([]byte, deltaBlockSize),
+	}, nil
+}
+
+// Write implements io.Writer.Write.
+func (w *DeltaWriter) Write(data []byte) (int, error) {
+	// Track the number of bytes that we've processed.
+	var processed int
+
+	// Loop until all data has been buffered, processing complete blocks.
+	for len(data) > 0 {
+		n := copy(w.block[w.buffered:], data)
+		w.buffered += n
+		data = data[n:]
+		processed += n
+		if w.buffered == len(w.block) {
+			if err := w.flush(); err != nil {
+				return processed, err
+			}
+		}
+	}
+
+	// Success.
+	return processed, nil
+}
+
+// flush processes the buffered block, recording it if it differs from the base.
+func (w *DeltaWriter) flush() error {
+	// Grab the buffered block.
+	block := w.block[:w.buffered]
+
+	// Determine whether or not the block matches the base. If the base can't
+	// be read (or has been exhausted), then we stop reading from it, meaning
+	// that all subsequent blocks will be recorded (except for zero-filled
+	// blocks beyond the end of the base).
+	var unchanged bool
+	if w.base != nil {
+		n, err := io.ReadFull(w.base, w.baseBlock[:len(block)])
+		if err != nil {
+			w.base = nil
+		}
+		unchanged = n == len(block) && bytes.Equal(block, w.baseBlock[:n])
+	}
+	if !unchanged && w.offset >= w.baseSize {
+		unchanged = bytes.Equal(block, deltaZeroBlock[:len(block)])
+	}
+
+	// Record the block if necessary.
+	if !unchanged {
+		if err := writeDeltaRecord(w.destination, w.offset, block); err != nil {
+			return fmt.Errorf("unable to write delta record: %w", err)
+		}
+	}
+
+	// Update the offset and reset the buffer.
+	w.offset += int64(len(block))
+	w.buffered = 0
+
+	// Success.
+	return nil
+}
+
+// Close processes any partially buffered block and records the final content
+// size. It does not close the destination or the base.
+func (w *DeltaWriter) Close() error {
+	// Process any partially buffered block.
+	if w.buffered > 0 {
+		if err := w.flush(); err != nil {
+			return err
+		}
+	}
+
+	// Write the terminating record.
+	if err := writeDeltaRecord(w.destination, w.offset, nil); err != nil {
+		return fmt.Errorf("unable to write delta terminator: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// writeDeltaRecord writes a delta record with the specified offset and data. A
+// record with empty data is a terminating record, in which case the offset
+// specifies the final content size.
+func writeDeltaRecord(destination io.Writer, offset int64, data []byte) error {
+	// Encode and write the record header.
+	var header [deltaRecordHeaderSize]byte
+	binary.BigEndian.PutUint64(header[:8], uint64(offset))
+	binary.BigEndian.PutUint32(header[8:], uint32(len(data)))
+	if _, err := destination.Write(header[:]); err != nil {
+		return err
+	}
+
+	// Write the record data, if any.
+	if len(data) > 0 {
+		if _, err := destination.Write(data); err != nil {
+			return err
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// deltaReader decodes deltas written by DeltaWriter.
+type deltaReader struct {
+	// source is the encoded delta.
+	source io.Reader
+	// baseSize is the size of the base against which the delta was computed.
+	baseSize int64
+	// baseModificationTime is the modification time of the base against which
+	// the delta was computed.
+	baseModificationTime time.Time
+	// offset is the offset immediately following the last record read. It is
+	// used to enforce that records are ordered and non-overlapping.
+	offset int64
+	// data is the buffer for record data.
+	data []byte
+}
+
+// newDeltaReader creates a new delta reader, reading the delta header from the
+// specified source.
+func newDeltaReader(source io.Reader) (*deltaReader, error) {
+	// Read the header.
+	var header [deltaHeaderSize]byte
+	if _, err := io.ReadFull(source, header[:]); err != nil {
+		return nil, fmt.Errorf("unable to read delta header: %w", err)
+	}
+	baseSize := int64(binary.BigEndian.Uint64(header[:8]))
+	if baseSize < 0 {
+		return nil, errors.New("invalid delta base size")
+	}
+
+	// Success.
+	return &deltaReader{
+		source:               source,
+		baseSize:             baseSize,
+		baseModificationTime: time.Unix(0, int64(binary.BigEndian.Uint64(header[8:]))),
+		data:                 make([]byte, deltaBlockSize),
+	}, nil
+}
+
+// matchesBase determines whether or not the specified size and modification
+// time match those of the base against which the delta was computed.
+func (r *deltaReader) matchesBase(size int64, modificationTime time.Time) bool {
+	return size == r.baseSize && modificationTime.Equal(r.baseModificationTime)
+}
+
+// next reads the next record from the delta. If the record is the terminating
+// record, then it returns the final content size and nil data. The returned
+// data is only valid until the next call to next.
+func (r *deltaReader) next() (int64, []byte, error) {
+	// Read and decode the record header.
+	var header [deltaRecordHeaderSize]byte
+	if _, err := io.ReadFull(r.source, header[:]); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, fmt.Errorf("unable to read delta record header: %w", err)
+	}
+	offset := int64(binary.BigEndian.Uint64(header[:8]))
+	length := binary.BigEndian.Uint32(header[8:])
+
+	// Validate the record.
+	if offset < r.offset {
+		return 0, nil, errors.New("delta record out of order")
+	} else if length > deltaBlockSize {
+		return 0, nil, errors.New("delta record too large")
+	}
+
+	// Handle the terminating record.
+	if length == 0 {
+		return offset, nil, nil
+	}
+
+	// Read the record data.
+	data := r.data[:length]
+	if _, err := io.ReadFull(r.source, data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, fmt.Errorf("unable to read delta record data: %w", err)
+	}
+	r.offset = offset + int64(length)
+
+	// Success.
+	return offset, data, nil
+}
@@ -0,0 +1,105 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+// TestDeltaWriter tests that DeltaWriter only records blocks that differ from
+// the base and that its output can be used to reconstruct content.
+func TestDeltaWriter(t *testing.T) {
+	// Create base content with distinct blocks.
+	base := make([]byte, 3*deltaBlockSize)
+	for i := range base {
+		base[i] = byte(i/deltaBlockSize + 1)
+	}
+
+	// Create variants of the base content.
+	modified := append([]byte(nil), base...)
+	modified[deltaBlockSize+1] = 0
+	zeroExtended := append(append([]byte(nil), base...), make([]byte, 2*deltaBlockSize)...)
+	extended := append(append([]byte(nil), base...), []byte("extension")...)
+
+	// Define test cases.
+	testCases := []struct {
+		base            []byte
+		content         []byte
+		expectedRecords int
+	}{
+		{base, base, 0},
+		{base, modified, 1},
+		{base, zeroExtended, 0},
+		{base, extended, 1},
+		{base, base[:deltaBlockSize+deltaBlockSize/2], 0},
+		{base, nil, 0},
+		{nil, base, 3},
+		{nil, make([]byte, deltaBlockSize+1), 0},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Encode the delta, writing in chunks that aren't block-aligned in
+		// order to exercise buffering.
+		encoded := &bytes.Buffer{}
+		modificationTime := time.Now()
+		writer, err := NewDeltaWriter(encoded, bytes.NewReader(testCase.base), int64(len(testCase.base)), modificationTime)
+		if err != nil {
+			t.Fatalf("test case %d: unable to create delta writer: %v", i, err)
+		}
+		for content := testCase.content; len(content) > 0; {
+			chunk := 1000
+			if chunk > len(content) {
+				chunk = len(content)
+			}
+			if _, err := writer.Write(content[:chunk]); err != nil {
+				t.Fatalf("test case %d: unable to write content: %v", i, err)
+			}
+			content = content[chunk:]
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatalf("test case %d: unable to close delta writer: %v", i, err)
+		}
+
+		// Decode the delta and verify its header and record count.
+		reader, err := newDeltaReader(bytes.NewReader(encoded.Bytes()))
+		if err != nil {
+			t.Fatalf("test case %d: unable to create delta reader: %v", i, err)
+		} else if !reader.matchesBase(int64(len(testCase.base)), modificationTime) {
+			t.Errorf("test case %d: delta base does not match", i)
+		}
+		var records int
+		for {
+			offset, data, err := reader.next()
+			if err != nil {
+				t.Fatalf("test case %d: unable to read delta record: %v", i, err)
+			} else if data == nil {
+				if offset != int64(len(testCase.content)) {
+					t.Errorf("test case %d: content size mismatch: %d != %d", i, offset, len(testCase.content))
+				}
+				break
+			}
+			records++
+		}
+		if records != testCase.expectedRecords {
+			t.Errorf("test case %d: record count mismatch: %d != %d", i, records, testCase.expectedRecords)
+		}
+
+		// Reconstruct the content and verify it.
+		reader, err = newDeltaReader(bytes.NewReader(encoded.Bytes()))
+		if err != nil {
+			t.Fatalf("test case %d: unable to create delta reader: %v", i, err)
+		}
+		transitioner := &transitioner{
+			cancelled:  context.Background().Done(),
+			copyBuffer: make([]byte, transitionCopyBufferSize),
+		}
+		reconstructed := &bytes.Buffer{}
+		if err := transitioner.reconstructFromDelta(reconstructed, bytes.NewReader(testCase.base), reader); err != nil {
+			t.Errorf("test case %d: unable to reconstruct content: %v", i, err)
+		} else if !bytes.Equal(reconstructed.Bytes(), testCase.content) {
+			t.Errorf("test case %d: reconstructed content does not match", i)
+		}
+	}
+}
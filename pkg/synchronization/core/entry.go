@@ -3,6 +3,7 @@ package core
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"strings"
 )
 
@@ -34,6 +35,31 @@ func (k EntryKind) MarshalText() ([]byte, error) {
 	return []byte(result), nil
 }
 
+// ensureExtendedAttributesValid ensures that extended attribute names are
+// non-empty.
+func ensureExtendedAttributesValid(attributes map[string][]byte) error {
+	for name := range attributes {
+		if name == "" {
+			return errors.New("empty extended attribute name detected")
+		}
+	}
+	return nil
+}
+
+// ExtendedAttributesEqual determines whether or not two sets of extended
+// attributes are equivalent. Nil and empty sets are considered equivalent.
+func ExtendedAttributesEqual(first, second map[string][]byte) bool {
+	if len(first) != len(second) {
+		return false
+	}
+	for name, value := range first {
+		if other, ok := second[name]; !ok || !bytes.Equal(value, other) {
+			return false
+		}
+	}
+	return true
+}
+
 // EnsureValid ensures that Entry's invariants are respected. If synchronizable
 // is true, then unsynchronizable content will be considered invalid.
 func (e *Entry) EnsureValid(synchronizable bool) error {
@@ -49,12 +75,21 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 			return errors.New("non-nil directory digest detected")
 		} else if e.Executable {
 			return errors.New("executable directory detected")
+		} else if e.ModificationTime != nil {
+			return errors.New("non-nil directory modification time detected")
+		} else if e.HardLinkGroup != "" {
+			return errors.New("non-empty hard link group detected for directory")
 		} else if e.Target != "" {
 			return errors.New("non-empty symbolic link target detected for directory")
 		} else if e.Problem != "" {
 			return errors.New("non-empty problem detected for directory")
 		}
 
+		// Validate extended attributes.
+		if err := ensureExtendedAttributesValid(e.ExtendedAttributes); err != nil {
+			return err
+		}
+
 		// Validate contents. Nil entries are not considered valid for contents.
 		for name, entry := range e.Contents {
 			if name == "" {
@@ -83,6 +118,23 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 		if len(e.Digest) == 0 {
 			return errors.New("file with empty digest detected")
 		}
+
+		// Validate extended attributes.
+		if err := ensureExtendedAttributesValid(e.ExtendedAttributes); err != nil {
+			return err
+		}
+
+		// Ensure that the modification time (if any) is valid.
+		if e.ModificationTime != nil {
+			if err := e.ModificationTime.CheckValid(); err != nil {
+				return fmt.Errorf("invalid file modification time detected: %w", err)
+			}
+		}
+
+		// Ensure that the hard link group (if any) is a valid path.
+		if e.HardLinkGroup != "" && !pathIsCanonical(e.HardLinkGroup) {
+			return errors.New("invalid hard link group detected")
+		}
 	} else if e.Kind == EntryKind_SymbolicLink {
 		// Ensure that no invalid fields are set.
 		if e.Contents != nil {
@@ -91,6 +143,12 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 			return errors.New("non-nil symbolic link digest detected")
 		} else if e.Executable {
 			return errors.New("executable symbolic link detected")
+		} else if e.ModificationTime != nil {
+			return errors.New("non-nil symbolic link modification time detected")
+		} else if e.HardLinkGroup != "" {
+			return errors.New("non-empty hard link group detected for symbolic link")
+		} else if e.ExtendedAttributes != nil {
+			return errors.New("non-nil symbolic link extended attributes detected")
 		} else if e.Problem != "" {
 			return errors.New("non-empty problem detected for symbolic link")
 		}
@@ -113,6 +171,12 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 			return errors.New("non-nil untracked content digest detected")
 		} else if e.Executable {
 			return errors.New("executable untracked content detected")
+		} else if e.ModificationTime != nil {
+			return errors.New("non-nil untracked content modification time detected")
+		} else if e.HardLinkGroup != "" {
+			return errors.New("non-empty hard link group detected for untracked content")
+		} else if e.ExtendedAttributes != nil {
+			return errors.New("non-nil untracked content extended attributes detected")
 		} else if e.Target != "" {
 			return errors.New("non-empty symbolic link target detected for untracked content")
 		} else if e.Problem != "" {
@@ -131,6 +195,12 @@ func (e *Entry) EnsureValid(synchronizable bool) error {
 			return errors.New("non-nil problematic content digest detected")
 		} else if e.Executable {
 			return errors.New("executable problematic content detected")
+		} else if e.ModificationTime != nil {
+			return errors.New("non-nil problematic content modification time detected")
+		} else if e.HardLinkGroup != "" {
+			return errors.New("non-empty hard link group detected for problematic content")
+		} else if e.ExtendedAttributes != nil {
+			return errors.New("non-nil problematic content extended attributes detected")
 		} else if e.Target != "" {
 			return errors.New("non-empty symbolic link target detected for problematic content")
 		}
@@ -246,7 +316,11 @@ func (e *Entry) Equal(other *Entry, deep bool) bool {
 		return false
 	}
 
-	// Compare all properties except for problem messages..
+	// Compare all properties except for problem messages. Modification times
+	// and hard link groups are intentionally excluded since they're only
+	// propagated alongside content and shouldn't participate in change or
+	// conflict detection. Extended attributes are also excluded since they're
+	// compared separately by reconciliation (see ExtendedAttributesEqual).
 	propertiesEquivalent := e.Kind == other.Kind &&
 		e.Executable == other.Executable &&
 		bytes.Equal(e.Digest, other.Digest) &&
@@ -308,11 +382,14 @@ func (e *Entry) Copy(deep bool) *Entry {
 
 	// Create a slim copy.
 	result := &Entry{
-		Kind:       e.Kind,
-		Executable: e.Executable,
-		Digest:     e.Digest,
-		Target:     e.Target,
-		Problem:    e.Problem,
+		Kind:               e.Kind,
+		Executable:         e.Executable,
+		Digest:             e.Digest,
+		ModificationTime:   e.ModificationTime,
+		HardLinkGroup:      e.HardLinkGroup,
+		ExtendedAttributes: e.ExtendedAttributes,
+		Target:             e.Target,
+		Problem:            e.Problem,
 	}
 
 	// If a deep copy wasn't requested, then we're done.
@@ -368,10 +445,13 @@ func (e *Entry) synchronizable() *Entry {
 	// Create a slim copy of the entry. We only need to copy fields for
 	// synchronizable entry types since we know this entry is synchronizable.
 	result := &Entry{
-		Kind:       e.Kind,
-		Executable: e.Executable,
-		Digest:     e.Digest,
-		Target:     e.Target,
+		Kind:               e.Kind,
+		Executable:         e.Executable,
+		Digest:             e.Digest,
+		ModificationTime:   e.ModificationTime,
+		HardLinkGroup:      e.HardLinkGroup,
+		ExtendedAttributes: e.ExtendedAttributes,
+		Target:             e.Target,
 	}
 
 	// Copy the entry contents. Some may not be synchronizable, in which case we
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"fmt"
+	"path"
+)
+
+// ValidExtendedAttributePattern checks whether or not a given pattern is a
+// valid extended attribute name pattern. Patterns use the same syntax as
+// path.Match (e.g. "user.*" or "system.posix_acl_*").
+func ValidExtendedAttributePattern(pattern string) bool {
+	if pattern == "" {
+		return false
+	}
+	_, err := path.Match(pattern, "")
+	return err == nil
+}
+
+// newExtendedAttributeFilter creates a filter function that accepts extended
+// attribute names matching any of the specified patterns. If no patterns are
+// specified, then it returns a nil filter, indicating that extended attributes
+// shouldn't be captured.
+func newExtendedAttributeFilter(patterns []string) (func(string) bool, error) {
+	// If there are no patterns, then there's no filter.
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	// Validate patterns.
+	for _, pattern := range patterns {
+		if !ValidExtendedAttributePattern(pattern) {
+			return nil, fmt.Errorf("invalid extended attribute pattern: %s", pattern)
+		}
+	}
+
+	// Create the filter. We've already validated the patterns, so we can
+	// ignore errors from path.Match.
+	return func(name string) bool {
+		for _, pattern := range patterns {
+			if match, _ := path.Match(pattern, name); match {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
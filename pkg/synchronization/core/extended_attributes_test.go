@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestValidExtendedAttributePattern tests ValidExtendedAttributePattern.
+func TestValidExtendedAttributePattern(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		pattern  string
+		expected bool
+	}{
+		{"", false},
+		{"user.*", true},
+		{"system.posix_acl_access", true},
+		{"user.[", false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		if valid := ValidExtendedAttributePattern(test.pattern); valid != test.expected {
+			t.Errorf("pattern validity (%t) does not match expected (%t) for pattern: %s",
+				valid, test.expected, test.pattern,
+			)
+		}
+	}
+}
+
+// TestNewExtendedAttributeFilter tests newExtendedAttributeFilter.
+func TestNewExtendedAttributeFilter(t *testing.T) {
+	// Verify that an empty pattern list yields a nil filter.
+	if filter, err := newExtendedAttributeFilter(nil); err != nil {
+		t.Fatal("unable to create empty filter:", err)
+	} else if filter != nil {
+		t.Error("empty pattern list yielded non-nil filter")
+	}
+
+	// Verify that invalid patterns are rejected.
+	if _, err := newExtendedAttributeFilter([]string{"user.["}); err == nil {
+		t.Error("invalid pattern accepted")
+	}
+
+	// Create a filter and verify its matching behavior.
+	filter, err := newExtendedAttributeFilter([]string{"user.*", "system.posix_acl_access"})
+	if err != nil {
+		t.Fatal("unable to create filter:", err)
+	}
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"user.build_cache", true},
+		{"system.posix_acl_access", true},
+		{"system.posix_acl_default", false},
+		{"security.selinux", false},
+	}
+	for _, test := range tests {
+		if match := filter(test.name); match != test.expected {
+			t.Errorf("match result (%t) does not match expected (%t) for name: %s",
+				match, test.expected, test.name,
+			)
+		}
+	}
+}
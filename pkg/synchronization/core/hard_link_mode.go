@@ -0,0 +1,72 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the hard link mode is
+// HardLinkMode_HardLinkModeDefault.
+func (m HardLinkMode) IsDefault() bool {
+	return m == HardLinkMode_HardLinkModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m HardLinkMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case HardLinkMode_HardLinkModeDefault:
+	case HardLinkMode_HardLinkModeIndependent:
+		result = "independent"
+	case HardLinkMode_HardLinkModePreserve:
+		result = "preserve"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *HardLinkMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a hard link mode.
+	switch text {
+	case "independent":
+		*m = HardLinkMode_HardLinkModeIndependent
+	case "preserve":
+		*m = HardLinkMode_HardLinkModePreserve
+	default:
+		return fmt.Errorf("unknown hard link mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular hard link mode is a valid,
+// non-default value.
+func (m HardLinkMode) Supported() bool {
+	switch m {
+	case HardLinkMode_HardLinkModeIndependent:
+		return true
+	case HardLinkMode_HardLinkModePreserve:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a hard link mode.
+func (m HardLinkMode) Description() string {
+	switch m {
+	case HardLinkMode_HardLinkModeDefault:
+		return "Default"
+	case HardLinkMode_HardLinkModeIndependent:
+		return "Independent"
+	case HardLinkMode_HardLinkModePreserve:
+		return "Preserve"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: synchronization/core/hard_link_mode.proto
+
+package core
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HardLinkMode specifies the mode for handling hard links.
+type HardLinkMode int32
+
+const (
+	// HardLinkMode_HardLinkModeDefault represents an unspecified hard link
+	// mode. It is not valid for use with Scan. It should be converted to one of
+	// the following values based on the desired default behavior.
+	HardLinkMode_HardLinkModeDefault HardLinkMode = 0
+	// HardLinkMode_HardLinkModeIndependent specifies that hard-linked paths
+	// should be treated as independent files, with each path's contents being
+	// staged and created separately.
+	HardLinkMode_HardLinkModeIndependent HardLinkMode = 1
+	// HardLinkMode_HardLinkModePreserve specifies that groups of hard-linked
+	// paths should be detected during scanning and recorded in snapshots, with
+	// their contents being staged only once and the links being recreated
+	// during transitions. Link structure is only propagated alongside content
+	// and is not considered in change or conflict detection.
+	HardLinkMode_HardLinkModePreserve HardLinkMode = 2
+)
+
+// Enum value maps for HardLinkMode.
+var (
+	HardLinkMode_name = map[int32]string{
+		0: "HardLinkModeDefault",
+		1: "HardLinkModeIndependent",
+		2: "HardLinkModePreserve",
+	}
+	HardLinkMode_value = map[string]int32{
+		"HardLinkModeDefault":     0,
+		"HardLinkModeIndependent": 1,
+		"HardLinkModePreserve":    2,
+	}
+)
+
+func (x HardLinkMode) Enum() *HardLinkMode {
+	p := new(HardLinkMode)
+	*p = x
+	return p
+}
+
+func (x HardLinkMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HardLinkMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_synchronization_core_hard_link_mode_proto_enumTypes[0].Descriptor()
+}
+
+func (HardLinkMode) Type() protoreflect.EnumType {
+	return &file_synchronization_core_hard_link_mode_proto_enumTypes[0]
+}
+
+func (x HardLinkMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HardLinkMode.Descriptor instead.
+func (HardLinkMode) EnumDescriptor() ([]byte, []int) {
+	return file_synchronization_core_hard_link_mode_proto_rawDescGZIP(), []int{0}
+}
+
+var File_synchronization_core_hard_link_mode_proto protoreflect.FileDescriptor
+
+var file_synchronization_core_hard_link_mode_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x68, 0x61, 0x72, 0x64, 0x5f, 0x6c, 0x69, 0x6e, 0x6b,
+	0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72,
+	0x65, 0x2a, 0x5e, 0x0a, 0x0c, 0x48, 0x61, 0x72, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x17, 0x0a, 0x13, 0x48, 0x61, 0x72, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64,
+	0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x61,
+	0x72, 0x64, 0x4c, 0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x70, 0x65,
+	0x6e, 0x64, 0x65, 0x6e, 0x74, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x48, 0x61, 0x72, 0x64, 0x4c,
+	0x69, 0x6e, 0x6b, 0x4d, 0x6f, 0x64, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x10,
+	0x02, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67,
+	0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_synchronization_core_hard_link_mode_proto_rawDescOnce sync.Once
+	file_synchronization_core_hard_link_mode_proto_rawDescData = file_synchronization_core_hard_link_mode_proto_rawDesc
+)
+
+func file_synchronization_core_hard_link_mode_proto_rawDescGZIP() []byte {
+	file_synchronization_core_hard_link_mode_proto_rawDescOnce.Do(func() {
+		file_synchronization_core_hard_link_mode_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_core_hard_link_mode_proto_rawDescData)
+	})
+	return file_synchronization_core_hard_link_mode_proto_rawDescData
+}
+
+var file_synchronization_core_hard_link_mode_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_synchronization_core_hard_link_mode_proto_goTypes = []interface{}{
+	(HardLinkMode)(0), // 0: core.HardLinkMode
+}
+var file_synchronization_core_hard_link_mode_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_core_hard_link_mode_proto_init() }
+func file_synchronization_core_hard_link_mode_proto_init() {
+	if File_synchronization_core_hard_link_mode_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_core_hard_link_mode_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_core_hard_link_mode_proto_goTypes,
+		DependencyIndexes: file_synchronization_core_hard_link_mode_proto_depIdxs,
+		EnumInfos:         file_synchronization_core_hard_link_mode_proto_enumTypes,
+	}.Build()
+	File_synchronization_core_hard_link_mode_proto = out.File
+	file_synchronization_core_hard_link_mode_proto_rawDesc = nil
+	file_synchronization_core_hard_link_mode_proto_goTypes = nil
+	file_synchronization_core_hard_link_mode_proto_depIdxs = nil
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestHardLinkModeIsDefault tests HardLinkMode.IsDefault.
+func TestHardLinkModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    HardLinkMode
+		expected bool
+	}{
+		{HardLinkMode_HardLinkModeDefault - 1, false},
+		{HardLinkMode_HardLinkModeDefault, true},
+		{HardLinkMode_HardLinkModeIndependent, false},
+		{HardLinkMode_HardLinkModePreserve, false},
+		{HardLinkMode_HardLinkModePreserve + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestHardLinkModeUnmarshalText tests HardLinkMode.UnmarshalText.
+func TestHardLinkModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  HardLinkMode
+		expectFailure bool
+	}{
+		{"", HardLinkMode_HardLinkModeDefault, true},
+		{"asdf", HardLinkMode_HardLinkModeDefault, true},
+		{"independent", HardLinkMode_HardLinkModeIndependent, false},
+		{"preserve", HardLinkMode_HardLinkModePreserve, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode HardLinkMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestHardLinkModeSupported tests HardLinkMode.Supported.
+func TestHardLinkModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            HardLinkMode
+		expectSupported bool
+	}{
+		{HardLinkMode_HardLinkModeDefault, false},
+		{HardLinkMode_HardLinkModeIndependent, true},
+		{HardLinkMode_HardLinkModePreserve, true},
+		{(HardLinkMode_HardLinkModePreserve + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestHardLinkModeDescription tests HardLinkMode.Description.
+func TestHardLinkModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                HardLinkMode
+		expectedDescription string
+	}{
+		{HardLinkMode_HardLinkModeDefault, "Default"},
+		{HardLinkMode_HardLinkModeIndependent, "Independent"},
+		{HardLinkMode_HardLinkModePreserve, "Preserve"},
+		{(HardLinkMode_HardLinkModePreserve + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"strings"
 
 	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
 )
 
 // ignorePattern represents a single parsed ignore pattern.
@@ -143,8 +145,13 @@ func newIgnorer(patterns []string) (*ignorer, error) {
 // on all provided ignore patterns and their order.
 func (i *ignorer) ignored(path string, directory bool) bool {
 	// Nothing is initially ignored.
-	ignored := false
+	return i.update(path, directory, false)
+}
 
+// update determines whether or not the specified path should be ignored based
+// on all provided ignore patterns and their order, starting from the specified
+// ignored state. Paths not matched by any pattern retain that state.
+func (i *ignorer) update(path string, directory, ignored bool) bool {
 	// Run through patterns, keeping track of the ignored state as we reach more
 	// specific rules.
 	for _, p := range i.patterns {
@@ -159,6 +166,88 @@ func (i *ignorer) ignored(path string, directory bool) bool {
 	return ignored
 }
 
+// ValidIgnoreFileName checks whether or not a given name is valid for use as a
+// per-directory ignore file name. The name must be a non-empty path leaf.
+func ValidIgnoreFileName(name string) bool {
+	return name != "" && name != "." && name != ".." &&
+		strings.IndexByte(name, '/') < 0 &&
+		!strings.HasPrefix(name, filesystem.TemporaryNamePrefix)
+}
+
+// parseIgnoreFile parses the contents of a per-directory ignore file into a
+// list of ignore patterns. The contents use .gitignore syntax: blank lines are
+// skipped, lines starting with "#" are treated as comments, and trailing spaces
+// are removed unless escaped with a backslash. A leading "#" or "!" can be
+// escaped with a backslash to be treated literally. As with Git, patterns that
+// can't be parsed are skipped rather than treated as errors.
+func parseIgnoreFile(contents []byte) []*ignorePattern {
+	var patterns []*ignorePattern
+	for _, line := range strings.Split(string(contents), "\n") {
+		// Remove any carriage return left by Windows-style line endings.
+		line = strings.TrimSuffix(line, "\r")
+
+		// Skip comments.
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Remove unescaped trailing spaces.
+		for strings.HasSuffix(line, " ") && !strings.HasSuffix(line, "\\ ") {
+			line = line[:len(line)-1]
+		}
+
+		// Skip blank lines.
+		if line == "" {
+			continue
+		}
+
+		// Parse the pattern. An escaped "#" prefix is unescaped here since it
+		// has no special meaning to the matcher, while an escaped "!" prefix
+		// is left for the matcher to treat literally.
+		if strings.HasPrefix(line, "\\#") {
+			line = line[1:]
+		}
+		if pattern, err := newIgnorePattern(line); err == nil {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// ignoreLayer is a set of ignore patterns loaded from per-directory ignore
+// files, scoped to the subtree rooted at the directory containing those files.
+// Layers are chained to the layers of their ancestor directories.
+type ignoreLayer struct {
+	// parent is the layer for the nearest ancestor directory containing ignore
+	// files, if any.
+	parent *ignoreLayer
+	// prefix is the joinable form of the path of the directory containing the
+	// ignore files. It is stripped from paths before matching so that patterns
+	// are evaluated relative to that directory.
+	prefix string
+	// ignorer is the ignorer for the loaded patterns.
+	ignorer *ignorer
+}
+
+// update determines whether or not the specified path should be ignored based
+// on the patterns in the layer and its ancestors, starting from the specified
+// ignored state. Patterns from ancestor layers are applied first, allowing
+// patterns in more deeply nested ignore files to take precedence. The path
+// must be within the subtree of the layer. It is valid to call this method on a
+// nil layer, in which case the ignored state is returned unmodified.
+func (l *ignoreLayer) update(path string, directory, ignored bool) bool {
+	// Handle the case of no layer.
+	if l == nil {
+		return ignored
+	}
+
+	// Apply ancestor patterns.
+	ignored = l.parent.update(path, directory, ignored)
+
+	// Apply our patterns relative to our directory.
+	return l.ignorer.update(path[len(l.prefix):], directory, ignored)
+}
+
 // IgnoreCacheKey represents a key in an ignore cache.
 type IgnoreCacheKey struct {
 	// path is the path used for testing ignore status.
@@ -250,3 +250,98 @@ func TestIgnoreInvalidPatternOnIgnorerConstruction(t *testing.T) {
 		t.Error("ignorer should be nil on failed creation")
 	}
 }
+
+func TestValidIgnoreFileName(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected bool
+	}{
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"a/b", false},
+		{".mutagen-temporary-ignore", false},
+		{".gitignore", true},
+		{".mutagenignore", true},
+	}
+	for _, testCase := range testCases {
+		if valid := ValidIgnoreFileName(testCase.name); valid != testCase.expected {
+			t.Errorf("ignore file name validity (%t) does not match expected (%t) for %q",
+				valid, testCase.expected, testCase.name,
+			)
+		}
+	}
+}
+
+func TestParseIgnoreFile(t *testing.T) {
+	// Parse an ignore file exercising comments, blank lines, whitespace
+	// handling, escapes, and invalid patterns.
+	patterns := parseIgnoreFile([]byte("# comment\r\n\nbuild/  \n\\#hash\n\\!bang\nspace\\ \n\\\n!keep\n"))
+
+	// Verify the parsed patterns.
+	expected := []string{"build", "#hash", "\\!bang", "space\\ ", "keep"}
+	if len(patterns) != len(expected) {
+		t.Fatal("parsed pattern count does not match expected:", len(patterns), "!=", len(expected))
+	}
+	for i, p := range patterns {
+		if p.pattern != expected[i] {
+			t.Errorf("parsed pattern %q does not match expected %q", p.pattern, expected[i])
+		}
+	}
+	if !patterns[0].directoryOnly {
+		t.Error("trailing slash not parsed as directory-only")
+	}
+	if patterns[2].negated || !patterns[4].negated {
+		t.Error("negation not parsed correctly")
+	}
+
+	// Verify escaped matching behavior.
+	if match, _ := patterns[2].matches("!bang", false); !match {
+		t.Error("escaped exclamation point pattern did not match literally")
+	}
+	if match, _ := patterns[3].matches("space ", false); !match {
+		t.Error("escaped trailing space pattern did not match literally")
+	}
+}
+
+func TestIgnoreLayer(t *testing.T) {
+	// Create a chain of layers.
+	root := &ignoreLayer{
+		ignorer: &ignorer{parseIgnoreFile([]byte("*.log\n/build\n"))},
+	}
+	nested := &ignoreLayer{
+		parent:  root,
+		prefix:  "sub/",
+		ignorer: &ignorer{parseIgnoreFile([]byte("!keep.log\n/cache/\n"))},
+	}
+
+	// Verify behavior.
+	testCases := []struct {
+		layer     *ignoreLayer
+		path      string
+		directory bool
+		initial   bool
+		expected  bool
+	}{
+		{nil, "a.log", false, false, false},
+		{nil, "a.log", false, true, true},
+		{root, "a.log", false, false, true},
+		{root, "build", true, false, true},
+		{root, "sub/build", true, false, false},
+		{root, "other", false, true, true},
+		{nested, "sub/a.log", false, false, true},
+		{nested, "sub/keep.log", false, false, false},
+		{nested, "sub/keep.log", false, true, false},
+		{nested, "sub/cache", true, false, true},
+		{nested, "sub/cache", false, false, false},
+		{nested, "sub/deeper/cache", true, false, false},
+	}
+	for _, testCase := range testCases {
+		ignored := testCase.layer.update(testCase.path, testCase.directory, testCase.initial)
+		if ignored != testCase.expected {
+			t.Errorf("ignore behavior (%t) not as expected (%t) for %s",
+				ignored, testCase.expected, testCase.path,
+			)
+		}
+	}
+}
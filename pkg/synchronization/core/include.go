@@ -0,0 +1,52 @@
+package core
+
+import (
+	"strings"
+)
+
+// ValidIncludePath checks whether or not a given path is a valid include
+// specification. Include paths must be non-empty, clean, synchronization
+// root-relative paths that don't traverse upward.
+func ValidIncludePath(path string) bool {
+	return path != "" && pathIsCanonical(path)
+}
+
+// includeStatus determines whether or not the specified path is included by
+// (i.e. is or is within) any of the specified include paths and, if not,
+// whether or not it is an ancestor of any include path (and thus needs to be
+// traversed to reach included content). The include paths are assumed to be
+// valid. If no include paths are specified, then all paths are included.
+func includeStatus(includes []string, path string) (included, ancestor bool) {
+	// If there are no includes, then everything is included.
+	if len(includes) == 0 {
+		return true, false
+	}
+
+	// The synchronization root is an ancestor of all include paths (since they
+	// can't be empty).
+	if path == "" {
+		return false, true
+	}
+
+	// Check each include path.
+	for _, include := range includes {
+		if path == include || strings.HasPrefix(path, include) && path[len(include)] == '/' {
+			return true, false
+		} else if strings.HasPrefix(include, path) && include[len(path)] == '/' {
+			ancestor = true
+		}
+	}
+
+	// Done.
+	return false, ancestor
+}
+
+// IncludeRelevant determines whether or not the specified path is relevant to
+// synchronization given the specified include paths, i.e. whether or not it is
+// included by or an ancestor of any of the include paths. The include paths
+// are assumed to be valid. If no include paths are specified, then all paths
+// are relevant.
+func IncludeRelevant(includes []string, path string) bool {
+	included, ancestor := includeStatus(includes, path)
+	return included || ancestor
+}
@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestValidIncludePath tests ValidIncludePath.
+func TestValidIncludePath(t *testing.T) {
+	testCases := []struct {
+		path     string
+		expected bool
+	}{
+		{"", false},
+		{".", false},
+		{"..", false},
+		{"../a", false},
+		{"/a", false},
+		{"a/", false},
+		{"a//b", false},
+		{"a/./b", false},
+		{"a/../b", false},
+		{"a", true},
+		{"a/b", true},
+		{"..a", true},
+	}
+	for _, testCase := range testCases {
+		if valid := ValidIncludePath(testCase.path); valid != testCase.expected {
+			t.Errorf("include path validity (%t) does not match expected (%t) for %q",
+				valid, testCase.expected, testCase.path,
+			)
+		}
+	}
+}
+
+// TestIncludeStatus tests includeStatus and IncludeRelevant.
+func TestIncludeStatus(t *testing.T) {
+	includes := []string{"services/api", "libs/common", "README.md"}
+	testCases := []struct {
+		includes         []string
+		path             string
+		expectedIncluded bool
+		expectedAncestor bool
+	}{
+		{nil, "", true, false},
+		{nil, "anything", true, false},
+		{includes, "", false, true},
+		{includes, "services", false, true},
+		{includes, "services/api", true, false},
+		{includes, "services/api/main.go", true, false},
+		{includes, "services/api2", false, false},
+		{includes, "services/web", false, false},
+		{includes, "libs", false, true},
+		{includes, "libs/common/x", true, false},
+		{includes, "README.md", true, false},
+		{includes, "README", false, false},
+		{includes, "other", false, false},
+	}
+	for _, testCase := range testCases {
+		included, ancestor := includeStatus(testCase.includes, testCase.path)
+		if included != testCase.expectedIncluded || ancestor != testCase.expectedAncestor {
+			t.Errorf("include status (%t, %t) does not match expected (%t, %t) for %q",
+				included, ancestor, testCase.expectedIncluded, testCase.expectedAncestor, testCase.path,
+			)
+		}
+		if relevant := IncludeRelevant(testCase.includes, testCase.path); relevant != (included || ancestor) {
+			t.Errorf("include relevance incorrect for %q", testCase.path)
+		}
+	}
+}
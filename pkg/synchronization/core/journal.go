@@ -0,0 +1,328 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+const (
+	// inPlaceJournalHeaderSize is the size of the fixed portion of the in-place
+	// journal header, which consists of the original file size and the original
+	// modification time (in nanoseconds since the Unix epoch), both encoded as
+	// 64-bit big-endian integers, followed by the length of the file name as a
+	// 32-bit big-endian integer. The fixed portion is followed by the file name
+	// and a 32-bit big-endian CRC-32 checksum of the preceding header content.
+	inPlaceJournalHeaderSize = 20
+	// inPlaceJournalMaximumNameLength is the maximum file name length that will
+	// be accepted when reading an in-place journal header.
+	inPlaceJournalMaximumNameLength = 4096
+	// inPlaceJournalRecordHeaderSize is the size of the header preceding each
+	// in-place journal record, which consists of a 64-bit big-endian offset, a
+	// 32-bit big-endian length, and a 32-bit big-endian CRC-32 checksum of the
+	// offset, length, and record data.
+	inPlaceJournalRecordHeaderSize = 16
+)
+
+// writeInPlaceJournalHeader writes an in-place journal header recording the
+// name, original size, and original modification time of the file being
+// updated.
+func writeInPlaceJournalHeader(journal io.Writer, name string, size int64, modificationTime time.Time) error {
+	// Encode the header.
+	header := make([]byte, inPlaceJournalHeaderSize, inPlaceJournalHeaderSize+len(name)+4)
+	binary.BigEndian.PutUint64(header[:8], uint64(size))
+	binary.BigEndian.PutUint64(header[8:16], uint64(modificationTime.UnixNano()))
+	binary.BigEndian.PutUint32(header[16:], uint32(len(name)))
+	header = append(header, name...)
+	header = binary.BigEndian.AppendUint32(header, crc32.ChecksumIEEE(header))
+
+	// Write the header.
+	_, err := journal.Write(header)
+	return err
+}
+
+// writeInPlaceJournalRecord writes an in-place journal record containing the
+// original content of the region at the specified offset.
+func writeInPlaceJournalRecord(journal io.Writer, offset int64, data []byte) error {
+	// Encode the record header.
+	var header [inPlaceJournalRecordHeaderSize]byte
+	binary.BigEndian.PutUint64(header[:8], uint64(offset))
+	binary.BigEndian.PutUint32(header[8:12], uint32(len(data)))
+	checksum := crc32.Update(crc32.ChecksumIEEE(header[:12]), crc32.IEEETable, data)
+	binary.BigEndian.PutUint32(header[12:], checksum)
+
+	// Write the record.
+	if _, err := journal.Write(header[:]); err != nil {
+		return err
+	}
+	_, err := journal.Write(data)
+	return err
+}
+
+// inPlaceJournalReader decodes in-place journals.
+type inPlaceJournalReader struct {
+	// source is the encoded journal.
+	source io.Reader
+	// name is the name of the file that the journal covers.
+	name string
+	// size is the original size of the file.
+	size int64
+	// modificationTime is the original modification time of the file.
+	modificationTime time.Time
+	// data is the buffer for record data.
+	data []byte
+}
+
+// newInPlaceJournalReader creates a new in-place journal reader, reading the
+// journal header from the specified source.
+func newInPlaceJournalReader(source io.Reader) (*inPlaceJournalReader, error) {
+	// Read the fixed portion of the header.
+	header := make([]byte, inPlaceJournalHeaderSize)
+	if _, err := io.ReadFull(source, header); err != nil {
+		return nil, fmt.Errorf("unable to read journal header: %w", err)
+	}
+	size := int64(binary.BigEndian.Uint64(header[:8]))
+	nameLength := binary.BigEndian.Uint32(header[16:])
+	if size < 0 {
+		return nil, errors.New("invalid journal file size")
+	} else if nameLength == 0 || nameLength > inPlaceJournalMaximumNameLength {
+		return nil, errors.New("invalid journal file name length")
+	}
+
+	// Read the file name and checksum, and verify the checksum.
+	header = append(header, make([]byte, nameLength+4)...)
+	if _, err := io.ReadFull(source, header[inPlaceJournalHeaderSize:]); err != nil {
+		return nil, fmt.Errorf("unable to read journal header: %w", err)
+	}
+	checksumOffset := len(header) - 4
+	if crc32.ChecksumIEEE(header[:checksumOffset]) != binary.BigEndian.Uint32(header[checksumOffset:]) {
+		return nil, errors.New("journal header checksum mismatch")
+	}
+
+	// Success.
+	return &inPlaceJournalReader{
+		source:           source,
+		name:             string(header[inPlaceJournalHeaderSize:checksumOffset]),
+		size:             size,
+		modificationTime: time.Unix(0, int64(binary.BigEndian.Uint64(header[8:16]))),
+		data:             make([]byte, deltaBlockSize),
+	}, nil
+}
+
+// next reads the next record from the journal. It returns io.EOF once no
+// further complete and intact records are available. Since each record is
+// made durable before the region that it covers is overwritten, an incomplete
+// or corrupt record can only occur at the end of the journal and indicates that
+// its region wasn't modified. The returned data is only valid until the next
+// call to next.
+func (r *inPlaceJournalReader) next() (int64, []byte, error) {
+	// Read and decode the record header.
+	var header [inPlaceJournalRecordHeaderSize]byte
+	if _, err := io.ReadFull(r.source, header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return 0, nil, err
+	}
+	offset := int64(binary.BigEndian.Uint64(header[:8]))
+	length := binary.BigEndian.Uint32(header[8:12])
+	if offset < 0 || length == 0 || length > deltaBlockSize {
+		return 0, nil, io.EOF
+	}
+
+	// Read the record data and verify the checksum.
+	data := r.data[:length]
+	if _, err := io.ReadFull(r.source, data); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return 0, nil, err
+	}
+	checksum := crc32.Update(crc32.ChecksumIEEE(header[:12]), crc32.IEEETable, data)
+	if checksum != binary.BigEndian.Uint32(header[12:]) {
+		return 0, nil, io.EOF
+	}
+
+	// Success.
+	return offset, data, nil
+}
+
+// replay restores the original content and size of the file that the journal
+// covers and flushes the file to disk. It does not restore the file's original
+// modification time.
+func (r *inPlaceJournalReader) replay(file *os.File) error {
+	// Restore the journaled regions.
+	for {
+		offset, data, err := r.next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("unable to read journal record: %w", err)
+		}
+		if _, err := file.WriteAt(data, offset); err != nil {
+			return fmt.Errorf("unable to restore content: %w", err)
+		}
+	}
+
+	// Restore the original size.
+	if err := file.Truncate(r.size); err != nil {
+		return fmt.Errorf("unable to restore file size: %w", err)
+	}
+
+	// Ensure that the restored content is durable.
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("unable to flush restored content: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// recoverInPlaceJournal restores the file covered by the in-place journal with
+// the specified name in the specified directory and then removes the journal.
+// If name is non-empty, then the journal is ignored unless it covers a file
+// with that name. It returns the name of the restored file, if any. If the
+// journal header is incomplete or corrupt, then the journal is simply removed,
+// since headers are made durable before the files that they cover are
+// modified. Likewise, if the file no longer exists, then the journal is no
+// longer needed and is removed. If restoration fails, then the journal is left
+// in place so that the original content isn't lost.
+func recoverInPlaceJournal(directory, journalName, name string) (string, error) {
+	// Open the parent directory and defer its closure.
+	parent, _, err := filesystem.OpenDirectory(directory, false)
+	if err != nil {
+		return "", fmt.Errorf("unable to open directory: %w", err)
+	}
+	defer parent.Close()
+
+	// Open the journal and read its header. We can't defer closure of the
+	// journal because we need to be able to remove it, which we can't do (on
+	// some platforms, notably Windows) if the file handle is open.
+	journalFile, _, err := parent.OpenFile(journalName)
+	if err != nil {
+		return "", fmt.Errorf("unable to open journal: %w", err)
+	}
+	journal, err := newInPlaceJournalReader(journalFile)
+	if err != nil {
+		journalFile.Close()
+		parent.RemoveFile(journalName)
+		return "", nil
+	} else if name != "" && journal.name != name {
+		journalFile.Close()
+		return "", nil
+	}
+
+	// Open the file covered by the journal.
+	file, err := parent.OpenFileForUpdating(journal.name)
+	if err != nil {
+		journalFile.Close()
+		if os.IsNotExist(err) {
+			parent.RemoveFile(journalName)
+			return "", nil
+		}
+		return "", fmt.Errorf("unable to open file for restoration: %w", err)
+	}
+
+	// Restore the file's content.
+	err = journal.replay(file)
+	file.Close()
+	journalFile.Close()
+	if err != nil {
+		return "", err
+	}
+
+	// Restore the file's modification time and remove the journal.
+	if err := parent.SetModificationTime(journal.name, journal.modificationTime); err != nil {
+		return "", fmt.Errorf("unable to restore modification time: %w", err)
+	} else if err := parent.RemoveFile(journalName); err != nil {
+		return "", fmt.Errorf("unable to remove journal: %w", err)
+	}
+
+	// Success.
+	return journal.name, nil
+}
+
+// RecoverInPlaceUpdates searches the synchronization root for journals left
+// behind by interrupted in-place file updates (e.g. due to a crash) and uses
+// them to restore the original content of the files that they cover, removing
+// each journal once its file has been restored. This should be performed
+// before the root is scanned, since partially updated files would otherwise be
+// treated as modified. It returns the root-relative paths of restored files
+// and problems encountered for journals that couldn't be used, which are left
+// in place.
+func RecoverInPlaceUpdates(root string) ([]string, []*Problem) {
+	// Track restored paths and problems.
+	var restored []string
+	var problems []*Problem
+
+	// If the root is a file, then any journal will reside in its parent
+	// directory, which we don't otherwise own, so only consider journals that
+	// cover the root. If the root doesn't exist or can't be queried, then
+	// there's nothing to recover (or the problem will be reported by scanning).
+	metadata, err := os.Stat(root)
+	if err != nil {
+		return nil, nil
+	} else if metadata.Mode().IsRegular() {
+		parent := filepath.Dir(root)
+		contents, err := os.ReadDir(parent)
+		if err != nil {
+			return nil, nil
+		}
+		for _, c := range contents {
+			if !strings.HasPrefix(c.Name(), inPlaceJournalNamePrefix) || !c.Type().IsRegular() {
+				continue
+			}
+			if name, err := recoverInPlaceJournal(parent, c.Name(), filepath.Base(root)); err != nil {
+				problems = append(problems, &Problem{Error: err.Error()})
+			} else if name != "" {
+				restored = append(restored, "")
+			}
+		}
+		return restored, problems
+	} else if !metadata.IsDir() {
+		return nil, nil
+	}
+
+	// Resolve the root in case it's a symbolic link, since walking doesn't
+	// traverse symbolic links.
+	root, err = filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, nil
+	}
+
+	// Walk the root, recovering any journals that we find. We skip content
+	// that can't be read, since it can't have been updated in place.
+	filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if entry != nil && entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		} else if !entry.Type().IsRegular() || !strings.HasPrefix(entry.Name(), inPlaceJournalNamePrefix) {
+			return nil
+		}
+		directory := filepath.Dir(path)
+		relativeDirectory := ""
+		if directory != root {
+			relativeDirectory = filepath.ToSlash(directory[len(root)+1:])
+		}
+		if name, err := recoverInPlaceJournal(directory, entry.Name(), ""); err != nil {
+			problems = append(problems, &Problem{Path: relativeDirectory, Error: err.Error()})
+		} else if name != "" {
+			restored = append(restored, pathJoinable(relativeDirectory)+name)
+		}
+		return nil
+	})
+
+	// Done.
+	return restored, problems
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the modification time mode is
+// ModificationTimeMode_ModificationTimeModeDefault.
+func (m ModificationTimeMode) IsDefault() bool {
+	return m == ModificationTimeMode_ModificationTimeModeDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m ModificationTimeMode) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case ModificationTimeMode_ModificationTimeModeDefault:
+	case ModificationTimeMode_ModificationTimeModeIgnore:
+		result = "ignore"
+	case ModificationTimeMode_ModificationTimeModePreserve:
+		result = "preserve"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *ModificationTimeMode) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a modification time mode.
+	switch text {
+	case "ignore":
+		*m = ModificationTimeMode_ModificationTimeModeIgnore
+	case "preserve":
+		*m = ModificationTimeMode_ModificationTimeModePreserve
+	default:
+		return fmt.Errorf("unknown modification time mode specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular modification time mode is a
+// valid, non-default value.
+func (m ModificationTimeMode) Supported() bool {
+	switch m {
+	case ModificationTimeMode_ModificationTimeModeIgnore:
+		return true
+	case ModificationTimeMode_ModificationTimeModePreserve:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a modification time
+// mode.
+func (m ModificationTimeMode) Description() string {
+	switch m {
+	case ModificationTimeMode_ModificationTimeModeDefault:
+		return "Default"
+	case ModificationTimeMode_ModificationTimeModeIgnore:
+		return "Ignore"
+	case ModificationTimeMode_ModificationTimeModePreserve:
+		return "Preserve"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,152 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: synchronization/core/modification_time_mode.proto
+
+package core
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ModificationTimeMode specifies the mode for handling modification time
+// propagation.
+type ModificationTimeMode int32
+
+const (
+	// ModificationTimeMode_ModificationTimeModeDefault represents an
+	// unspecified modification time mode. It is not valid for use with Scan. It
+	// should be converted to one of the following values based on the desired
+	// default behavior.
+	ModificationTimeMode_ModificationTimeModeDefault ModificationTimeMode = 0
+	// ModificationTimeMode_ModificationTimeModeIgnore specifies that
+	// modification times should not be propagated. Files created or updated by
+	// Mutagen will have the modification time of their creation.
+	ModificationTimeMode_ModificationTimeModeIgnore ModificationTimeMode = 1
+	// ModificationTimeMode_ModificationTimeModePreserve specifies that
+	// modification times should be propagated along with file contents. They
+	// are not considered in change or conflict detection.
+	ModificationTimeMode_ModificationTimeModePreserve ModificationTimeMode = 2
+)
+
+// Enum value maps for ModificationTimeMode.
+var (
+	ModificationTimeMode_name = map[int32]string{
+		0: "ModificationTimeModeDefault",
+		1: "ModificationTimeModeIgnore",
+		2: "ModificationTimeModePreserve",
+	}
+	ModificationTimeMode_value = map[string]int32{
+		"ModificationTimeModeDefault":  0,
+		"ModificationTimeModeIgnore":   1,
+		"ModificationTimeModePreserve": 2,
+	}
+)
+
+func (x ModificationTimeMode) Enum() *ModificationTimeMode {
+	p := new(ModificationTimeMode)
+	*p = x
+	return p
+}
+
+func (x ModificationTimeMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ModificationTimeMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_synchronization_core_modification_time_mode_proto_enumTypes[0].Descriptor()
+}
+
+func (ModificationTimeMode) Type() protoreflect.EnumType {
+	return &file_synchronization_core_modification_time_mode_proto_enumTypes[0]
+}
+
+func (x ModificationTimeMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ModificationTimeMode.Descriptor instead.
+func (ModificationTimeMode) EnumDescriptor() ([]byte, []int) {
+	return file_synchronization_core_modification_time_mode_proto_rawDescGZIP(), []int{0}
+}
+
+var File_synchronization_core_modification_time_mode_proto protoreflect.FileDescriptor
+
+var file_synchronization_core_modification_time_mode_proto_rawDesc = []byte{
+	0x0a, 0x31, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x6d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x2a, 0x79, 0x0a, 0x14, 0x4d, 0x6f, 0x64,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x1f, 0x0a, 0x1b, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x10, 0x00, 0x12, 0x1e, 0x0a, 0x1a, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x49, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x10, 0x01, 0x12, 0x20, 0x0a, 0x1c, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x6f, 0x64, 0x65, 0x50, 0x72, 0x65, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x10, 0x02, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75,
+	0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72,
+	0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_synchronization_core_modification_time_mode_proto_rawDescOnce sync.Once
+	file_synchronization_core_modification_time_mode_proto_rawDescData = file_synchronization_core_modification_time_mode_proto_rawDesc
+)
+
+func file_synchronization_core_modification_time_mode_proto_rawDescGZIP() []byte {
+	file_synchronization_core_modification_time_mode_proto_rawDescOnce.Do(func() {
+		file_synchronization_core_modification_time_mode_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_core_modification_time_mode_proto_rawDescData)
+	})
+	return file_synchronization_core_modification_time_mode_proto_rawDescData
+}
+
+var file_synchronization_core_modification_time_mode_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_synchronization_core_modification_time_mode_proto_goTypes = []interface{}{
+	(ModificationTimeMode)(0), // 0: core.ModificationTimeMode
+}
+var file_synchronization_core_modification_time_mode_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_core_modification_time_mode_proto_init() }
+func file_synchronization_core_modification_time_mode_proto_init() {
+	if File_synchronization_core_modification_time_mode_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_core_modification_time_mode_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_core_modification_time_mode_proto_goTypes,
+		DependencyIndexes: file_synchronization_core_modification_time_mode_proto_depIdxs,
+		EnumInfos:         file_synchronization_core_modification_time_mode_proto_enumTypes,
+	}.Build()
+	File_synchronization_core_modification_time_mode_proto = out.File
+	file_synchronization_core_modification_time_mode_proto_rawDesc = nil
+	file_synchronization_core_modification_time_mode_proto_goTypes = nil
+	file_synchronization_core_modification_time_mode_proto_depIdxs = nil
+}
@@ -0,0 +1,113 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestModificationTimeModeIsDefault tests ModificationTimeMode.IsDefault.
+func TestModificationTimeModeIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    ModificationTimeMode
+		expected bool
+	}{
+		{ModificationTimeMode_ModificationTimeModeDefault - 1, false},
+		{ModificationTimeMode_ModificationTimeModeDefault, true},
+		{ModificationTimeMode_ModificationTimeModeIgnore, false},
+		{ModificationTimeMode_ModificationTimeModePreserve, false},
+		{ModificationTimeMode_ModificationTimeModePreserve + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestModificationTimeModeUnmarshalText tests
+// ModificationTimeMode.UnmarshalText.
+func TestModificationTimeModeUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  ModificationTimeMode
+		expectFailure bool
+	}{
+		{"", ModificationTimeMode_ModificationTimeModeDefault, true},
+		{"asdf", ModificationTimeMode_ModificationTimeModeDefault, true},
+		{"ignore", ModificationTimeMode_ModificationTimeModeIgnore, false},
+		{"preserve", ModificationTimeMode_ModificationTimeModePreserve, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode ModificationTimeMode
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestModificationTimeModeSupported tests ModificationTimeMode.Supported.
+func TestModificationTimeModeSupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            ModificationTimeMode
+		expectSupported bool
+	}{
+		{ModificationTimeMode_ModificationTimeModeDefault, false},
+		{ModificationTimeMode_ModificationTimeModeIgnore, true},
+		{ModificationTimeMode_ModificationTimeModePreserve, true},
+		{(ModificationTimeMode_ModificationTimeModePreserve + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestModificationTimeModeDescription tests ModificationTimeMode.Description.
+func TestModificationTimeModeDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                ModificationTimeMode
+		expectedDescription string
+	}{
+		{ModificationTimeMode_ModificationTimeModeDefault, "Default"},
+		{ModificationTimeMode_ModificationTimeModeIgnore, "Ignore"},
+		{ModificationTimeMode_ModificationTimeModePreserve, "Preserve"},
+		{(ModificationTimeMode_ModificationTimeModePreserve + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
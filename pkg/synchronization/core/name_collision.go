@@ -0,0 +1,287 @@
+package core
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// nameCollisionProblem is the problem message used for entries that are
+	// excluded from reconciliation due to name collisions.
+	nameCollisionProblem = "name collides with another name on an endpoint that ignores case or Unicode normalization"
+)
+
+// EnsureValid ensures that NameCollision's invariants are respected.
+func (c *NameCollision) EnsureValid() error {
+	// A nil name collision is not valid.
+	if c == nil {
+		return errors.New("nil name collision")
+	}
+
+	// A name collision must involve at least two paths.
+	if len(c.Paths) < 2 {
+		return errors.New("name collision has fewer than two paths")
+	}
+
+	// If a winner is specified, then it must be one of the colliding paths.
+	if c.Winner != "" {
+		var found bool
+		for _, path := range c.Paths {
+			if path == c.Winner {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.New("name collision winner is not a colliding path")
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// CopyNameCollisions creates a copy of a list of name collisions in a new
+// slice, usually for the purpose of modifying the list. The name collision
+// objects themselves are not copied. It preserves nil vs. non-nil
+// characteristics for empty slices.
+func CopyNameCollisions(collisions []*NameCollision) []*NameCollision {
+	// If the slice is nil, then preserve its nilness. For zero-length, non-nil
+	// slices, we still allocate on the heap to preserve non-nilness.
+	if collisions == nil {
+		return nil
+	}
+
+	// Make a copy.
+	result := make([]*NameCollision, len(collisions))
+	copy(result, collisions)
+
+	// Done.
+	return result
+}
+
+// SortNameCollisions sorts a list of name collisions based on their first
+// colliding paths.
+func SortNameCollisions(collisions []*NameCollision) {
+	sort.Slice(collisions, func(i, j int) bool {
+		return pathLess(collisions[i].Paths[0], collisions[j].Paths[0])
+	})
+}
+
+// nameCollisionResolver provides the recursive implementation of name
+// collision resolution.
+type nameCollisionResolver struct {
+	// ignoreCase indicates whether or not names that differ only in case
+	// should be considered colliding.
+	ignoreCase bool
+	// policy is the name collision policy.
+	policy NameCollisionPolicy
+	// collisions are the name collisions identified during resolution.
+	collisions []*NameCollision
+}
+
+// key computes the collision key for a name. Names with identical keys are
+// considered colliding.
+func (r *nameCollisionResolver) key(name string) string {
+	key := norm.NFC.String(name)
+	if r.ignoreCase {
+		key = strings.ToLower(key)
+	}
+	return key
+}
+
+// resolve performs recursive name collision resolution, returning the
+// (potentially modified) alpha and beta entries. Modified entries are copied,
+// so the original entries are never mutated.
+func (r *nameCollisionResolver) resolve(path string, ancestor, alpha, beta *Entry) (*Entry, *Entry) {
+	// Grab content maps. If neither side has any contents, then there's nothing
+	// that can collide at this level or below.
+	alphaContents := alpha.GetContents()
+	betaContents := beta.GetContents()
+	if len(alphaContents) == 0 && len(betaContents) == 0 {
+		return alpha, beta
+	}
+	ancestorContents := ancestor.GetContents()
+
+	// Compute the prefix to add to content names to compute their paths.
+	contentPathPrefix := pathJoinable(path)
+
+	// Set up lazily allocated replacement content maps.
+	var newAlphaContents, newBetaContents map[string]*Entry
+	replaceAlpha := func(name string, entry *Entry) {
+		if newAlphaContents == nil {
+			newAlphaContents = make(map[string]*Entry, len(alphaContents))
+			for n, e := range alphaContents {
+				newAlphaContents[n] = e
+			}
+		}
+		if entry == nil {
+			delete(newAlphaContents, name)
+		} else {
+			newAlphaContents[name] = entry
+		}
+	}
+	replaceBeta := func(name string, entry *Entry) {
+		if newBetaContents == nil {
+			newBetaContents = make(map[string]*Entry, len(betaContents))
+			for n, e := range betaContents {
+				newBetaContents[n] = e
+			}
+		}
+		if entry == nil {
+			delete(newBetaContents, name)
+		} else {
+			newBetaContents[name] = entry
+		}
+	}
+
+	// Group names by their collision keys, tracking only those groups that
+	// contain multiple names.
+	names := nameUnion(alphaContents, betaContents)
+	firstNames := make(map[string]string, len(names))
+	var groups map[string][]string
+	for name := range names {
+		key := r.key(name)
+		if first, ok := firstNames[key]; !ok {
+			firstNames[key] = name
+		} else {
+			if groups == nil {
+				groups = make(map[string][]string)
+			}
+			if len(groups[key]) == 0 {
+				groups[key] = []string{first}
+			}
+			groups[key] = append(groups[key], name)
+		}
+	}
+
+	// Handle each group.
+	for _, group := range groups {
+		// Filter out names that are being deleted (i.e. that are present in
+		// the ancestor but absent on at least one endpoint). Reconciliation
+		// will handle these normally, which allows for case-only renames.
+		var live []string
+		for _, name := range group {
+			deleted := ancestorContents[name] != nil &&
+				(alphaContents[name] == nil || betaContents[name] == nil)
+			if !deleted {
+				live = append(live, name)
+			}
+		}
+		if len(live) < 2 {
+			continue
+		}
+		sort.Strings(live)
+
+		// Determine the winner (if any) based on the policy.
+		var winner string
+		switch r.policy {
+		case NameCollisionPolicy_NameCollisionPolicyFirst:
+			winner = live[0]
+		case NameCollisionPolicy_NameCollisionPolicyLast:
+			winner = live[len(live)-1]
+		}
+
+		// Record the collision.
+		collision := &NameCollision{Paths: make([]string, len(live))}
+		for n, name := range live {
+			collision.Paths[n] = contentPathPrefix + name
+		}
+		if winner != "" {
+			collision.Winner = contentPathPrefix + winner
+		}
+		r.collisions = append(r.collisions, collision)
+
+		// Exclude names from reconciliation as necessary. If there's no
+		// winner, then all colliding names are marked as problematic, which
+		// leaves them untouched and preserves their ancestor state. If there
+		// is a winner, then the other names are hidden on any endpoint where
+		// the winner also exists, allowing the winner to replace them on the
+		// other endpoint.
+		for _, name := range live {
+			if name == winner {
+				continue
+			}
+			if winner == "" {
+				problematic := &Entry{Kind: EntryKind_Problematic, Problem: nameCollisionProblem}
+				if alphaContents[name] != nil {
+					replaceAlpha(name, problematic)
+				}
+				if betaContents[name] != nil {
+					replaceBeta(name, problematic)
+				}
+			} else {
+				if alphaContents[winner] != nil && alphaContents[name] != nil {
+					replaceAlpha(name, nil)
+				}
+				if betaContents[winner] != nil && betaContents[name] != nil {
+					replaceBeta(name, nil)
+				}
+			}
+		}
+	}
+
+	// Recursively handle contents, using any replacement content maps so that
+	// we don't recurse into excluded entries.
+	currentAlphaContents, currentBetaContents := alphaContents, betaContents
+	if newAlphaContents != nil {
+		currentAlphaContents = newAlphaContents
+	}
+	if newBetaContents != nil {
+		currentBetaContents = newBetaContents
+	}
+	for name := range names {
+		alphaChild, betaChild := currentAlphaContents[name], currentBetaContents[name]
+		if alphaChild.GetKind() != EntryKind_Directory && betaChild.GetKind() != EntryKind_Directory {
+			continue
+		}
+		newAlphaChild, newBetaChild := r.resolve(contentPathPrefix+name, ancestorContents[name], alphaChild, betaChild)
+		if newAlphaChild != alphaChild {
+			replaceAlpha(name, newAlphaChild)
+		}
+		if newBetaChild != betaChild {
+			replaceBeta(name, newBetaChild)
+		}
+	}
+
+	// Create modified entries if necessary.
+	if newAlphaContents != nil {
+		alpha = alpha.Copy(false)
+		alpha.Contents = newAlphaContents
+	}
+	if newBetaContents != nil {
+		beta = beta.Copy(false)
+		beta.Contents = newBetaContents
+	}
+
+	// Done.
+	return alpha, beta
+}
+
+// ResolveNameCollisions identifies names within each directory of alpha and
+// beta that are distinct but that would refer to the same entry on an endpoint
+// that ignores Unicode normalization (and, if ignoreCase is true, case). Such
+// collisions can't be represented on the insensitive endpoint, so they're
+// excluded from reconciliation according to the specified policy in order to
+// avoid opaque transition failures. Names that are being deleted aren't
+// considered colliding, which allows for case-only and normalization-only
+// renames. The function returns (potentially modified copies of) alpha and beta
+// that should be used for reconciliation, as well as a list of the collisions
+// identified. The original entries are not modified. The policy must not be
+// the default policy.
+func ResolveNameCollisions(ancestor, alpha, beta *Entry, ignoreCase bool, policy NameCollisionPolicy) (*Entry, *Entry, []*NameCollision) {
+	// Create the resolver.
+	r := &nameCollisionResolver{
+		ignoreCase: ignoreCase,
+		policy:     policy,
+	}
+
+	// Perform resolution.
+	alpha, beta = r.resolve("", ancestor, alpha, beta)
+
+	// Done.
+	return alpha, beta, r.collisions
+}
@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: synchronization/core/name_collision.proto
+
+package core
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// NameCollision encodes a set of paths within a single directory whose names
+// are distinct but that would refer to the same entry on an endpoint that
+// ignores case or Unicode normalization. NameCollision objects should be
+// considered immutable and must not be modified.
+type NameCollision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Paths are the colliding paths (relative to the synchronization root), in
+	// sorted order.
+	Paths []string `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"`
+	// Winner is the path that is synchronized based on the name collision
+	// policy. It is empty if none of the colliding paths are synchronized.
+	Winner string `protobuf:"bytes,2,opt,name=winner,proto3" json:"winner,omitempty"`
+}
+
+func (x *NameCollision) Reset() {
+	*x = NameCollision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_synchronization_core_name_collision_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NameCollision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameCollision) ProtoMessage() {}
+
+func (x *NameCollision) ProtoReflect() protoreflect.Message {
+	mi := &file_synchronization_core_name_collision_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameCollision.ProtoReflect.Descriptor instead.
+func (*NameCollision) Descriptor() ([]byte, []int) {
+	return file_synchronization_core_name_collision_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *NameCollision) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *NameCollision) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+var File_synchronization_core_name_collision_proto protoreflect.FileDescriptor
+
+var file_synchronization_core_name_collision_proto_rawDesc = []byte{
+	0x0a, 0x29, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72,
+	0x65, 0x22, 0x3d, 0x0a, 0x0d, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x6e,
+	0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x77, 0x69, 0x6e, 0x6e, 0x65, 0x72,
+	0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d,
+	0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65,
+	0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_synchronization_core_name_collision_proto_rawDescOnce sync.Once
+	file_synchronization_core_name_collision_proto_rawDescData = file_synchronization_core_name_collision_proto_rawDesc
+)
+
+func file_synchronization_core_name_collision_proto_rawDescGZIP() []byte {
+	file_synchronization_core_name_collision_proto_rawDescOnce.Do(func() {
+		file_synchronization_core_name_collision_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_core_name_collision_proto_rawDescData)
+	})
+	return file_synchronization_core_name_collision_proto_rawDescData
+}
+
+var file_synchronization_core_name_collision_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_synchronization_core_name_collision_proto_goTypes = []interface{}{
+	(*NameCollision)(nil), // 0: core.NameCollision
+}
+var file_synchronization_core_name_collision_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_core_name_collision_proto_init() }
+func file_synchronization_core_name_collision_proto_init() {
+	if File_synchronization_core_name_collision_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_synchronization_core_name_collision_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NameCollision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_core_name_collision_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_core_name_collision_proto_goTypes,
+		DependencyIndexes: file_synchronization_core_name_collision_proto_depIdxs,
+		MessageInfos:      file_synchronization_core_name_collision_proto_msgTypes,
+	}.Build()
+	File_synchronization_core_name_collision_proto = out.File
+	file_synchronization_core_name_collision_proto_rawDesc = nil
+	file_synchronization_core_name_collision_proto_goTypes = nil
+	file_synchronization_core_name_collision_proto_depIdxs = nil
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+)
+
+// IsDefault indicates whether or not the name collision policy is
+// NameCollisionPolicy_NameCollisionPolicyDefault.
+func (m NameCollisionPolicy) IsDefault() bool {
+	return m == NameCollisionPolicy_NameCollisionPolicyDefault
+}
+
+// MarshalText implements encoding.TextMarshaler.MarshalText.
+func (m NameCollisionPolicy) MarshalText() ([]byte, error) {
+	var result string
+	switch m {
+	case NameCollisionPolicy_NameCollisionPolicyDefault:
+	case NameCollisionPolicy_NameCollisionPolicyReport:
+		result = "report"
+	case NameCollisionPolicy_NameCollisionPolicyFirst:
+		result = "first"
+	case NameCollisionPolicy_NameCollisionPolicyLast:
+		result = "last"
+	default:
+		result = "unknown"
+	}
+	return []byte(result), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.UnmarshalText.
+func (m *NameCollisionPolicy) UnmarshalText(textBytes []byte) error {
+	// Convert the bytes to a string.
+	text := string(textBytes)
+
+	// Convert to a name collision policy.
+	switch text {
+	case "report":
+		*m = NameCollisionPolicy_NameCollisionPolicyReport
+	case "first":
+		*m = NameCollisionPolicy_NameCollisionPolicyFirst
+	case "last":
+		*m = NameCollisionPolicy_NameCollisionPolicyLast
+	default:
+		return fmt.Errorf("unknown name collision policy specification: %s", text)
+	}
+
+	// Success.
+	return nil
+}
+
+// Supported indicates whether or not a particular name collision policy is a
+// valid, non-default value.
+func (m NameCollisionPolicy) Supported() bool {
+	switch m {
+	case NameCollisionPolicy_NameCollisionPolicyReport:
+		return true
+	case NameCollisionPolicy_NameCollisionPolicyFirst:
+		return true
+	case NameCollisionPolicy_NameCollisionPolicyLast:
+		return true
+	default:
+		return false
+	}
+}
+
+// Description returns a human-readable description of a name collision
+// policy.
+func (m NameCollisionPolicy) Description() string {
+	switch m {
+	case NameCollisionPolicy_NameCollisionPolicyDefault:
+		return "Default"
+	case NameCollisionPolicy_NameCollisionPolicyReport:
+		return "Report"
+	case NameCollisionPolicy_NameCollisionPolicyFirst:
+		return "First"
+	case NameCollisionPolicy_NameCollisionPolicyLast:
+		return "Last"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,158 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: synchronization/core/name_collision_policy.proto
+
+package core
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// NameCollisionPolicy specifies the policy for handling names that are distinct
+// on one endpoint but that would refer to the same entry on an endpoint that
+// ignores case or Unicode normalization.
+type NameCollisionPolicy int32
+
+const (
+	// NameCollisionPolicy_NameCollisionPolicyDefault represents an unspecified
+	// name collision policy. It should be converted to one of the following
+	// values based on the desired default behavior.
+	NameCollisionPolicy_NameCollisionPolicyDefault NameCollisionPolicy = 0
+	// NameCollisionPolicy_NameCollisionPolicyReport specifies that colliding
+	// names should be reported and excluded from synchronization.
+	NameCollisionPolicy_NameCollisionPolicyReport NameCollisionPolicy = 1
+	// NameCollisionPolicy_NameCollisionPolicyFirst specifies that colliding
+	// names should be reported and that the first colliding name (in byte-wise
+	// lexicographical order) should be synchronized.
+	NameCollisionPolicy_NameCollisionPolicyFirst NameCollisionPolicy = 2
+	// NameCollisionPolicy_NameCollisionPolicyLast specifies that colliding
+	// names should be reported and that the last colliding name (in byte-wise
+	// lexicographical order) should be synchronized.
+	NameCollisionPolicy_NameCollisionPolicyLast NameCollisionPolicy = 3
+)
+
+// Enum value maps for NameCollisionPolicy.
+var (
+	NameCollisionPolicy_name = map[int32]string{
+		0: "NameCollisionPolicyDefault",
+		1: "NameCollisionPolicyReport",
+		2: "NameCollisionPolicyFirst",
+		3: "NameCollisionPolicyLast",
+	}
+	NameCollisionPolicy_value = map[string]int32{
+		"NameCollisionPolicyDefault": 0,
+		"NameCollisionPolicyReport":  1,
+		"NameCollisionPolicyFirst":   2,
+		"NameCollisionPolicyLast":    3,
+	}
+)
+
+func (x NameCollisionPolicy) Enum() *NameCollisionPolicy {
+	p := new(NameCollisionPolicy)
+	*p = x
+	return p
+}
+
+func (x NameCollisionPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NameCollisionPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_synchronization_core_name_collision_policy_proto_enumTypes[0].Descriptor()
+}
+
+func (NameCollisionPolicy) Type() protoreflect.EnumType {
+	return &file_synchronization_core_name_collision_policy_proto_enumTypes[0]
+}
+
+func (x NameCollisionPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NameCollisionPolicy.Descriptor instead.
+func (NameCollisionPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_synchronization_core_name_collision_policy_proto_rawDescGZIP(), []int{0}
+}
+
+var File_synchronization_core_name_collision_policy_proto protoreflect.FileDescriptor
+
+var file_synchronization_core_name_collision_policy_proto_rawDesc = []byte{
+	0x0a, 0x30, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c,
+	0x69, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x04, 0x63, 0x6f, 0x72, 0x65, 0x2a, 0x8f, 0x01, 0x0a, 0x13, 0x4e, 0x61, 0x6d,
+	0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x1e, 0x0a, 0x1a, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x10, 0x00,
+	0x12, 0x1d, 0x0a, 0x19, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f,
+	0x6e, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x10, 0x01, 0x12,
+	0x1c, 0x0a, 0x18, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x46, 0x69, 0x72, 0x73, 0x74, 0x10, 0x02, 0x12, 0x1b, 0x0a,
+	0x17, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x4c, 0x61, 0x73, 0x74, 0x10, 0x03, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e,
+	0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f,
+	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_synchronization_core_name_collision_policy_proto_rawDescOnce sync.Once
+	file_synchronization_core_name_collision_policy_proto_rawDescData = file_synchronization_core_name_collision_policy_proto_rawDesc
+)
+
+func file_synchronization_core_name_collision_policy_proto_rawDescGZIP() []byte {
+	file_synchronization_core_name_collision_policy_proto_rawDescOnce.Do(func() {
+		file_synchronization_core_name_collision_policy_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_core_name_collision_policy_proto_rawDescData)
+	})
+	return file_synchronization_core_name_collision_policy_proto_rawDescData
+}
+
+var file_synchronization_core_name_collision_policy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_synchronization_core_name_collision_policy_proto_goTypes = []interface{}{
+	(NameCollisionPolicy)(0), // 0: core.NameCollisionPolicy
+}
+var file_synchronization_core_name_collision_policy_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_core_name_collision_policy_proto_init() }
+func file_synchronization_core_name_collision_policy_proto_init() {
+	if File_synchronization_core_name_collision_policy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_core_name_collision_policy_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_core_name_collision_policy_proto_goTypes,
+		DependencyIndexes: file_synchronization_core_name_collision_policy_proto_depIdxs,
+		EnumInfos:         file_synchronization_core_name_collision_policy_proto_enumTypes,
+	}.Build()
+	File_synchronization_core_name_collision_policy_proto = out.File
+	file_synchronization_core_name_collision_policy_proto_rawDesc = nil
+	file_synchronization_core_name_collision_policy_proto_goTypes = nil
+	file_synchronization_core_name_collision_policy_proto_depIdxs = nil
+}
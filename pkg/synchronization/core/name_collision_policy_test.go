@@ -0,0 +1,116 @@
+package core
+
+import (
+	"testing"
+)
+
+// TestNameCollisionPolicyIsDefault tests NameCollisionPolicy.IsDefault.
+func TestNameCollisionPolicyIsDefault(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		value    NameCollisionPolicy
+		expected bool
+	}{
+		{NameCollisionPolicy_NameCollisionPolicyDefault - 1, false},
+		{NameCollisionPolicy_NameCollisionPolicyDefault, true},
+		{NameCollisionPolicy_NameCollisionPolicyReport, false},
+		{NameCollisionPolicy_NameCollisionPolicyFirst, false},
+		{NameCollisionPolicy_NameCollisionPolicyLast, false},
+		{NameCollisionPolicy_NameCollisionPolicyLast + 1, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if result := test.value.IsDefault(); result && !test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as default", i)
+		} else if !result && test.expected {
+			t.Errorf("test index %d: value was unexpectedly classified as non-default", i)
+		}
+	}
+}
+
+// TestNameCollisionPolicyUnmarshalText tests NameCollisionPolicy.UnmarshalText.
+func TestNameCollisionPolicyUnmarshalText(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		text          string
+		expectedMode  NameCollisionPolicy
+		expectFailure bool
+	}{
+		{"", NameCollisionPolicy_NameCollisionPolicyDefault, true},
+		{"asdf", NameCollisionPolicy_NameCollisionPolicyDefault, true},
+		{"report", NameCollisionPolicy_NameCollisionPolicyReport, false},
+		{"first", NameCollisionPolicy_NameCollisionPolicyFirst, false},
+		{"last", NameCollisionPolicy_NameCollisionPolicyLast, false},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		var mode NameCollisionPolicy
+		if err := mode.UnmarshalText([]byte(test.text)); err != nil {
+			if !test.expectFailure {
+				t.Errorf("unable to unmarshal text (%s): %s", test.text, err)
+			}
+		} else if test.expectFailure {
+			t.Error("unmarshaling succeeded unexpectedly for text:", test.text)
+		} else if mode != test.expectedMode {
+			t.Errorf(
+				"unmarshaled mode (%s) does not match expected (%s)",
+				mode,
+				test.expectedMode,
+			)
+		}
+	}
+}
+
+// TestNameCollisionPolicySupported tests NameCollisionPolicy.Supported.
+func TestNameCollisionPolicySupported(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode            NameCollisionPolicy
+		expectSupported bool
+	}{
+		{NameCollisionPolicy_NameCollisionPolicyDefault, false},
+		{NameCollisionPolicy_NameCollisionPolicyReport, true},
+		{NameCollisionPolicy_NameCollisionPolicyFirst, true},
+		{NameCollisionPolicy_NameCollisionPolicyLast, true},
+		{(NameCollisionPolicy_NameCollisionPolicyLast + 1), false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if supported := testCase.mode.Supported(); supported != testCase.expectSupported {
+			t.Errorf(
+				"mode support status (%t) does not match expected (%t)",
+				supported,
+				testCase.expectSupported,
+			)
+		}
+	}
+}
+
+// TestNameCollisionPolicyDescription tests NameCollisionPolicy.Description.
+func TestNameCollisionPolicyDescription(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		mode                NameCollisionPolicy
+		expectedDescription string
+	}{
+		{NameCollisionPolicy_NameCollisionPolicyDefault, "Default"},
+		{NameCollisionPolicy_NameCollisionPolicyReport, "Report"},
+		{NameCollisionPolicy_NameCollisionPolicyFirst, "First"},
+		{NameCollisionPolicy_NameCollisionPolicyLast, "Last"},
+		{(NameCollisionPolicy_NameCollisionPolicyLast + 1), "Unknown"},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		if description := testCase.mode.Description(); description != testCase.expectedDescription {
+			t.Errorf(
+				"mode description (%s) does not match expected (%s)",
+				description,
+				testCase.expectedDescription,
+			)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/comparison"
+)
+
+// TestResolveNameCollisions tests ResolveNameCollisions.
+func TestResolveNameCollisions(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		description        string
+		ancestor           *Entry
+		alpha              *Entry
+		beta               *Entry
+		ignoreCase         bool
+		policy             NameCollisionPolicy
+		expectedCollisions []*NameCollision
+		expectedAlpha      *Entry
+		expectedBeta       *Entry
+	}{
+		{
+			description: "case difference with case sensitivity",
+			alpha:       &Entry{Contents: map[string]*Entry{"Readme": tF1, "README": tF2}},
+			beta:        tD0,
+			policy:      NameCollisionPolicy_NameCollisionPolicyReport,
+		},
+		{
+			description: "case difference with case insensitivity",
+			ancestor:    &Entry{Contents: map[string]*Entry{"Readme": tF1}},
+			alpha:       &Entry{Contents: map[string]*Entry{"Readme": tF1, "README": tF2}},
+			beta:        &Entry{Contents: map[string]*Entry{"Readme": tF1}},
+			ignoreCase:  true,
+			policy:      NameCollisionPolicy_NameCollisionPolicyReport,
+			expectedCollisions: []*NameCollision{
+				{Paths: []string{"README", "Readme"}},
+			},
+			expectedAlpha: &Entry{Contents: map[string]*Entry{
+				"Readme": {Kind: EntryKind_Problematic, Problem: nameCollisionProblem},
+				"README": {Kind: EntryKind_Problematic, Problem: nameCollisionProblem},
+			}},
+			expectedBeta: &Entry{Contents: map[string]*Entry{
+				"Readme": {Kind: EntryKind_Problematic, Problem: nameCollisionProblem},
+			}},
+		},
+		{
+			description: "case difference with first policy",
+			ancestor:    &Entry{Contents: map[string]*Entry{"Readme": tF1}},
+			alpha:       &Entry{Contents: map[string]*Entry{"Readme": tF1, "README": tF2}},
+			beta:        &Entry{Contents: map[string]*Entry{"Readme": tF1}},
+			ignoreCase:  true,
+			policy:      NameCollisionPolicy_NameCollisionPolicyFirst,
+			expectedCollisions: []*NameCollision{
+				{Paths: []string{"README", "Readme"}, Winner: "README"},
+			},
+			expectedAlpha: &Entry{Contents: map[string]*Entry{"README": tF2}},
+			expectedBeta:  &Entry{Contents: map[string]*Entry{"Readme": tF1}},
+		},
+		{
+			description: "case difference with last policy",
+			alpha:       &Entry{Contents: map[string]*Entry{"Readme": tF1, "README": tF2}},
+			beta:        tD0,
+			ignoreCase:  true,
+			policy:      NameCollisionPolicy_NameCollisionPolicyLast,
+			expectedCollisions: []*NameCollision{
+				{Paths: []string{"README", "Readme"}, Winner: "Readme"},
+			},
+			expectedAlpha: &Entry{Contents: map[string]*Entry{"Readme": tF1}},
+			expectedBeta:  tD0,
+		},
+		{
+			description: "case-only rename",
+			ancestor:    &Entry{Contents: map[string]*Entry{"Readme": tF1}},
+			alpha:       &Entry{Contents: map[string]*Entry{"README": tF1}},
+			beta:        &Entry{Contents: map[string]*Entry{"Readme": tF1}},
+			ignoreCase:  true,
+			policy:      NameCollisionPolicy_NameCollisionPolicyReport,
+		},
+		{
+			description: "normalization difference",
+			alpha:       &Entry{Contents: map[string]*Entry{"\xc3\xa9": tF1}},
+			beta:        &Entry{Contents: map[string]*Entry{"\x65\xcc\x81": tF2}},
+			policy:      NameCollisionPolicy_NameCollisionPolicyReport,
+			expectedCollisions: []*NameCollision{
+				{Paths: []string{"\x65\xcc\x81", "\xc3\xa9"}},
+			},
+			expectedAlpha: &Entry{Contents: map[string]*Entry{
+				"\xc3\xa9": {Kind: EntryKind_Problematic, Problem: nameCollisionProblem},
+			}},
+			expectedBeta: &Entry{Contents: map[string]*Entry{
+				"\x65\xcc\x81": {Kind: EntryKind_Problematic, Problem: nameCollisionProblem},
+			}},
+		},
+		{
+			description: "nested collision in new directory",
+			alpha:       nested("directory", &Entry{Contents: map[string]*Entry{"file": tF1, "FILE": tF2}}),
+			ignoreCase:  true,
+			policy:      NameCollisionPolicy_NameCollisionPolicyReport,
+			expectedCollisions: []*NameCollision{
+				{Paths: []string{"directory/FILE", "directory/file"}},
+			},
+			expectedAlpha: nested("directory", &Entry{Contents: map[string]*Entry{
+				"file": {Kind: EntryKind_Problematic, Problem: nameCollisionProblem},
+				"FILE": {Kind: EntryKind_Problematic, Problem: nameCollisionProblem},
+			}}),
+		},
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		// Record the original entries so that we can verify that they aren't
+		// modified.
+		alphaOriginal := test.alpha.Copy(true)
+		betaOriginal := test.beta.Copy(true)
+
+		// Perform resolution.
+		alpha, beta, collisions := ResolveNameCollisions(
+			test.ancestor, test.alpha, test.beta,
+			test.ignoreCase, test.policy,
+		)
+
+		// Verify that the original entries weren't modified.
+		if !test.alpha.Equal(alphaOriginal, true) || !test.beta.Equal(betaOriginal, true) {
+			t.Errorf("%s: original entries modified", test.description)
+		}
+
+		// Verify collisions.
+		if len(collisions) != len(test.expectedCollisions) {
+			t.Errorf("%s: collision count does not match expected: %d != %d",
+				test.description, len(collisions), len(test.expectedCollisions),
+			)
+			continue
+		}
+		for c, collision := range collisions {
+			if err := collision.EnsureValid(); err != nil {
+				t.Errorf("%s: invalid collision: %v", test.description, err)
+			}
+			expected := test.expectedCollisions[c]
+			if !comparison.StringSlicesEqual(collision.Paths, expected.Paths) {
+				t.Errorf("%s: collision paths do not match expected: %v != %v",
+					test.description, collision.Paths, expected.Paths,
+				)
+			}
+			if collision.Winner != expected.Winner {
+				t.Errorf("%s: collision winner does not match expected: %s != %s",
+					test.description, collision.Winner, expected.Winner,
+				)
+			}
+		}
+
+		// Verify resulting entries. If no collisions were expected, then the
+		// entries should be returned unmodified.
+		if len(test.expectedCollisions) == 0 {
+			if alpha != test.alpha || beta != test.beta {
+				t.Errorf("%s: entries modified without collisions", test.description)
+			}
+			continue
+		}
+		if !alpha.Equal(test.expectedAlpha, true) {
+			t.Errorf("%s: alpha does not match expected", test.description)
+		}
+		if !beta.Equal(test.expectedBeta, true) {
+			t.Errorf("%s: beta does not match expected", test.description)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	pathpkg "path"
 	"strings"
 )
 
@@ -15,6 +16,19 @@ func pathJoinable(base string) string {
 	return base + "/"
 }
 
+// pathIsCanonical returns whether or not a path is a canonical root-relative
+// synchronization path, i.e. a clean, relative, slash-separated path that
+// doesn't traverse upward. The synchronization root (represented by an empty
+// path) is considered canonical.
+func pathIsCanonical(path string) bool {
+	if path == "" {
+		return true
+	}
+	return path != "." && pathpkg.Clean(path) == path &&
+		!strings.HasPrefix(path, "/") &&
+		path != ".." && !strings.HasPrefix(path, "../")
+}
+
 // pathDir is a fast alternative to path.Dir designed specifically for
 // root-relative synchronization paths. It avoids the unnecessary path cleaning
 // overhead incurred by path.Dir. Note that, unlike path.Dir, this function
@@ -100,7 +100,29 @@ func (r *reconciler) reconcile(path string, ancestor, alpha, beta *Entry) {
 		// Finally, since we'll be wiping out the old ancestor value at this
 		// path, we don't want to recursively add deletion changes for its old
 		// child entries as well, so we nil them out at this point.
+		//
+		// If the ancestor agrees with the content but alpha and beta disagree
+		// on extended attributes, then we handle that disagreement separately.
+		// If alpha and beta agree on extended attributes but the ancestor
+		// doesn't, then we update the ancestor as described above. If the
+		// ancestor needs to be updated and alpha and beta disagree on extended
+		// attributes, then we record no extended attributes for the ancestor,
+		// which will cause the disagreement to be treated as a modification on
+		// the next synchronization cycle.
+		extendedAttributesAgree := ExtendedAttributesEqual(alpha.ExtendedAttributes, beta.ExtendedAttributes)
 		if !ancestor.Equal(alpha, false) {
+			newAncestor := alpha.Copy(false)
+			if !extendedAttributesAgree {
+				newAncestor.ExtendedAttributes = nil
+			}
+			r.ancestorChanges = append(r.ancestorChanges, &Change{
+				Path: path,
+				New:  newAncestor,
+			})
+			ancestorContents = nil
+		} else if !extendedAttributesAgree {
+			r.handleExtendedAttributeDisagreement(path, ancestor, alpha, beta)
+		} else if !ExtendedAttributesEqual(ancestor.ExtendedAttributes, alpha.ExtendedAttributes) {
 			r.ancestorChanges = append(r.ancestorChanges, &Change{
 				Path: path,
 				New:  alpha.Copy(false),
@@ -163,6 +185,64 @@ func (r *reconciler) reconcile(path string, ancestor, alpha, beta *Entry) {
 	}
 }
 
+// extendedAttributeChange creates a change that updates the extended
+// attributes of an existing file or directory to match those of a source entry
+// with equivalent content. Both entries in the change are slim copies, which
+// Transition and Apply treat as a metadata-only update in the case of
+// directories.
+func extendedAttributeChange(path string, existing, source *Entry) *Change {
+	updated := existing.Copy(false)
+	updated.ExtendedAttributes = source.ExtendedAttributes
+	return &Change{
+		Path: path,
+		Old:  existing.Copy(false),
+		New:  updated,
+	}
+}
+
+// handleExtendedAttributeDisagreement handles extended attribute disagreements
+// between alpha and beta at a particular path where the ancestor, alpha, and
+// beta all agree on the content at that path. Direction is determined using the
+// same three-way merge logic as for content, with the ancestor's extended
+// attributes as the base.
+func (r *reconciler) handleExtendedAttributeDisagreement(path string, ancestor, alpha, beta *Entry) {
+	// Determine which sides have modified their extended attributes.
+	alphaModified := !ExtendedAttributesEqual(ancestor.ExtendedAttributes, alpha.ExtendedAttributes)
+	betaModified := !ExtendedAttributesEqual(ancestor.ExtendedAttributes, beta.ExtendedAttributes)
+
+	// Determine the direction of propagation based on the synchronization
+	// mode. If neither direction is viable, then we have a conflict.
+	var alphaToBeta, betaToAlpha bool
+	switch r.mode {
+	case SynchronizationMode_SynchronizationModeTwoWaySafe:
+		alphaToBeta = !betaModified
+		betaToAlpha = !alphaModified
+	case SynchronizationMode_SynchronizationModeTwoWayResolved:
+		alphaToBeta = alphaModified || !betaModified
+		betaToAlpha = !alphaToBeta
+	case SynchronizationMode_SynchronizationModeOneWaySafe:
+		alphaToBeta = !betaModified
+	case SynchronizationMode_SynchronizationModeOneWayReplica:
+		alphaToBeta = true
+	default:
+		panic("invalid synchronization mode")
+	}
+
+	// Record the appropriate change or conflict. For the conflict, we use slim
+	// copies of the entries since only this path's metadata is in question.
+	if alphaToBeta {
+		r.betaChanges = append(r.betaChanges, extendedAttributeChange(path, beta, alpha))
+	} else if betaToAlpha {
+		r.alphaChanges = append(r.alphaChanges, extendedAttributeChange(path, alpha, beta))
+	} else {
+		r.conflicts = append(r.conflicts, &Conflict{
+			Root:         path,
+			AlphaChanges: []*Change{{Path: path, Old: ancestor.Copy(false), New: alpha.Copy(false)}},
+			BetaChanges:  []*Change{{Path: path, Old: ancestor.Copy(false), New: beta.Copy(false)}},
+		})
+	}
+}
+
 // handleDisagreementBidirectional handles content disagreements between alpha
 // and beta at a particular path in bidirectional synchronization modes.
 func (r *reconciler) handleDisagreementBidirectional(path string, ancestor, alpha, beta *Entry) {
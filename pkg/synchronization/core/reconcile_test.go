@@ -1077,6 +1077,84 @@ func TestReconcile(t *testing.T) {
 	}
 }
 
+// TestReconcileExtendedAttributes tests reconciliation of extended attribute
+// disagreements between entries that agree on content.
+func TestReconcileExtendedAttributes(t *testing.T) {
+	// Define a function to create a copy of an entry with extended attributes.
+	withAttributes := func(entry *Entry, value string) *Entry {
+		result := entry.Copy(false)
+		result.Contents = entry.Contents
+		if value != "" {
+			result.ExtendedAttributes = map[string][]byte{"user.mutagen": []byte(value)}
+		}
+		return result
+	}
+
+	// Define a function to create expected extended attribute values.
+	attribute := func(value string) *string {
+		return &value
+	}
+
+	// Define test cases. The expected values indicate the extended attribute
+	// value ("" for none) expected in the new entry of the single expected
+	// alpha, beta, or ancestor change (if any).
+	tests := []struct {
+		description      string
+		ancestor         *Entry
+		alpha            *Entry
+		beta             *Entry
+		modes            []SynchronizationMode
+		expectedAlpha    *string
+		expectedBeta     *string
+		expectedAncestor *string
+		expectConflict   bool
+	}{
+		{"alpha file modified", tF1, withAttributes(tF1, "alpha"), tF1, allModes, nil, attribute("alpha"), nil, false},
+		{"alpha directory modified", tD1, withAttributes(tD1, "alpha"), tD1, allModes, nil, attribute("alpha"), nil, false},
+		{"beta file modified", tF1, tF1, withAttributes(tF1, "beta"), twoWayModes, attribute("beta"), nil, nil, false},
+		{"beta file modified in one-way-safe mode", tF1, tF1, withAttributes(tF1, "beta"), oneWaySafeMode, nil, nil, nil, true},
+		{"beta file modified in one-way-replica mode", tF1, tF1, withAttributes(tF1, "beta"), oneWayReplicaMode, nil, attribute(""), nil, false},
+		{"both files modified", tF1, withAttributes(tF1, "alpha"), withAttributes(tF1, "beta"), safeModes, nil, nil, nil, true},
+		{"both files modified in resolved modes", tF1, withAttributes(tF1, "alpha"), withAttributes(tF1, "beta"), resolvedModes, nil, attribute("alpha"), nil, false},
+		{"both files modified identically", tF1, withAttributes(tF1, "same"), withAttributes(tF1, "same"), allModes, nil, nil, attribute("same"), false},
+		{"both files created differently", nil, withAttributes(tF1, "alpha"), withAttributes(tF1, "beta"), allModes, nil, nil, attribute(""), false},
+	}
+
+	// Define a function to verify a change list against an expected extended
+	// attribute value.
+	verify := func(description, side string, mode SynchronizationMode, changes []*Change, expected *string) {
+		if expected == nil {
+			if len(changes) > 0 {
+				t.Errorf("%s: unexpected %s changes in mode %s", description, side, mode)
+			}
+			return
+		} else if len(changes) != 1 {
+			t.Errorf("%s: unexpected number of %s changes in mode %s: %d", description, side, mode, len(changes))
+			return
+		}
+		if value := string(changes[0].New.ExtendedAttributes["user.mutagen"]); value != *expected {
+			t.Errorf("%s: unexpected %s extended attribute value in mode %s: %s", description, side, mode, value)
+		} else if changes[0].New.Kind == EntryKind_Directory && changes[0].New.Contents != nil {
+			t.Errorf("%s: %s directory metadata change is not slim in mode %s", description, side, mode)
+		}
+	}
+
+	// Process test cases.
+	for _, test := range tests {
+		for _, mode := range test.modes {
+			ancestorChanges, alphaChanges, betaChanges, conflicts := Reconcile(test.ancestor, test.alpha, test.beta, mode)
+			verify(test.description, "ancestor", mode, ancestorChanges, test.expectedAncestor)
+			verify(test.description, "alpha", mode, alphaChanges, test.expectedAlpha)
+			verify(test.description, "beta", mode, betaChanges, test.expectedBeta)
+			if test.expectConflict && len(conflicts) != 1 {
+				t.Errorf("%s: conflict not detected in mode %s", test.description, mode)
+			} else if !test.expectConflict && len(conflicts) > 0 {
+				t.Errorf("%s: unexpected conflicts in mode %s", test.description, mode)
+			}
+		}
+	}
+}
+
 // TestReconcilePanicWithInvalidSynchronizationMode tests that Reconcile panics
 // when provided with disagreeing contents and an invalid synchronization mode.
 func TestReconcilePanicWithInvalidSynchronizationMode(t *testing.T) {
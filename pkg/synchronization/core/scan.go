@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 	"unicode/utf8"
 
 	"golang.org/x/text/unicode/norm"
@@ -43,6 +45,26 @@ const (
 	// doubling on insert without always allocating a huge cache. Its value is
 	// somewhat arbitrary.
 	defaultInitialCacheCapacity = 1024
+
+	// maximumIgnoreFileSize is the maximum size of a per-directory ignore file
+	// that a scanner will load.
+	maximumIgnoreFileSize = 1024 * 1024
+
+	// scannerHashQueueDepthPerWorker specifies the number of pending hashing
+	// jobs that a scanner will queue per hashing worker. Since each pending job
+	// holds an open file, this bounds the number of file descriptors that a
+	// scan will hold open.
+	scannerHashQueueDepthPerWorker = 4
+
+	// directoryCacheRaceWindow is the minimum age that a directory's
+	// modification time must have (relative to the start of a scan) in order
+	// for the directory's metadata to be recorded in the cache. Modifications
+	// that occur within the same modification time granularity as the recorded
+	// modification time can't be detected, so recent modification times can't
+	// be trusted. This window is large enough to cover the granularity of most
+	// filesystems (the notable exception being FAT, which uses 2-second
+	// granularity).
+	directoryCacheRaceWindow = 2 * time.Second
 )
 
 // ErrScanCancelled indicates that the scan was cancelled.
@@ -82,12 +104,80 @@ var behaviorCache struct {
 	preservesExecutability map[uint64]bool
 	// decomposesUnicode maps device IDs to Unicode decomposition behavior.
 	decomposesUnicode map[uint64]bool
+	// ignoresCase maps device IDs to case-insensitivity behavior.
+	ignoresCase map[uint64]bool
 }
 
 func init() {
 	// Initialize the behavior cache.
 	behaviorCache.preservesExecutability = make(map[uint64]bool)
 	behaviorCache.decomposesUnicode = make(map[uint64]bool)
+	behaviorCache.ignoresCase = make(map[uint64]bool)
+}
+
+// computeDigest computes the digest of a file's contents using the specified
+// hasher and copy buffer, verifying that the expected number of bytes were
+// hashed. It returns ErrScanCancelled if the operation is preempted by the
+// specified cancellation channel. Any other error indicates that the file
+// should be treated as problematic.
+func computeDigest(
+	hasher hash.Hash,
+	file io.Reader,
+	expectedSize uint64,
+	buffer []byte,
+	cancelled <-chan struct{},
+) ([]byte, error) {
+	// Reset the hash state.
+	hasher.Reset()
+
+	// Copy data into the hash and verify that we copied the amount expected. We
+	// use a preemptable wrapper around the hasher to enable timely
+	// cancellation.
+	preemptableHasher := stream.NewPreemptableWriter(hasher, cancelled, scannerCopyPreemptionInterval)
+	if copied, err := io.CopyBuffer(preemptableHasher, file, buffer); err != nil {
+		if err == stream.ErrWritePreempted {
+			return nil, ErrScanCancelled
+		}
+		return nil, fmt.Errorf("unable to hash file contents: %w", err)
+	} else if uint64(copied) != expectedSize {
+		return nil, fmt.Errorf("hashed size mismatch: %d != %d", copied, expectedSize)
+	}
+
+	// Compute the digest.
+	return hasher.Sum(nil), nil
+}
+
+// hashJob represents a file whose digest computation has been deferred to a
+// hashing worker.
+type hashJob struct {
+	// path is the path of the file.
+	path string
+	// file is the open file. It is closed by the hashing worker.
+	file io.ReadSeekCloser
+	// size is the expected size of the file.
+	size uint64
+	// entry is the entry for the file, whose digest is populated once the job
+	// completes.
+	entry *Entry
+	// cacheEntry is the new cache entry for the file, whose digest is
+	// populated once the job completes.
+	cacheEntry *CacheEntry
+	// digest is the computed digest. It is set by the hashing worker.
+	digest []byte
+	// err is the error that occurred while hashing, if any. It is set by the
+	// hashing worker.
+	err error
+}
+
+// hashWorker processes hashing jobs until the jobs channel is closed. Jobs are
+// processed using the specified hasher, with cancellation regulated by the
+// specified channel.
+func hashWorker(jobs <-chan *hashJob, hasher hash.Hash, cancelled <-chan struct{}) {
+	buffer := make([]byte, scannerCopyBufferSize)
+	for job := range jobs {
+		job.digest, job.err = computeDigest(hasher, job.file, job.size, buffer, cancelled)
+		job.file.Close()
+	}
 }
 
 // scanner provides the recursive implementation of scanning.
@@ -99,18 +189,59 @@ type scanner struct {
 	// dirtyPaths is the set of tainted paths for which a baseline snapshot
 	// can't be trusted.
 	dirtyPaths map[string]bool
-	// hasher is the hashing function to use for computing file digests.
+	// trustedBaseline indicates that the baseline may be stale and that its
+	// directory contents should only be re-used if directory metadata matches
+	// that recorded in the cache.
+	trustedBaseline bool
+	// directoryCacheCutoff is the time before which directory modification
+	// times must fall in order for directory metadata to be recorded in the
+	// new cache.
+	directoryCacheCutoff time.Time
+	// hasher is the hashing function to use for computing file digests on the
+	// scanning Goroutine.
 	hasher hash.Hash
+	// directoryWorkers is the pool of workers available for scanning
+	// directories on separate Goroutines. Receiving a worker from the channel
+	// acquires it and sending it back releases it. If nil, then directories are
+	// scanned serially.
+	directoryWorkers chan *directoryWorker
+	// forks are the scanners used to scan directories on separate Goroutines,
+	// including any that they themselves created. Their results are merged
+	// into this scanner by mergeForks once scanning is complete.
+	forks []*scanner
+	// hashJobs is the channel used to submit hashing jobs to hashing workers.
+	// If nil, then file digests are computed on the scanning Goroutine.
+	hashJobs chan<- *hashJob
+	// pendingHashJobs are the hashing jobs that have been submitted, in order
+	// of submission.
+	pendingHashJobs []*hashJob
 	// cache is the existing cache to use for fast digest lookups.
 	cache *Cache
 	// ignorer is the ignorer identifying ignored paths.
 	ignorer *ignorer
-	// ignoreCache is the cache of ignored path behavior.
+	// ignoreCache is the cache of ignored path behavior. It is nil if
+	// per-directory ignore files are in use.
 	ignoreCache IgnoreCache
+	// ignoreFiles are the names of per-directory ignore files to load.
+	ignoreFiles []string
+	// includes are the include paths limiting the scan. If empty, then all
+	// paths are included.
+	includes []string
 	// symbolicLinkMode is the symbolic link mode being used.
 	symbolicLinkMode SymbolicLinkMode
 	// permissionsMode is the permissions mode being used.
 	permissionsMode PermissionsMode
+	// modificationTimeMode is the modification time mode being used.
+	modificationTimeMode ModificationTimeMode
+	// hardLinks tracks the file entries encountered for each file ID. It is
+	// only non-nil if hard links are being preserved.
+	hardLinks map[uint64][]hardLinkMember
+	// reusedFiles tracks the file entries re-used from the baseline. It is only
+	// populated if hard links are being preserved.
+	reusedFiles []hardLinkMember
+	// extendedAttributeFilter is the filter identifying extended attributes to
+	// capture. If nil, then extended attributes aren't captured.
+	extendedAttributeFilter func(string) bool
 	// newCache is the new file digest cache to populate.
 	newCache *Cache
 	// newIgnoreCache is the new ignored path behavior cache to populate.
@@ -136,6 +267,161 @@ type scanner struct {
 	totalFileSize uint64
 }
 
+// directoryWorker holds the resources used by a scanner when scanning
+// directories on a separate Goroutine.
+type directoryWorker struct {
+	// hasher is the hashing function to use for computing file digests.
+	hasher hash.Hash
+	// copyBuffer is the copy buffer used for computing file digests.
+	copyBuffer []byte
+}
+
+// forkedDirectory represents a directory being scanned on a separate Goroutine.
+type forkedDirectory struct {
+	// name is the content name of the directory.
+	name string
+	// path is the path of the directory.
+	path string
+	// scanner is the scanner used to scan the directory.
+	scanner *scanner
+	// entry is the resulting entry. It is only valid once scanning completes.
+	entry *Entry
+	// err is the resulting error. It is only valid once scanning completes.
+	err error
+}
+
+// directoryForks tracks the child directories of a directory that are being
+// scanned on separate Goroutines.
+type directoryForks struct {
+	// group tracks the completion of scanning Goroutines.
+	group sync.WaitGroup
+	// directories are the directories being scanned.
+	directories []*forkedDirectory
+}
+
+// fork creates a scanner for scanning a directory on a separate Goroutine using
+// the resources of the specified worker. The resulting scanner shares this
+// scanner's configuration but records its own results.
+func (s *scanner) fork(worker *directoryWorker) *scanner {
+	// Copy the scanner configuration.
+	result := *s
+
+	// Set up independent resources and results.
+	result.hasher = worker.hasher
+	result.copyBuffer = worker.copyBuffer
+	result.forks = nil
+	result.pendingHashJobs = nil
+	result.newCache = &Cache{
+		Entries:     make(map[string]*CacheEntry),
+		Directories: make(map[string]*CacheEntry),
+	}
+	result.newIgnoreCache = make(IgnoreCache)
+	if s.hardLinks != nil {
+		result.hardLinks = make(map[uint64][]hardLinkMember)
+	}
+	result.reusedFiles = nil
+	result.directories = 0
+	result.files = 0
+	result.symbolicLinks = 0
+	result.totalFileSize = 0
+
+	// Done.
+	return &result
+}
+
+// forkDirectory attempts to scan the specified child directory on a separate
+// Goroutine, tracking it using the specified forks. The arguments are otherwise
+// the same as those for directory. It returns false if no directory worker is
+// available, in which case the directory should be scanned on the current
+// Goroutine.
+func (s *scanner) forkDirectory(
+	forks *directoryForks,
+	name, path string,
+	parent *filesystem.Directory,
+	metadata *filesystem.Metadata,
+	baseline *Entry,
+	layer *ignoreLayer,
+) bool {
+	// Attempt to acquire a worker.
+	var worker *directoryWorker
+	select {
+	case worker = <-s.directoryWorkers:
+	default:
+		return false
+	}
+
+	// Start scanning the directory, releasing the worker once complete.
+	forked := &forkedDirectory{name: name, path: path, scanner: s.fork(worker)}
+	forks.directories = append(forks.directories, forked)
+	forks.group.Add(1)
+	go func() {
+		forked.entry, forked.err = forked.scanner.directory(path, parent, metadata, nil, baseline, layer)
+		s.directoryWorkers <- worker
+		forks.group.Done()
+	}()
+
+	// Success.
+	return true
+}
+
+// joinDirectories waits for directories being scanned on separate Goroutines
+// to complete and records their entries in the specified contents, along with
+// their ignore cache entries. It also records the scanners used to scan them
+// so that their results can be merged. Directories that no longer exist are
+// skipped, while any other error is returned.
+func (s *scanner) joinDirectories(forks *directoryForks, contents map[string]*Entry) error {
+	// Wait for scanning to complete.
+	forks.group.Wait()
+
+	// Process results.
+	for _, forked := range forks.directories {
+		s.forks = append(s.forks, forked.scanner)
+		s.forks = append(s.forks, forked.scanner.forks...)
+		if forked.err != nil {
+			if os.IsNotExist(forked.err) {
+				continue
+			}
+			return forked.err
+		}
+		s.newIgnoreCache[IgnoreCacheKey{forked.path, true}] = false
+		contents[forked.name] = forked.entry
+	}
+
+	// Success.
+	return nil
+}
+
+// mergeForks merges the results recorded by forked scanners into the scanner.
+// It must only be called once scanning is complete.
+func (s *scanner) mergeForks() {
+	for _, fork := range s.forks {
+		for path, entry := range fork.newCache.Entries {
+			s.newCache.Entries[path] = entry
+		}
+		for path, entry := range fork.newCache.Directories {
+			s.newCache.Directories[path] = entry
+		}
+		for key, ignored := range fork.newIgnoreCache {
+			s.newIgnoreCache[key] = ignored
+		}
+		for fileID, members := range fork.hardLinks {
+			s.hardLinks[fileID] = append(s.hardLinks[fileID], members...)
+		}
+		s.reusedFiles = append(s.reusedFiles, fork.reusedFiles...)
+		s.pendingHashJobs = append(s.pendingHashJobs, fork.pendingHashJobs...)
+		s.directories += fork.directories
+		s.files += fork.files
+		s.symbolicLinks += fork.symbolicLinks
+		s.totalFileSize += fork.totalFileSize
+	}
+	s.forks = nil
+}
+
+// fullPath computes the filesystem path for the specified root-relative path.
+func (s *scanner) fullPath(path string) string {
+	return filepath.Join(s.root, filepath.FromSlash(path))
+}
+
 // file performs processing of a file entry. Exactly one of parent or file will
 // be non-nil, depending on whether or not the path represents the
 // synchronization root. If the path represents the synchronization root, then
@@ -175,15 +461,20 @@ func (s *scanner) file(
 		metadata.Mode == filesystem.Mode(cached.Mode)
 
 	// Compute the digest, either by pulling it from the cache or computing it
-	// from the on-disk contents.
+	// from the on-disk contents. If we open the file here and hashing workers
+	// are available, then we defer digest computation to those workers, in
+	// which case ownership of the file passes to the hashing job once it's
+	// submitted.
 	var digest []byte
+	var job *hashJob
 	var err error
 	if cacheContentMatch {
 		digest = cached.Digest
 	} else {
-		// If the file is not yet opened, then open it and defer its closure. We
-		// can also update the metadata at this point since we'll pay the cost
-		// of accessing it when opening the file.
+		// If the file is not yet opened, then open it and arrange for its
+		// closure. We can also update the metadata at this point since we'll
+		// pay the cost of accessing it when opening the file.
+		var opened bool
 		if file == nil {
 			file, metadata, err = parent.OpenFile(metadata.Name)
 			if err != nil {
@@ -195,39 +486,36 @@ func (s *scanner) file(
 					Problem: fmt.Errorf("unable to open file: %w", err).Error(),
 				}, nil
 			}
-			defer file.Close()
+			opened = true
 		}
 
-		// Reset the hash state.
-		s.hasher.Reset()
-
-		// Copy data into the hash and verify that we copied the amount
-		// expected. We use a preemptable wrapper around the hasher to enable
-		// timely cancellation.
-		preemptableHasher := stream.NewPreemptableWriter(s.hasher, s.cancelled, scannerCopyPreemptionInterval)
-		if copied, err := io.CopyBuffer(preemptableHasher, file, s.copyBuffer); err != nil {
-			if err == stream.ErrWritePreempted {
-				return nil, ErrScanCancelled
+		// Either defer digest computation or perform it here.
+		if opened && s.hashJobs != nil {
+			job = &hashJob{path: path, file: file, size: metadata.Size}
+			defer func() {
+				if job != nil {
+					job.file.Close()
+				}
+			}()
+		} else {
+			if opened {
+				defer file.Close()
+			}
+			if digest, err = computeDigest(s.hasher, file, metadata.Size, s.copyBuffer, s.cancelled); err != nil {
+				if err == ErrScanCancelled {
+					return nil, err
+				}
+				return &Entry{
+					Kind:    EntryKind_Problematic,
+					Problem: err.Error(),
+				}, nil
 			}
-			return &Entry{
-				Kind:    EntryKind_Problematic,
-				Problem: fmt.Errorf("unable to hash file contents: %w", err).Error(),
-			}, nil
-		} else if uint64(copied) != metadata.Size {
-			return &Entry{
-				Kind:    EntryKind_Problematic,
-				Problem: fmt.Sprintf("hashed size mismatch: %d != %d", copied, metadata.Size),
-			}, nil
 		}
-
-		// Compute the digest.
-		digest = s.hasher.Sum(nil)
 	}
 
 	// Add an entry to the new cache.
-	if cacheEntryReusable {
-		s.newCache.Entries[path] = cached
-	} else {
+	cacheEntry := cached
+	if !cacheEntryReusable {
 		// Convert the new modification time to Protocol Buffers format.
 		modificationTime := timestamppb.New(metadata.ModificationTime)
 		if err := modificationTime.CheckValid(); err != nil {
@@ -238,7 +526,7 @@ func (s *scanner) file(
 		}
 
 		// Create the new cache entry.
-		s.newCache.Entries[path] = &CacheEntry{
+		cacheEntry = &CacheEntry{
 			Mode:             uint32(metadata.Mode),
 			ModificationTime: modificationTime,
 			Size:             metadata.Size,
@@ -246,17 +534,270 @@ func (s *scanner) file(
 			Digest:           digest,
 		}
 	}
+	s.newCache.Entries[path] = cacheEntry
+
+	// If we're preserving modification times, then record the modification
+	// time in the entry. We can share the cache entry's timestamp since both
+	// are treated as immutable.
+	var entryModificationTime *timestamppb.Timestamp
+	if s.modificationTimeMode == ModificationTimeMode_ModificationTimeModePreserve {
+		entryModificationTime = cacheEntry.ModificationTime
+	}
+
+	// Capture extended attributes, if necessary.
+	var extendedAttributes map[string][]byte
+	if s.extendedAttributeFilter != nil && parent != nil {
+		extendedAttributes, err = filesystem.ReadExtendedAttributesByPath(s.fullPath(path), s.extendedAttributeFilter)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, err
+			}
+			return &Entry{
+				Kind:    EntryKind_Problematic,
+				Problem: fmt.Errorf("unable to read extended attributes: %w", err).Error(),
+			}, nil
+		}
+	}
+
+	// Create the entry.
+	entry := &Entry{
+		Kind:               EntryKind_File,
+		Executable:         executable,
+		Digest:             digest,
+		ModificationTime:   entryModificationTime,
+		ExtendedAttributes: extendedAttributes,
+	}
+
+	// If we're preserving hard links, then record the entry so that we can
+	// identify hard link groups once the scan is complete. We can't do this for
+	// file roots (which can't be part of a group anyway) or on platforms that
+	// don't provide file IDs.
+	if s.hardLinks != nil && parent != nil && metadata.FileID != 0 {
+		s.hardLinks[metadata.FileID] = append(s.hardLinks[metadata.FileID], hardLinkMember{path, entry})
+	}
+
+	// If digest computation has been deferred, then submit the hashing job.
+	// The entry and cache entry digests will be populated once hashing
+	// workers have completed.
+	if job != nil {
+		job.entry = entry
+		job.cacheEntry = cacheEntry
+		select {
+		case s.hashJobs <- job:
+			s.pendingHashJobs = append(s.pendingHashJobs, job)
+			job = nil
+		case <-s.cancelled:
+			return nil, ErrScanCancelled
+		}
+	}
 
 	// Increment the total file count and size.
 	s.files++
 	s.totalFileSize += metadata.Size
 
 	// Success.
-	return &Entry{
-		Kind:       EntryKind_File,
-		Executable: executable,
-		Digest:     digest,
-	}, nil
+	return entry, nil
+}
+
+// completeHashJobs applies the results of completed hashing jobs, populating
+// entry and cache entry digests. Files that couldn't be hashed are converted to
+// problematic entries and removed from the new cache. This method must only be
+// called once all hashing workers have exited.
+func (s *scanner) completeHashJobs() error {
+	for _, job := range s.pendingHashJobs {
+		// Handle failed jobs.
+		if job.err != nil {
+			// If the scan was cancelled, then abort.
+			if job.err == ErrScanCancelled {
+				return ErrScanCancelled
+			}
+
+			// Convert the entry to a problematic entry and remove the
+			// corresponding cache entry and accounting.
+			job.entry.Kind = EntryKind_Problematic
+			job.entry.Problem = job.err.Error()
+			job.entry.Executable = false
+			job.entry.ModificationTime = nil
+			job.entry.ExtendedAttributes = nil
+			delete(s.newCache.Entries, job.path)
+			s.files--
+			s.totalFileSize -= job.size
+			continue
+		}
+
+		// Populate digests.
+		job.entry.Digest = job.digest
+		job.cacheEntry.Digest = job.digest
+	}
+
+	// Success.
+	return nil
+}
+
+// hardLinkMember represents a file entry that may be part of a hard link group.
+type hardLinkMember struct {
+	// path is the path of the file.
+	path string
+	// entry is the file entry.
+	entry *Entry
+}
+
+// assignHardLinkGroups identifies groups of file entries that reference the
+// same underlying file and records their group in each member entry. It must
+// only be called once hashing jobs have been completed. Entries that failed to
+// scan are excluded, and groups whose members don't agree on content (which
+// can happen if the file is modified during scanning) are ignored.
+func (s *scanner) assignHardLinkGroups() {
+	for _, members := range s.hardLinks {
+		// Filter out any members that didn't scan successfully.
+		files := members[:0]
+		for _, member := range members {
+			if member.entry.Kind == EntryKind_File {
+				files = append(files, member)
+			}
+		}
+
+		// Groups only make sense with multiple members.
+		if len(files) < 2 {
+			continue
+		}
+
+		// Identify the group path and verify content agreement.
+		group := files[0].path
+		consistent := true
+		for _, member := range files[1:] {
+			if member.path < group {
+				group = member.path
+			}
+			if !bytes.Equal(member.entry.Digest, files[0].entry.Digest) {
+				consistent = false
+				break
+			}
+		}
+		if !consistent {
+			continue
+		}
+
+		// Record the group in each member entry.
+		for _, member := range files {
+			member.entry.HardLinkGroup = group
+		}
+	}
+}
+
+// relinkReusedFiles identifies file entries re-used from the baseline whose
+// hard link groups may have been affected by rescanned content and records them
+// for hard link group assignment. A re-used entry is affected if it references
+// the same underlying file as a rescanned entry (e.g. because a link to it has
+// been created or because it was modified through another link) or if it
+// belongs to a baseline group with members that weren't re-used (e.g. because
+// they've been modified or removed). Entries that reference the same underlying
+// file as a rescanned entry are replaced with copies of that entry, since they
+// share its content and metadata. Since re-used entries are shared with the
+// baseline, affected entries (and their parent directories) are copied rather
+// than modified. The updated content is returned. This method must only be
+// called once hashing jobs have been completed and before
+// assignHardLinkGroups.
+func (s *scanner) relinkReusedFiles(content, baseline *Entry) *Entry {
+	// Count the re-used members of each hard link group.
+	reusedGroupSizes := make(map[string]int)
+	for _, member := range s.reusedFiles {
+		if member.entry.HardLinkGroup != "" {
+			reusedGroupSizes[member.entry.HardLinkGroup]++
+		}
+	}
+
+	// If any re-used entries belong to groups, then count the members of each
+	// group in the baseline.
+	var baselineGroupSizes map[string]int
+	if len(reusedGroupSizes) > 0 {
+		baselineGroupSizes = make(map[string]int, len(reusedGroupSizes))
+		baseline.walk("", func(_ string, entry *Entry) {
+			if entry != nil && entry.HardLinkGroup != "" {
+				baselineGroupSizes[entry.HardLinkGroup]++
+			}
+		}, false)
+	}
+
+	// Identify affected entries. We rely on file IDs from the cache since they
+	// aren't recorded in entries. We don't record affected entries until all
+	// of them have been identified, that way we only use rescanned entries
+	// when looking for entries that reference the same file.
+	relinkedMembers := make(map[uint64][]hardLinkMember)
+	for _, member := range s.reusedFiles {
+		// Look for a rescanned entry that references the same file.
+		cacheEntry := s.newCache.Entries[member.path]
+		var rescanned *hardLinkMember
+		if cacheEntry.FileID != 0 {
+			for m, candidate := range s.hardLinks[cacheEntry.FileID] {
+				if candidate.entry.Kind == EntryKind_File {
+					rescanned = &s.hardLinks[cacheEntry.FileID][m]
+					break
+				}
+			}
+		}
+
+		// Determine whether or not the entry is affected.
+		group := member.entry.HardLinkGroup
+		if rescanned == nil && (group == "" || reusedGroupSizes[group] == baselineGroupSizes[group]) {
+			continue
+		}
+
+		// Create an updated entry, using the rescanned entry if available (in
+		// which case we also update the cache and total file size).
+		var relinked *Entry
+		if rescanned != nil {
+			relinked = rescanned.entry.Copy(false)
+			rescannedCacheEntry := s.newCache.Entries[rescanned.path]
+			s.newCache.Entries[member.path] = rescannedCacheEntry
+			s.totalFileSize = s.totalFileSize - cacheEntry.Size + rescannedCacheEntry.Size
+		} else {
+			relinked = member.entry.Copy(false)
+		}
+		relinked.HardLinkGroup = ""
+
+		// Replace the entry and track it for group assignment.
+		content = replaceEntry(content, member.path, relinked)
+		if cacheEntry.FileID != 0 {
+			relinkedMembers[cacheEntry.FileID] = append(relinkedMembers[cacheEntry.FileID], hardLinkMember{member.path, relinked})
+		}
+	}
+
+	// Record affected entries for group assignment.
+	for fileID, members := range relinkedMembers {
+		s.hardLinks[fileID] = append(s.hardLinks[fileID], members...)
+	}
+
+	// Done.
+	return content
+}
+
+// replaceEntry replaces the entry at the specified path within the specified
+// hierarchy, which must contain the path's parent directories. The directories
+// along the path are copied (rather than modified) since they may be shared
+// with other hierarchies. The resulting hierarchy is returned.
+func replaceEntry(root *Entry, path string, entry *Entry) *Entry {
+	// Handle the trivial case of replacing the root.
+	if path == "" {
+		return entry
+	}
+
+	// Split off the first path component.
+	name, remaining := path, ""
+	if slash := strings.IndexByte(path, '/'); slash >= 0 {
+		name, remaining = path[:slash], path[slash+1:]
+	}
+
+	// Copy the root and its contents, replacing the relevant child.
+	result := root.Copy(false)
+	result.Contents = make(map[string]*Entry, len(root.Contents))
+	for childName, child := range root.Contents {
+		result.Contents[childName] = child
+	}
+	result.Contents[name] = replaceEntry(root.Contents[name], remaining, entry)
+
+	// Done.
+	return result
 }
 
 // symbolicLink performs processing of a symbolic link entry.
@@ -305,19 +846,91 @@ func (s *scanner) symbolicLink(
 	}, nil
 }
 
+// loadIgnoreFiles loads and parses any per-directory ignore files present in a
+// directory. It also determines whether or not the ignore files have changed
+// relative to the baseline (if any) by comparing their digests with those of
+// the corresponding baseline entries. Ignore files are loaded even if they are
+// themselves ignored, though in that case they'll always be treated as changed
+// because the baseline won't have their digests.
+func (s *scanner) loadIgnoreFiles(
+	directory *filesystem.Directory,
+	contents []*filesystem.Metadata,
+	baseline *Entry,
+) ([]*ignorePattern, bool, error) {
+	var patterns []*ignorePattern
+	var changed bool
+	for _, name := range s.ignoreFiles {
+		// Look up the baseline entry for the ignore file, if any.
+		var baselineEntry *Entry
+		if baseline != nil {
+			baselineEntry = baseline.Contents[name]
+		}
+
+		// Determine whether or not the ignore file exists as a regular file.
+		var present bool
+		for _, c := range contents {
+			if c.Name == name && (c.Mode&filesystem.ModeTypeMask) == filesystem.ModeTypeFile {
+				present = true
+				break
+			}
+		}
+		if !present {
+			if baselineEntry != nil && baselineEntry.Kind == EntryKind_File {
+				changed = true
+			}
+			continue
+		}
+
+		// Read the ignore file.
+		file, _, err := directory.OpenFile(name)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, false, err
+			}
+			return nil, false, fmt.Errorf("unable to open ignore file (%s): %w", name, err)
+		}
+		data, err := io.ReadAll(io.LimitReader(file, maximumIgnoreFileSize+1))
+		file.Close()
+		if err != nil {
+			return nil, false, fmt.Errorf("unable to read ignore file (%s): %w", name, err)
+		} else if len(data) > maximumIgnoreFileSize {
+			return nil, false, fmt.Errorf("ignore file (%s) exceeds maximum allowed size", name)
+		}
+
+		// Compare the ignore file's digest with that of the baseline.
+		if baselineEntry == nil || baselineEntry.Kind != EntryKind_File {
+			changed = true
+		} else {
+			s.hasher.Reset()
+			s.hasher.Write(data)
+			if !bytes.Equal(s.hasher.Sum(nil), baselineEntry.Digest) {
+				changed = true
+			}
+		}
+
+		// Parse the ignore file.
+		patterns = append(patterns, parseIgnoreFile(data)...)
+	}
+
+	// Done.
+	return patterns, changed, nil
+}
+
 // directory performs processing of a directory entry. Exactly one of parent or
 // directory will be non-nil, depending on whether or not the path represents
 // the synchronization root. If the path represents the synchronization root,
 // then directory will be provided and the caller will be responsible for its
 // closure (i.e. this function should not close it). Otherwise, the parent of
 // the path is provided and this function is responsible for opening and closing
-// the directory as necessary.
+// the directory as necessary. The layer argument provides the patterns loaded
+// from per-directory ignore files in ancestor directories, if any.
 func (s *scanner) directory(
 	path string,
 	parent *filesystem.Directory,
 	metadata *filesystem.Metadata,
 	directory *filesystem.Directory,
 	baseline *Entry,
+	layer *ignoreLayer,
 ) (*Entry, error) {
 	// Verify that the baseline, if any, is sane.
 	if baseline != nil && baseline.Kind != EntryKind_Directory {
@@ -350,6 +963,37 @@ func (s *scanner) directory(
 		}
 	}
 
+	// Capture extended attributes, if necessary.
+	var extendedAttributes map[string][]byte
+	if s.extendedAttributeFilter != nil && parent != nil {
+		if attributes, err := filesystem.ReadExtendedAttributesByPath(s.fullPath(path), s.extendedAttributeFilter); err != nil {
+			if os.IsNotExist(err) {
+				return nil, err
+			}
+			return &Entry{
+				Kind:    EntryKind_Problematic,
+				Problem: fmt.Errorf("unable to read extended attributes: %w", err).Error(),
+			}, nil
+		} else {
+			extendedAttributes = attributes
+		}
+	}
+
+	// If the baseline is trusted, then attempt to re-use its contents.
+	if s.trustedBaseline && baseline != nil {
+		if contents, err := s.trustedContents(path, directory, metadata, baseline, layer); err != nil {
+			return nil, err
+		} else if contents != nil {
+			s.recordDirectory(path, metadata)
+			s.directories++
+			return &Entry{
+				Kind:               EntryKind_Directory,
+				Contents:           contents,
+				ExtendedAttributes: extendedAttributes,
+			}, nil
+		}
+	}
+
 	// Read directory contents.
 	directoryContents, err := directory.ReadContents()
 	if err != nil {
@@ -376,6 +1020,38 @@ func (s *scanner) directory(
 		contentPathPrefix = pathJoinable(path)
 	}
 
+	// Load any per-directory ignore files, scoping their patterns to this
+	// directory. If the ignore files have changed relative to the baseline,
+	// then none of the baseline's contents can be trusted (since their ignore
+	// status may have changed), so we discard the baseline and rescan.
+	if len(s.ignoreFiles) > 0 {
+		patterns, changed, err := s.loadIgnoreFiles(directory, directoryContents, baseline)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil, err
+			}
+			return &Entry{
+				Kind:    EntryKind_Problematic,
+				Problem: err.Error(),
+			}, nil
+		}
+		if changed {
+			baseline = nil
+		}
+		if len(patterns) > 0 {
+			layer = &ignoreLayer{
+				parent:  layer,
+				prefix:  pathJoinable(path),
+				ignorer: &ignorer{patterns},
+			}
+		}
+	}
+
+	// Track child directories scanned on separate Goroutines, ensuring that
+	// they've completed before we return (and close the directory).
+	forks := &directoryForks{}
+	defer forks.group.Wait()
+
 	// Compute entries.
 	contents := make(map[string]*Entry, len(directoryContents))
 	for _, contentMetadata := range directoryContents {
@@ -435,13 +1111,22 @@ func (s *scanner) directory(
 			continue
 		}
 
+		// If include paths are specified, then ensure that this path is either
+		// included or a directory that leads to included content. If not, then
+		// record an untracked entry without any further inspection.
+		contentIsDirectory := contentKind == EntryKind_Directory
+		if included, ancestor := includeStatus(s.includes, contentPath); !included && !(ancestor && contentIsDirectory) {
+			contents[contentName] = &Entry{Kind: EntryKind_Untracked}
+			continue
+		}
+
 		// Determine whether or not this path is ignored and update the new
 		// ignore cache. If the path is ignored, then record an untracked entry.
-		contentIsDirectory := contentKind == EntryKind_Directory
 		ignoreCacheKey := IgnoreCacheKey{contentPath, contentIsDirectory}
 		ignored, ok := s.ignoreCache[ignoreCacheKey]
 		if !ok {
 			ignored = s.ignorer.ignored(contentPath, contentIsDirectory)
+			ignored = layer.update(contentPath, contentIsDirectory, ignored)
 		}
 		s.newIgnoreCache[ignoreCacheKey] = ignored
 		if ignored {
@@ -493,7 +1178,11 @@ func (s *scanner) directory(
 		// directory had been modified. FSEvents and ReadDirectoryChangesW don't
 		// have this problem, because they would report the path for the
 		// directory being deleted and/or renamed.
-		if directoryBaseline != nil {
+		//
+		// Trusted baselines aren't re-used wholesale since they may be stale.
+		// Their directory entries are instead passed down and validated using
+		// directory metadata.
+		if directoryBaseline != nil && !s.trustedBaseline {
 			contentDirty := s.dirtyPaths[contentPath]
 			if runtime.GOOS == "linux" && !contentDirty &&
 				len(directoryBaseline.Contents) == 0 {
@@ -525,7 +1214,8 @@ func (s *scanner) directory(
 					// that the baseline corresponds to the provided cache,
 					// though note that this is not a full verification (e.g. we
 					// don't check that digests or modes match) because that
-					// would be too costly.
+					// would be too costly. Directory cache entries are
+					// propagated if present, but they aren't required.
 					if entry.Kind == EntryKind_File {
 						if oldCacheEntry, ok := s.cache.Entries[path]; ok {
 							s.newCache.Entries[path] = oldCacheEntry
@@ -533,6 +1223,17 @@ func (s *scanner) directory(
 						} else {
 							missingCacheEntries = true
 						}
+					} else if entry.Kind == EntryKind_Directory {
+						if oldCacheEntry, ok := s.cache.Directories[path]; ok {
+							s.newCache.Directories[path] = oldCacheEntry
+						}
+					}
+
+					// If we're preserving hard links, then record re-used
+					// files so that their hard link groups can be updated once
+					// the scan is complete.
+					if s.hardLinks != nil && entry.Kind == EntryKind_File {
+						s.reusedFiles = append(s.reusedFiles, hardLinkMember{path, entry})
 					}
 				}, false)
 				if missingCacheEntries {
@@ -545,7 +1246,12 @@ func (s *scanner) directory(
 		// If we didn't have a baseline, or if the content path was marked as
 		// dirty, then we need to handle it manually. Note that we're still
 		// passing the directory baseline down at this point, because its child
-		// entries may not be marked as dirty and may be reusable.
+		// entries may not be marked as dirty and may be reusable. If this is a
+		// directory and a directory worker is available, then we scan it on a
+		// separate Goroutine.
+		if contentIsDirectory && s.forkDirectory(forks, contentName, contentPath, directory, contentMetadata, directoryBaseline, layer) {
+			continue
+		}
 		var entry *Entry
 		var err error
 		if contentKind == EntryKind_File {
@@ -561,7 +1267,7 @@ func (s *scanner) directory(
 				panic("unsupported symbolic link mode")
 			}
 		} else if contentKind == EntryKind_Directory {
-			entry, err = s.directory(contentPath, directory, contentMetadata, nil, directoryBaseline)
+			entry, err = s.directory(contentPath, directory, contentMetadata, nil, directoryBaseline, layer)
 		} else {
 			panic("unhandled entry kind")
 		}
@@ -580,29 +1286,218 @@ func (s *scanner) directory(
 		contents[contentName] = entry
 	}
 
-	// Increment the total directory count.
+	// Record the contents scanned on separate Goroutines.
+	if err := s.joinDirectories(forks, contents); err != nil {
+		return nil, err
+	}
+
+	// Record the directory's metadata and increment the total directory count.
+	s.recordDirectory(path, metadata)
 	s.directories++
 
 	// Success.
 	return &Entry{
-		Kind:     EntryKind_Directory,
-		Contents: contents,
+		Kind:               EntryKind_Directory,
+		Contents:           contents,
+		ExtendedAttributes: extendedAttributes,
 	}, nil
 }
 
+// recordDirectory records directory metadata in the new cache, so long as the
+// directory's modification time is old enough to be trusted.
+//
+// RACE: The modification time of a directory is only updated when its listing
+// changes, and with finite granularity, so a modification that occurs shortly
+// after the metadata was read may not be reflected in the recorded value. The
+// race window cutoff ensures that such modification times aren't recorded,
+// though it relies on reasonable agreement between the local clock and the
+// clock used to set modification times (which may differ on network
+// filesystems).
+func (s *scanner) recordDirectory(path string, metadata *filesystem.Metadata) {
+	// Ensure that the modification time is old enough to be trusted.
+	if !metadata.ModificationTime.Before(s.directoryCacheCutoff) {
+		return
+	}
+
+	// Convert the modification time to Protocol Buffers format.
+	modificationTime := timestamppb.New(metadata.ModificationTime)
+	if modificationTime.CheckValid() != nil {
+		return
+	}
+
+	// Record the entry.
+	s.newCache.Directories[path] = &CacheEntry{
+		Mode:             uint32(metadata.Mode),
+		ModificationTime: modificationTime,
+		FileID:           metadata.FileID,
+	}
+}
+
+// trustedContents attempts to re-use the contents of a trusted baseline entry
+// for a directory. The directory's listing is considered unchanged if its
+// metadata matches that recorded in the cache, in which case the baseline's
+// content names and kinds are re-used without re-reading the listing or
+// re-evaluating ignores. Child directories are scanned using their own baseline
+// entries. Because the modification time of a directory isn't updated when its
+// child files are modified in-place (or when their permissions or extended
+// attributes change), child files are always re-examined, though their digests
+// are only recomputed if their metadata doesn't match that recorded in the
+// cache. Symbolic links and untracked content are re-used directly, since they
+// can't be modified without updating the listing. If the baseline contents
+// can't be re-used, then nil contents are returned and the directory should be
+// scanned normally.
+func (s *scanner) trustedContents(
+	path string,
+	directory *filesystem.Directory,
+	metadata *filesystem.Metadata,
+	baseline *Entry,
+	layer *ignoreLayer,
+) (map[string]*Entry, error) {
+	// Verify that the directory's metadata matches that recorded in the cache.
+	cached, ok := s.cache.Directories[path]
+	if !ok ||
+		metadata.Mode != filesystem.Mode(cached.Mode) ||
+		!metadata.ModificationTime.Equal(cached.ModificationTime.AsTime()) ||
+		metadata.FileID != cached.FileID {
+		return nil, nil
+	}
+
+	// Compute the prefix to add to content names to compute their paths.
+	contentPathPrefix := pathJoinable(path)
+
+	// Verify that all baseline contents can be re-used before making any
+	// changes to scan state. Problematic content is always re-examined, while
+	// child files and directories must still exist with the same type. We
+	// record their metadata for use in scanning them.
+	var contentMetadata map[string]*filesystem.Metadata
+	for name, entry := range baseline.Contents {
+		var expectedType filesystem.Mode
+		switch entry.Kind {
+		case EntryKind_Problematic:
+			return nil, nil
+		case EntryKind_File:
+			expectedType = filesystem.ModeTypeFile
+		case EntryKind_Directory:
+			expectedType = filesystem.ModeTypeDirectory
+		default:
+			continue
+		}
+		m, err := directory.ReadContentMetadata(name)
+		if err != nil || m.Mode&filesystem.ModeTypeMask != expectedType {
+			return nil, nil
+		}
+		if contentMetadata == nil {
+			contentMetadata = make(map[string]*filesystem.Metadata)
+		}
+		contentMetadata[name] = m
+	}
+
+	// Track child directories scanned on separate Goroutines, ensuring that
+	// they've completed before we return.
+	forks := &directoryForks{}
+	defer forks.group.Wait()
+
+	// Re-use baseline contents, scanning child directories.
+	contents := make(map[string]*Entry, len(baseline.Contents))
+	for name, entry := range baseline.Contents {
+		// Check for cancellation.
+		select {
+		case <-s.cancelled:
+			return nil, ErrScanCancelled
+		default:
+		}
+
+		// Compute the content path.
+		contentPath := contentPathPrefix + name
+
+		// Handle the content based on its kind. Untracked content is re-used
+		// directly, since its presence and kind are reflected in the listing.
+		switch entry.Kind {
+		case EntryKind_Directory:
+			if s.forkDirectory(forks, name, contentPath, directory, contentMetadata[name], entry, layer) {
+				continue
+			}
+			child, err := s.directory(contentPath, directory, contentMetadata[name], nil, entry, layer)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			s.newIgnoreCache[IgnoreCacheKey{contentPath, true}] = false
+			entry = child
+		case EntryKind_File:
+			child, err := s.file(contentPath, directory, contentMetadata[name], nil)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			s.newIgnoreCache[IgnoreCacheKey{contentPath, false}] = false
+			entry = child
+		case EntryKind_SymbolicLink:
+			s.newIgnoreCache[IgnoreCacheKey{contentPath, false}] = false
+			s.symbolicLinks++
+		}
+
+		// Record the content.
+		contents[name] = entry
+	}
+
+	// Record the contents scanned on separate Goroutines.
+	if err := s.joinDirectories(forks, contents); err != nil {
+		return nil, err
+	}
+
+	// Success.
+	return contents, nil
+}
+
 // Scan creates a new filesystem snapshot at the specified root. The only
-// required arguments are ctx, root, hasher, ignores, probeMode,
-// symbolicLinkMode, and permissionsMode. The baseline, recheckPaths, cache, and
-// ignoreCache fields merely provide acceleration options.
+// required arguments are ctx, root, newHasher, ignores, probeMode,
+// symbolicLinkMode, permissionsMode, modificationTimeMode, and hardLinkMode.
+// The newHasher function is used to create the hashers used for computing file
+// digests. The ignoreFiles argument specifies the names of per-directory ignore
+// files whose patterns should be loaded and applied to the subtrees containing
+// them (with none being loaded if it's empty). The includes argument specifies
+// synchronization root-relative paths to which the scan should be limited (with
+// no limit being applied if it's empty). Content that's neither included nor
+// along the path to included content is recorded as untracked without being
+// traversed. If hardLinkMode is HardLinkMode_HardLinkModePreserve, then file
+// entries that reference the same underlying file are assigned a common hard
+// link group. Entries re-used from the baseline retain their hard link groups
+// unless those groups are affected by rescanned content. The extendedAttributes
+// argument specifies patterns for extended attributes that should be captured
+// (with none being captured if it's empty). Extended attributes are not
+// captured for the synchronization root itself. The parallelism argument
+// specifies the number of workers to use for traversing directories and for
+// computing file digests, with values less than 2 indicating that scanning
+// should be performed serially. Regardless of parallelism, the resulting
+// snapshot is deterministic. The baseline, recheckPaths, cache, and ignoreCache
+// fields merely provide acceleration options. If trustedBaseline is true, then
+// the baseline is treated as potentially stale (e.g. because it was loaded from
+// disk after a restart) and recheckPaths is ignored. Rather than being re-used
+// wholesale, the listing of each directory in a trusted baseline is re-used
+// only if its metadata matches that recorded in the cache, with files in those
+// directories still being re-examined.
+// Trusted baselines aren't used if per-directory ignore files are in use or if
+// the filesystem decomposes Unicode.
 func Scan(
 	ctx context.Context,
 	root string,
-	baseline *Snapshot, recheckPaths map[string]bool,
-	hasher hash.Hash, cache *Cache,
+	baseline *Snapshot, recheckPaths map[string]bool, trustedBaseline bool,
+	newHasher func() hash.Hash, cache *Cache,
 	ignores []string, ignoreCache IgnoreCache,
+	ignoreFiles []string,
+	includes []string,
 	probeMode behavior.ProbeMode,
 	symbolicLinkMode SymbolicLinkMode,
 	permissionsMode PermissionsMode,
+	modificationTimeMode ModificationTimeMode,
+	hardLinkMode HardLinkMode,
+	extendedAttributes []string,
+	parallelism int,
 ) (*Snapshot, *Cache, IgnoreCache, error) {
 	// Verify that the symbolic link mode is valid for this platform.
 	if symbolicLinkMode == SymbolicLinkMode_SymbolicLinkModePOSIXRaw && runtime.GOOS == "windows" {
@@ -648,13 +1543,14 @@ func Scan(
 	behaviorCache.RLock()
 	cachedPreserves, cachedPreservesOk := behaviorCache.preservesExecutability[metadata.DeviceID]
 	cachedDecomposes, cachedDecomposesOk := behaviorCache.decomposesUnicode[metadata.DeviceID]
+	cachedIgnoresCase, cachedIgnoresCaseOk := behaviorCache.ignoresCase[metadata.DeviceID]
 	behaviorCache.RUnlock()
 
 	// Track whether or not we use probe files when determining behavior.
 	var usedProbeFiles bool
 
 	// Probe the behavior of the synchronization root.
-	var preservesExecutability, decomposesUnicode bool
+	var preservesExecutability, decomposesUnicode, ignoresCase bool
 	if rootKind == EntryKind_Directory {
 		// Check executability preservation behavior.
 		if cachedPreservesOk {
@@ -675,6 +1571,16 @@ func Scan(
 			decomposesUnicode = decomposes
 			usedProbeFiles = usedProbeFiles || usedFiles
 		}
+
+		// Check case-insensitivity behavior.
+		if cachedIgnoresCaseOk {
+			ignoresCase = cachedIgnoresCase
+		} else if ignores, usedFiles, err := behavior.IgnoresCase(directoryRoot, probeMode); err != nil {
+			return nil, nil, nil, fmt.Errorf("unable to probe root case-insensitivity behavior: %w", err)
+		} else {
+			ignoresCase = ignores
+			usedProbeFiles = usedProbeFiles || usedFiles
+		}
 	} else if rootKind == EntryKind_File {
 		// For file roots, we use the behavioral information of their parent
 		// directory.
@@ -721,6 +1627,9 @@ func Scan(
 			decomposesUnicode = decomposes
 			usedProbeFiles = usedProbeFiles || usedFiles
 		}
+
+		// Case-insensitivity behavior is irrelevant for file roots since names
+		// can't collide, so we don't bother probing it.
 	} else {
 		panic("unhandled root kind")
 	}
@@ -732,6 +1641,9 @@ func Scan(
 		behaviorCache.Lock()
 		behaviorCache.preservesExecutability[metadata.DeviceID] = preservesExecutability
 		behaviorCache.decomposesUnicode[metadata.DeviceID] = decomposesUnicode
+		if rootKind == EntryKind_Directory {
+			behaviorCache.ignoresCase[metadata.DeviceID] = ignoresCase
+		}
 		behaviorCache.Unlock()
 	}
 
@@ -741,7 +1653,8 @@ func Scan(
 		baselineInvalid := baseline.Content == nil ||
 			baseline.Content.Kind != rootKind ||
 			baseline.PreservesExecutability != preservesExecutability ||
-			baseline.DecomposesUnicode != decomposesUnicode
+			baseline.DecomposesUnicode != decomposesUnicode ||
+			baseline.IgnoresCase != ignoresCase
 		if baselineInvalid {
 			baseline = nil
 		}
@@ -752,15 +1665,23 @@ func Scan(
 	// We don't explicitly check here that the digest cache and ignore cache
 	// correspond to the baseline, because doing so is expensive. We place the
 	// burden of enforcing that invariant on the caller.
-	if baseline != nil && len(recheckPaths) == 0 {
+	if baseline != nil && !trustedBaseline && len(recheckPaths) == 0 {
 		return baseline, cache, ignoreCache, nil
 	}
 
+	// If per-directory ignore files are in use, then we can't detect changes
+	// to them without re-reading them, and if the filesystem decomposes
+	// Unicode, then baseline names may not correspond to on-disk names. In
+	// either case, we can't use a trusted baseline.
+	if trustedBaseline && (len(ignoreFiles) > 0 || decomposesUnicode) {
+		baseline = nil
+	}
+
 	// Convert the list of re-check paths into a set of dirty paths. The rule is
 	// that we add any re-check path as well as any parent component of any
 	// re-check path.
 	var dirtyPaths map[string]bool
-	if baseline != nil && len(recheckPaths) > 0 {
+	if baseline != nil && !trustedBaseline && len(recheckPaths) > 0 {
 		dirtyPaths = make(map[string]bool)
 		for path := range recheckPaths {
 			for {
@@ -785,6 +1706,19 @@ func Scan(
 		return nil, nil, nil, fmt.Errorf("unable to create ignorer: %w", err)
 	}
 
+	// Validate include paths.
+	for _, include := range includes {
+		if !ValidIncludePath(include) {
+			return nil, nil, nil, fmt.Errorf("invalid include path: %s", include)
+		}
+	}
+
+	// Create the extended attribute filter.
+	extendedAttributeFilter, err := newExtendedAttributeFilter(extendedAttributes)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to create extended attribute filter: %w", err)
+	}
+
 	// Create a new cache to populate. Estimate its capacity based on the
 	// existing cache length. If the existing cache is empty, create one with
 	// the default capacity.
@@ -793,7 +1727,8 @@ func Scan(
 		initialCacheCapacity = cacheLength
 	}
 	newCache := &Cache{
-		Entries: make(map[string]*CacheEntry, initialCacheCapacity),
+		Entries:     make(map[string]*CacheEntry, initialCacheCapacity),
+		Directories: make(map[string]*CacheEntry, len(cache.Directories)),
 	}
 
 	// Create a new ignore cache to populate. Estimate its capacity based on the
@@ -805,23 +1740,43 @@ func Scan(
 	}
 	newIgnoreCache := make(IgnoreCache, initialIgnoreCacheCapacity)
 
+	// If per-directory ignore files are in use, then ignore status can change
+	// without any change to the ignore specifications provided here, so we
+	// can't trust the existing ignore cache.
+	if len(ignoreFiles) > 0 {
+		ignoreCache = nil
+	}
+
+	// If we're preserving hard links, then create the hard link tracking map.
+	var hardLinks map[uint64][]hardLinkMember
+	if hardLinkMode == HardLinkMode_HardLinkModePreserve {
+		hardLinks = make(map[uint64][]hardLinkMember)
+	}
+
 	// Create a scanner.
 	s := &scanner{
-		cancelled:              ctx.Done(),
-		root:                   root,
-		dirtyPaths:             dirtyPaths,
-		hasher:                 hasher,
-		cache:                  cache,
-		ignorer:                ignorer,
-		ignoreCache:            ignoreCache,
-		symbolicLinkMode:       symbolicLinkMode,
-		permissionsMode:        permissionsMode,
-		newCache:               newCache,
-		newIgnoreCache:         newIgnoreCache,
-		copyBuffer:             make([]byte, scannerCopyBufferSize),
-		deviceID:               metadata.DeviceID,
-		recomposeUnicode:       decomposesUnicode,
-		preservesExecutability: preservesExecutability,
+		cancelled:               ctx.Done(),
+		root:                    root,
+		dirtyPaths:              dirtyPaths,
+		trustedBaseline:         trustedBaseline && baseline != nil,
+		directoryCacheCutoff:    time.Now().Add(-directoryCacheRaceWindow),
+		hasher:                  newHasher(),
+		cache:                   cache,
+		ignorer:                 ignorer,
+		ignoreCache:             ignoreCache,
+		ignoreFiles:             ignoreFiles,
+		includes:                includes,
+		symbolicLinkMode:        symbolicLinkMode,
+		permissionsMode:         permissionsMode,
+		modificationTimeMode:    modificationTimeMode,
+		hardLinks:               hardLinks,
+		extendedAttributeFilter: extendedAttributeFilter,
+		newCache:                newCache,
+		newIgnoreCache:          newIgnoreCache,
+		copyBuffer:              make([]byte, scannerCopyBufferSize),
+		deviceID:                metadata.DeviceID,
+		recomposeUnicode:        decomposesUnicode,
+		preservesExecutability:  preservesExecutability,
 	}
 
 	// Handle the scan based on the root type.
@@ -831,7 +1786,62 @@ func Scan(
 		if baseline != nil {
 			directoryBaseline = baseline.Content
 		}
-		content, err = s.directory("", nil, metadata, directoryRoot, directoryBaseline)
+
+		// If parallel scanning has been requested, then create directory
+		// workers (with the scanning Goroutine acting as one of them) and
+		// start hashing workers. Hashing workers operate with a cancellation
+		// channel that's also closed if the scan fails, that way they don't
+		// waste time hashing content that won't be used.
+		var hashJobs chan *hashJob
+		var hashWorkers sync.WaitGroup
+		workerCtx, cancelWorkers := context.WithCancel(ctx)
+		defer cancelWorkers()
+		if parallelism > 1 {
+			s.directoryWorkers = make(chan *directoryWorker, parallelism-1)
+			for i := 1; i < parallelism; i++ {
+				s.directoryWorkers <- &directoryWorker{
+					hasher:     newHasher(),
+					copyBuffer: make([]byte, scannerCopyBufferSize),
+				}
+			}
+			hashJobs = make(chan *hashJob, parallelism*scannerHashQueueDepthPerWorker)
+			s.hashJobs = hashJobs
+			for i := 0; i < parallelism; i++ {
+				hashWorkers.Add(1)
+				go func(hasher hash.Hash) {
+					hashWorker(hashJobs, hasher, workerCtx.Done())
+					hashWorkers.Done()
+				}(newHasher())
+			}
+		}
+
+		// Perform the scan and merge the results of any directories scanned on
+		// separate Goroutines.
+		content, err = s.directory("", nil, metadata, directoryRoot, directoryBaseline, nil)
+		if err == nil {
+			s.mergeForks()
+		}
+
+		// If hashing workers were started, then wait for them to complete any
+		// pending jobs and apply their results.
+		if hashJobs != nil {
+			if err != nil {
+				cancelWorkers()
+			}
+			close(hashJobs)
+			hashWorkers.Wait()
+			if err == nil {
+				err = s.completeHashJobs()
+			}
+		}
+
+		// If we're preserving hard links, then update any re-used baseline
+		// entries whose hard link groups may have been affected by changes and
+		// identify hard link groups.
+		if err == nil && s.hardLinks != nil {
+			content = s.relinkReusedFiles(content, directoryBaseline)
+			s.assignHardLinkGroups()
+		}
 	} else if rootKind == EntryKind_File {
 		content, err = s.file("", nil, metadata, fileRoot)
 	} else {
@@ -846,6 +1856,7 @@ func Scan(
 		Content:                content,
 		PreservesExecutability: preservesExecutability,
 		DecomposesUnicode:      decomposesUnicode,
+		IgnoresCase:            ignoresCase,
 		Directories:            s.directories,
 		Files:                  s.files,
 		SymbolicLinks:          s.symbolicLinks,
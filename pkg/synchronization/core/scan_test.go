@@ -1,16 +1,22 @@
 package core
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"hash"
+	"io/fs"
 	"net"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
+	"google.golang.org/protobuf/types/known/timestamppb"
+
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
 )
@@ -530,12 +536,18 @@ func TestScan(t *testing.T) {
 			snapshot, cache, ignoreCache, err := Scan(
 				test.ctx,
 				root,
-				nil, nil,
-				hasher, nil,
+				nil, nil, false,
+				newTestingHasher, nil,
 				test.ignores, nil,
+				nil,
+				nil,
 				behavior.ProbeMode_ProbeModeProbe,
 				test.symbolicLinkMode,
 				test.permissionsMode,
+				ModificationTimeMode_ModificationTimeModeIgnore,
+				HardLinkMode_HardLinkModeIndependent,
+				nil,
+				0,
 			)
 			if test.expectFailure {
 				if err == nil {
@@ -612,12 +624,18 @@ func TestScan(t *testing.T) {
 			newSnapshot, newCache, newIgnoreCache, err := Scan(
 				test.ctx,
 				root,
-				nil, nil,
-				rescanHasher, cache,
+				nil, nil, false,
+				func() hash.Hash { return rescanHasher }, cache,
 				test.ignores, ignoreCache,
+				nil,
+				nil,
 				behavior.ProbeMode_ProbeModeProbe,
 				test.symbolicLinkMode,
 				test.permissionsMode,
+				ModificationTimeMode_ModificationTimeModeIgnore,
+				HardLinkMode_HardLinkModeIndependent,
+				nil,
+				0,
 			)
 
 			// Handle scan failure (which isn't expected at this point).
@@ -684,12 +702,18 @@ func TestScan(t *testing.T) {
 			newSnapshot, newCache, newIgnoreCache, err = Scan(
 				test.ctx,
 				root,
-				snapshot, nil,
-				hasher, cache,
+				snapshot, nil, false,
+				newTestingHasher, cache,
 				test.ignores, ignoreCache,
+				nil,
+				nil,
 				behavior.ProbeMode_ProbeModeProbe,
 				test.symbolicLinkMode,
 				test.permissionsMode,
+				ModificationTimeMode_ModificationTimeModeIgnore,
+				HardLinkMode_HardLinkModeIndependent,
+				nil,
+				0,
 			)
 
 			// Handle scan failure (which isn't expected at this point).
@@ -783,12 +807,18 @@ func TestScan(t *testing.T) {
 			newSnapshot, newCache, newIgnoreCache, err = Scan(
 				test.ctx,
 				root,
-				snapshot, recheckPaths,
-				hasher, cache,
+				snapshot, recheckPaths, false,
+				newTestingHasher, cache,
 				test.ignores, ignoreCache,
+				nil,
+				nil,
 				behavior.ProbeMode_ProbeModeProbe,
 				test.symbolicLinkMode,
 				test.permissionsMode,
+				ModificationTimeMode_ModificationTimeModeIgnore,
+				HardLinkMode_HardLinkModeIndependent,
+				nil,
+				0,
 			)
 
 			// Handle scan failure (which isn't expected at this point).
@@ -896,12 +926,18 @@ func TestScanCrossFilesystemBoundary(t *testing.T) {
 	snapshot, _, _, err := Scan(
 		context.Background(),
 		parent,
-		nil, nil,
-		newTestingHasher(), nil,
+		nil, nil, false,
+		newTestingHasher, nil,
 		[]string{"*", "!" + name}, nil,
+		nil,
+		nil,
 		behavior.ProbeMode_ProbeModeProbe,
 		SymbolicLinkMode_SymbolicLinkModePortable,
 		PermissionsMode_PermissionsModePortable,
+		ModificationTimeMode_ModificationTimeModeIgnore,
+		HardLinkMode_HardLinkModeIndependent,
+		nil,
+		0,
 	)
 	if err != nil {
 		t.Fatalf("unable to perform scan: %v", err)
@@ -917,3 +953,747 @@ func TestScanCrossFilesystemBoundary(t *testing.T) {
 		t.Errorf("result does not match expected: %v != %v", snapshot.Content.Contents[name], expected)
 	}
 }
+
+// TestScanModificationTimePreservation tests that modification times applied
+// during transitions are captured by scans in modification time preservation
+// mode and excluded from entry equality.
+func TestScanModificationTimePreservation(t *testing.T) {
+	// Create a file entry with a modification time.
+	modificationTime := time.Date(2020, time.January, 2, 3, 4, 5, 0, time.UTC)
+	baseline := tF1.Copy(false)
+	baseline.ModificationTime = timestamppb.New(modificationTime)
+
+	// Generate the content on disk, which will apply the modification time.
+	generator := &testingContentManager{
+		baseline:           baseline,
+		baselineContentMap: tF1ContentMap,
+	}
+	root, err := generator.generate()
+	if err != nil {
+		t.Fatal("unable to generate test content:", err)
+	}
+	defer generator.remove()
+
+	// Verify that the modification time was applied on disk.
+	if metadata, err := os.Lstat(root); err != nil {
+		t.Fatal("unable to query file metadata:", err)
+	} else if !metadata.ModTime().Equal(modificationTime) {
+		t.Error("modification time not applied:", metadata.ModTime())
+	}
+
+	// Perform scans with and without modification time preservation.
+	for _, mode := range []ModificationTimeMode{
+		ModificationTimeMode_ModificationTimeModeIgnore,
+		ModificationTimeMode_ModificationTimeModePreserve,
+	} {
+		snapshot, _, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil, false,
+			newTestingHasher, nil,
+			nil, nil,
+			nil,
+			nil,
+			behavior.ProbeMode_ProbeModeProbe,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			PermissionsMode_PermissionsModePortable,
+			mode,
+			HardLinkMode_HardLinkModeIndependent,
+			nil,
+			0,
+		)
+		if err != nil {
+			t.Fatalf("unable to perform scan in %s mode: %v", mode.Description(), err)
+		} else if !snapshot.Content.Equal(tF1, true) {
+			t.Errorf("snapshot content in %s mode does not match expected", mode.Description())
+		}
+
+		// Verify modification time propagation.
+		if mode == ModificationTimeMode_ModificationTimeModePreserve {
+			if snapshot.Content.ModificationTime == nil {
+				t.Error("modification time not recorded in preserve mode")
+			} else if !snapshot.Content.ModificationTime.AsTime().Equal(modificationTime) {
+				t.Error("recorded modification time does not match expected:", snapshot.Content.ModificationTime.AsTime())
+			}
+		} else if snapshot.Content.ModificationTime != nil {
+			t.Error("modification time unexpectedly recorded in ignore mode")
+		}
+	}
+}
+
+// TestScanExtendedAttributes tests that extended attributes applied during
+// transitions are captured by scans when matched by an extended attribute
+// pattern and excluded from entry equality.
+func TestScanExtendedAttributes(t *testing.T) {
+	// Create a directory entry containing a file with extended attributes.
+	attributes := map[string][]byte{"user.mutagen_test": []byte("value")}
+	file := tF1.Copy(false)
+	file.ExtendedAttributes = attributes
+	baseline := &Entry{
+		Kind:     EntryKind_Directory,
+		Contents: map[string]*Entry{"file": file},
+	}
+
+	// Generate the content on disk, which will apply the extended attributes.
+	// If the filesystem doesn't support extended attributes, then skip the
+	// test.
+	generator := &testingContentManager{
+		baseline:           baseline,
+		baselineContentMap: testingContentMap{"file": []byte(tF1Content)},
+	}
+	root, err := generator.generate()
+	if err != nil {
+		t.Skip("unable to generate content with extended attributes:", err)
+	}
+	defer generator.remove()
+
+	// Perform scans with and without a matching extended attribute pattern.
+	for _, patterns := range [][]string{nil, {"user.mutagen_*"}} {
+		snapshot, _, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil, false,
+			newTestingHasher, nil,
+			nil, nil,
+			nil,
+			nil,
+			behavior.ProbeMode_ProbeModeProbe,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			PermissionsMode_PermissionsModePortable,
+			ModificationTimeMode_ModificationTimeModeIgnore,
+			HardLinkMode_HardLinkModeIndependent,
+			patterns,
+			0,
+		)
+		if err != nil {
+			t.Fatalf("unable to perform scan with patterns %v: %v", patterns, err)
+		} else if !snapshot.Content.Equal(tD1, true) {
+			t.Errorf("snapshot content with patterns %v does not match expected", patterns)
+		}
+
+		// Verify extended attribute capture.
+		captured := snapshot.Content.Contents["file"].ExtendedAttributes
+		if len(patterns) == 0 {
+			if captured != nil {
+				t.Error("extended attributes unexpectedly captured without patterns")
+			}
+		} else if string(captured["user.mutagen_test"]) != "value" {
+			t.Error("extended attribute not captured:", captured)
+		}
+	}
+}
+
+// TestScanIgnoreFiles tests that per-directory ignore files are applied to
+// their subtrees and that changes to them invalidate accelerated scans.
+func TestScanIgnoreFiles(t *testing.T) {
+	// Create content on disk.
+	root := t.TempDir()
+	contents := map[string]string{
+		".gitignore":         "*.log\n",
+		"a.log":              "log",
+		"data/file":          "data",
+		"sub/.mutagenignore": "/data\n!keep.log\n",
+		"sub/keep.log":       "keep",
+		"sub/data/file":      "data",
+		"sub/inner/deep.txt": "deep",
+	}
+	for path, content := range contents {
+		fullPath := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0700); err != nil {
+			t.Fatal("unable to create parent directory:", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0600); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+	}
+
+	// Define a function to perform a scan.
+	ignoreFiles := []string{".gitignore", ".mutagenignore"}
+	scan := func(baseline *Snapshot, recheckPaths map[string]bool, cache *Cache, ignoreCache IgnoreCache) (*Snapshot, *Cache, IgnoreCache) {
+		snapshot, cache, ignoreCache, err := Scan(
+			context.Background(),
+			root,
+			baseline, recheckPaths, false,
+			newTestingHasher, cache,
+			nil, ignoreCache,
+			ignoreFiles,
+			nil,
+			behavior.ProbeMode_ProbeModeProbe,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			PermissionsMode_PermissionsModePortable,
+			ModificationTimeMode_ModificationTimeModeIgnore,
+			HardLinkMode_HardLinkModeIndependent,
+			nil,
+			0,
+		)
+		if err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		return snapshot, cache, ignoreCache
+	}
+
+	// Define a function to look up an entry kind by path.
+	kind := func(snapshot *Snapshot, path string) EntryKind {
+		entry := snapshot.Content
+		for _, component := range strings.Split(path, "/") {
+			entry = entry.Contents[component]
+			if entry == nil {
+				t.Fatal("entry not found:", path)
+			}
+		}
+		return entry.Kind
+	}
+
+	// Perform an initial scan and verify ignore behavior.
+	snapshot, cache, ignoreCache := scan(nil, nil, nil, nil)
+	expected := map[string]EntryKind{
+		"a.log":              EntryKind_Untracked,
+		"data":               EntryKind_Directory,
+		"sub/keep.log":       EntryKind_File,
+		"sub/data":           EntryKind_Untracked,
+		"sub/inner/deep.txt": EntryKind_File,
+	}
+	for path, expectedKind := range expected {
+		if k := kind(snapshot, path); k != expectedKind {
+			t.Errorf("initial entry kind for %s (%s) does not match expected (%s)", path, k, expectedKind)
+		}
+	}
+
+	// Modify the nested ignore file and perform an accelerated scan that only
+	// rechecks the ignore file. Even though the inner directory isn't marked
+	// as dirty, its baseline shouldn't be reused.
+	ignoreFilePath := filepath.Join(root, "sub", ".mutagenignore")
+	if err := os.WriteFile(ignoreFilePath, []byte("/data\n!keep.log\ndeep.txt\n"), 0600); err != nil {
+		t.Fatal("unable to modify ignore file:", err)
+	}
+	snapshot, _, _ = scan(snapshot, map[string]bool{"sub/.mutagenignore": true}, cache, ignoreCache)
+	if k := kind(snapshot, "sub/inner/deep.txt"); k != EntryKind_Untracked {
+		t.Error("ignore file change not reflected in accelerated scan:", k)
+	}
+}
+
+// TestScanIncludes tests that scans are limited to include paths and that
+// content outside of them isn't traversed.
+func TestScanIncludes(t *testing.T) {
+	// Create content on disk.
+	root := t.TempDir()
+	contents := map[string]string{
+		"README.md":             "readme",
+		"top.txt":               "top",
+		"services/api/main.go":  "api",
+		"services/web/index.js": "web",
+		"services/api2/file":    "api2",
+		"libs/common/util.go":   "util",
+		"libs/other/deep/file":  "other",
+	}
+	for path, content := range contents {
+		fullPath := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0700); err != nil {
+			t.Fatal("unable to create parent directory:", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0600); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+	}
+
+	// Perform a scan.
+	snapshot, _, _, err := Scan(
+		context.Background(),
+		root,
+		nil, nil, false,
+		newTestingHasher, nil,
+		nil, nil,
+		nil,
+		[]string{"services/api", "libs/common", "README.md"},
+		behavior.ProbeMode_ProbeModeProbe,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		PermissionsMode_PermissionsModePortable,
+		ModificationTimeMode_ModificationTimeModeIgnore,
+		HardLinkMode_HardLinkModeIndependent,
+		nil,
+		0,
+	)
+	if err != nil {
+		t.Fatal("unable to perform scan:", err)
+	}
+
+	// Verify entry kinds.
+	expected := map[string]EntryKind{
+		"README.md":            EntryKind_File,
+		"top.txt":              EntryKind_Untracked,
+		"services":             EntryKind_Directory,
+		"services/api/main.go": EntryKind_File,
+		"services/web":         EntryKind_Untracked,
+		"services/api2":        EntryKind_Untracked,
+		"libs/common/util.go":  EntryKind_File,
+		"libs/other":           EntryKind_Untracked,
+	}
+	for path, expectedKind := range expected {
+		entry := snapshot.Content
+		for _, component := range strings.Split(path, "/") {
+			entry = entry.Contents[component]
+			if entry == nil {
+				t.Fatal("entry not found:", path)
+			}
+		}
+		if entry.Kind != expectedKind {
+			t.Errorf("entry kind for %s (%s) does not match expected (%s)", path, entry.Kind, expectedKind)
+		}
+	}
+
+	// Verify that excluded directories weren't traversed.
+	if snapshot.Directories != 5 {
+		t.Error("directory count does not match expected:", snapshot.Directories, "!=", 5)
+	}
+
+	// Verify that invalid include paths are rejected.
+	if _, _, _, err := Scan(
+		context.Background(),
+		root,
+		nil, nil, false,
+		newTestingHasher, nil,
+		nil, nil,
+		nil,
+		[]string{"../outside"},
+		behavior.ProbeMode_ProbeModeProbe,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		PermissionsMode_PermissionsModePortable,
+		ModificationTimeMode_ModificationTimeModeIgnore,
+		HardLinkMode_HardLinkModeIndependent,
+		nil,
+		0,
+	); err == nil {
+		t.Error("scan succeeded with invalid include path")
+	}
+}
+
+// TestScanParallel tests that scans using parallel directory traversal and
+// hashing produce the same results as serial scans.
+func TestScanParallel(t *testing.T) {
+	// Create a nested directory hierarchy with enough directories to keep
+	// directory workers busy and enough files to keep hashing workers busy and
+	// to exceed the hashing queue depth. We also include hard links that span
+	// directories and a symbolic link.
+	root := t.TempDir()
+	for a := 0; a < 4; a++ {
+		for b := 0; b < 4; b++ {
+			directory := filepath.Join(root, fmt.Sprintf("directory%d", a), fmt.Sprintf("subdirectory%d", b))
+			if err := os.MkdirAll(directory, 0700); err != nil {
+				t.Fatal("unable to create directory:", err)
+			}
+			for f := 0; f < 15; f++ {
+				content := []byte(fmt.Sprintf("content %d %d %d", a, b, f))
+				if err := os.WriteFile(filepath.Join(directory, fmt.Sprintf("file%d", f)), content, 0600); err != nil {
+					t.Fatal("unable to create file:", err)
+				}
+			}
+		}
+	}
+	if runtime.GOOS != "windows" {
+		for a := 1; a < 4; a++ {
+			if err := os.Link(
+				filepath.Join(root, "directory0", "subdirectory0", "file0"),
+				filepath.Join(root, fmt.Sprintf("directory%d", a), "link"),
+			); err != nil {
+				t.Fatal("unable to create hard link:", err)
+			}
+		}
+		if err := os.Symlink("subdirectory0", filepath.Join(root, "directory0", "symlink")); err != nil {
+			t.Fatal("unable to create symbolic link:", err)
+		}
+	}
+
+	// Set directory modification times outside of the race window so that
+	// directory metadata will be recorded and trusted scans can re-use it.
+	old := time.Now().Add(-time.Hour)
+	if err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.IsDir() {
+			return err
+		}
+		return os.Chtimes(path, old, old)
+	}); err != nil {
+		t.Fatal("unable to set directory modification times:", err)
+	}
+
+	// Define a function to perform a scan with the specified parallelism.
+	scan := func(parallelism int, baseline *Snapshot, recheckPaths map[string]bool, trusted bool, cache *Cache) (*Snapshot, *Cache, IgnoreCache) {
+		snapshot, cache, ignoreCache, err := Scan(
+			context.Background(),
+			root,
+			baseline, recheckPaths, trusted,
+			newTestingHasher, cache,
+			nil, nil,
+			nil,
+			nil,
+			behavior.ProbeMode_ProbeModeAssume,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			PermissionsMode_PermissionsModePortable,
+			ModificationTimeMode_ModificationTimeModeIgnore,
+			HardLinkMode_HardLinkModePreserve,
+			nil,
+			parallelism,
+		)
+		if err != nil {
+			t.Fatalf("unable to perform scan with parallelism %d: %v", parallelism, err)
+		}
+		return snapshot, cache, ignoreCache
+	}
+
+	// Define a function to extract the hard link groups from a snapshot.
+	groups := func(snapshot *Snapshot) map[string]string {
+		result := make(map[string]string)
+		snapshot.Content.walk("", func(path string, entry *Entry) {
+			if entry.Kind == EntryKind_File && entry.HardLinkGroup != "" {
+				result[path] = entry.HardLinkGroup
+			}
+		}, false)
+		return result
+	}
+
+	// Define a function to compare parallel scan results with serial scan
+	// results.
+	compare := func(description string, parallelism int, serial, parallel *Snapshot, serialCache, parallelCache *Cache, serialIgnoreCache, parallelIgnoreCache IgnoreCache) {
+		if !parallel.Equal(serial) {
+			t.Errorf("%s: parallel snapshot (parallelism %d) does not match serial snapshot", description, parallelism)
+		}
+		if parallel.Directories != serial.Directories ||
+			parallel.Files != serial.Files ||
+			parallel.SymbolicLinks != serial.SymbolicLinks ||
+			parallel.TotalFileSize != serial.TotalFileSize {
+			t.Errorf("%s: parallel snapshot (parallelism %d) statistics do not match serial snapshot", description, parallelism)
+		}
+		serialGroups, parallelGroups := groups(serial), groups(parallel)
+		if len(parallelGroups) != len(serialGroups) {
+			t.Errorf("%s: parallel snapshot (parallelism %d) hard link group count does not match serial snapshot", description, parallelism)
+		}
+		for path, group := range serialGroups {
+			if parallelGroups[path] != group {
+				t.Errorf("%s: parallel snapshot (parallelism %d) hard link group for %s does not match serial snapshot", description, parallelism, path)
+			}
+		}
+		if !parallelCache.Equal(serialCache) || len(parallelCache.Directories) != len(serialCache.Directories) {
+			t.Errorf("%s: parallel cache (parallelism %d) does not match serial cache", description, parallelism)
+		}
+		if len(parallelIgnoreCache) != len(serialIgnoreCache) {
+			t.Errorf("%s: parallel ignore cache (parallelism %d) does not match serial ignore cache", description, parallelism)
+		}
+		for key, ignored := range serialIgnoreCache {
+			if parallelIgnoreCache[key] != ignored {
+				t.Errorf("%s: parallel ignore cache (parallelism %d) does not match serial ignore cache", description, parallelism)
+				break
+			}
+		}
+	}
+
+	// Define the levels of parallelism to test.
+	parallelisms := []int{2, 4, 16}
+
+	// Perform a serial cold scan and verify its statistics and a digest.
+	serial, serialCache, serialIgnoreCache := scan(0, nil, nil, false, nil)
+	if serial.Directories != 21 || serial.Files < 240 {
+		t.Fatal("serial snapshot statistics incorrect:", serial.Directories, serial.Files)
+	} else if len(serialCache.Directories) != 21 {
+		t.Fatal("serial cache directory count incorrect:", len(serialCache.Directories))
+	}
+	file := serial.Content.Contents["directory2"].Contents["subdirectory3"].Contents["file7"]
+	if !bytes.Equal(file.Digest, testingDigest("content 2 3 7")) {
+		t.Error("serial scan computed incorrect digest")
+	}
+
+	// Perform parallel cold and warm scans and compare the results with those
+	// of the serial scan.
+	for _, parallelism := range parallelisms {
+		parallel, parallelCache, parallelIgnoreCache := scan(parallelism, nil, nil, false, nil)
+		compare("cold scan", parallelism, serial, parallel, serialCache, parallelCache, serialIgnoreCache, parallelIgnoreCache)
+		warm, warmCache, warmIgnoreCache := scan(parallelism, nil, nil, false, parallelCache)
+		compare("warm scan", parallelism, serial, warm, serialCache, warmCache, serialIgnoreCache, warmIgnoreCache)
+	}
+
+	// Modify a file in place (without changing its parent's modification time)
+	// and perform serial accelerated scans.
+	modified := filepath.Join(root, "directory1", "subdirectory2", "file3")
+	if err := os.WriteFile(modified, []byte("modified"), 0600); err != nil {
+		t.Fatal("unable to modify file:", err)
+	}
+	recheckPaths := map[string]bool{"directory1/subdirectory2/file3": true}
+	serialRecheck, serialRecheckCache, serialRecheckIgnoreCache := scan(0, serial, recheckPaths, false, serialCache)
+	serialTrusted, serialTrustedCache, serialTrustedIgnoreCache := scan(0, serial, nil, true, serialCache)
+	if serialRecheck.Equal(serial) || !serialTrusted.Equal(serialRecheck) {
+		t.Fatal("serial accelerated scans did not see modification")
+	}
+
+	// Perform parallel accelerated scans and compare the results with those of
+	// the serial scans.
+	for _, parallelism := range parallelisms {
+		recheck, recheckCache, recheckIgnoreCache := scan(parallelism, serial, recheckPaths, false, serialCache)
+		compare("re-check scan", parallelism, serialRecheck, recheck, serialRecheckCache, recheckCache, serialRecheckIgnoreCache, recheckIgnoreCache)
+		trusted, trustedCache, trustedIgnoreCache := scan(parallelism, serial, nil, true, serialCache)
+		compare("trusted scan", parallelism, serialTrusted, trusted, serialTrustedCache, trustedCache, serialTrustedIgnoreCache, trustedIgnoreCache)
+	}
+}
+
+// TestScanTrustedBaseline tests that scans using a trusted baseline re-use the
+// contents of directories whose metadata is unchanged and rescan those whose
+// metadata has changed.
+func TestScanTrustedBaseline(t *testing.T) {
+	// Create content on disk.
+	root := t.TempDir()
+	contents := map[string]string{
+		"first/file":  "first",
+		"second/file": "second",
+		"third/file":  "third",
+	}
+	for path, content := range contents {
+		fullPath := filepath.Join(root, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0700); err != nil {
+			t.Fatal("unable to create parent directory:", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0600); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+	}
+
+	// Set directory modification times outside of the race window so that
+	// directory metadata will be recorded.
+	old := time.Now().Add(-time.Hour)
+	for _, path := range []string{"first", "second", "third", ""} {
+		if err := os.Chtimes(filepath.Join(root, path), old, old); err != nil {
+			t.Fatal("unable to set directory modification time:", err)
+		}
+	}
+
+	// Define a function to perform a scan. We avoid filesystem behavior probing
+	// since it creates temporary files in the root directory and would thus
+	// update its modification time.
+	scan := func(baseline *Snapshot, trusted bool, cache *Cache) (*Snapshot, *Cache) {
+		snapshot, cache, _, err := Scan(
+			context.Background(),
+			root,
+			baseline, nil, trusted,
+			newTestingHasher, cache,
+			nil, nil,
+			nil,
+			nil,
+			behavior.ProbeMode_ProbeModeAssume,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			PermissionsMode_PermissionsModePortable,
+			ModificationTimeMode_ModificationTimeModeIgnore,
+			HardLinkMode_HardLinkModeIndependent,
+			nil,
+			0,
+		)
+		if err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		return snapshot, cache
+	}
+
+	// Perform a baseline scan and verify that directory metadata was recorded.
+	baseline, cache := scan(nil, false, nil)
+	if len(cache.Directories) != 4 {
+		t.Fatal("directory metadata count does not match expected:", len(cache.Directories), "!=", 4)
+	}
+
+	// Modify a file in-place (without changing its parent's modification
+	// time), add a file to another directory, and make the file in the last
+	// directory executable (which also doesn't change its parent's
+	// modification time).
+	firstFile := filepath.Join(root, "first", "file")
+	if err := os.WriteFile(firstFile, []byte("modified"), 0600); err != nil {
+		t.Fatal("unable to modify file:", err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "second", "new"), []byte("new"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	if err := os.Chmod(filepath.Join(root, "third", "file"), 0700); err != nil {
+		t.Fatal("unable to change file permissions:", err)
+	}
+
+	// Perform a trusted scan. Even though the listings of the unmodified
+	// directories are re-used, the in-place modification and permission change
+	// should be seen, as should the new file.
+	trustedSnapshot, trustedCache := scan(baseline, true, cache)
+	if file := trustedSnapshot.Content.Contents["first"].Contents["file"]; !bytes.Equal(file.Digest, testingDigest("modified")) {
+		t.Error("trusted scan did not see in-place modification")
+	}
+	if runtime.GOOS != "windows" && !trustedSnapshot.Content.Contents["third"].Contents["file"].Executable {
+		t.Error("trusted scan did not see permission change")
+	}
+	if trustedSnapshot.Content.Contents["second"].Contents["new"] == nil {
+		t.Error("new file not seen in modified directory")
+	}
+	if trustedSnapshot.Directories != 4 || trustedSnapshot.Files != 4 {
+		t.Error("trusted scan statistics incorrect:", trustedSnapshot.Directories, trustedSnapshot.Files)
+	}
+
+	// Verify that metadata for the modified directory wasn't recorded (since
+	// its modification time is within the race window) but that the rest was.
+	if _, ok := trustedCache.Directories["second"]; ok {
+		t.Error("metadata recorded for recently modified directory")
+	} else if len(trustedCache.Directories) != 3 {
+		t.Error("directory metadata count does not match expected:", len(trustedCache.Directories), "!=", 3)
+	}
+
+	// Perform an untrusted scan and verify that the modification is seen.
+	fullSnapshot, _ := scan(baseline, false, cache)
+	if baseline != fullSnapshot {
+		t.Error("baseline not re-used directly without re-check paths")
+	}
+	fullSnapshot, _ = scan(nil, false, cache)
+	if !bytes.Equal(fullSnapshot.Content.Contents["first"].Contents["file"].Digest, testingDigest("modified")) {
+		t.Error("full scan did not see in-place modification")
+	}
+}
+
+// TestScanHardLinks tests that scans re-using a baseline update the hard link
+// groups of re-used entries when they're affected by changes.
+func TestScanHardLinks(t *testing.T) {
+	// Skip this test on Windows, where file IDs aren't available from scans.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create content on disk with a pair of hard-linked files and an unlinked
+	// file, each in a separate directory so that re-scanning one of them won't
+	// re-scan the others.
+	root := t.TempDir()
+	for _, path := range []string{"x", "y", "z"} {
+		if err := os.Mkdir(filepath.Join(root, path), 0700); err != nil {
+			t.Fatal("unable to create directory:", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "x", "a"), []byte("linked"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	} else if err = os.Link(filepath.Join(root, "x", "a"), filepath.Join(root, "y", "b")); err != nil {
+		t.Fatal("unable to create hard link:", err)
+	} else if err = os.WriteFile(filepath.Join(root, "z", "c"), []byte("unlinked"), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Define a function to perform a scan.
+	scan := func(baseline *Snapshot, recheckPaths map[string]bool, cache *Cache) (*Snapshot, *Cache) {
+		snapshot, cache, _, err := Scan(
+			context.Background(),
+			root,
+			baseline, recheckPaths, false,
+			newTestingHasher, cache,
+			nil, nil,
+			nil,
+			nil,
+			behavior.ProbeMode_ProbeModeAssume,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			PermissionsMode_PermissionsModePortable,
+			ModificationTimeMode_ModificationTimeModeIgnore,
+			HardLinkMode_HardLinkModePreserve,
+			nil,
+			0,
+		)
+		if err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		return snapshot, cache
+	}
+
+	// Define a function to extract the hard link groups from a snapshot.
+	groups := func(snapshot *Snapshot) map[string]string {
+		result := make(map[string]string)
+		snapshot.Content.walk("", func(path string, entry *Entry) {
+			if entry.Kind == EntryKind_File {
+				result[path] = entry.HardLinkGroup
+			}
+		}, false)
+		return result
+	}
+
+	// Perform a baseline scan.
+	baseline, cache := scan(nil, nil, nil)
+
+	// Define test cases. Each case modifies the filesystem and specifies the
+	// paths that a watcher would report, after which the baseline scan should
+	// match a full scan.
+	testCases := []struct {
+		description  string
+		modify       func() error
+		recheckPaths []string
+		group        string
+	}{
+		{
+			"link created to re-used files",
+			func() error {
+				return os.Link(filepath.Join(root, "x", "a"), filepath.Join(root, "z", "d"))
+			},
+			[]string{"z/d"},
+			"x/a",
+		},
+		{
+			"linked content modified through a single link",
+			func() error {
+				return os.WriteFile(filepath.Join(root, "z", "d"), []byte("modified linked"), 0600)
+			},
+			[]string{"z/d"},
+			"x/a",
+		},
+		{
+			"group member removed",
+			func() error {
+				return os.Remove(filepath.Join(root, "y", "b"))
+			},
+			[]string{"y/b"},
+			"x/a",
+		},
+		{
+			"group reduced to a single member",
+			func() error {
+				return os.Remove(filepath.Join(root, "z", "d"))
+			},
+			[]string{"z/d"},
+			"",
+		},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Modify the filesystem.
+		if err := testCase.modify(); err != nil {
+			t.Fatalf("test case %d (%s): unable to modify filesystem: %v", i, testCase.description, err)
+		}
+
+		// Perform a scan using the baseline and a full scan. We record the
+		// baseline groups to verify that the baseline isn't modified.
+		baselineGroups := groups(baseline)
+		recheckPaths := make(map[string]bool, len(testCase.recheckPaths))
+		for _, path := range testCase.recheckPaths {
+			recheckPaths[path] = true
+		}
+		snapshot, newCache := scan(baseline, recheckPaths, cache)
+		full, _ := scan(nil, nil, nil)
+
+		// Verify that the scans agree.
+		if !snapshot.Content.Equal(full.Content, true) {
+			t.Errorf("test case %d (%s): snapshot content does not match full scan", i, testCase.description)
+		} else if snapshot.Files != full.Files || snapshot.TotalFileSize != full.TotalFileSize {
+			t.Errorf("test case %d (%s): snapshot statistics do not match full scan", i, testCase.description)
+		}
+		snapshotGroups, fullGroups := groups(snapshot), groups(full)
+		for path, group := range fullGroups {
+			if snapshotGroups[path] != group {
+				t.Errorf("test case %d (%s): hard link group for %s does not match full scan: %q != %q",
+					i, testCase.description, path, snapshotGroups[path], group,
+				)
+			}
+		}
+		if fullGroups["x/a"] != testCase.group {
+			t.Errorf("test case %d (%s): unexpected hard link group: %q", i, testCase.description, fullGroups["x/a"])
+		}
+
+		// Verify that the baseline wasn't modified.
+		for path, group := range groups(baseline) {
+			if baselineGroups[path] != group {
+				t.Errorf("test case %d (%s): baseline modified", i, testCase.description)
+			}
+		}
+
+		// Update the baseline.
+		baseline, cache = snapshot, newCache
+	}
+}
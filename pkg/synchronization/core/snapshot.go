@@ -36,5 +36,6 @@ func (s *Snapshot) EnsureValid() error {
 func (s *Snapshot) Equal(other *Snapshot) bool {
 	return s.Content.Equal(other.Content, true) &&
 		s.PreservesExecutability == other.PreservesExecutability &&
-		s.DecomposesUnicode == other.DecomposesUnicode
+		s.DecomposesUnicode == other.DecomposesUnicode &&
+		s.IgnoresCase == other.IgnoresCase
 }
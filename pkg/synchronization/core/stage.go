@@ -12,6 +12,9 @@ type stagingPathFinder struct {
 	// digests is the list of digests for encountered file entries, with length
 	// and contents corresponding to paths.
 	digests [][]byte
+	// hardLinkGroups is the set of hard link groups that have already been
+	// recorded. It is lazily initialized.
+	hardLinkGroups map[string]bool
 }
 
 // find recursively searches for file entries that need staging.
@@ -30,6 +33,21 @@ func (f *stagingPathFinder) find(path string, entry *Entry) {
 			f.find(contentPathPrefix+name, entry)
 		}
 	} else if entry.Kind == EntryKind_File {
+		// If the file is part of a hard link group, then its contents only need
+		// to be staged once for the entire group, which we do using the group
+		// path. The remaining group members will be linked to whichever member
+		// is created first during transitioning.
+		if entry.HardLinkGroup != "" {
+			if f.hardLinkGroups[entry.HardLinkGroup] {
+				return
+			} else if f.hardLinkGroups == nil {
+				f.hardLinkGroups = make(map[string]bool)
+			}
+			f.hardLinkGroups[entry.HardLinkGroup] = true
+			path = entry.HardLinkGroup
+		}
+
+		// Record the path and digest.
 		f.paths = append(f.paths, path)
 		f.digests = append(f.digests, entry.Digest)
 	}
@@ -38,7 +56,8 @@ func (f *stagingPathFinder) find(path string, entry *Entry) {
 // TransitionDependencies analyzes a list of transitions and determines the file
 // paths (and their corresponding digests) that will need to be provided in
 // order to apply the transitions using Transition. It will return these paths
-// in depth-first traversal order.
+// in depth-first traversal order. Files belonging to a hard link group are only
+// represented once, using the group path.
 func TransitionDependencies(transitions []*Change) ([]string, [][]byte) {
 	// Create a path finder.
 	finder := &stagingPathFinder{}
@@ -46,8 +65,8 @@ func TransitionDependencies(transitions []*Change) ([]string, [][]byte) {
 	// Have it find paths for all the transitions.
 	for _, t := range transitions {
 		// If this is a file-to-file transition and only the executability bit
-		// is changing, then we don't need to stage, because Transition will
-		// just modify the target on disk.
+		// (or extended attributes) are changing, then we don't need to stage,
+		// because Transition will just modify the target on disk.
 		fileToFileSameContents := t.Old != nil && t.New != nil &&
 			t.Old.Kind == EntryKind_File && t.New.Kind == EntryKind_File &&
 			bytes.Equal(t.Old.Digest, t.New.Digest)
@@ -55,6 +74,12 @@ func TransitionDependencies(transitions []*Change) ([]string, [][]byte) {
 			continue
 		}
 
+		// Similarly, if this is a directory-to-directory transition, then it's
+		// a metadata update that doesn't involve any content.
+		if t.directoryMetadataOnly() {
+			continue
+		}
+
 		// Otherwise we need to perform a full scan.
 		finder.find(t.Path, t.New)
 	}
@@ -9,6 +9,10 @@ import (
 
 // TestTransitionDependencies tests TransitionDependencies.
 func TestTransitionDependencies(t *testing.T) {
+	// Create entries representing hard-linked files.
+	tHL := &Entry{Kind: EntryKind_File, Digest: tF1.Digest, HardLinkGroup: "a"}
+	tDHL := &Entry{Contents: map[string]*Entry{"a": tHL, "b": tHL}}
+
 	// Define test cases.
 	tests := []struct {
 		transitions     []*Change
@@ -25,6 +29,9 @@ func TestTransitionDependencies(t *testing.T) {
 		{[]*Change{{New: tD1}}, []string{"file"}, [][]byte{tF1.Digest}},
 		{[]*Change{{Old: tF3, New: tF3E}}, nil, nil},
 		{[]*Change{{Old: tF3E, New: tF3}}, nil, nil},
+		{[]*Change{{New: tDHL}}, []string{"a"}, [][]byte{tF1.Digest}},
+		{[]*Change{{Path: "b", New: tHL}}, []string{"a"}, [][]byte{tF1.Digest}},
+		{[]*Change{{Path: "a", New: tHL}, {Path: "b", New: tHL}}, []string{"a"}, [][]byte{tF1.Digest}},
 	}
 
 	// Process test cases.
@@ -54,3 +54,28 @@ func (p *testingProvider) Provide(path string, digest []byte) (string, error) {
 	// Success.
 	return temporaryFile.Name(), nil
 }
+
+// testingDeltaProvider is an implementation of the Provider and DeltaProvider
+// interfaces for tests. It provides a single staged delta.
+type testingDeltaProvider struct {
+	// path is the path for which the delta was staged.
+	path string
+	// digest is the digest of the content produced by the delta.
+	digest []byte
+	// delta is the path to the staged delta.
+	delta string
+}
+
+// Provide implements the Provider interface for testingDeltaProvider.
+func (p *testingDeltaProvider) Provide(_ string, _ []byte) (string, error) {
+	return "", os.ErrNotExist
+}
+
+// ProvideDelta implements the DeltaProvider interface for
+// testingDeltaProvider.
+func (p *testingDeltaProvider) ProvideDelta(path string, digest []byte) (string, error) {
+	if path != p.path || !bytes.Equal(digest, p.digest) {
+		return "", os.ErrNotExist
+	}
+	return p.delta, nil
+}
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"golang.org/x/text/unicode/norm"
@@ -22,6 +23,14 @@ const (
 	// intermediate temporary files used in cross-device renames.
 	crossDeviceRenameTemporaryNamePrefix = filesystem.TemporaryNamePrefix + "cross-device-rename"
 
+	// hardLinkTemporaryNamePrefix is the file name prefix to use for
+	// intermediate hard links used when replacing existing files.
+	hardLinkTemporaryNamePrefix = filesystem.TemporaryNamePrefix + "hard-link-"
+
+	// inPlaceJournalNamePrefix is the file name prefix to use for journals
+	// that record the original content of files updated in place.
+	inPlaceJournalNamePrefix = filesystem.TemporaryNamePrefix + "in-place-journal"
+
 	// transitionCopyBufferSize specifies the size of the internal buffer that a
 	// transitioner uses to copy file data (e.g. when performing cross-device
 	// renames).
@@ -41,6 +50,9 @@ const (
 var (
 	// errTransitionCancelled indicates that the transition was cancelled.
 	errTransitionCancelled = errors.New("transition cancelled")
+	// errDeltaBaseModified indicates that the base against which a staged
+	// delta was computed has been modified since staging.
+	errDeltaBaseModified = errors.New("delta base modified")
 )
 
 // Provider defines the interface that higher-level logic can use to provide
@@ -54,6 +66,18 @@ type Provider interface {
 	Provide(path string, digest []byte) (string, error)
 }
 
+// DeltaProvider is an optional interface that can be implemented by providers
+// that stage content for large files as deltas against the existing files.
+type DeltaProvider interface {
+	// ProvideDelta returns a filesystem path to a delta (in the format written
+	// by DeltaWriter) that transforms the file at the path given as the first
+	// argument into content with the digest specified by the second argument.
+	// If the provider is unable to locate a delta matching the specified
+	// parameters in its internal storage, it should return an error for which
+	// os.IsNotExist evaluates to true.
+	ProvideDelta(path string, digest []byte) (string, error)
+}
+
 // transitioner provides the recursive implementation of transitioning.
 type transitioner struct {
 	// cancelled is the cancellation channel from the transition context.
@@ -87,6 +111,22 @@ type transitioner struct {
 	// providerMissingFiles indicates that the staged file provider returned an
 	// os.IsNotExist error for at least one file that was expected to be staged.
 	providerMissingFiles bool
+	// hardLinkSources maps hard link groups to the path of the first member of
+	// the group created during transitioning. It is lazily initialized.
+	hardLinkSources map[string]string
+	// hardLinkTemporaryCount is the number of intermediate hard links created
+	// so far, which is used to generate their names.
+	hardLinkTemporaryCount uint64
+}
+
+// fullPath computes the filesystem path for the content with the specified
+// name in the parent directory of the specified root-relative path (i.e. the
+// parent directory and name returned by walkToParentAndComputeLeafName).
+func (t *transitioner) fullPath(path, name string) string {
+	if path == "" {
+		return filepath.Join(filepath.Dir(t.root), name)
+	}
+	return filepath.Join(t.root, filepath.FromSlash(pathDir(path)), name)
 }
 
 // recordProblem records a new problem.
@@ -549,18 +589,131 @@ func (t *transitioner) remove(path string, entry *Entry) *Entry {
 	return nil
 }
 
+// recordHardLinkSource records the specified path as the link source for its
+// hard link group (if any and if a source hasn't already been recorded).
+func (t *transitioner) recordHardLinkSource(path string, target *Entry) {
+	if target.HardLinkGroup == "" {
+		return
+	} else if t.hardLinkSources == nil {
+		t.hardLinkSources = make(map[string]string)
+	}
+	if _, ok := t.hardLinkSources[target.HardLinkGroup]; !ok {
+		t.hardLinkSources[target.HardLinkGroup] = path
+	}
+}
+
+// createHardLink creates a hard link to the file at the specified source path
+// with the name specified inside of parent. If replace is true, then any
+// existing file at the target location will be atomically replaced.
+func (t *transitioner) createHardLink(source string, parent *filesystem.Directory, name string, replace bool) error {
+	// Walk down to the parent of the source and compute its leaf name. If we
+	// are successful, defer closure of the parent.
+	sourceParent, sourceName, err := t.walkToParentAndComputeLeafName(source, false)
+	if err != nil {
+		return fmt.Errorf("unable to walk to link source: %w", err)
+	}
+	defer sourceParent.Close()
+
+	// If we don't need to replace an existing file, then we can just create
+	// the link directly.
+	if !replace {
+		return parent.CreateHardLink(name, sourceParent, sourceName)
+	}
+
+	// Otherwise, create the link at an intermediate location and then rename
+	// it into place. We remove any stale content at the intermediate location
+	// first, though we don't worry about errors since they'll be caught when
+	// creating the link.
+	temporaryName := hardLinkTemporaryNamePrefix + strconv.FormatUint(t.hardLinkTemporaryCount, 10)
+	t.hardLinkTemporaryCount++
+	parent.RemoveFile(temporaryName)
+	if err := parent.CreateHardLink(temporaryName, sourceParent, sourceName); err != nil {
+		return err
+	}
+	if err := filesystem.Rename(parent, temporaryName, parent, name, true); err != nil {
+		parent.RemoveFile(temporaryName)
+		return fmt.Errorf("unable to relocate intermediate link: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// hardLinkGroupPathExists determines whether or not the member at the group
+// path of the target's hard link group exists on disk with the target content.
+func (t *transitioner) hardLinkGroupPathExists(target *Entry) bool {
+	// Walk down to the parent of the group path and compute its leaf name. If
+	// we are successful, defer closure of the parent.
+	parent, name, err := t.walkToParentAndComputeLeafName(target.HardLinkGroup, true)
+	if err != nil {
+		return false
+	}
+	defer parent.Close()
+
+	// Verify the on-disk content.
+	return t.ensureExpectedFile(parent, name, target.HardLinkGroup, target) == nil
+}
+
+// copyHardLinkSource creates a file at the location specified by the
+// combination of parent directory and content name by copying the content of
+// the specified hard link group member (which must have already been created
+// during transitioning). It's used when the member can't be linked into place.
+func (t *transitioner) copyHardLinkSource(
+	source, path string,
+	target *Entry,
+	parent *filesystem.Directory,
+	name string,
+	mode filesystem.Mode,
+	replace bool,
+) error {
+	// Walk down to the parent of the source and compute its leaf name. If we
+	// are successful, defer closure of the parent.
+	sourceParent, sourceName, err := t.walkToParentAndComputeLeafName(source, false)
+	if err != nil {
+		return fmt.Errorf("unable to walk to link source: %w", err)
+	}
+	defer sourceParent.Close()
+
+	// Open the source and defer its closure.
+	sourceFile, _, err := sourceParent.OpenFile(sourceName)
+	if err != nil {
+		return fmt.Errorf("unable to open link source: %w", err)
+	}
+	defer sourceFile.Close()
+
+	// Copy the source contents into place.
+	return t.copyIntoPlace(path, target, parent, name, mode, replace, func(temporary io.Writer) error {
+		preemptableTemporary := stream.NewPreemptableWriter(
+			temporary,
+			t.cancelled,
+			transitionCopyPreemptionInterval,
+		)
+		_, err := io.CopyBuffer(preemptableTemporary, sourceFile, t.copyBuffer)
+		return err
+	})
+}
+
 // findAndMoveStagedFileIntoPlace locates a staged file for the specified
 // combination of path and entry, sets its permissions appropriately, and moves
 // it to the location specified by the combination of parent directory and
-// content name. If this requires a cross-device rename, this function will
-// approximate atomicity using an intermediate temporary file.
+// content name. If existing is non-nil, then it specifies the (already
+// validated) file expected at that location, which will be replaced. If this
+// requires a cross-device rename, this function will approximate atomicity
+// using an intermediate temporary file. If the content was staged as a delta,
+// then the delta is applied instead. If the target is part of a hard link group
+// for which another member has already been created (or for which the member
+// at the group path already exists on disk with the expected content), then
+// that member is linked into place instead.
 func (t *transitioner) findAndMoveStagedFileIntoPlace(
 	path string,
 	target *Entry,
 	parent *filesystem.Directory,
 	name string,
-	replace bool,
+	existing *Entry,
 ) error {
+	// Determine whether or not we're replacing an existing file.
+	replace := existing != nil
+
 	// Compute the new file mode. If we're in a mode where executability
 	// information is being propagated, then we'll already have enforced that
 	// the default file mode doesn't contain executability bits, and therefore
@@ -573,14 +726,48 @@ func (t *transitioner) findAndMoveStagedFileIntoPlace(
 		mode = markExecutableForReaders(mode)
 	}
 
+	// If the target is part of a hard link group and another member of that
+	// group has already been created, then attempt to link to that member. The
+	// group's staged content will have been consumed in creating that member,
+	// so if linking fails (e.g. because the filesystem doesn't support hard
+	// links), then we fall back to copying the member's content. Otherwise, if
+	// the member at the group path already exists on disk with the expected
+	// content, then we attempt to link to it, falling back to staged content
+	// (which is staged using the group path) if that fails.
+	stagingPath := path
+	if target.HardLinkGroup != "" {
+		if source, ok := t.hardLinkSources[target.HardLinkGroup]; ok {
+			if err := t.createHardLink(source, parent, name, replace); err == nil {
+				return nil
+			}
+			return t.copyHardLinkSource(source, path, target, parent, name, mode, replace)
+		} else if target.HardLinkGroup != path && t.hardLinkGroupPathExists(target) {
+			if err := t.createHardLink(target.HardLinkGroup, parent, name, replace); err == nil {
+				t.recordHardLinkSource(path, target)
+				return nil
+			}
+		}
+		stagingPath = target.HardLinkGroup
+	}
+
 	// Compute the path to the staged file. If the provider indicates that no
-	// staged file exists with the specified parameters, then update our missing
+	// staged file exists with the specified parameters, then check whether or
+	// not the content was staged as a delta against an existing file, in which
+	// case we apply it. If no staged content exists, then update our missing
 	// file tracking.
-	stagedPath, err := t.provider.Provide(path, target.Digest)
+	stagedPath, err := t.provider.Provide(stagingPath, target.Digest)
 	if err != nil {
-		if os.IsNotExist(err) {
-			t.providerMissingFiles = true
+		if !os.IsNotExist(err) {
+			return fmt.Errorf("unable to locate staged file: %w", err)
+		}
+		if deltaProvider, ok := t.provider.(DeltaProvider); ok {
+			if deltaPath, deltaErr := deltaProvider.ProvideDelta(stagingPath, target.Digest); deltaErr == nil {
+				return t.applyStagedDelta(path, stagingPath, deltaPath, target, parent, name, mode, existing)
+			} else if !os.IsNotExist(deltaErr) {
+				return fmt.Errorf("unable to locate staged delta: %w", deltaErr)
+			}
 		}
+		t.providerMissingFiles = true
 		return fmt.Errorf("unable to locate staged file: %w", err)
 	}
 
@@ -589,9 +776,28 @@ func (t *transitioner) findAndMoveStagedFileIntoPlace(
 		return fmt.Errorf("unable to set staged file permissions: %w", err)
 	}
 
+	// Set extended attributes for the staged file, if any. We do this after
+	// setting permissions because setting permissions can modify POSIX ACLs.
+	if len(target.ExtendedAttributes) > 0 {
+		if err := filesystem.SetExtendedAttributesByPath(stagedPath, target.ExtendedAttributes); err != nil {
+			return fmt.Errorf("unable to set staged file extended attributes: %w", err)
+		}
+	}
+
+	// Set the modification time for the staged file, if specified. We do this
+	// before relocation so that the file appears in place with its final
+	// metadata (renames preserve modification times).
+	if target.ModificationTime != nil {
+		modificationTime := target.ModificationTime.AsTime()
+		if err := os.Chtimes(stagedPath, modificationTime, modificationTime); err != nil {
+			return fmt.Errorf("unable to set staged file modification time: %w", err)
+		}
+	}
+
 	// Attempt to atomically rename the file. If we succeed, we're done.
 	renameErr := filesystem.Rename(nil, stagedPath, parent, name, replace)
 	if renameErr == nil {
+		t.recordHardLinkSource(path, target)
 		return nil
 	}
 
@@ -603,8 +809,7 @@ func (t *transitioner) findAndMoveStagedFileIntoPlace(
 
 	// At this point, we know we're dealing with a cross-device rename, for
 	// which we'll have to simulate atomicity with an intermediate temporary
-	// file. We'll also need to monitor for preemption at this point because
-	// cross-device copies can take a significant amount of time.
+	// file.
 
 	// Open the staged file. We can't defer its closure because we need to be
 	// able to remove it after a successful rename, which we can't do (on some
@@ -614,37 +819,76 @@ func (t *transitioner) findAndMoveStagedFileIntoPlace(
 		return fmt.Errorf("unable to open staged file: %w", err)
 	}
 
+	// Copy the file contents into place, preserving holes if possible.
+	err = t.copyIntoPlace(path, target, parent, name, mode, replace, func(temporary io.Writer) error {
+		if sparseTemporary, ok := temporary.(filesystem.SparseFile); ok {
+			return t.copySparse(sparseTemporary, stagedFile)
+		}
+		preemptableTemporary := stream.NewPreemptableWriter(
+			temporary,
+			t.cancelled,
+			transitionCopyPreemptionInterval,
+		)
+		_, err := io.CopyBuffer(preemptableTemporary, stagedFile, t.copyBuffer)
+		return err
+	})
+
+	// Close the staged file.
+	stagedFile.Close()
+
+	// Handle copying errors.
+	if err != nil {
+		return err
+	}
+
+	// Remove the staged file. We don't bother checking for errors because
+	// there's not much we can or need to do about them at this point.
+	os.Remove(stagedPath)
+
+	// Record the file as a hard link source if necessary.
+	t.recordHardLinkSource(path, target)
+
+	// Success.
+	return nil
+}
+
+// copyIntoPlace creates a file at the location specified by the combination of
+// parent directory and content name by writing its contents to an intermediate
+// temporary file using the specified function, setting the intermediate file's
+// metadata based on the target entry and mode, and then renaming it into place
+// (replacing any existing file if replace is true). The path argument is the
+// root-relative path of the file. Since writing contents can take a significant
+// amount of time, the writing function should be preemptable, in which case it
+// should return stream.ErrWritePreempted.
+func (t *transitioner) copyIntoPlace(
+	path string,
+	target *Entry,
+	parent *filesystem.Directory,
+	name string,
+	mode filesystem.Mode,
+	replace bool,
+	write func(io.Writer) error,
+) error {
 	// Create a temporary file in the target directory. We can't defer its
 	// closure because we'll want to be rename it or remove it on rename
 	// failure, which we can't do (on some platforms, notably Windows) if the
 	// file handle is open.
 	temporaryName, temporary, err := parent.CreateTemporaryFile(crossDeviceRenameTemporaryNamePrefix)
 	if err != nil {
-		stagedFile.Close()
-		return fmt.Errorf("unable to create temporary file for cross-device rename: %w", err)
+		return fmt.Errorf("unable to create intermediate file: %w", err)
 	}
 
-	// Wrap the temporary file in a preemptable writer to enable cancellation.
-	preemptableTemporary := stream.NewPreemptableWriter(
-		temporary,
-		t.cancelled,
-		transitionCopyPreemptionInterval,
-	)
-
-	// Copy the file contents. We'll handle errors below.
-	_, copyErr := io.CopyBuffer(preemptableTemporary, stagedFile, t.copyBuffer)
-
-	// Close out files.
-	stagedFile.Close()
+	// Write the file contents and close the temporary file.
+	writeErr := write(temporary)
 	temporary.Close()
 
-	// If there was a copy error, then remove the temporary and abort.
-	if copyErr != nil {
+	// If there was a write error, then remove the temporary and abort.
+	if writeErr != nil {
 		parent.RemoveFile(temporaryName)
-		if copyErr == stream.ErrWritePreempted {
+		if writeErr == stream.ErrWritePreempted {
 			return errTransitionCancelled
 		}
-		return fmt.Errorf("unable to copy file contents: %w", copyErr)
+		return fmt.Errorf("unable to write file contents: %w", writeErr)
 	}
 
 	// Set permissions on the temporary file.
@@ -653,20 +897,526 @@ func (t *transitioner) findAndMoveStagedFileIntoPlace(
 		return fmt.Errorf("unable to set intermediate file permissions: %w", err)
 	}
 
+	// Set extended attributes on the temporary file, if any.
+	if len(target.ExtendedAttributes) > 0 {
+		if err := filesystem.SetExtendedAttributesByPath(t.fullPath(path, temporaryName), target.ExtendedAttributes); err != nil {
+			parent.RemoveFile(temporaryName)
+			return fmt.Errorf("unable to set intermediate file extended attributes: %w", err)
+		}
+	}
+
+	// Set the modification time on the temporary file, if specified, since it
+	// won't have been preserved by the copy.
+	if target.ModificationTime != nil {
+		if err := parent.SetModificationTime(temporaryName, target.ModificationTime.AsTime()); err != nil {
+			parent.RemoveFile(temporaryName)
+			return fmt.Errorf("unable to set intermediate file modification time: %w", err)
+		}
+	}
+
 	// Rename the file.
 	if err := filesystem.Rename(parent, temporaryName, parent, name, replace); err != nil {
 		parent.RemoveFile(temporaryName)
 		return fmt.Errorf("unable to relocate intermediate file: %w", err)
 	}
 
-	// Remove the staged file. We don't bother checking for errors because
-	// there's not much we can or need to do about them at this point.
-	os.Remove(stagedPath)
+	// Success.
+	return nil
+}
+
+// copySparse copies the contents of a staged file to an empty destination file.
+// Holes in the staged file are preserved and zero-filled blocks are converted
+// to holes. Copying is preemptable, in which case stream.ErrWritePreempted is
+// returned.
+func (t *transitioner) copySparse(destination filesystem.SparseFile, source *os.File) error {
+	// Create a sparse writer for the destination and wrap it in a preemptable
+	// writer to enable cancellation.
+	writer := filesystem.NewSparseWriter(destination)
+	preemptable := stream.NewPreemptableWriter(writer, t.cancelled, transitionCopyPreemptionInterval)
+
+	// Copy data regions, skipping over any holes that precede them.
+	var offset int64
+	for {
+		start, end, err := filesystem.NextDataRegion(source, offset)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return fmt.Errorf("unable to locate data region: %w", err)
+		}
+		if err := writer.Skip(start - offset); err != nil {
+			return fmt.Errorf("unable to skip hole: %w", err)
+		}
+		if _, err := source.Seek(start, io.SeekStart); err != nil {
+			return fmt.Errorf("unable to seek to data region: %w", err)
+		}
+		if _, err := io.CopyBuffer(preemptable, io.LimitReader(source, end-start), t.copyBuffer); err != nil {
+			return err
+		}
+		offset = end
+	}
+
+	// Account for any trailing hole.
+	metadata, err := source.Stat()
+	if err != nil {
+		return fmt.Errorf("unable to query file size: %w", err)
+	} else if err := writer.Skip(metadata.Size() - offset); err != nil {
+		return fmt.Errorf("unable to skip trailing hole: %w", err)
+	}
+
+	// Set the final file size.
+	return writer.Finalize()
+}
+
+// applyStagedDelta creates the target file at the location specified by the
+// combination of parent directory and content name using a staged delta that
+// was computed against the file at the staging path. If the delta's base is
+// the existing file being replaced and that file can be safely modified, then
+// the delta is applied to it directly. Otherwise, the content is reconstructed
+// from the delta and its base in an intermediate temporary file that is then
+// renamed into place. If the delta's base has been modified since staging,
+// then the delta is discarded and treated as missing so that the content will
+// be restaged.
+func (t *transitioner) applyStagedDelta(
+	path, stagingPath, deltaPath string,
+	target *Entry,
+	parent *filesystem.Directory,
+	name string,
+	mode filesystem.Mode,
+	existing *Entry,
+) error {
+	// Open the delta and read its header, discarding the delta if its header
+	// is invalid. We can't defer its closure because we need to be able to
+	// remove it after it's been applied, which we can't do (on some platforms,
+	// notably Windows) if the file handle is open.
+	deltaFile, err := os.Open(deltaPath)
+	if err != nil {
+		return fmt.Errorf("unable to open staged delta: %w", err)
+	}
+	delta, err := newDeltaReader(deltaFile)
+	if err != nil {
+		deltaFile.Close()
+		os.Remove(deltaPath)
+		t.providerMissingFiles = true
+		return err
+	}
+
+	// Apply the delta. We only update the existing file in place if it's the
+	// delta's base and if it can be opened for updating (which will fail if,
+	// for example, it has multiple links).
+	var applyErr error
+	updatedInPlace := false
+	if existing != nil && stagingPath == path {
+		if file, err := parent.OpenFileForUpdating(name); err == nil {
+			applyErr = t.updateFileInPlace(delta, file, parent, path, name, mode, existing, target)
+			updatedInPlace = true
+		}
+	}
+	if !updatedInPlace {
+		applyErr = t.reconstructIntoPlace(delta, stagingPath, path, target, parent, name, mode, existing != nil)
+	}
+
+	// Close the delta.
+	deltaFile.Close()
+
+	// Handle application errors. If the delta's base has been modified, then
+	// the delta is no longer usable.
+	if applyErr == errDeltaBaseModified {
+		os.Remove(deltaPath)
+		t.providerMissingFiles = true
+		return fmt.Errorf("unable to apply staged delta: %w", applyErr)
+	} else if applyErr != nil {
+		return applyErr
+	}
+
+	// Remove the delta. We don't bother checking for errors because there's
+	// not much we can or need to do about them at this point.
+	os.Remove(deltaPath)
+
+	// Record the file as a hard link source if necessary.
+	t.recordHardLinkSource(path, target)
+
+	// Success.
+	return nil
+}
+
+// reconstructIntoPlace reconstructs content from a delta and its base (the
+// file at the staging path) in an intermediate temporary file and renames it
+// into place. If the base has been modified since the delta was computed, then
+// errDeltaBaseModified is returned.
+func (t *transitioner) reconstructIntoPlace(
+	delta *deltaReader,
+	stagingPath, path string,
+	target *Entry,
+	parent *filesystem.Directory,
+	name string,
+	mode filesystem.Mode,
+	replace bool,
+) error {
+	// Open the base and defer its closure.
+	baseParent, baseName, err := t.walkToParentAndComputeLeafName(stagingPath, false)
+	if err != nil {
+		return fmt.Errorf("unable to walk to delta base: %w", err)
+	}
+	defer baseParent.Close()
+	base, metadata, err := baseParent.OpenFile(baseName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return errDeltaBaseModified
+		}
+		return fmt.Errorf("unable to open delta base: %w", err)
+	}
+	defer base.Close()
+
+	// Verify that the base hasn't been modified since the delta was computed.
+	if !delta.matchesBase(int64(metadata.Size), metadata.ModificationTime) {
+		return errDeltaBaseModified
+	}
+
+	// Reconstruct the content into place.
+	return t.copyIntoPlace(path, target, parent, name, mode, replace, func(temporary io.Writer) error {
+		return t.reconstructFromDelta(temporary, base, delta)
+	})
+}
+
+// reconstructFromDelta writes the content produced by applying a delta to its
+// base to an empty destination. Zero-filled blocks are converted to holes if
+// the destination supports sparse writes. Writing is preemptable, in which case
+// stream.ErrWritePreempted is returned.
+func (t *transitioner) reconstructFromDelta(destination io.Writer, base io.ReadSeeker, delta *deltaReader) error {
+	// Wrap the destination in a sparse writer (if possible) and a preemptable
+	// writer to enable cancellation.
+	var sparse *filesystem.SparseWriter
+	writer := destination
+	if sparseDestination, ok := destination.(filesystem.SparseFile); ok {
+		sparse = filesystem.NewSparseWriter(sparseDestination)
+		writer = sparse
+	}
+	preemptable := stream.NewPreemptableWriter(writer, t.cancelled, transitionCopyPreemptionInterval)
+
+	// Process records, filling the regions between them with base content (or
+	// zeros beyond the end of the base).
+	var offset int64
+	for {
+		// Read the next record.
+		recordOffset, data, err := delta.next()
+		if err != nil {
+			return err
+		}
+
+		// Fill the region preceding the record (or the end of the content).
+		if recordOffset > offset {
+			if _, err := base.Seek(offset, io.SeekStart); err != nil {
+				return fmt.Errorf("unable to seek delta base: %w", err)
+			}
+			copied, err := io.CopyBuffer(preemptable, io.LimitReader(base, recordOffset-offset), t.copyBuffer)
+			if err != nil {
+				return err
+			}
+			for remaining := recordOffset - offset - copied; remaining > 0; {
+				n := int64(len(deltaZeroBlock))
+				if remaining < n {
+					n = remaining
+				}
+				if _, err := preemptable.Write(deltaZeroBlock[:n]); err != nil {
+					return err
+				}
+				remaining -= n
+			}
+		}
+
+		// Handle the terminating record.
+		if data == nil {
+			break
+		}
+
+		// Write the record data.
+		if _, err := preemptable.Write(data); err != nil {
+			return err
+		}
+		offset = recordOffset + int64(len(data))
+	}
+
+	// Set the final file size if we're writing sparsely.
+	if sparse != nil {
+		return sparse.Finalize()
+	}
+
+	// Success.
+	return nil
+}
+
+// updateFileInPlace applies a delta directly to the existing file (opened for
+// reading and writing) against which it was computed and then applies the
+// target's metadata. Before each region of the file is overwritten, its
+// original content is recorded and flushed to a journal (an intermediate
+// temporary file in the parent directory), so if the update fails or is
+// cancelled, then the file is restored from the journal. If restoration also
+// fails, or if the update is interrupted (e.g. by a crash), then the journal is
+// left in place so that the original content isn't lost and can be restored by
+// RecoverInPlaceUpdates. If the file isn't the delta's base, then
+// errDeltaBaseModified is returned without modifying the file. The file will be
+// closed by this method.
+func (t *transitioner) updateFileInPlace(
+	delta *deltaReader,
+	file *os.File,
+	parent *filesystem.Directory,
+	path, name string,
+	mode filesystem.Mode,
+	existing, target *Entry,
+) error {
+	// Verify that the file is the delta's base.
+	metadata, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("unable to query file metadata: %w", err)
+	} else if !delta.matchesBase(metadata.Size(), metadata.ModTime()) {
+		file.Close()
+		return errDeltaBaseModified
+	}
+
+	// Create the journal.
+	journalName, journalWriter, err := parent.CreateTemporaryFile(inPlaceJournalNamePrefix)
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("unable to create journal: %w", err)
+	}
+	journal, ok := journalWriter.(*os.File)
+	if !ok {
+		panic("invalid file object returned from temporary file creation")
+	}
+
+	// Write the journal header and ensure that it's durable before the file is
+	// modified, that way the journal can always be identified on recovery.
+	if err := writeInPlaceJournalHeader(journal, name, metadata.Size(), metadata.ModTime()); err != nil {
+		journal.Close()
+		file.Close()
+		parent.RemoveFile(journalName)
+		return fmt.Errorf("unable to write journal header: %w", err)
+	} else if err := journal.Sync(); err != nil {
+		journal.Close()
+		file.Close()
+		parent.RemoveFile(journalName)
+		return fmt.Errorf("unable to flush journal header: %w", err)
+	}
+
+	// Apply the delta and close the journal. If the update succeeded, then
+	// ensure that the updated content is durable before the journal is removed.
+	updateErr := t.applyDelta(file, delta, journal, metadata.Size())
+	journal.Close()
+	if updateErr == nil {
+		if err := file.Sync(); err != nil {
+			updateErr = fmt.Errorf("unable to flush file: %w", err)
+		}
+	}
+
+	// If the update failed, then restore the original content and modification
+	// time (so that the file continues to match the cache).
+	if updateErr != nil {
+		if err := restoreFromJournal(file, parent, journalName); err != nil {
+			file.Close()
+			return fmt.Errorf("unable to restore file contents after failed update (%v), original content preserved in %s: %w",
+				updateErr, journalName, err,
+			)
+		}
+		file.Close()
+		parent.SetModificationTime(name, metadata.ModTime())
+		parent.RemoveFile(journalName)
+		if updateErr == errTransitionCancelled {
+			return updateErr
+		}
+		return fmt.Errorf("unable to update file contents in place: %w", updateErr)
+	}
+
+	// Close the file and remove the journal.
+	file.Close()
+	parent.RemoveFile(journalName)
+
+	// Set permissions on the file.
+	if err := parent.SetPermissions(name, t.defaultOwnership, mode); err != nil {
+		return fmt.Errorf("unable to set file permissions: %w", err)
+	}
+
+	// Update extended attributes on the file, removing any that the target no
+	// longer has.
+	if err := updateExtendedAttributes(t.fullPath(path, name), existing.ExtendedAttributes, target.ExtendedAttributes); err != nil {
+		return fmt.Errorf("unable to update file extended attributes: %w", err)
+	}
+
+	// Set the modification time on the file, if specified.
+	if target.ModificationTime != nil {
+		if err := parent.SetModificationTime(name, target.ModificationTime.AsTime()); err != nil {
+			return fmt.Errorf("unable to set file modification time: %w", err)
+		}
+	}
 
 	// Success.
 	return nil
 }
 
+// applyDelta applies the records of a delta to a file with the specified
+// original size. The original content of each region that will be overwritten
+// or truncated is recorded in the journal and flushed to disk before the region
+// is modified, so all of the file's original content can be restored from the
+// journal if an error is returned or if application is interrupted.
+func (t *transitioner) applyDelta(file *os.File, delta *deltaReader, journal *os.File, originalSize int64) error {
+	// Create a buffer for original content.
+	original := make([]byte, deltaBlockSize)
+
+	// Create a function to journal the original content of a region. Regions
+	// beyond the original size will be removed on restoration.
+	journalRegion := func(offset, length int64) error {
+		if remaining := originalSize - offset; remaining < length {
+			length = remaining
+		}
+		if _, err := file.ReadAt(original[:length], offset); err != nil {
+			return fmt.Errorf("unable to read original content: %w", err)
+		}
+		if err := writeInPlaceJournalRecord(journal, offset, original[:length]); err != nil {
+			return fmt.Errorf("unable to write journal record: %w", err)
+		} else if err := journal.Sync(); err != nil {
+			return fmt.Errorf("unable to flush journal: %w", err)
+		}
+		return nil
+	}
+
+	// Apply records.
+	for {
+		// Check for cancellation.
+		select {
+		case <-t.cancelled:
+			return errTransitionCancelled
+		default:
+		}
+
+		// Read the next record.
+		offset, data, err := delta.next()
+		if err != nil {
+			return err
+		}
+
+		// If this is the terminating record, then set the final file size. If
+		// the file is shrinking, then journal the content that will be removed
+		// first. If this fails, then the size will be unchanged.
+		if data == nil {
+			if offset < originalSize {
+				for o := offset; o < originalSize; o += deltaBlockSize {
+					if err := journalRegion(o, deltaBlockSize); err != nil {
+						return err
+					}
+				}
+			}
+			if offset != originalSize {
+				if err := file.Truncate(offset); err != nil {
+					return fmt.Errorf("unable to set file size: %w", err)
+				}
+			}
+			return nil
+		}
+
+		// Journal the original content of the region that will be overwritten.
+		if offset < originalSize {
+			if err := journalRegion(offset, int64(len(data))); err != nil {
+				return err
+			}
+		}
+
+		// Overwrite the region.
+		if _, err := file.WriteAt(data, offset); err != nil {
+			return fmt.Errorf("unable to write content: %w", err)
+		}
+	}
+}
+
+// restoreFromJournal restores the original content and size of a file that was
+// partially updated by applyDelta using the journal with the specified name.
+func restoreFromJournal(file *os.File, parent *filesystem.Directory, journalName string) error {
+	// Open the journal and defer its closure.
+	journalFile, _, err := parent.OpenFile(journalName)
+	if err != nil {
+		return fmt.Errorf("unable to open journal: %w", err)
+	}
+	defer journalFile.Close()
+
+	// Read the journal header and restore the file.
+	journal, err := newInPlaceJournalReader(journalFile)
+	if err != nil {
+		return err
+	}
+	return journal.replay(file)
+}
+
+// updateExtendedAttributes updates the extended attributes of the content at
+// the specified path from an existing set to a target set. Attributes that are
+// new or modified are set and attributes that the target no longer has are
+// removed. Attributes that aren't in either set (e.g. because they're excluded
+// from synchronization) are left untouched.
+func updateExtendedAttributes(path string, existing, target map[string][]byte) error {
+	// Compute the attributes that need to be set.
+	var modified map[string][]byte
+	for name, value := range target {
+		if current, ok := existing[name]; !ok || !bytes.Equal(current, value) {
+			if modified == nil {
+				modified = make(map[string][]byte, len(target))
+			}
+			modified[name] = value
+		}
+	}
+
+	// Compute the attributes that need to be removed.
+	var removed []string
+	for name := range existing {
+		if _, ok := target[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+
+	// Set modified attributes.
+	if len(modified) > 0 {
+		if err := filesystem.SetExtendedAttributesByPath(path, modified); err != nil {
+			return fmt.Errorf("unable to set extended attributes: %w", err)
+		}
+	}
+
+	// Remove stale attributes.
+	if len(removed) > 0 {
+		if err := filesystem.RemoveExtendedAttributesByPath(path, removed); err != nil {
+			return fmt.Errorf("unable to remove extended attributes: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// updateDirectoryMetadata updates the metadata (currently just the extended
+// attributes) of an existing directory at the specified path, enforcing that
+// the existing content is a directory. The contents of the directory are not
+// modified.
+func (t *transitioner) updateDirectoryMetadata(path string, oldEntry, newEntry *Entry) error {
+	// Walk down to the parent of the target and compute the target's leaf name.
+	// If we are successful, defer closure of the parent.
+	parent, name, err := t.walkToParentAndComputeLeafName(path, true)
+	if err != nil {
+		return fmt.Errorf("unable to walk to transition root: %w", err)
+	}
+	defer parent.Close()
+
+	// Ensure that the existing content is still a directory.
+	if metadata, err := parent.ReadContentMetadata(name); err != nil {
+		return fmt.Errorf("unable to read existing content metadata: %w", err)
+	} else if metadata.Mode&filesystem.ModeTypeMask != filesystem.ModeTypeDirectory {
+		return errors.New("existing content is not a directory")
+	}
+
+	// RACE: There is a race condition here between the directory check and the
+	// metadata update that we have to live with due to limitations in
+	// filesystem APIs. The worst case fallout is that the metadata is applied
+	// to content that replaced the directory during this window.
+
+	// Update the extended attributes.
+	return updateExtendedAttributes(t.fullPath(path, name), oldEntry.ExtendedAttributes, newEntry.ExtendedAttributes)
+}
+
 // swapFile atomically swaps files at the specified path, enforcing that the
 // existing file matches what's expected.
 func (t *transitioner) swapFile(path string, oldEntry, newEntry *Entry) error {
@@ -713,17 +1463,23 @@ func (t *transitioner) swapFile(path string, oldEntry, newEntry *Entry) error {
 			return fmt.Errorf("unable to change file permissions: %w", err)
 		}
 
+		// Update extended attributes, removing any that the new entry no
+		// longer has.
+		if err := updateExtendedAttributes(t.fullPath(path, name), oldEntry.ExtendedAttributes, newEntry.ExtendedAttributes); err != nil {
+			return fmt.Errorf("unable to update file extended attributes: %w", err)
+		}
+
 		// Success.
 		return nil
 	}
 
 	// Otherwise, we will have a staged file, so find it and move it into place.
-	return t.findAndMoveStagedFileIntoPlace(path, newEntry, parent, name, true)
+	return t.findAndMoveStagedFileIntoPlace(path, newEntry, parent, name, oldEntry)
 }
 
 // createFile creates the target file at the specified path.
 func (t *transitioner) createFile(parent *filesystem.Directory, name, path string, target *Entry) error {
-	return t.findAndMoveStagedFileIntoPlace(path, target, parent, name, false)
+	return t.findAndMoveStagedFileIntoPlace(path, target, parent, name, nil)
 }
 
 // createSymbolicLink creates the target symbolic link at the specified path.
@@ -802,6 +1558,15 @@ func (t *transitioner) createDirectory(parent *filesystem.Directory, name, path
 		return created
 	}
 
+	// Set directory extended attributes, if any. As with permissions, a
+	// failure here aborts the remainder of the operation.
+	if len(target.ExtendedAttributes) > 0 {
+		if err := filesystem.SetExtendedAttributesByPath(t.fullPath(path, name), target.ExtendedAttributes); err != nil {
+			t.recordProblem(path, fmt.Errorf("unable to set directory extended attributes: %w", err))
+			return created
+		}
+	}
+
 	// If there are contents in the target, allocate a map for created, because
 	// we'll need to populate it, and open the directory for operations
 	// (deferring its closure).
@@ -976,6 +1741,20 @@ func Transition(
 			continue
 		}
 
+		// Handle the special case where both old and new are a directory. This
+		// only occurs for metadata updates generated by reconciliation, in
+		// which case the entries are shallow and the directory contents are
+		// left untouched.
+		if t.directoryMetadataOnly() {
+			if err := transitioner.updateDirectoryMetadata(t.Path, t.Old, t.New); err != nil {
+				results = append(results, t.Old)
+				transitioner.recordProblem(t.Path, fmt.Errorf("unable to update directory metadata: %w", err))
+			} else {
+				results = append(results, t.New)
+			}
+			continue
+		}
+
 		// Reduce whatever we expect to see on disk to nil (remove it). If we
 		// don't expect to see anything (t.Old == nil), this is a no-op. If this
 		// fails, then record the reduced entry and continue to the next
@@ -1,13 +1,16 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
 	"testing"
 	"time"
 
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
 )
 
@@ -678,9 +681,6 @@ func TestTransition(t *testing.T) {
 		},
 	}
 
-	// Create a hasher that we can use for testing.
-	hasher := newTestingHasher()
-
 	// Create a temporary directory that transition content providers can use
 	// for staging. We'll put this on the OS temporary directory so that we test
 	// same-device staging for the OS filesystem and cross-device staging for
@@ -726,12 +726,18 @@ func TestTransition(t *testing.T) {
 			snapshot, cache, _, err := Scan(
 				backgroundCtx,
 				root,
+				nil, nil, false,
+				newTestingHasher, nil,
 				nil, nil,
-				hasher, nil,
-				nil, nil,
+				nil,
+				nil,
 				behavior.ProbeMode_ProbeModeProbe,
 				test.symbolicLinkMode,
 				PermissionsMode_PermissionsModePortable,
+				ModificationTimeMode_ModificationTimeModeIgnore,
+				HardLinkMode_HardLinkModeIndependent,
+				nil,
+				0,
 			)
 			if err != nil {
 				t.Errorf("%s: unable to perform scan of baseline on %s filesystem: %v",
@@ -801,3 +807,679 @@ func TestTransition(t *testing.T) {
 		}
 	}
 }
+
+// TestTransitionHardLinks tests that hard link groups detected by scanning are
+// staged once and recreated during transitioning.
+func TestTransitionHardLinks(t *testing.T) {
+	// Skip this test on Windows, where file IDs aren't available from scans.
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+
+	// Create source content with a pair of hard-linked files and an unlinked
+	// file with identical contents.
+	source := t.TempDir()
+	content := []byte("linked content")
+	if err := os.WriteFile(filepath.Join(source, "a"), content, 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	} else if err = os.Link(filepath.Join(source, "a"), filepath.Join(source, "b")); err != nil {
+		t.Fatal("unable to create hard link:", err)
+	} else if err = os.WriteFile(filepath.Join(source, "c"), content, 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+
+	// Define a function to perform a scan.
+	scan := func(root string) (*Snapshot, *Cache) {
+		snapshot, cache, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil, false,
+			newTestingHasher, nil,
+			nil, nil,
+			nil,
+			nil,
+			behavior.ProbeMode_ProbeModeProbe,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			PermissionsMode_PermissionsModePortable,
+			ModificationTimeMode_ModificationTimeModeIgnore,
+			HardLinkMode_HardLinkModePreserve,
+			nil,
+			0,
+		)
+		if err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		return snapshot, cache
+	}
+
+	// Define a function to perform a transition, providing content for the
+	// transition dependencies from the source.
+	transition := func(target string, transitions []*Change, cache *Cache) {
+		paths, _ := TransitionDependencies(transitions)
+		contentMap := make(testingContentMap, len(paths))
+		for _, path := range paths {
+			if data, err := os.ReadFile(filepath.Join(source, path)); err != nil {
+				t.Fatal("unable to read dependency:", err)
+			} else {
+				contentMap[path] = data
+			}
+		}
+		provider := &testingProvider{
+			storage:    t.TempDir(),
+			contentMap: contentMap,
+			hasher:     newTestingHasher(),
+		}
+		_, problems, missingFiles := Transition(
+			context.Background(),
+			target,
+			transitions,
+			cache,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			0600,
+			0700,
+			nil,
+			false,
+			provider,
+		)
+		if len(problems) > 0 {
+			t.Fatal("transition problems encountered:", problems[0].Error)
+		} else if missingFiles {
+			t.Fatal("transition missing staged files")
+		}
+	}
+
+	// Define a function to verify target link structure and content. The
+	// unlinked file should always have the original content.
+	verify := func(target string, linkedContent []byte) {
+		metadata := make(map[string]os.FileInfo, 3)
+		for _, name := range []string{"a", "b", "c"} {
+			expected := linkedContent
+			if name == "c" {
+				expected = content
+			}
+			if data, err := os.ReadFile(filepath.Join(target, name)); err != nil {
+				t.Fatal("unable to read target file:", err)
+			} else if !bytes.Equal(data, expected) {
+				t.Error("target file content incorrect:", name)
+			}
+			if m, err := os.Stat(filepath.Join(target, name)); err != nil {
+				t.Fatal("unable to query target file metadata:", err)
+			} else {
+				metadata[name] = m
+			}
+		}
+		if !os.SameFile(metadata["a"], metadata["b"]) {
+			t.Error("hard-linked files not linked on target")
+		} else if os.SameFile(metadata["a"], metadata["c"]) {
+			t.Error("independent files linked on target")
+		}
+	}
+
+	// Scan the source and verify group assignment.
+	snapshot, _ := scan(source)
+	if group := snapshot.Content.Contents["a"].HardLinkGroup; group != "a" {
+		t.Error("incorrect hard link group for first link:", group)
+	} else if group = snapshot.Content.Contents["b"].HardLinkGroup; group != "a" {
+		t.Error("incorrect hard link group for second link:", group)
+	} else if group = snapshot.Content.Contents["c"].HardLinkGroup; group != "" {
+		t.Error("unlinked file assigned to hard link group:", group)
+	}
+
+	// Transition the content to a new target and verify the result.
+	target := filepath.Join(t.TempDir(), "target")
+	transition(target, []*Change{{New: snapshot.Content}}, &Cache{})
+	verify(target, content)
+
+	// Modify the linked content on the source, rescan, and transition the
+	// modified files on the target, verifying that links are preserved.
+	updated := []byte("updated linked content")
+	if err := os.WriteFile(filepath.Join(source, "a"), updated, 0600); err != nil {
+		t.Fatal("unable to modify file:", err)
+	}
+	updatedSnapshot, _ := scan(source)
+	_, targetCache := scan(target)
+	transition(target, []*Change{
+		{Path: "a", Old: snapshot.Content.Contents["a"], New: updatedSnapshot.Content.Contents["a"]},
+		{Path: "b", Old: snapshot.Content.Contents["b"], New: updatedSnapshot.Content.Contents["b"]},
+	}, targetCache)
+	verify(target, updated)
+
+	// Create an additional link on the source, rescan, and transition only
+	// the new link on the target, verifying that it's linked to the existing
+	// members of its group.
+	if err := os.Link(filepath.Join(source, "a"), filepath.Join(source, "d")); err != nil {
+		t.Fatal("unable to create hard link:", err)
+	}
+	linkedSnapshot, _ := scan(source)
+	_, targetCache = scan(target)
+	transition(target, []*Change{
+		{Path: "d", New: linkedSnapshot.Content.Contents["d"]},
+	}, targetCache)
+	verify(target, updated)
+	if first, err := os.Stat(filepath.Join(target, "a")); err != nil {
+		t.Fatal("unable to query target file metadata:", err)
+	} else if second, err := os.Stat(filepath.Join(target, "d")); err != nil {
+		t.Fatal("unable to query target file metadata:", err)
+	} else if !os.SameFile(first, second) {
+		t.Error("new link not linked to existing group members on target")
+	}
+}
+
+// testingInPlaceUpdate is a helper for testing in-place file updates. It holds
+// an existing file and a delta against it.
+type testingInPlaceUpdate struct {
+	// root is the directory containing the existing file.
+	root string
+	// parent is the open root directory.
+	parent *filesystem.Directory
+	// original is the original content of the existing file.
+	original []byte
+	// originalMetadata is the original metadata of the existing file.
+	originalMetadata os.FileInfo
+	// delta is the encoded delta.
+	delta []byte
+}
+
+// newTestingInPlaceUpdate creates an existing file with the specified content
+// in a temporary directory and encodes a delta that transforms it into the
+// updated content.
+func newTestingInPlaceUpdate(t *testing.T, original, updated []byte) *testingInPlaceUpdate {
+	// Mark this function as a test helper.
+	t.Helper()
+
+	// Create the existing file and set its modification time in the past so
+	// that modifications would be detectable.
+	root := t.TempDir()
+	path := filepath.Join(root, "file")
+	if err := os.WriteFile(path, original, 0600); err != nil {
+		t.Fatal("unable to create existing file:", err)
+	}
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal("unable to set existing file modification time:", err)
+	}
+	metadata, err := os.Lstat(path)
+	if err != nil {
+		t.Fatal("unable to query existing file metadata:", err)
+	}
+
+	// Encode the delta.
+	delta := &bytes.Buffer{}
+	writer, err := NewDeltaWriter(delta, bytes.NewReader(original), metadata.Size(), metadata.ModTime())
+	if err != nil {
+		t.Fatal("unable to create delta writer:", err)
+	} else if _, err := writer.Write(updated); err != nil {
+		t.Fatal("unable to write delta:", err)
+	} else if err := writer.Close(); err != nil {
+		t.Fatal("unable to close delta writer:", err)
+	}
+
+	// Open the parent directory.
+	parent, _, err := filesystem.OpenDirectory(root, false)
+	if err != nil {
+		t.Fatal("unable to open parent directory:", err)
+	}
+	t.Cleanup(func() { parent.Close() })
+
+	// Done.
+	return &testingInPlaceUpdate{
+		root:             root,
+		parent:           parent,
+		original:         original,
+		originalMetadata: metadata,
+		delta:            delta.Bytes(),
+	}
+}
+
+// apply performs the in-place update using the specified transitioner and
+// delta source.
+func (u *testingInPlaceUpdate) apply(t *testing.T, transitioner *transitioner, source io.Reader) error {
+	// Mark this function as a test helper.
+	t.Helper()
+
+	// Decode the delta header.
+	delta, err := newDeltaReader(source)
+	if err != nil {
+		t.Fatal("unable to read delta header:", err)
+	}
+
+	// Open the existing file and update it.
+	file, err := u.parent.OpenFileForUpdating("file")
+	if err != nil {
+		t.Fatal("unable to open existing file for updating:", err)
+	}
+	existing := &Entry{Kind: EntryKind_File, Digest: []byte{1}}
+	target := &Entry{Kind: EntryKind_File, Digest: []byte{0}}
+	return transitioner.updateFileInPlace(delta, file, u.parent, "file", "file", 0600, existing, target)
+}
+
+// verify verifies that the file has the expected content and that no journal
+// has been left behind. If the original content is expected, then it also
+// verifies that the original modification time was restored.
+func (u *testingInPlaceUpdate) verify(t *testing.T, expected []byte) {
+	// Mark this function as a test helper.
+	t.Helper()
+
+	// Verify the file contents.
+	path := filepath.Join(u.root, "file")
+	if contents, err := os.ReadFile(path); err != nil {
+		t.Fatal("unable to read file:", err)
+	} else if !bytes.Equal(contents, expected) {
+		t.Error("file contents do not match expected")
+	}
+
+	// Verify the modification time if necessary.
+	if bytes.Equal(expected, u.original) {
+		if metadata, err := os.Lstat(path); err != nil {
+			t.Fatal("unable to query file metadata:", err)
+		} else if !metadata.ModTime().Equal(u.originalMetadata.ModTime()) {
+			t.Error("original modification time not restored")
+		}
+	}
+
+	// Verify that no journal was left behind.
+	if names, err := u.parent.ReadContentNames(); err != nil {
+		t.Fatal("unable to read directory contents:", err)
+	} else if len(names) != 1 {
+		t.Error("unexpected directory contents:", names)
+	}
+}
+
+// TestTransitionUpdateFileInPlace tests in-place file updates using deltas.
+func TestTransitionUpdateFileInPlace(t *testing.T) {
+	// Create content with several blocks of distinct data.
+	original := make([]byte, 4*deltaBlockSize)
+	for i := range original {
+		original[i] = byte(i / deltaBlockSize)
+	}
+	modified := append([]byte(nil), original...)
+	copy(modified[deltaBlockSize+100:], "modified")
+	extended := append(append([]byte(nil), modified...), make([]byte, 2*deltaBlockSize)...)
+	copy(extended[len(extended)-10:], "extension")
+
+	// Define test cases.
+	testCases := []struct {
+		original []byte
+		updated  []byte
+	}{
+		{original, original},
+		{original, modified},
+		{original, extended},
+		{original, modified[:deltaBlockSize+500]},
+		{original, nil},
+		{nil, modified},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		update := newTestingInPlaceUpdate(t, testCase.original, testCase.updated)
+		transitioner := &transitioner{
+			cancelled:  context.Background().Done(),
+			root:       update.root,
+			copyBuffer: make([]byte, transitionCopyBufferSize),
+		}
+		if err := update.apply(t, transitioner, bytes.NewReader(update.delta)); err != nil {
+			t.Errorf("test case %d: unable to update file in place: %v", i, err)
+			continue
+		}
+		update.verify(t, testCase.updated)
+	}
+}
+
+// testingCancellingReader is an io.Reader that invokes a cancellation function
+// once a specified number of bytes have been read.
+type testingCancellingReader struct {
+	// reader is the underlying reader.
+	reader io.Reader
+	// remaining is the number of bytes remaining before cancellation.
+	remaining int
+	// cancel is the cancellation function.
+	cancel context.CancelFunc
+}
+
+// Read implements io.Reader.Read.
+func (r *testingCancellingReader) Read(buffer []byte) (int, error) {
+	n, err := r.reader.Read(buffer)
+	if r.remaining -= n; r.remaining <= 0 {
+		r.cancel()
+	}
+	return n, err
+}
+
+// TestTransitionUpdateFileInPlaceRestoration tests that files updated in place
+// are restored to their original content if the update is cancelled or fails
+// partway through.
+func TestTransitionUpdateFileInPlaceRestoration(t *testing.T) {
+	// Create original and updated content that differ in every block and have
+	// different sizes.
+	original := bytes.Repeat([]byte{1}, 4*deltaBlockSize)
+	updated := bytes.Repeat([]byte{2}, 5*deltaBlockSize)
+
+	// Test cancellation after the first two records have been read (and thus
+	// after content has been overwritten).
+	update := newTestingInPlaceUpdate(t, original, updated)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cancellable := &transitioner{
+		cancelled:  ctx.Done(),
+		root:       update.root,
+		copyBuffer: make([]byte, transitionCopyBufferSize),
+	}
+	source := &testingCancellingReader{
+		reader:    bytes.NewReader(update.delta),
+		remaining: deltaHeaderSize + 2*(deltaRecordHeaderSize+deltaBlockSize),
+		cancel:    cancel,
+	}
+	if err := update.apply(t, cancellable, source); err != errTransitionCancelled {
+		t.Error("in-place update not cancelled:", err)
+	}
+	update.verify(t, original)
+
+	// Test failure due to a truncated delta.
+	update = newTestingInPlaceUpdate(t, original, updated)
+	uncancellable := &transitioner{
+		cancelled:  context.Background().Done(),
+		root:       update.root,
+		copyBuffer: make([]byte, transitionCopyBufferSize),
+	}
+	truncated := update.delta[:deltaHeaderSize+3*(deltaRecordHeaderSize+deltaBlockSize)-10]
+	if err := update.apply(t, uncancellable, bytes.NewReader(truncated)); err == nil {
+		t.Error("in-place update succeeded with truncated delta")
+	}
+	update.verify(t, original)
+
+	// Test that the update is rejected if the file isn't the delta's base.
+	update = newTestingInPlaceUpdate(t, original, updated)
+	if err := os.WriteFile(filepath.Join(update.root, "file"), updated[:10], 0600); err != nil {
+		t.Fatal("unable to modify existing file:", err)
+	}
+	if err := update.apply(t, uncancellable, bytes.NewReader(update.delta)); err != errDeltaBaseModified {
+		t.Error("in-place update not rejected for modified base:", err)
+	}
+}
+
+// TestTransitionRecoverInPlaceUpdates tests that files whose in-place updates
+// were interrupted (e.g. by a crash) are restored from their journals.
+func TestTransitionRecoverInPlaceUpdates(t *testing.T) {
+	// Create original and updated content that differ in every block.
+	original := bytes.Repeat([]byte{1}, 4*deltaBlockSize)
+	updated := bytes.Repeat([]byte{2}, 5*deltaBlockSize)
+
+	// Define test cases. Each case specifies the updated content and the
+	// length of the delta to apply before the update is interrupted.
+	testCases := []struct {
+		updated     []byte
+		deltaLength int
+	}{
+		{updated, deltaHeaderSize + 3*(deltaRecordHeaderSize+deltaBlockSize) - 10},
+		{updated[:deltaBlockSize+100], -1},
+		{nil, -1},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the existing file and the delta to apply.
+		update := newTestingInPlaceUpdate(t, original, testCase.updated)
+		delta := update.delta
+		if testCase.deltaLength >= 0 {
+			delta = delta[:testCase.deltaLength]
+		}
+
+		// Simulate an interrupted update by performing the update steps that
+		// precede restoration and then abandoning the file and journal. We also
+		// append a partial record to the journal to simulate an interruption
+		// while journaling.
+		file, err := update.parent.OpenFileForUpdating("file")
+		if err != nil {
+			t.Fatalf("test case %d: unable to open file for updating: %v", i, err)
+		}
+		_, journalWriter, err := update.parent.CreateTemporaryFile(inPlaceJournalNamePrefix)
+		if err != nil {
+			t.Fatalf("test case %d: unable to create journal: %v", i, err)
+		}
+		journal := journalWriter.(*os.File)
+		if err := writeInPlaceJournalHeader(journal, "file", update.originalMetadata.Size(), update.originalMetadata.ModTime()); err != nil {
+			t.Fatalf("test case %d: unable to write journal header: %v", i, err)
+		}
+		reader, err := newDeltaReader(bytes.NewReader(delta))
+		if err != nil {
+			t.Fatalf("test case %d: unable to read delta header: %v", i, err)
+		}
+		transitioner := &transitioner{
+			cancelled:  context.Background().Done(),
+			root:       update.root,
+			copyBuffer: make([]byte, transitionCopyBufferSize),
+		}
+		transitioner.applyDelta(file, reader, journal, update.originalMetadata.Size())
+		if _, err := journal.Write([]byte{0, 0, 0}); err != nil {
+			t.Fatalf("test case %d: unable to write partial journal record: %v", i, err)
+		}
+		journal.Close()
+		file.Close()
+
+		// Verify that the file was modified.
+		if contents, err := os.ReadFile(filepath.Join(update.root, "file")); err != nil {
+			t.Fatalf("test case %d: unable to read file: %v", i, err)
+		} else if bytes.Equal(contents, original) {
+			t.Fatalf("test case %d: file not modified by interrupted update", i)
+		}
+
+		// Perform recovery and verify the results.
+		restored, problems := RecoverInPlaceUpdates(update.root)
+		if len(problems) > 0 {
+			t.Errorf("test case %d: recovery encountered problems: %v", i, problems)
+		} else if len(restored) != 1 || restored[0] != "file" {
+			t.Errorf("test case %d: unexpected restored paths: %v", i, restored)
+		}
+		update.verify(t, original)
+	}
+
+	// Verify that a journal with an incomplete header (which can only occur if
+	// the file wasn't modified) is removed without affecting the file.
+	update := newTestingInPlaceUpdate(t, original, updated)
+	if err := os.WriteFile(filepath.Join(update.root, inPlaceJournalNamePrefix+"1"), []byte{0, 0, 0}, 0600); err != nil {
+		t.Fatal("unable to create incomplete journal:", err)
+	}
+	if restored, problems := RecoverInPlaceUpdates(update.root); len(restored) > 0 || len(problems) > 0 {
+		t.Error("unexpected recovery results for incomplete journal:", restored, problems)
+	}
+	update.verify(t, original)
+}
+
+// TestTransitionDeltaProvider tests that transitions apply staged deltas, both
+// in place and (for files with multiple links) by reconstruction, and that
+// deltas whose bases have been modified are treated as missing.
+func TestTransitionDeltaProvider(t *testing.T) {
+	// Create original and updated content.
+	original := bytes.Repeat([]byte{1}, 3*deltaBlockSize)
+	updated := append([]byte(nil), original...)
+	copy(updated[deltaBlockSize:], "updated")
+
+	// Define test cases.
+	testCases := []struct {
+		link          bool
+		modify        bool
+		expectMissing bool
+	}{
+		{false, false, false},
+		{true, false, false},
+		{false, true, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		// Create the original file and, if requested, link it elsewhere.
+		root := t.TempDir()
+		path := filepath.Join(root, "file")
+		if err := os.WriteFile(path, original, 0600); err != nil {
+			t.Fatal("unable to create file:", err)
+		}
+		if testCase.link {
+			if err := os.Link(path, filepath.Join(t.TempDir(), "link")); err != nil {
+				t.Fatal("unable to create hard link:", err)
+			}
+		}
+
+		// Create the existing and target entries.
+		existing := &Entry{Kind: EntryKind_File, Digest: testingDigest(string(original))}
+		target := &Entry{Kind: EntryKind_File, Digest: testingDigest(string(updated))}
+
+		// Stage a delta.
+		metadata, err := os.Lstat(path)
+		if err != nil {
+			t.Fatal("unable to query file metadata:", err)
+		}
+		deltaPath := filepath.Join(t.TempDir(), "delta")
+		deltaFile, err := os.Create(deltaPath)
+		if err != nil {
+			t.Fatal("unable to create delta:", err)
+		}
+		writer, err := NewDeltaWriter(deltaFile, bytes.NewReader(original), metadata.Size(), metadata.ModTime())
+		if err != nil {
+			t.Fatal("unable to create delta writer:", err)
+		} else if _, err := writer.Write(updated); err != nil {
+			t.Fatal("unable to write delta:", err)
+		} else if err := writer.Close(); err != nil {
+			t.Fatal("unable to close delta writer:", err)
+		}
+		deltaFile.Close()
+
+		// Modify the file if necessary.
+		if testCase.modify {
+			if err := os.WriteFile(path, updated[:10], 0600); err != nil {
+				t.Fatal("unable to modify file:", err)
+			}
+		}
+
+		// Perform the transition.
+		provider := &testingDeltaProvider{path: "", digest: target.Digest, delta: deltaPath}
+		transitioner := &transitioner{
+			cancelled:       context.Background().Done(),
+			root:            path,
+			defaultFileMode: 0600,
+			copyBuffer:      make([]byte, transitionCopyBufferSize),
+			provider:        provider,
+		}
+		parent, name, err := transitioner.walkToParentAndComputeLeafName("", false)
+		if err != nil {
+			t.Fatal("unable to walk to parent:", err)
+		}
+		err = transitioner.findAndMoveStagedFileIntoPlace("", target, parent, name, existing)
+		parent.Close()
+
+		// Verify the results.
+		if testCase.expectMissing {
+			if err == nil {
+				t.Errorf("test case %d: delta applied to modified base", i)
+			} else if !transitioner.providerMissingFiles {
+				t.Errorf("test case %d: delta with modified base not treated as missing", i)
+			}
+		} else if err != nil {
+			t.Errorf("test case %d: unable to apply delta: %v", i, err)
+		} else if contents, err := os.ReadFile(path); err != nil {
+			t.Fatalf("test case %d: unable to read file: %v", i, err)
+		} else if !bytes.Equal(contents, updated) {
+			t.Errorf("test case %d: file contents do not match expected", i)
+		}
+		if _, err := os.Lstat(deltaPath); !os.IsNotExist(err) {
+			t.Errorf("test case %d: delta not removed", i)
+		}
+	}
+}
+
+// TestTransitionExtendedAttributes tests that metadata-only transitions update
+// the extended attributes of existing files and directories, removing those
+// that are no longer present, without modifying their contents.
+func TestTransitionExtendedAttributes(t *testing.T) {
+	// Create content on disk with stale extended attributes. If the filesystem
+	// doesn't support extended attributes, then skip the test.
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "directory"), 0700); err != nil {
+		t.Fatal("unable to create directory:", err)
+	} else if err = os.WriteFile(filepath.Join(root, "directory", "file"), []byte(tF1Content), 0600); err != nil {
+		t.Fatal("unable to create directory child:", err)
+	} else if err = os.WriteFile(filepath.Join(root, "file"), []byte(tF1Content), 0600); err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	stale := map[string][]byte{"user.mutagen_stale": []byte("stale")}
+	for _, name := range []string{"directory", "file"} {
+		if err := filesystem.SetExtendedAttributesByPath(filepath.Join(root, name), stale); err != nil {
+			t.Skip("unable to set extended attributes:", err)
+		}
+	}
+
+	// Define a function to perform a scan.
+	scan := func() (*Snapshot, *Cache) {
+		snapshot, cache, _, err := Scan(
+			context.Background(),
+			root,
+			nil, nil, false,
+			newTestingHasher, nil,
+			nil, nil,
+			nil,
+			nil,
+			behavior.ProbeMode_ProbeModeProbe,
+			SymbolicLinkMode_SymbolicLinkModePortable,
+			PermissionsMode_PermissionsModePortable,
+			ModificationTimeMode_ModificationTimeModeIgnore,
+			HardLinkMode_HardLinkModeIndependent,
+			[]string{"user.mutagen_*"},
+			0,
+		)
+		if err != nil {
+			t.Fatal("unable to perform scan:", err)
+		}
+		return snapshot, cache
+	}
+
+	// Perform an initial scan and create metadata-only changes.
+	snapshot, cache := scan()
+	updated := map[string][]byte{"user.mutagen_new": []byte("new")}
+	var transitions []*Change
+	for _, name := range []string{"directory", "file"} {
+		entry := snapshot.Content.Contents[name]
+		if !ExtendedAttributesEqual(entry.ExtendedAttributes, stale) {
+			t.Fatal("initial extended attributes not captured:", name)
+		}
+		transitions = append(transitions, extendedAttributeChange(name, entry, &Entry{ExtendedAttributes: updated}))
+	}
+
+	// Verify that no staging is required for the transitions.
+	if paths, _ := TransitionDependencies(transitions); len(paths) > 0 {
+		t.Error("metadata-only transitions require staging:", paths)
+	}
+
+	// Perform the transition.
+	results, problems, _ := Transition(
+		context.Background(),
+		root,
+		transitions,
+		cache,
+		SymbolicLinkMode_SymbolicLinkModePortable,
+		0600,
+		0700,
+		nil,
+		false,
+		&testingProvider{},
+	)
+	if len(problems) > 0 {
+		t.Fatal("transition problems encountered:", problems[0].Error)
+	}
+	for r, result := range results {
+		if result != transitions[r].New {
+			t.Error("transition result does not match new entry:", transitions[r].Path)
+		}
+	}
+
+	// Rescan and verify that extended attributes were updated and that the
+	// directory contents were unaffected.
+	snapshot, _ = scan()
+	for _, name := range []string{"directory", "file"} {
+		if attributes := snapshot.Content.Contents[name].ExtendedAttributes; !ExtendedAttributesEqual(attributes, updated) {
+			t.Errorf("extended attributes for %s do not match expected: %v", name, attributes)
+		}
+	}
+	if !snapshot.Content.Contents["directory"].Equal(tD1, true) {
+		t.Error("directory contents modified by metadata-only transition")
+	}
+}
@@ -2,6 +2,7 @@ package synchronization
 
 import (
 	"context"
+	"time"
 
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/rsync"
@@ -69,6 +70,16 @@ type Endpoint interface {
 	// cancellation until they're all done anyway.
 	Transition(ctx context.Context, transitions []*core.Change) ([]*core.Entry, []*core.Problem, bool, error)
 
+	// Restore restores snapshotted content that existed on the endpoint at the
+	// specified point in time and removes content created since then. If any
+	// paths are specified, then restoration is restricted to those paths and
+	// their subtrees. Any file content replaced or removed by restoration is
+	// itself snapshotted first. It returns the restored paths, a list of
+	// non-fatal problems encountered during restoration, and any error that
+	// occurred while trying to perform the restoration operation. If snapshots
+	// are disabled on the endpoint, then no content is restored.
+	Restore(before time.Time, paths []string) ([]string, []*core.Problem, error)
+
 	// Shutdown terminates any resources associated with the endpoint. For local
 	// endpoints, Shutdown will not preempt calls, but for remote endpoints it
 	// will because it closes the underlying connection to the endpoint
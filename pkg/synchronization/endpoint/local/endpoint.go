@@ -36,6 +36,10 @@ const (
 	watchPollScanSignalCoalescingWindow = 10 * time.Millisecond
 )
 
+// newRecursiveWatcher is the constructor used to create recursive watchers. It
+// is a variable so that tests can simulate watch establishment failures.
+var newRecursiveWatcher = watching.NewRecursiveWatcher
+
 // reifiedWatchMode describes a fully reified watch mode based on the watch mode
 // specified for the endpoint and the availability of modes on the system.
 type reifiedWatchMode uint8
@@ -65,12 +69,22 @@ type endpoint struct {
 	// maximumEntryCount is the maximum number of entries that the endpoint will
 	// synchronize. This field is static and thus safe for concurrent reads.
 	maximumEntryCount uint64
-	// watchMode indicates the watch mode being used. This field is static and
-	// thus safe for concurrent reads.
+	// watchMode indicates the watch mode being used. It may be downgraded from
+	// recursive to poll-based watching by the watching Goroutine if recursive
+	// watching reaches a system watch limit.
 	watchMode reifiedWatchMode
 	// accelerationAllowed indicates whether or not scan acceleration is
 	// allowed. This field is static and thus safe for concurrent reads.
 	accelerationAllowed bool
+	// trustBaselines indicates whether or not scan baselines should be
+	// persisted to disk and trusted to accelerate the first full scan after
+	// endpoint creation. If true, then accelerationAllowed will also be true.
+	// This field is static and thus safe for concurrent reads.
+	trustBaselines bool
+	// scanParallelism is the number of workers used to traverse directories and
+	// compute file digests when scanning. This field is static and thus safe
+	// for concurrent reads.
+	scanParallelism int
 	// probeMode is the probe mode. This field is static and thus safe for
 	// concurrent reads.
 	probeMode behavior.ProbeMode
@@ -80,9 +94,24 @@ type endpoint struct {
 	// ignores are the path ignore specifications. This field is static and thus
 	// safe for concurrent reads.
 	ignores []string
+	// ignoreFiles are the names of per-directory ignore files. This field is
+	// static and thus safe for concurrent reads.
+	ignoreFiles []string
+	// includes are the include paths limiting synchronization. This field is
+	// static and thus safe for concurrent reads.
+	includes []string
 	// permissionsMode is the permissions mode. This field is static and thus
 	// safe for concurrent reads.
 	permissionsMode core.PermissionsMode
+	// modificationTimeMode is the modification time mode. This field is static
+	// and thus safe for concurrent reads.
+	modificationTimeMode core.ModificationTimeMode
+	// hardLinkMode is the hard link mode. This field is static and thus safe
+	// for concurrent reads.
+	hardLinkMode core.HardLinkMode
+	// extendedAttributes are the extended attribute name patterns. This field
+	// is static and thus safe for concurrent reads.
+	extendedAttributes []string
 	// defaultFileMode is the default file permission mode to use in "portable"
 	// permission propagation. This field is static and thus safe for concurrent
 	// reads.
@@ -125,11 +154,12 @@ type endpoint struct {
 	// timer-based signal)). This field is static and never closed, and is thus
 	// safe for concurrent send operations.
 	recursiveWatchRetryEstablish chan struct{}
-	// scanLock serializes access to accelerate, recheckPaths, snapshot, hasher,
-	// cache, ignoreCache, cacheWriteError, and lastScanEntryCount. This lock is
-	// not necessitated by the Endpoint interface (which doesn't permit
-	// concurrent usage), but rather the endpoint's background worker Goroutines
-	// for cache saving and filesystem watching. This lock also notably excludes
+	// scanLock serializes access to watchMode, accelerate, recheckPaths,
+	// snapshot, trustedBaseline, staleBaselinePaths, cache, ignoreCache,
+	// cacheWriteError, and lastScanEntryCount. This lock is not
+	// necessitated by the Endpoint interface (which doesn't permit concurrent
+	// usage), but rather the endpoint's background worker Goroutines for cache
+	// saving and filesystem watching. This lock also notably excludes
 	// coverage of scannedSinceLastStageCall, scannedSinceLastTransitionCall,
 	// lastReturnedScanCache, lastReturnedScanSnapshotDecomposesUnicode, which
 	// are only updated by Scan and read by Stage and Transition, thus making
@@ -144,8 +174,20 @@ type endpoint struct {
 	recheckPaths map[string]bool
 	// snapshot is the snapshot from the last scan.
 	snapshot *core.Snapshot
-	// hasher is the hasher used for scans.
-	hasher hash.Hash
+	// trustedBaseline is the baseline snapshot loaded from disk when the
+	// endpoint was created, if any. It is used (and then cleared) by the first
+	// full scan performed by the endpoint. It will only be non-nil if
+	// trustBaselines is true.
+	trustedBaseline *core.Snapshot
+	// staleBaselinePaths is the set of paths at which watching events have been
+	// observed since the last successful scan. Since snapshot doesn't reflect
+	// changes at these paths, directory metadata for these paths (and their
+	// parent directories) isn't persisted alongside it. It is only tracked if
+	// trustBaselines is true.
+	staleBaselinePaths map[string]bool
+	// newHasher creates the hashers used for scans. This field is static and
+	// thus safe for concurrent reads.
+	newHasher func() hash.Hash
 	// cache is the cache from the last successful scan on the endpoint.
 	cache *core.Cache
 	// ignoreCache is the ignore cache from the last successful scan on the
@@ -177,6 +219,12 @@ type endpoint struct {
 	// stager will only be used in at most one of Stage or Transition methods at
 	// any given time.
 	stager *stager
+	// snapshotStore is the snapshot store used to preserve content displaced by
+	// transitions. It is nil if snapshots are disabled. It is not safe for
+	// concurrent usage, but since Endpoint doesn't allow concurrent usage, we
+	// know that it will only be used in at most one of Transition or Restore
+	// at any given time.
+	snapshotStore *snapshotStore
 }
 
 // NewEndpoint creates a new local endpoint instance using the specified session
@@ -240,7 +288,19 @@ func NewEndpoint(
 	if scanMode.IsDefault() {
 		scanMode = version.DefaultScanMode()
 	}
-	accelerationAllowed := scanMode == synchronization.ScanMode_ScanModeAccelerated
+	trustBaselines := scanMode == synchronization.ScanMode_ScanModeTrusted
+	accelerationAllowed := scanMode == synchronization.ScanMode_ScanModeAccelerated || trustBaselines
+
+	// Compute the effective scan parallelism. We clamp the result since the
+	// default may exceed the maximum on very large systems and since endpoints
+	// shouldn't rely on callers having validated the configuration.
+	scanParallelism := configuration.ScanParallelism
+	if scanParallelism == 0 {
+		scanParallelism = version.DefaultScanParallelism()
+	}
+	if scanParallelism > synchronization.MaximumScanParallelism {
+		scanParallelism = synchronization.MaximumScanParallelism
+	}
 
 	// Compute the effective probe mode.
 	probeMode := configuration.ProbeMode
@@ -280,6 +340,18 @@ func NewEndpoint(
 		permissionsMode = version.DefaultPermissionsMode()
 	}
 
+	// Compute the effective modification time mode.
+	modificationTimeMode := configuration.ModificationTimeMode
+	if modificationTimeMode.IsDefault() {
+		modificationTimeMode = version.DefaultModificationTimeMode()
+	}
+
+	// Compute the effective hard link mode.
+	hardLinkMode := configuration.HardLinkMode
+	if hardLinkMode.IsDefault() {
+		hardLinkMode = version.DefaultHardLinkMode()
+	}
+
 	// Compute the effective default file mode.
 	defaultFileMode := filesystem.Mode(configuration.DefaultFileMode)
 	if defaultFileMode == 0 {
@@ -319,6 +391,18 @@ func NewEndpoint(
 		return nil, fmt.Errorf("unable to create ownership specification: %w", err)
 	}
 
+	// Restore any files whose in-place updates were interrupted (e.g. by a
+	// crash), since they'd otherwise be seen as modified by scanning. Journals
+	// that can't be used are left in place (and reported) so that the original
+	// content isn't lost.
+	restored, problems := core.RecoverInPlaceUpdates(root)
+	for _, path := range restored {
+		logger.Infof("Restored file after interrupted in-place update: \"%s\"", path)
+	}
+	for _, problem := range problems {
+		logger.Warnf("Unable to restore file after interrupted in-place update (\"%s\"): %s", problem.Path, problem.Error)
+	}
+
 	// Compute the cache path if this isn't an ephemeral endpoint.
 	cachePath, err := pathForCache(sessionIdentifier, alpha)
 	if err != nil {
@@ -336,6 +420,24 @@ func NewEndpoint(
 		cache = &core.Cache{}
 	}
 
+	// If baselines are trusted, then compute the baseline path and load any
+	// existing baseline. If it fails to load or validate, then just ignore it.
+	// We don't need to verify that the baseline corresponds to the cache,
+	// because baselines are always saved before their corresponding caches and
+	// the scan process validates trusted baselines against the cache.
+	var baselinePath string
+	var trustedBaseline *core.Snapshot
+	if trustBaselines {
+		baselinePath, err = pathForBaseline(sessionIdentifier, alpha)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute/create baseline path: %w", err)
+		}
+		baseline := &core.Snapshot{}
+		if encoding.LoadAndUnmarshalProtobuf(baselinePath, baseline) == nil && baseline.EnsureValid() == nil {
+			trustedBaseline = baseline
+		}
+	}
+
 	// Check if this endpoint is running inside a sidecar container and, if so,
 	// whether or not the root exists beneath a volume mount point (which it
 	// almost certainly does, but that's not guaranteed). We track the latter
@@ -401,6 +503,20 @@ func NewEndpoint(
 		}
 	}
 
+	// Create the snapshot store if snapshots are enabled.
+	var snapshotStore *snapshotStore
+	if configuration.MaximumSnapshotSize != 0 {
+		snapshotStoreRoot, err := pathForSnapshotStore(sessionIdentifier, alpha)
+		if err != nil {
+			return nil, fmt.Errorf("unable to compute snapshot store path: %w", err)
+		}
+		snapshotStore = newSnapshotStore(
+			snapshotStoreRoot,
+			configuration.MaximumSnapshotSize,
+			version.Hasher(),
+		)
+	}
+
 	// Create a cancellable context in which the endpoint's background worker
 	// Goroutines will operate.
 	workerCtx, workerCancel := context.WithCancel(context.Background())
@@ -420,10 +536,17 @@ func NewEndpoint(
 		maximumEntryCount:            maximumEntryCount,
 		watchMode:                    actualWatchMode,
 		accelerationAllowed:          accelerationAllowed,
+		trustBaselines:               trustBaselines,
+		scanParallelism:              int(scanParallelism),
 		probeMode:                    probeMode,
 		symbolicLinkMode:             symbolicLinkMode,
 		ignores:                      ignores,
+		ignoreFiles:                  configuration.IgnoreFiles,
+		includes:                     configuration.Includes,
 		permissionsMode:              permissionsMode,
+		modificationTimeMode:         modificationTimeMode,
+		hardLinkMode:                 hardLinkMode,
+		extendedAttributes:           configuration.ExtendedAttributes,
 		defaultFileMode:              defaultFileMode,
 		defaultDirectoryMode:         defaultDirectoryMode,
 		defaultOwnership:             defaultOwnership,
@@ -433,19 +556,23 @@ func NewEndpoint(
 		watchDone:                    watchDone,
 		pollSignal:                   state.NewCoalescer(pollSignalCoalescingWindow),
 		recursiveWatchRetryEstablish: make(chan struct{}),
-		hasher:                       version.Hasher(),
+		trustedBaseline:              trustedBaseline,
+		newHasher:                    version.Hasher,
 		cache:                        cache,
 		stager: newStager(
 			stagingRoot,
 			hideStagingRoot,
 			version.Hasher(),
 			maximumStagingFileSize,
+			root,
+			configuration.InPlaceUpdateThreshold,
 		),
+		snapshotStore: snapshotStore,
 	}
 
 	// Start the cache saving Goroutine.
 	go func() {
-		endpoint.saveCache(workerCtx, cachePath, saveCacheSignal)
+		endpoint.saveCache(workerCtx, cachePath, baselinePath, saveCacheSignal)
 		close(saveCacheDone)
 	}()
 
@@ -469,9 +596,37 @@ func NewEndpoint(
 	return endpoint, nil
 }
 
-// saveCache serializes the cache and writes the result to disk at regular
+// persistCache serializes the cache and writes the result to disk. If
+// baselinePath is non-empty, then the snapshot corresponding to the cache is
+// written to disk first, that way a persisted cache is never newer than the
+// persisted baseline (which would allow outdated baseline contents to be
+// trusted). In that case, directory metadata for any stale baseline paths is
+// omitted from the persisted cache. The caller must hold the scan lock.
+func (e *endpoint) persistCache(cachePath, baselinePath string) error {
+	// Save the baseline, if necessary, and determine which cache to persist.
+	cache := e.cache
+	if baselinePath != "" && e.snapshot != nil {
+		if err := encoding.MarshalAndSaveProtobuf(baselinePath, e.snapshot); err != nil {
+			return fmt.Errorf("unable to save baseline: %w", err)
+		}
+		if len(e.staleBaselinePaths) > 0 {
+			cache = cache.WithoutDirectories(e.staleBaselinePaths)
+		}
+	}
+
+	// Save the cache.
+	if err := encoding.MarshalAndSaveProtobuf(cachePath, cache); err != nil {
+		return fmt.Errorf("unable to save cache: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// saveCache serializes the cache (and, if baselinePath is non-empty, the
+// corresponding baseline snapshot) and writes the result to disk at regular
 // intervals. It runs as a background Goroutine for all endpoints.
-func (e *endpoint) saveCache(ctx context.Context, cachePath string, signal <-chan struct{}) {
+func (e *endpoint) saveCache(ctx context.Context, cachePath, baselinePath string, signal <-chan struct{}) {
 	// Track the last saved cache. If it hasn't changed, there's no point in
 	// rewriting it. It's safe to keep a reference to the cache since caches are
 	// treated as immutable. The only cost is (possibly) keeping an old cache
@@ -487,6 +642,19 @@ func (e *endpoint) saveCache(ctx context.Context, cachePath string, signal <-cha
 	for {
 		select {
 		case <-ctx.Done():
+			// If baselines are being persisted, then perform a final save
+			// (regardless of interval) so that the persisted baseline is as
+			// recent as possible when the endpoint is next created.
+			if baselinePath != "" {
+				e.scanLock.Lock()
+				if e.cache != lastSavedCache || len(e.staleBaselinePaths) > 0 {
+					e.logger.Debug("Saving cache and baseline to disk")
+					if err := e.persistCache(cachePath, baselinePath); err != nil {
+						e.logger.Warn("Final cache save failed:", err)
+					}
+				}
+				e.scanLock.Unlock()
+			}
 			return
 		case <-signal:
 			// If it's been less than our minimum cache save interval, then skip
@@ -508,7 +676,7 @@ func (e *endpoint) saveCache(ctx context.Context, cachePath string, signal <-cha
 
 			// Save the cache.
 			e.logger.Debug("Saving cache to disk")
-			if err := encoding.MarshalAndSaveProtobuf(cachePath, e.cache); err != nil {
+			if err := e.persistCache(cachePath, baselinePath); err != nil {
 				e.logger.Error("Cache save failed:", err)
 				e.cacheWriteError = err
 				e.scanLock.Unlock()
@@ -683,7 +851,7 @@ func (e *endpoint) watchPoll(ctx context.Context, pollingInterval uint32, nonRec
 		// strobe the poll events channel. The controller can then perform a
 		// full scan.
 		logger.Debug("Performing filesystem scan")
-		if err := e.scan(ctx, nil, nil); err != nil {
+		if err := e.fullScan(ctx); err != nil {
 			// Log the error.
 			logger.Debug("Scan failed:", err)
 
@@ -776,8 +944,16 @@ WatchEstablishment:
 	for {
 		// Attempt to establish the watch.
 		logger.Debug("Attempting to establish recursive watch")
-		watcher, err = watching.NewRecursiveWatcher(e.root)
+		watcher, err = newRecursiveWatcher(e.root)
 		if err != nil {
+			// If the failure is due to a watch limit, then retrying will just
+			// walk the entire hierarchy again only to fail in the same way, so
+			// fall back to poll-based watching instead.
+			if errors.Is(err, watching.ErrWatchLimitReached) {
+				e.fallBackToPolling(ctx, logger, pollingInterval, err)
+				return
+			}
+
 			// Log the failure.
 			logger.Debug("Unable to establish recursive watch:", err)
 
@@ -838,7 +1014,7 @@ WatchEstablishment:
 
 				// Attempt to perform a baseline scan to enable acceleration.
 				e.scanLock.Lock()
-				if err := e.scan(ctx, nil, nil); err != nil {
+				if err := e.fullScan(ctx); err != nil {
 					logger.Debug("Unable to perform baseline scan:", err)
 					timer.Reset(pollingDuration)
 				} else {
@@ -877,6 +1053,14 @@ WatchEstablishment:
 				watcher.Terminate()
 				watcher = nil
 
+				// If the watcher failed due to a watch limit (e.g. because a
+				// newly created directory pushed it over the limit), then fall
+				// back to poll-based watching for the same reasons as above.
+				if errors.Is(err, watching.ErrWatchLimitReached) {
+					e.fallBackToPolling(ctx, logger, pollingInterval, err)
+					return
+				}
+
 				// If the watcher failed due to an internal event overflow, then
 				// events are likely happening on disk faster than we can
 				// process them. In that case, wait one polling interval before
@@ -900,8 +1084,11 @@ WatchEstablishment:
 				// check the entire path for a temporary prefix to identify
 				// temporary directories (whose contents may have non-temporary
 				// names, such as in the case of internal staging directories).
+				// We also filter paths that lie outside of any include paths,
+				// since they'll never be traversed during scanning.
 				ignore := strings.HasPrefix(path, filesystem.TemporaryNamePrefix) ||
-					strings.HasPrefix(core.PathBase(path), filesystem.TemporaryNamePrefix)
+					strings.HasPrefix(core.PathBase(path), filesystem.TemporaryNamePrefix) ||
+					!core.IncludeRelevant(e.includes, path)
 				if ignore {
 					logger.Tracef("Ignoring event path: \"%s\"", path)
 					continue
@@ -919,6 +1106,12 @@ WatchEstablishment:
 					if e.accelerate {
 						e.recheckPaths[path] = true
 					}
+					if e.trustBaselines {
+						if e.staleBaselinePaths == nil {
+							e.staleBaselinePaths = make(map[string]bool)
+						}
+						e.staleBaselinePaths[path] = true
+					}
 					e.scanLock.Unlock()
 				}
 
@@ -929,6 +1122,26 @@ WatchEstablishment:
 	}
 }
 
+// fallBackToPolling switches the endpoint from recursive to poll-based
+// watching after recursive watching has failed due to a watch limit and then
+// runs the poll-based watch loop until cancellation. Non-recursive watching is
+// still allowed since its watch count is bounded.
+func (e *endpoint) fallBackToPolling(ctx context.Context, logger *logging.Logger, pollingInterval uint32, err error) {
+	// Warn about the fallback, since it's something that the user can correct.
+	logger.Warn("Recursive watch limit reached (consider raising fs.inotify.max_user_watches), falling back to polling:", err)
+
+	// Switch the watch mode and reset the recursive watching acceleration
+	// state, which isn't used by poll-based watching.
+	e.scanLock.Lock()
+	e.watchMode = reifiedWatchModePoll
+	e.accelerate = false
+	e.recheckPaths = nil
+	e.scanLock.Unlock()
+
+	// Perform poll-based watching.
+	e.watchPoll(ctx, pollingInterval, true)
+}
+
 // Poll implements the Poll method for local endpoints.
 func (e *endpoint) Poll(ctx context.Context) error {
 	// Wait for either cancellation or an event.
@@ -943,17 +1156,23 @@ func (e *endpoint) Poll(ctx context.Context) error {
 
 // scan is the internal function which performs a scan operation on the root and
 // updates the endpoint scan parameters. The caller must hold the scan lock.
-func (e *endpoint) scan(ctx context.Context, baseline *core.Snapshot, recheckPaths map[string]bool) error {
+func (e *endpoint) scan(ctx context.Context, baseline *core.Snapshot, recheckPaths map[string]bool, trustedBaseline bool) error {
 	// Perform a full (warm) scan, watching for errors.
 	snapshot, newCache, newIgnoreCache, err := core.Scan(
 		ctx,
 		e.root,
-		baseline, recheckPaths,
-		e.hasher, e.cache,
+		baseline, recheckPaths, trustedBaseline,
+		e.newHasher, e.cache,
 		e.ignores, e.ignoreCache,
+		e.ignoreFiles,
+		e.includes,
 		e.probeMode,
 		e.symbolicLinkMode,
 		e.permissionsMode,
+		e.modificationTimeMode,
+		e.hardLinkMode,
+		e.extendedAttributes,
+		e.scanParallelism,
 	)
 	if err != nil {
 		return err
@@ -969,6 +1188,10 @@ func (e *endpoint) scan(ctx context.Context, baseline *core.Snapshot, recheckPat
 	// Update the last scan entry count.
 	e.lastScanEntryCount = snapshot.Content.Count()
 
+	// Since the scan reflects any changes seen before it started, the new
+	// snapshot can't be stale.
+	e.staleBaselinePaths = nil
+
 	// Trigger an asynchronous cache save operation.
 	select {
 	case e.saveCacheSignal <- struct{}{}:
@@ -979,6 +1202,22 @@ func (e *endpoint) scan(ctx context.Context, baseline *core.Snapshot, recheckPat
 	return nil
 }
 
+// fullScan performs a full scan operation, using the trusted baseline (if any)
+// to accelerate the scan. The trusted baseline is only used once, regardless of
+// outcome, since subsequent scans will use more recent baselines. The caller
+// must hold the scan lock.
+func (e *endpoint) fullScan(ctx context.Context) error {
+	// If a trusted baseline is available, then use it.
+	if baseline := e.trustedBaseline; baseline != nil {
+		e.trustedBaseline = nil
+		e.logger.Debug("Using trusted baseline")
+		return e.scan(ctx, baseline, nil, true)
+	}
+
+	// Otherwise perform a standard full scan.
+	return e.scan(ctx, nil, nil, false)
+}
+
 // Scan implements the Scan method for local endpoints.
 func (e *endpoint) Scan(ctx context.Context, _ *core.Entry, full bool) (*core.Snapshot, error, bool) {
 	// Grab the scan lock and defer its release.
@@ -1014,7 +1253,7 @@ func (e *endpoint) Scan(ctx context.Context, _ *core.Entry, full bool) (*core.Sn
 	if e.accelerate && !full {
 		if e.watchMode == reifiedWatchModeRecursive {
 			e.logger.Debug("Performing accelerated scan with", len(e.recheckPaths), "recheck paths")
-			if err := e.scan(ctx, e.snapshot, e.recheckPaths); err != nil {
+			if err := e.scan(ctx, e.snapshot, e.recheckPaths, false); err != nil {
 				return nil, err, true
 			} else {
 				e.recheckPaths = make(map[string]bool)
@@ -1022,9 +1261,15 @@ func (e *endpoint) Scan(ctx context.Context, _ *core.Entry, full bool) (*core.Sn
 		} else {
 			e.logger.Debug("Performing accelerated scan with existing snapshot")
 		}
+	} else if full {
+		e.logger.Debug("Performing full scan")
+		e.trustedBaseline = nil
+		if err := e.scan(ctx, nil, nil, false); err != nil {
+			return nil, err, true
+		}
 	} else {
 		e.logger.Debug("Performing full scan")
-		if err := e.scan(ctx, nil, nil); err != nil {
+		if err := e.fullScan(ctx); err != nil {
 			return nil, err, true
 		}
 	}
@@ -1087,8 +1332,7 @@ func (e *endpoint) stageFromRoot(
 	}
 
 	// Ensure that everything staged correctly.
-	_, err = e.stager.Provide(path, digest)
-	return err == nil
+	return e.stager.contains(path, digest)
 }
 
 // Stage implements the Stage method for local endpoints.
@@ -1155,7 +1399,7 @@ func (e *endpoint) Stage(paths []string, digests [][]byte) ([]string, []*rsync.S
 	filteredPaths := paths[:0]
 	for p, path := range paths {
 		digest := digests[p]
-		if _, err := e.stager.Provide(path, digest); err == nil {
+		if e.stager.contains(path, digest) {
 			continue
 		} else if e.stageFromRoot(path, digest, reverseLookupMap, opener) {
 			continue
@@ -1260,6 +1504,16 @@ func (e *endpoint) Transition(ctx context.Context, transitions []*core.Change) (
 	// because these aren't updated concurrently and thus don't fall under the
 	// scope of the scan lock.
 	e.scanLock.Unlock()
+
+	// If snapshots are enabled, then preserve any content that the transition
+	// will displace. This is a best-effort operation.
+	if e.snapshotStore != nil {
+		if err := e.snapshotStore.preserve(e.root, transitions, e.lastReturnedScanCache); err != nil {
+			e.logger.Warn("Unable to preserve displaced content:", err)
+		}
+	}
+
+	// Perform the transition.
 	results, problems, stagerMissingFiles := core.Transition(
 		ctx,
 		e.root,
@@ -1274,10 +1528,15 @@ func (e *endpoint) Transition(ctx context.Context, transitions []*core.Change) (
 	)
 	e.scanLock.Lock()
 
-	// Determine whether or not the transition made any changes on disk.
+	// Determine whether or not the transition made any changes on disk. Since
+	// entry comparison doesn't include extended attributes, we check those
+	// separately, though only at the transition root, since that's the only
+	// location where metadata-only updates occur.
 	var transitionMadeChanges bool
 	for r, result := range results {
-		if !result.Equal(transitions[r].Old, true) {
+		old := transitions[r].Old
+		if !result.Equal(old, true) ||
+			!core.ExtendedAttributesEqual(result.GetExtendedAttributes(), old.GetExtendedAttributes()) {
 			transitionMadeChanges = true
 			break
 		}
@@ -1365,6 +1624,55 @@ func (e *endpoint) Transition(ctx context.Context, transitions []*core.Change) (
 	return results, problems, stagerMissingFiles, nil
 }
 
+// Restore implements the Restore method for local endpoints.
+func (e *endpoint) Restore(before time.Time, paths []string) ([]string, []*core.Problem, error) {
+	// If snapshots are disabled, then there's nothing to restore.
+	if e.snapshotStore == nil {
+		return nil, nil, nil
+	}
+
+	// Perform the restoration.
+	restored, problems, err := e.snapshotStore.restore(
+		e.root,
+		before,
+		paths,
+		e.defaultFileMode,
+		e.defaultDirectoryMode,
+		e.defaultOwnership,
+	)
+	if err != nil {
+		e.logger.Warn("Unable to persist snapshot index after restoration:", err)
+	}
+
+	// If nothing was restored, then there's no need to update scan state.
+	if len(restored) == 0 {
+		return restored, problems, nil
+	}
+
+	// Ensure that accelerated scanning doesn't return a stale (pre-restoration)
+	// snapshot, using the same strategy as Transition.
+	e.scanLock.Lock()
+	if e.accelerate {
+		if e.watchMode == reifiedWatchModePoll {
+			e.accelerate = false
+		} else if e.watchMode == reifiedWatchModeRecursive {
+			for _, path := range restored {
+				e.recheckPaths[path] = true
+			}
+		}
+	}
+	polling := e.watchMode == reifiedWatchModePoll
+	e.scanLock.Unlock()
+
+	// If we're using poll-based watching, then strobe the poll signal.
+	if polling {
+		e.pollSignal.Strobe()
+	}
+
+	// Done.
+	return restored, problems, nil
+}
+
 // Shutdown implements the Shutdown method for local endpoints.
 func (e *endpoint) Shutdown() error {
 	// Signal background worker Goroutines to terminate.
@@ -1,3 +1,79 @@
 package local
 
-// TODO: Implement.
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem/watching"
+	"github.com/mutagen-io/mutagen/pkg/logging"
+	"github.com/mutagen-io/mutagen/pkg/synchronization"
+)
+
+// TestWatchRecursiveWatchLimitFallback tests that recursive watching falls back
+// to poll-based watching (rather than repeatedly retrying establishment) when a
+// watch limit is reached.
+func TestWatchRecursiveWatchLimitFallback(t *testing.T) {
+	// Skip this test on platforms without recursive watching, since portable
+	// watching won't use recursive watching there.
+	if !watching.RecursiveWatchingSupported {
+		t.Skip("recursive watching not supported")
+	}
+
+	// Isolate the Mutagen data directory.
+	t.Setenv("MUTAGEN_DATA_DIRECTORY", t.TempDir())
+
+	// Simulate recursive watch establishment failing due to a watch limit,
+	// tracking the number of establishment attempts.
+	var attempts int32
+	defer func(original func(string) (watching.RecursiveWatcher, error)) {
+		newRecursiveWatcher = original
+	}(newRecursiveWatcher)
+	newRecursiveWatcher = func(_ string) (watching.RecursiveWatcher, error) {
+		atomic.AddInt32(&attempts, 1)
+		return nil, fmt.Errorf("%w after establishing 0 watches", watching.ErrWatchLimitReached)
+	}
+
+	// Create the endpoint and defer its shutdown.
+	configuration := &synchronization.Configuration{
+		WatchMode:            synchronization.WatchMode_WatchModePortable,
+		WatchPollingInterval: 1,
+	}
+	e, err := NewEndpoint(
+		logging.NewLogger(logging.LevelDisabled, io.Discard),
+		t.TempDir(),
+		"session",
+		synchronization.Version_Version1,
+		configuration,
+		true,
+	)
+	if err != nil {
+		t.Fatal("unable to create endpoint:", err)
+	}
+	defer e.Shutdown()
+	endpoint := e.(*endpoint)
+
+	// Wait for the endpoint to switch to poll-based watching and for polling
+	// to complete its baseline scan.
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		endpoint.scanLock.Lock()
+		polling := endpoint.watchMode == reifiedWatchModePoll && endpoint.accelerate
+		endpoint.scanLock.Unlock()
+		if polling {
+			break
+		} else if time.Now().After(deadline) {
+			t.Fatal("endpoint did not fall back to polling")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// Wait for several polling intervals and ensure that watch establishment
+	// wasn't retried.
+	time.Sleep(2500 * time.Millisecond)
+	if a := atomic.LoadInt32(&attempts); a != 1 {
+		t.Error("unexpected number of watch establishment attempts:", a)
+	}
+}
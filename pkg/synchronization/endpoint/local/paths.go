@@ -16,6 +16,10 @@ const (
 	// betaName is the name to use for beta when distinguishing endpoints.
 	betaName = "beta"
 
+	// baselineSuffix is the suffix added to cache paths to compute baseline
+	// snapshot paths.
+	baselineSuffix = "_baseline"
+
 	// stagingPrefixLength is the byte length to use for prefix directories when
 	// load-balancing staged files.
 	stagingPrefixLength = 1
@@ -43,6 +47,20 @@ func pathForCache(session string, alpha bool) (string, error) {
 	return filepath.Join(cachesDirectoryPath, cacheName), nil
 }
 
+// pathForBaseline computes the path to the serialized baseline snapshot for the
+// given session identifier and endpoint role. Baselines are stored alongside
+// caches so that they're subject to the same housekeeping.
+func pathForBaseline(session string, alpha bool) (string, error) {
+	// Compute the cache path.
+	cachePath, err := pathForCache(session, alpha)
+	if err != nil {
+		return "", err
+	}
+
+	// Success.
+	return cachePath + baselineSuffix, nil
+}
+
 // pathForMutagenStagingRoot computes the path to the staging root in the
 // Mutagen data directory for the given session identifier and endpoint. It
 // ensures that staging subdirectory of the Mutagen data directory exists, but
@@ -68,6 +86,30 @@ func pathForMutagenStagingRoot(session string, alpha bool) (string, error) {
 	return filepath.Join(stagingDataPath, stagingRootName), nil
 }
 
+// pathForSnapshotStore computes the path to the snapshot store in the Mutagen
+// data directory for the given session identifier and endpoint. It ensures that
+// the snapshots subdirectory of the Mutagen data directory exists, but it does
+// not create the snapshot store itself.
+func pathForSnapshotStore(session string, alpha bool) (string, error) {
+	// Compute the path to the snapshot store parent and ensure that it exists.
+	snapshotsDataPath, err := filesystem.Mutagen(true, filesystem.MutagenSynchronizationSnapshotsDirectoryName)
+	if err != nil {
+		return "", fmt.Errorf("unable to create snapshots data directory: %w", err)
+	}
+
+	// Compute the endpoint name.
+	endpointName := alphaName
+	if !alpha {
+		endpointName = betaName
+	}
+
+	// Compute the snapshot store name.
+	snapshotStoreName := fmt.Sprintf("%s-%s", session, endpointName)
+
+	// Compute the combined path.
+	return filepath.Join(snapshotsDataPath, snapshotStoreName), nil
+}
+
 // pathForNeighboringStagingRoot computes the path to the staging root which
 // neighbors the synchronization root for the given root, session identifier,
 // and endpoint. It does not create the directory or any parent directories.
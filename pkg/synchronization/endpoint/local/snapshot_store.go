@@ -0,0 +1,615 @@
+package local
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/mutagen-io/mutagen/pkg/encoding"
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+const (
+	// snapshotIndexName is the name of the index file within a snapshot store.
+	snapshotIndexName = "index"
+	// snapshotTemporaryNamePrefix is the file name prefix to use for
+	// intermediate temporary files used when preserving or restoring content.
+	snapshotTemporaryNamePrefix = filesystem.TemporaryNamePrefix + "snapshot"
+	// maximumSnapshotRecords is the maximum number of records retained in a
+	// snapshot index. Creation records don't reference any content and thus
+	// aren't bounded by the store's maximum size, so this bounds the index size
+	// in the face of large numbers of created paths.
+	maximumSnapshotRecords = 100 * 1024
+)
+
+// EnsureValid ensures that SnapshotRecord's invariants are respected.
+func (r *SnapshotRecord) EnsureValid() error {
+	// A nil record is not valid.
+	if r == nil {
+		return errors.New("nil snapshot record")
+	}
+
+	// Ensure that the digest is non-empty for content records and empty for
+	// creation records.
+	if r.Created && len(r.Digest) != 0 {
+		return errors.New("non-empty digest for creation record")
+	} else if !r.Created && len(r.Digest) == 0 {
+		return errors.New("empty digest")
+	}
+
+	// Ensure that the time is valid.
+	if err := r.Time.CheckValid(); err != nil {
+		return fmt.Errorf("invalid time: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid ensures that SnapshotIndex's invariants are respected.
+func (i *SnapshotIndex) EnsureValid() error {
+	// A nil index is not valid.
+	if i == nil {
+		return errors.New("nil snapshot index")
+	}
+
+	// Validate records.
+	for _, record := range i.Records {
+		if err := record.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid record: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// snapshotStore is a bounded, content-addressable store that retains file
+// contents replaced or removed by transition operations so that they can later
+// be restored. Contents are stored once per digest and referenced by records
+// in a persistent index. The index also records the paths created by
+// transition operations so that restoration can remove content that didn't
+// exist at the restoration time. It is not safe for concurrent access.
+type snapshotStore struct {
+	// root is the snapshot store root path.
+	root string
+	// maximumSize is the maximum total size of content retained in the store.
+	maximumSize uint64
+	// hasher is the hash function to use when verifying preserved content.
+	hasher hash.Hash
+	// index is the snapshot index.
+	index *SnapshotIndex
+}
+
+// newSnapshotStore creates a new snapshot store rooted at the specified path,
+// loading any existing index. If the existing index fails to load or validate,
+// then the store is treated as empty. If the store already exists, then its
+// modification time is updated to indicate to housekeeping that it's in use.
+func newSnapshotStore(root string, maximumSize uint64, hasher hash.Hash) *snapshotStore {
+	// Mark the store as in use. We ignore failures here since the store may not
+	// exist yet and since this is only an input to housekeeping.
+	now := time.Now()
+	os.Chtimes(root, now, now)
+
+	// Load any existing index, replacing it with an empty one on failure.
+	index := &SnapshotIndex{}
+	if encoding.LoadAndUnmarshalProtobuf(filepath.Join(root, snapshotIndexName), index) != nil {
+		index = &SnapshotIndex{}
+	} else if index.EnsureValid() != nil {
+		index = &SnapshotIndex{}
+	}
+
+	// Create the store.
+	return &snapshotStore{
+		root:        root,
+		maximumSize: maximumSize,
+		hasher:      hasher,
+		index:       index,
+	}
+}
+
+// pathForContent computes the storage path for the specified digest.
+func (s *snapshotStore) pathForContent(digest []byte) string {
+	digestHex := hex.EncodeToString(digest)
+	return filepath.Join(s.root, digestHex[:2], digestHex)
+}
+
+// save persists the snapshot index.
+func (s *snapshotStore) save() error {
+	if err := encoding.MarshalAndSaveProtobuf(filepath.Join(s.root, snapshotIndexName), s.index); err != nil {
+		return fmt.Errorf("unable to save snapshot index: %w", err)
+	}
+	return nil
+}
+
+// copyToStorage copies the content at the specified path into temporary
+// storage in the store root, computing its digest. It returns the path to the
+// temporary storage, the content digest, and the content size. The caller is
+// responsible for relocating or removing the temporary storage.
+func (s *snapshotStore) copyToStorage(path string) (string, []byte, uint64, error) {
+	// Open the source file and defer its closure.
+	source, err := os.Open(path)
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("unable to open file: %w", err)
+	}
+	defer source.Close()
+
+	// Create temporary storage in the store root.
+	storage, err := os.CreateTemp(s.root, snapshotTemporaryNamePrefix)
+	if err != nil {
+		return "", nil, 0, fmt.Errorf("unable to create temporary storage file: %w", err)
+	}
+
+	// Copy the content while computing its digest.
+	s.hasher.Reset()
+	size, err := io.Copy(io.MultiWriter(storage, s.hasher), source)
+	if closeErr := storage.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(storage.Name())
+		return "", nil, 0, fmt.Errorf("unable to copy content: %w", err)
+	}
+
+	// Success.
+	return storage.Name(), s.hasher.Sum(nil), uint64(size), nil
+}
+
+// relocate moves temporary storage into place as the content for the
+// specified digest, ensuring that the corresponding prefix directory exists.
+// The temporary storage is removed on failure.
+func (s *snapshotStore) relocate(storage string, digest []byte) error {
+	// Ensure that the prefix directory exists.
+	destination := s.pathForContent(digest)
+	if err := os.MkdirAll(filepath.Dir(destination), 0700); err != nil {
+		os.Remove(storage)
+		return fmt.Errorf("unable to create prefix directory: %w", err)
+	}
+
+	// Relocate the content into place.
+	if err := filesystem.Rename(nil, storage, nil, destination, true); err != nil {
+		os.Remove(storage)
+		return fmt.Errorf("unable to relocate content: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// store copies the content at the specified path into the store, verifying
+// that its digest matches the expected digest. If content with the specified
+// digest already exists in the store, then no copy is performed.
+func (s *snapshotStore) store(path string, digest []byte) error {
+	// If the content already exists, then we're done.
+	if _, err := os.Lstat(s.pathForContent(digest)); err == nil {
+		return nil
+	}
+
+	// Copy the content into temporary storage.
+	storage, actual, _, err := s.copyToStorage(path)
+	if err != nil {
+		return err
+	}
+
+	// Verify that the content wasn't modified since it was last scanned.
+	if !bytes.Equal(actual, digest) {
+		os.Remove(storage)
+		return errors.New("content modified since scan")
+	}
+
+	// Relocate the content into place.
+	return s.relocate(storage, digest)
+}
+
+// displace stores the current content of the file at the specified path and
+// records it as having been displaced at the specified time. Unlike preserve,
+// it doesn't rely on scan information and doesn't bound the content size
+// (though the content will be subject to pruning).
+func (s *snapshotStore) displace(root, path string, executable bool, now *timestamppb.Timestamp) error {
+	// Copy the content into temporary storage and relocate it into place.
+	storage, digest, size, err := s.copyToStorage(filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		return err
+	} else if err = s.relocate(storage, digest); err != nil {
+		return err
+	}
+
+	// Record the content.
+	s.index.Records = append(s.index.Records, &SnapshotRecord{
+		Path:       path,
+		Digest:     digest,
+		Executable: executable,
+		Size:       size,
+		Time:       now,
+	})
+
+	// Success.
+	return nil
+}
+
+// preserve records and stores any file content within the synchronization root
+// that will be displaced by the specified transitions, as well as any paths
+// that the transitions will create. The provided cache is used to skip content
+// that would exceed the store's maximum size. Failures to preserve individual
+// files are not considered errors, since preservation is a best-effort
+// operation, though failures to persist the index are.
+func (s *snapshotStore) preserve(root string, transitions []*core.Change, cache *core.Cache) error {
+	// Ensure that the store root exists.
+	if err := os.MkdirAll(s.root, 0700); err != nil {
+		return fmt.Errorf("unable to create snapshot store: %w", err)
+	}
+
+	// Record the preservation time.
+	now := timestamppb.Now()
+
+	// Preserve displaced content. We record displaced content before created
+	// paths so that, for paths whose content changes kind, the displaced
+	// content is treated as the earlier state.
+	var preserved bool
+	for _, transition := range transitions {
+		for path, entry := range transition.DisplacedFiles() {
+			// Skip content that would exceed the store's maximum size on its
+			// own. If we don't have cached metadata, then we don't know the
+			// size and skip the content as well.
+			cacheEntry, ok := cache.GetEntries()[path]
+			if !ok || cacheEntry.Size > s.maximumSize {
+				continue
+			}
+
+			// Store the content.
+			if s.store(filepath.Join(root, filepath.FromSlash(path)), entry.Digest) != nil {
+				continue
+			}
+
+			// Record the content.
+			s.index.Records = append(s.index.Records, &SnapshotRecord{
+				Path:       path,
+				Digest:     entry.Digest,
+				Executable: entry.Executable,
+				Size:       cacheEntry.Size,
+				Time:       now,
+			})
+			preserved = true
+		}
+	}
+
+	// Record created paths.
+	for _, transition := range transitions {
+		for path := range transition.CreatedEntries() {
+			s.index.Records = append(s.index.Records, &SnapshotRecord{
+				Path:    path,
+				Time:    now,
+				Created: true,
+			})
+			preserved = true
+		}
+	}
+
+	// If nothing was preserved, then there's nothing to prune or persist.
+	if !preserved {
+		return nil
+	}
+
+	// Prune the store to its maximum size and persist the index.
+	s.prune()
+	return s.save()
+}
+
+// prune removes the oldest records from the index until the total size of
+// unique content referenced by the index is within the maximum size and the
+// number of records is within the maximum record count, removing any content
+// that is no longer referenced.
+func (s *snapshotStore) prune() {
+	// Compute reference counts and the total size of unique content.
+	references := make(map[string]int, len(s.index.Records))
+	var total uint64
+	for _, record := range s.index.Records {
+		if record.Created {
+			continue
+		}
+		key := string(record.Digest)
+		if references[key] == 0 {
+			total += record.Size
+		}
+		references[key]++
+	}
+
+	// Remove the oldest records until we're within bounds. Records are appended
+	// in chronological order, so the oldest records are at the front.
+	var removed int
+	for removed < len(s.index.Records) &&
+		(total > s.maximumSize || len(s.index.Records)-removed > maximumSnapshotRecords) {
+		record := s.index.Records[removed]
+		removed++
+		if record.Created {
+			continue
+		}
+		key := string(record.Digest)
+		references[key]--
+		if references[key] == 0 {
+			total -= record.Size
+			os.Remove(s.pathForContent(record.Digest))
+		}
+	}
+	s.index.Records = s.index.Records[removed:]
+}
+
+// restore restores the state that existed at the specified time to any of the
+// specified paths (or their subtrees) within the synchronization root. If no
+// paths are specified, then all recorded paths are eligible. Files that existed
+// at the specified time are restored (replacing any files or symbolic links
+// that have since been created at their paths) and content created after the
+// specified time is removed (with directories only removed if empty). Any file
+// content replaced or removed by restoration is itself preserved in the store
+// first. Parent directories are created as necessary using the specified
+// default directory mode and ownership, and restored files use the specified
+// default file mode (made executable if the original content was executable).
+// It returns the affected paths and any problems encountered. Failure to
+// persist the index is returned as an error.
+func (s *snapshotStore) restore(
+	root string,
+	before time.Time,
+	paths []string,
+	defaultFileMode filesystem.Mode,
+	defaultDirectoryMode filesystem.Mode,
+	defaultOwnership *filesystem.OwnershipSpecification,
+) ([]string, []*core.Problem, error) {
+	// For each eligible path, identify the earliest record at or after the
+	// specified time, which represents the state that existed at that time:
+	// either the file content that was subsequently displaced or the absence of
+	// content if the path was subsequently created.
+	candidates := make(map[string]*SnapshotRecord)
+	for _, record := range s.index.Records {
+		if record.Time.AsTime().Before(before) || !snapshotPathSelected(record.Path, paths) {
+			continue
+		} else if _, ok := candidates[record.Path]; ok {
+			continue
+		}
+		candidates[record.Path] = record
+	}
+
+	// Separate the candidate paths into those requiring removal and those
+	// requiring content restoration. We sort them to ensure that content is
+	// removed depth-first and restored (with parents created) in a
+	// deterministic fashion.
+	var removals, restorations []string
+	for path, record := range candidates {
+		if record.Created {
+			removals = append(removals, path)
+		} else {
+			restorations = append(restorations, path)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(removals)))
+	sort.Strings(restorations)
+
+	// Record the restoration time, which we'll use for records of any content
+	// that restoration displaces.
+	now := timestamppb.Now()
+	recordCount := len(s.index.Records)
+
+	// Remove created content.
+	var restored []string
+	var problems []*core.Problem
+	for _, path := range removals {
+		if removed, err := s.removeCreated(root, path, now); err != nil {
+			problems = append(problems, &core.Problem{Path: path, Error: err.Error()})
+		} else if removed {
+			restored = append(restored, path)
+		}
+	}
+
+	// Restore content.
+	for _, path := range restorations {
+		if err := s.restoreRecord(
+			root, candidates[path], now,
+			defaultFileMode, defaultDirectoryMode, defaultOwnership,
+		); err != nil {
+			problems = append(problems, &core.Problem{Path: path, Error: err.Error()})
+		} else {
+			restored = append(restored, path)
+		}
+	}
+
+	// If restoration displaced any content, then prune the store and persist
+	// the index.
+	if len(s.index.Records) != recordCount {
+		s.prune()
+		if err := s.save(); err != nil {
+			return restored, problems, err
+		}
+	}
+
+	// Done.
+	return restored, problems, nil
+}
+
+// removeCreated removes the content at the specified path, which was created
+// after the restoration time. File content is preserved in the store before
+// removal and directories are only removed if empty. It returns whether or not
+// any content was removed.
+func (s *snapshotStore) removeCreated(root, path string, now *timestamppb.Timestamp) (bool, error) {
+	// Query the target. If it no longer exists, then there's nothing to do.
+	target := filepath.Join(root, filepath.FromSlash(path))
+	metadata, err := os.Lstat(target)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to query target: %w", err)
+	}
+
+	// Preserve file content before removing it. Directories and symbolic links
+	// don't have any content that needs preservation.
+	if mode := metadata.Mode(); mode.IsRegular() {
+		if err := s.displace(root, path, mode&0111 != 0, now); err != nil {
+			return false, fmt.Errorf("unable to preserve existing content: %w", err)
+		}
+	} else if !mode.IsDir() && mode&os.ModeSymlink == 0 {
+		return false, errors.New("unsupported content type at path")
+	}
+
+	// Remove the content.
+	if err := os.Remove(target); err != nil {
+		return false, fmt.Errorf("unable to remove content: %w", err)
+	}
+
+	// Success.
+	return true, nil
+}
+
+// restoreRecord restores the content for a single record. Any file or symbolic
+// link at the target path is replaced (with file content preserved in the store
+// first), but directories are not.
+func (s *snapshotStore) restoreRecord(
+	root string,
+	record *SnapshotRecord,
+	now *timestamppb.Timestamp,
+	defaultFileMode filesystem.Mode,
+	defaultDirectoryMode filesystem.Mode,
+	defaultOwnership *filesystem.OwnershipSpecification,
+) error {
+	// Compute the target path and determine what (if anything) exists there.
+	target := filepath.Join(root, filepath.FromSlash(record.Path))
+	var replace bool
+	if metadata, err := os.Lstat(target); err == nil {
+		if mode := metadata.Mode(); mode.IsDir() {
+			return errors.New("directory exists at path")
+		} else if mode.IsRegular() {
+			if err := s.displace(root, record.Path, mode&0111 != 0, now); err != nil {
+				return fmt.Errorf("unable to preserve existing content: %w", err)
+			}
+		} else if mode&os.ModeSymlink == 0 {
+			return errors.New("unsupported content type at path")
+		}
+		replace = true
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("unable to query target: %w", err)
+	}
+
+	// Ensure that parent directories exist, creating any that are missing.
+	if err := ensureParentDirectoriesExist(root, record.Path, defaultDirectoryMode, defaultOwnership); err != nil {
+		return err
+	}
+
+	// Open the stored content and defer its closure.
+	source, err := os.Open(s.pathForContent(record.Digest))
+	if err != nil {
+		return fmt.Errorf("unable to open snapshotted content: %w", err)
+	}
+	defer source.Close()
+
+	// Create a temporary file alongside the target and copy the content.
+	temporary, err := os.CreateTemp(filepath.Dir(target), snapshotTemporaryNamePrefix)
+	if err != nil {
+		return fmt.Errorf("unable to create temporary file: %w", err)
+	}
+	_, err = io.Copy(temporary, source)
+	if closeErr := temporary.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(temporary.Name())
+		return fmt.Errorf("unable to copy content: %w", err)
+	}
+
+	// Set permissions on the temporary file.
+	mode := defaultFileMode
+	if record.Executable {
+		mode = markExecutableForReaders(mode)
+	}
+	if err := filesystem.SetPermissionsByPath(temporary.Name(), defaultOwnership, mode); err != nil {
+		os.Remove(temporary.Name())
+		return fmt.Errorf("unable to set permissions: %w", err)
+	}
+
+	// Move the file into place. If nothing existed at the target path, then we
+	// avoid replacing anything that may have been created in the meantime.
+	if err := filesystem.Rename(nil, temporary.Name(), nil, target, replace); err != nil {
+		os.Remove(temporary.Name())
+		return fmt.Errorf("unable to move content into place: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureParentDirectoriesExist ensures that all parent directories for the
+// specified root-relative path exist, creating any missing directories with the
+// specified mode and ownership.
+func ensureParentDirectoriesExist(
+	root, path string,
+	mode filesystem.Mode,
+	ownership *filesystem.OwnershipSpecification,
+) error {
+	// Walk down the parent components, creating as necessary.
+	components := strings.Split(path, "/")
+	current := root
+	for _, component := range components[:len(components)-1] {
+		current = filepath.Join(current, component)
+		if metadata, err := os.Lstat(current); err == nil {
+			if !metadata.IsDir() {
+				return errors.New("parent path is not a directory")
+			}
+			continue
+		} else if !os.IsNotExist(err) {
+			return fmt.Errorf("unable to query parent directory: %w", err)
+		}
+		if err := os.Mkdir(current, 0700); err != nil {
+			return fmt.Errorf("unable to create parent directory: %w", err)
+		}
+		if err := filesystem.SetPermissionsByPath(current, ownership, mode); err != nil {
+			return fmt.Errorf("unable to set parent directory permissions: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// snapshotPathSelected determines whether or not a root-relative path is
+// selected by a list of root-relative path filters, where a filter selects both
+// its own path and any paths within its subtree. An empty filter list selects
+// all paths.
+func snapshotPathSelected(path string, filters []string) bool {
+	// An empty filter list selects everything.
+	if len(filters) == 0 {
+		return true
+	}
+
+	// Check each filter.
+	for _, filter := range filters {
+		if filter == "" || path == filter || strings.HasPrefix(path, filter+"/") {
+			return true
+		}
+	}
+
+	// No filter selected the path.
+	return false
+}
+
+// markExecutableForReaders sets the executable bit for the user, group, and
+// others categories in the specified mode if the corresponding read bit is set.
+func markExecutableForReaders(mode filesystem.Mode) filesystem.Mode {
+	if (mode & filesystem.ModePermissionUserRead) != 0 {
+		mode |= filesystem.ModePermissionUserExecute
+	}
+	if (mode & filesystem.ModePermissionGroupRead) != 0 {
+		mode |= filesystem.ModePermissionGroupExecute
+	}
+	if (mode & filesystem.ModePermissionOthersRead) != 0 {
+		mode |= filesystem.ModePermissionOthersExecute
+	}
+	return mode
+}
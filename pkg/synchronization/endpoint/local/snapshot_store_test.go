@@ -0,0 +1,204 @@
+package local
+
+import (
+	"crypto/sha1"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// TestSnapshotPathSelected tests snapshotPathSelected.
+func TestSnapshotPathSelected(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		path     string
+		filters  []string
+		expected bool
+	}{
+		{"file", nil, true},
+		{"file", []string{""}, true},
+		{"file", []string{"file"}, true},
+		{"file", []string{"other"}, false},
+		{"directory/file", []string{"directory"}, true},
+		{"directory/file", []string{"dir"}, false},
+		{"directory2/file", []string{"directory"}, false},
+		{"directory/file", []string{"other", "directory/file"}, true},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if selected := snapshotPathSelected(test.path, test.filters); selected != test.expected {
+			t.Errorf("test index %d: selection does not match expected: %t != %t", i, selected, test.expected)
+		}
+	}
+}
+
+// TestSnapshotStorePreserveAndRestore tests content preservation and
+// restoration using snapshotStore.
+func TestSnapshotStorePreserveAndRestore(t *testing.T) {
+	// Create a synchronization root and a store root.
+	root := t.TempDir()
+	storeRoot := filepath.Join(t.TempDir(), "store")
+
+	// Create a file in the synchronization root and compute its digest.
+	content := []byte("snapshotted content")
+	if err := os.WriteFile(filepath.Join(root, "file"), content, 0600); err != nil {
+		t.Fatal("unable to create test file:", err)
+	}
+	digest := sha1.Sum(content)
+	entry := &core.Entry{Kind: core.EntryKind_File, Digest: digest[:]}
+	cache := &core.Cache{Entries: map[string]*core.CacheEntry{
+		"file": {Size: uint64(len(content)), Digest: digest[:]},
+	}}
+
+	// Create a store and preserve the file as if it were being deleted.
+	store := newSnapshotStore(storeRoot, 1024, sha1.New())
+	transitions := []*core.Change{{Path: "file", Old: entry}}
+	before := time.Now()
+	if err := store.preserve(root, transitions, cache); err != nil {
+		t.Fatal("unable to preserve content:", err)
+	}
+
+	// Verify that the index was persisted and can be reloaded.
+	if reloaded := newSnapshotStore(storeRoot, 1024, sha1.New()); len(reloaded.index.Records) != 1 {
+		t.Fatal("persisted index has unexpected record count:", len(reloaded.index.Records))
+	}
+
+	// Modify the file and verify that restoration replaces it, preserving the
+	// modified content first.
+	modified := []byte("modified content")
+	if err := os.WriteFile(filepath.Join(root, "file"), modified, 0600); err != nil {
+		t.Fatal("unable to modify test file:", err)
+	}
+	restored, problems, err := store.restore(root, before, nil, 0600, 0700, nil)
+	if err != nil {
+		t.Fatal("unable to persist index after restoration:", err)
+	} else if len(problems) != 0 {
+		t.Fatal("restoration encountered problems:", problems[0].Error)
+	} else if len(restored) != 1 || restored[0] != "file" {
+		t.Fatal("restoration did not restore expected path")
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "file")); err != nil {
+		t.Fatal("unable to read restored file:", err)
+	} else if string(data) != string(content) {
+		t.Error("restored content does not match original")
+	}
+	if len(store.index.Records) != 2 {
+		t.Fatal("replaced content was not preserved")
+	}
+	modifiedDigest := sha1.Sum(modified)
+	if data, err := os.ReadFile(store.pathForContent(modifiedDigest[:])); err != nil {
+		t.Fatal("unable to read preserved replaced content:", err)
+	} else if string(data) != string(modified) {
+		t.Error("preserved replaced content does not match modified content")
+	}
+
+	// Remove the file and restore it.
+	if err := os.Remove(filepath.Join(root, "file")); err != nil {
+		t.Fatal("unable to remove test file:", err)
+	}
+	restored, problems, _ = store.restore(root, before, nil, filesystem.Mode(0600), filesystem.Mode(0700), nil)
+	if len(problems) != 0 {
+		t.Fatal("restoration encountered problems:", problems[0].Error)
+	} else if len(restored) != 1 || restored[0] != "file" {
+		t.Fatal("restoration did not restore expected path")
+	}
+	if data, err := os.ReadFile(filepath.Join(root, "file")); err != nil {
+		t.Fatal("unable to read restored file:", err)
+	} else if string(data) != string(content) {
+		t.Error("restored content does not match original")
+	}
+
+	// Verify that restoration refuses to replace a directory.
+	os.Remove(filepath.Join(root, "file"))
+	if err := os.Mkdir(filepath.Join(root, "file"), 0700); err != nil {
+		t.Fatal("unable to create directory:", err)
+	}
+	restored, problems, _ = store.restore(root, before, nil, 0600, 0700, nil)
+	if len(restored) != 0 || len(problems) != 1 {
+		t.Error("restoration replaced directory")
+	}
+	os.Remove(filepath.Join(root, "file"))
+
+	// Verify that content displaced before the restoration time isn't
+	// restored.
+	restored, _, _ = store.restore(root, time.Now().Add(time.Hour), nil, 0600, 0700, nil)
+	if len(restored) != 0 {
+		t.Error("content restored despite being displaced before restoration time")
+	}
+}
+
+// TestSnapshotStoreRestoreRemovesCreatedContent tests that snapshotStore
+// restoration removes content created after the restoration time.
+func TestSnapshotStoreRestoreRemovesCreatedContent(t *testing.T) {
+	// Create a synchronization root and a store root.
+	root := t.TempDir()
+	storeRoot := filepath.Join(t.TempDir(), "store")
+
+	// Create a store and record the creation of a directory containing a file,
+	// then create that content.
+	content := []byte("created content")
+	digest := sha1.Sum(content)
+	file := &core.Entry{Kind: core.EntryKind_File, Digest: digest[:]}
+	directory := &core.Entry{Kind: core.EntryKind_Directory, Contents: map[string]*core.Entry{"file": file}}
+	store := newSnapshotStore(storeRoot, 1024, sha1.New())
+	transitions := []*core.Change{{Path: "directory", New: directory}}
+	before := time.Now()
+	if err := store.preserve(root, transitions, &core.Cache{}); err != nil {
+		t.Fatal("unable to record created content:", err)
+	} else if len(store.index.Records) != 2 {
+		t.Fatal("unexpected record count after recording creation:", len(store.index.Records))
+	}
+	if err := os.Mkdir(filepath.Join(root, "directory"), 0700); err != nil {
+		t.Fatal("unable to create test directory:", err)
+	} else if err := os.WriteFile(filepath.Join(root, "directory", "file"), content, 0600); err != nil {
+		t.Fatal("unable to create test file:", err)
+	}
+
+	// Restore to a time before the creation and verify that the content was
+	// removed.
+	restored, problems, err := store.restore(root, before, nil, 0600, 0700, nil)
+	if err != nil {
+		t.Fatal("unable to persist index after restoration:", err)
+	} else if len(problems) != 0 {
+		t.Fatal("restoration encountered problems:", problems[0].Error)
+	} else if len(restored) != 2 {
+		t.Fatal("restoration did not affect expected paths")
+	}
+	if _, err := os.Lstat(filepath.Join(root, "directory")); !os.IsNotExist(err) {
+		t.Error("created content still exists after restoration")
+	}
+
+	// Verify that the removed file content was preserved.
+	if data, err := os.ReadFile(store.pathForContent(digest[:])); err != nil {
+		t.Fatal("unable to read preserved content:", err)
+	} else if string(data) != string(content) {
+		t.Error("preserved content does not match removed content")
+	}
+}
+
+// TestSnapshotStorePrune tests snapshotStore.prune.
+func TestSnapshotStorePrune(t *testing.T) {
+	// Create a store with a small maximum size and populate its index with
+	// synthetic records.
+	store := newSnapshotStore(t.TempDir(), 10, sha1.New())
+	store.index.Records = []*SnapshotRecord{
+		{Path: "a", Digest: []byte{1}, Size: 6},
+		{Path: "b", Digest: []byte{2}, Size: 4},
+		{Path: "e", Created: true},
+		{Path: "c", Digest: []byte{2}, Size: 4},
+		{Path: "d", Digest: []byte{3}, Size: 5},
+	}
+
+	// Prune the store and verify that only the oldest record was removed.
+	store.prune()
+	if len(store.index.Records) != 4 {
+		t.Fatal("unexpected record count after pruning:", len(store.index.Records))
+	} else if store.index.Records[0].Path != "b" {
+		t.Error("pruning did not remove oldest record")
+	}
+}
@@ -9,6 +9,13 @@ import (
 	"path/filepath"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+const (
+	// deltaStagingSuffix is the suffix added to staging paths for content that
+	// is staged as a delta against the existing file.
+	deltaStagingSuffix = "-delta"
 )
 
 // existsAndIsDirectory returns true if the target path exists, is readable, and
@@ -40,6 +47,16 @@ type stagingSink struct {
 	path string
 	// storage is the temporary storage for the data.
 	storage *os.File
+	// sparse is the sparse writer wrapping storage. It avoids materializing
+	// zero-filled regions of the received content. It is nil if the content is
+	// being staged as a delta.
+	sparse *filesystem.SparseWriter
+	// base is the existing file against which the content is being staged as a
+	// delta. It is nil if the content isn't being staged as a delta.
+	base io.ReadSeekCloser
+	// delta is the delta writer wrapping storage. It is nil if the content
+	// isn't being staged as a delta.
+	delta *core.DeltaWriter
 	// digester is the hash of the data already written.
 	digester hash.Hash
 	// maximumSize is the maximum number of bytes allowed to be written to the
@@ -57,7 +74,13 @@ func (s *stagingSink) Write(data []byte) (int, error) {
 	}
 
 	// Write to the underlying storage.
-	n, err := s.storage.Write(data)
+	var n int
+	var err error
+	if s.delta != nil {
+		n, err = s.delta.Write(data)
+	} else {
+		n, err = s.sparse.Write(data)
+	}
 
 	// Write as much to the digester as we wrote to the underlying storage. This
 	// can't fail.
@@ -74,6 +97,22 @@ func (s *stagingSink) Write(data []byte) (int, error) {
 
 // Close closes the sink and moves the file into place.
 func (s *stagingSink) Close() error {
+	// Finalize the underlying storage. For deltas, this records the final
+	// content size and we can then close the base. Otherwise, this establishes
+	// the final file size, which will include any trailing hole.
+	var finalizeErr error
+	if s.delta != nil {
+		finalizeErr = s.delta.Close()
+		s.base.Close()
+	} else {
+		finalizeErr = s.sparse.Finalize()
+	}
+	if finalizeErr != nil {
+		s.storage.Close()
+		os.Remove(s.storage.Name())
+		return fmt.Errorf("unable to finalize underlying storage: %w", finalizeErr)
+	}
+
 	// Close the underlying storage.
 	if err := s.storage.Close(); err != nil {
 		return fmt.Errorf("unable to close underlying storage: %w", err)
@@ -88,6 +127,9 @@ func (s *stagingSink) Close() error {
 		os.Remove(s.storage.Name())
 		return fmt.Errorf("unable to compute staging destination: %w", err)
 	}
+	if s.delta != nil {
+		destination += deltaStagingSuffix
+	}
 
 	// Ensure the prefix directory exists.
 	if err = s.stager.ensurePrefixExists(prefixByte, prefix); err != nil {
@@ -107,9 +149,11 @@ func (s *stagingSink) Close() error {
 
 // stager is an ephemeral content-addressable store implementation. It allows
 // files to be staged in a load-balanced fashion in a temporary directory and
-// then rapidly located by their digests. It implements both rsync.Sinker and
-// sync.Provider. It is not safe for concurrent access, and each sink that it
-// produces should be closed before any other method is invoked.
+// then rapidly located by their digests. If a delta threshold is specified,
+// then content for paths whose existing files meet that threshold is staged as
+// a delta against the existing file. It implements rsync.Sinker, core.Provider,
+// and core.DeltaProvider. It is not safe for concurrent access, and each sink
+// that it produces should be closed before any other method is invoked.
 type stager struct {
 	// root is the staging root path.
 	root string
@@ -120,6 +164,12 @@ type stager struct {
 	digester hash.Hash
 	// maximumFileSize is the maximum allowed size for a single staged file.
 	maximumFileSize uint64
+	// synchronizationRoot is the synchronization root path, relative to which
+	// existing files are located.
+	synchronizationRoot string
+	// deltaThreshold is the minimum size of existing files against which
+	// content will be staged as a delta. A value of 0 disables delta staging.
+	deltaThreshold uint64
 	// rootExists indicates whether or not the staging root currently exists.
 	rootExists bool
 	// prefixExists tracks whether or not individual prefix directories exist.
@@ -127,13 +177,22 @@ type stager struct {
 }
 
 // newStager creates a new stager.
-func newStager(root string, hideRoot bool, digester hash.Hash, maximumFileSize uint64) *stager {
+func newStager(
+	root string,
+	hideRoot bool,
+	digester hash.Hash,
+	maximumFileSize uint64,
+	synchronizationRoot string,
+	deltaThreshold uint64,
+) *stager {
 	return &stager{
-		root:            root,
-		hideRoot:        hideRoot,
-		digester:        digester,
-		maximumFileSize: maximumFileSize,
-		rootExists:      existsAndIsDirectory(root),
+		root:                root,
+		hideRoot:            hideRoot,
+		digester:            digester,
+		maximumFileSize:     maximumFileSize,
+		synchronizationRoot: synchronizationRoot,
+		deltaThreshold:      deltaThreshold,
+		rootExists:          existsAndIsDirectory(root),
 	}
 }
 
@@ -202,14 +261,61 @@ func (s *stager) Sink(path string) (io.WriteCloser, error) {
 	// Reset the hash function state.
 	s.digester.Reset()
 
-	// Success.
-	return &stagingSink{
+	// Create the sink.
+	sink := &stagingSink{
 		stager:      s,
 		path:        path,
 		storage:     storage,
 		digester:    s.digester,
 		maximumSize: s.maximumFileSize,
-	}, nil
+	}
+
+	// If the existing file at the path is large enough, then stage the content
+	// as a delta against it. Otherwise, stage the content in full.
+	if base, metadata := s.openDeltaBase(path); base != nil {
+		if delta, err := core.NewDeltaWriter(storage, base, int64(metadata.Size), metadata.ModificationTime); err != nil {
+			base.Close()
+			storage.Close()
+			os.Remove(storage.Name())
+			return nil, fmt.Errorf("unable to create delta writer: %w", err)
+		} else {
+			sink.base = base
+			sink.delta = delta
+		}
+	} else {
+		sink.sparse = filesystem.NewSparseWriter(storage)
+	}
+
+	// Success.
+	return sink, nil
+}
+
+// openDeltaBase opens the existing file at the specified path if delta staging
+// is enabled and the file meets the delta threshold. If no suitable base
+// exists, then it returns nil.
+func (s *stager) openDeltaBase(path string) (io.ReadSeekCloser, *filesystem.Metadata) {
+	// Check whether or not delta staging is enabled.
+	if s.deltaThreshold == 0 {
+		return nil, nil
+	}
+
+	// Open the existing file. The opener only needs to remain open while the
+	// file itself is being opened.
+	opener := filesystem.NewOpener(s.synchronizationRoot)
+	base, metadata, err := opener.OpenFile(path)
+	opener.Close()
+	if err != nil {
+		return nil, nil
+	}
+
+	// Verify that the file meets the threshold.
+	if metadata.Size < s.deltaThreshold {
+		base.Close()
+		return nil, nil
+	}
+
+	// Success.
+	return base, metadata
 }
 
 // Provide implements the Provide method of sync.Provider.
@@ -240,3 +346,39 @@ func (s *stager) Provide(path string, digest []byte) (string, error) {
 	// Success.
 	return expectedLocation, nil
 }
+
+// ProvideDelta implements the ProvideDelta method of core.DeltaProvider.
+func (s *stager) ProvideDelta(path string, digest []byte) (string, error) {
+	// If the root doesn't exist, then there's no way the delta exists.
+	if !s.rootExists {
+		return "", os.ErrNotExist
+	}
+
+	// Compute the expected location of the delta.
+	expectedLocation, _, _, err := pathForStaging(s.root, path, digest)
+	if err != nil {
+		return "", fmt.Errorf("unable to compute staging path: %w", err)
+	}
+	expectedLocation += deltaStagingSuffix
+
+	// Ensure that the delta exists.
+	if _, err := os.Lstat(expectedLocation); err != nil {
+		if os.IsNotExist(err) {
+			return "", err
+		}
+		return "", fmt.Errorf("unable to query staged delta metadata: %w", err)
+	}
+
+	// Success.
+	return expectedLocation, nil
+}
+
+// contains returns whether or not content for the specified path and digest has
+// been staged, either in full or as a delta.
+func (s *stager) contains(path string, digest []byte) bool {
+	if _, err := s.Provide(path, digest); err == nil {
+		return true
+	}
+	_, err := s.ProvideDelta(path, digest)
+	return err == nil
+}
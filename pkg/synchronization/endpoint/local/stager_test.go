@@ -1,3 +1,140 @@
 package local
 
-// TODO: Implement.
+import (
+	"bytes"
+	"crypto/sha1"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mutagen-io/mutagen/pkg/filesystem"
+)
+
+// testingStage stages content for the specified path using the specified
+// stager and returns the content's digest.
+func testingStage(t *testing.T, stager *stager, path string, content []byte) []byte {
+	// Mark this function as a test helper.
+	t.Helper()
+
+	// Create a sink and write the content to it in chunks that aren't aligned
+	// with filesystem blocks.
+	sink, err := stager.Sink(path)
+	if err != nil {
+		t.Fatal("unable to create sink:", err)
+	}
+	for remaining := content; len(remaining) > 0; {
+		chunk := 3000
+		if chunk > len(remaining) {
+			chunk = len(remaining)
+		}
+		if _, err := sink.Write(remaining[:chunk]); err != nil {
+			sink.Close()
+			t.Fatal("unable to write to sink:", err)
+		}
+		remaining = remaining[chunk:]
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal("unable to close sink:", err)
+	}
+
+	// Compute the digest.
+	digest := sha1.Sum(content)
+	return digest[:]
+}
+
+// TestStagerSparse tests that the stager stages complete content when delta
+// staging isn't applicable and that it converts zero-filled regions to holes.
+func TestStagerSparse(t *testing.T) {
+	// Create content with a leading hole, data, and a trailing hole.
+	content := make([]byte, 1024*1024)
+	copy(content[512*1024:], "data")
+
+	// Create a stager (with delta staging enabled, though there's no existing
+	// file to use as a base) and stage the content.
+	stager := newStager(filepath.Join(t.TempDir(), "staging"), false, sha1.New(), ^uint64(0), t.TempDir(), 1)
+	digest := testingStage(t, stager, "file", content)
+
+	// Verify that the content was staged in full.
+	stagedPath, err := stager.Provide("file", digest)
+	if err != nil {
+		t.Fatal("unable to locate staged file:", err)
+	} else if _, err := stager.ProvideDelta("file", digest); !os.IsNotExist(err) {
+		t.Error("delta unexpectedly staged")
+	} else if !stager.contains("file", digest) {
+		t.Error("stager does not report staged content")
+	}
+	staged, err := os.Open(stagedPath)
+	if err != nil {
+		t.Fatal("unable to open staged file:", err)
+	}
+	defer staged.Close()
+	if stagedContent, err := io.ReadAll(staged); err != nil {
+		t.Fatal("unable to read staged file:", err)
+	} else if !bytes.Equal(stagedContent, content) {
+		t.Fatal("staged content does not match expected")
+	}
+
+	// Verify that the leading zero-filled region was converted to a hole. We
+	// can only check this on filesystems that support hole detection, which we
+	// determine by checking whether or not a file consisting of a single hole
+	// is reported as having no data.
+	hole, err := os.Create(filepath.Join(t.TempDir(), "hole"))
+	if err != nil {
+		t.Fatal("unable to create file:", err)
+	}
+	defer hole.Close()
+	if err := hole.Truncate(int64(len(content))); err != nil {
+		t.Fatal("unable to extend file:", err)
+	} else if _, _, err := filesystem.NextDataRegion(hole, 0); err != io.EOF {
+		t.Skip("filesystem does not support hole detection")
+	}
+	if start, _, err := filesystem.NextDataRegion(staged, 0); err != nil {
+		t.Fatal("unable to locate data region:", err)
+	} else if start == 0 || start > 512*1024 {
+		t.Error("data region does not match content:", start)
+	}
+}
+
+// TestStagerDelta tests that the stager stages content as a delta when the
+// existing file meets the delta threshold.
+func TestStagerDelta(t *testing.T) {
+	// Create a synchronization root with a large file and a small file.
+	root := t.TempDir()
+	original := bytes.Repeat([]byte{1}, 1024*1024)
+	if err := os.WriteFile(filepath.Join(root, "large"), original, 0600); err != nil {
+		t.Fatal("unable to create large file:", err)
+	} else if err := os.WriteFile(filepath.Join(root, "small"), original[:1024], 0600); err != nil {
+		t.Fatal("unable to create small file:", err)
+	}
+
+	// Create a stager.
+	stager := newStager(filepath.Join(t.TempDir(), "staging"), false, sha1.New(), ^uint64(0), root, 512*1024)
+
+	// Stage modified content for the large file and verify that it was staged
+	// as a (relatively small) delta.
+	updated := append([]byte(nil), original...)
+	copy(updated[300*1024:], "modified")
+	digest := testingStage(t, stager, "large", updated)
+	if _, err := stager.Provide("large", digest); !os.IsNotExist(err) {
+		t.Error("complete content unexpectedly staged for large file")
+	} else if !stager.contains("large", digest) {
+		t.Error("stager does not report staged delta")
+	}
+	if deltaPath, err := stager.ProvideDelta("large", digest); err != nil {
+		t.Fatal("unable to locate staged delta:", err)
+	} else if metadata, err := os.Lstat(deltaPath); err != nil {
+		t.Fatal("unable to query staged delta metadata:", err)
+	} else if metadata.Size() >= int64(len(updated))/4 {
+		t.Error("staged delta unexpectedly large:", metadata.Size())
+	}
+
+	// Stage modified content for the small file and verify that it was staged
+	// in full.
+	digest = testingStage(t, stager, "small", updated[:2048])
+	if _, err := stager.Provide("small", digest); err != nil {
+		t.Error("complete content not staged for small file:", err)
+	} else if _, err := stager.ProvideDelta("small", digest); !os.IsNotExist(err) {
+		t.Error("delta unexpectedly staged for small file")
+	}
+}
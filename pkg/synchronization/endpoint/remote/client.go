@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/mutagen-io/mutagen/pkg/encoding"
 	"github.com/mutagen-io/mutagen/pkg/logging"
@@ -514,6 +516,33 @@ func (c *endpointClient) Transition(ctx context.Context, transitions []*core.Cha
 	return results, response.Problems, response.StagerMissingFiles, nil
 }
 
+// Restore implements the Restore method for remote endpoints.
+func (c *endpointClient) Restore(before time.Time, paths []string) ([]string, []*core.Problem, error) {
+	// Create and send the restore request.
+	request := &EndpointRequest{
+		Restore: &RestoreRequest{
+			Before: timestamppb.New(before),
+			Paths:  paths,
+		},
+	}
+	if err := c.encodeAndFlush(request); err != nil {
+		return nil, nil, fmt.Errorf("unable to send restore request: %w", err)
+	}
+
+	// Receive the response and check for remote errors.
+	response := &RestoreResponse{}
+	if err := c.decoder.Decode(response); err != nil {
+		return nil, nil, fmt.Errorf("unable to receive restore response: %w", err)
+	} else if err = response.ensureValid(); err != nil {
+		return nil, nil, fmt.Errorf("invalid restore response: %w", err)
+	} else if response.Error != "" {
+		return nil, nil, fmt.Errorf("remote error: %s", response.Error)
+	}
+
+	// Success.
+	return response.Restored, response.Problems, nil
+}
+
 // Shutdown implements the Shutdown method for remote endpoints.
 func (c *endpointClient) Shutdown() error {
 	// Close the compression resources and the control stream. This will cause
@@ -314,6 +314,40 @@ func (r *TransitionResponse) ensureValid(expectedCount int) error {
 	return nil
 }
 
+// ensureValid ensures that RestoreRequest's invariants are respected.
+func (r *RestoreRequest) ensureValid() error {
+	// A nil restore request is not valid.
+	if r == nil {
+		return errors.New("nil restore request")
+	}
+
+	// Ensure that the timestamp is valid.
+	if err := r.Before.CheckValid(); err != nil {
+		return fmt.Errorf("invalid restoration time: %w", err)
+	}
+
+	// Success.
+	return nil
+}
+
+// ensureValid ensures that RestoreResponse's invariants are respected.
+func (r *RestoreResponse) ensureValid() error {
+	// A nil restore response is not valid.
+	if r == nil {
+		return errors.New("nil restore response")
+	}
+
+	// Validate that each problem is a valid problem specification.
+	for _, problem := range r.Problems {
+		if err := problem.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid problem returned: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // ensureValid ensures that EndpointRequest's invariants are respected.
 func (r *EndpointRequest) ensureValid() error {
 	// A nil endpoint request is not valid.
@@ -338,6 +372,9 @@ func (r *EndpointRequest) ensureValid() error {
 	if r.Transition != nil {
 		set++
 	}
+	if r.Restore != nil {
+		set++
+	}
 	if set != 1 {
 		return errors.New("invalid number of fields set")
 	}
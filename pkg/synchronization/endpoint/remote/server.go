@@ -166,6 +166,10 @@ func (s *endpointServer) serve() error {
 			if err := s.serveTransition(request.Transition); err != nil {
 				return fmt.Errorf("unable to serve transition request: %w", err)
 			}
+		} else if request.Restore != nil {
+			if err := s.serveRestore(request.Restore); err != nil {
+				return fmt.Errorf("unable to serve restore request: %w", err)
+			}
 		} else {
 			// TODO: Should we panic here? The request validation already
 			// ensures that one and only one message component is set, so we
@@ -488,3 +492,30 @@ func (s *endpointServer) serveTransition(request *TransitionRequest) error {
 	// Success.
 	return nil
 }
+
+// serveRestore serves a restore request.
+func (s *endpointServer) serveRestore(request *RestoreRequest) error {
+	// Ensure the request is valid.
+	if err := request.ensureValid(); err != nil {
+		return fmt.Errorf("invalid restore request: %w", err)
+	}
+
+	// Perform restoration.
+	restored, problems, err := s.endpoint.Restore(request.Before.AsTime(), request.Paths)
+	if err != nil {
+		s.encodeAndFlush(&RestoreResponse{Error: err.Error()})
+		return fmt.Errorf("unable to perform restoration: %w", err)
+	}
+
+	// Send the response.
+	response := &RestoreResponse{
+		Restored: restored,
+		Problems: problems,
+	}
+	if err = s.encodeAndFlush(response); err != nil {
+		return fmt.Errorf("unable to send restore response: %w", err)
+	}
+
+	// Success.
+	return nil
+}
@@ -0,0 +1,69 @@
+package synchronization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
+)
+
+// EnsureValid ensures that ExportedSession's invariants are respected.
+func (e *ExportedSession) EnsureValid() error {
+	// A nil exported session is not valid.
+	if e == nil {
+		return errors.New("nil exported session")
+	}
+
+	// Ensure that the session is valid.
+	if err := e.Session.EnsureValid(); err != nil {
+		return fmt.Errorf("invalid session: %w", err)
+	}
+
+	// Ensure that the archive is valid, if present.
+	if e.Archive != nil {
+		if err := e.Archive.EnsureValid(true); err != nil {
+			return fmt.Errorf("invalid archive: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// EnsureValid ensures that SessionExport's invariants are respected.
+func (e *SessionExport) EnsureValid() error {
+	// A nil session export is not valid.
+	if e == nil {
+		return errors.New("nil session export")
+	}
+
+	// Ensure that all exported sessions are valid.
+	for _, s := range e.Sessions {
+		if err := s.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid exported session: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
+// verifyEndpointContents verifies that the contents of an endpoint match the
+// contents of an imported ancestor archive. It returns an error if they don't
+// match or if the endpoint can't be scanned.
+func verifyEndpointContents(ctx context.Context, endpoint Endpoint, archive *core.Archive) error {
+	// Perform a full scan of the endpoint.
+	snapshot, err, _ := endpoint.Scan(ctx, nil, true)
+	if err != nil {
+		return fmt.Errorf("unable to scan endpoint: %w", err)
+	}
+
+	// Compare the contents with those of the archive.
+	if !snapshot.Content.Equal(archive.Content, true) {
+		return errors.New("endpoint contents don't match archive")
+	}
+
+	// Success.
+	return nil
+}
@@ -0,0 +1,242 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: synchronization/export.proto
+
+package synchronization
+
+import (
+	core "github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ExportedSession is the portable representation of a single session.
+type ExportedSession struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Session is the session definition. Its identifier and other header
+	// fields are informational only and are regenerated on import.
+	Session *Session `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	// Archive is the session's ancestor archive. It may be nil if archives
+	// weren't requested at export time.
+	Archive *core.Archive `protobuf:"bytes,2,opt,name=archive,proto3" json:"archive,omitempty"`
+}
+
+func (x *ExportedSession) Reset() {
+	*x = ExportedSession{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_synchronization_export_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportedSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportedSession) ProtoMessage() {}
+
+func (x *ExportedSession) ProtoReflect() protoreflect.Message {
+	mi := &file_synchronization_export_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportedSession.ProtoReflect.Descriptor instead.
+func (*ExportedSession) Descriptor() ([]byte, []int) {
+	return file_synchronization_export_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExportedSession) GetSession() *Session {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+func (x *ExportedSession) GetArchive() *core.Archive {
+	if x != nil {
+		return x.Archive
+	}
+	return nil
+}
+
+// SessionExport is a portable collection of session definitions that can be
+// used to recreate sessions on another daemon.
+type SessionExport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Sessions are the exported sessions.
+	Sessions []*ExportedSession `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *SessionExport) Reset() {
+	*x = SessionExport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_synchronization_export_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionExport) ProtoMessage() {}
+
+func (x *SessionExport) ProtoReflect() protoreflect.Message {
+	mi := &file_synchronization_export_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionExport.ProtoReflect.Descriptor instead.
+func (*SessionExport) Descriptor() ([]byte, []int) {
+	return file_synchronization_export_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SessionExport) GetSessions() []*ExportedSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+var File_synchronization_export_proto protoreflect.FileDescriptor
+
+var file_synchronization_export_proto_rawDesc = []byte{
+	0x0a, 0x1c, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x65, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0f,
+	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a,
+	0x1d, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x2f, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x22,
+	0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f,
+	0x63, 0x6f, 0x72, 0x65, 0x2f, 0x61, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x6e, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x32, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x07, 0x61, 0x72, 0x63,
+	0x68, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x63, 0x6f, 0x72,
+	0x65, 0x2e, 0x41, 0x72, 0x63, 0x68, 0x69, 0x76, 0x65, 0x52, 0x07, 0x61, 0x72, 0x63, 0x68, 0x69,
+	0x76, 0x65, 0x22, 0x4d, 0x0a, 0x0d, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x12, 0x3c, 0x0a, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67,
+	0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69,
+	0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_synchronization_export_proto_rawDescOnce sync.Once
+	file_synchronization_export_proto_rawDescData = file_synchronization_export_proto_rawDesc
+)
+
+func file_synchronization_export_proto_rawDescGZIP() []byte {
+	file_synchronization_export_proto_rawDescOnce.Do(func() {
+		file_synchronization_export_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_export_proto_rawDescData)
+	})
+	return file_synchronization_export_proto_rawDescData
+}
+
+var file_synchronization_export_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_synchronization_export_proto_goTypes = []interface{}{
+	(*ExportedSession)(nil), // 0: synchronization.ExportedSession
+	(*SessionExport)(nil),   // 1: synchronization.SessionExport
+	(*Session)(nil),         // 2: synchronization.Session
+	(*core.Archive)(nil),    // 3: core.Archive
+}
+var file_synchronization_export_proto_depIdxs = []int32{
+	2, // 0: synchronization.ExportedSession.session:type_name -> synchronization.Session
+	3, // 1: synchronization.ExportedSession.archive:type_name -> core.Archive
+	0, // 2: synchronization.SessionExport.sessions:type_name -> synchronization.ExportedSession
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_export_proto_init() }
+func file_synchronization_export_proto_init() {
+	if File_synchronization_export_proto != nil {
+		return
+	}
+	file_synchronization_session_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_synchronization_export_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExportedSession); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_synchronization_export_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionExport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_export_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_export_proto_goTypes,
+		DependencyIndexes: file_synchronization_export_proto_depIdxs,
+		MessageInfos:      file_synchronization_export_proto_msgTypes,
+	}.Build()
+	File_synchronization_export_proto = out.File
+	file_synchronization_export_proto_rawDesc = nil
+	file_synchronization_export_proto_goTypes = nil
+	file_synchronization_export_proto_depIdxs = nil
+}
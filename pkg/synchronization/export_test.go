@@ -0,0 +1,26 @@
+package synchronization
+
+import (
+	"testing"
+)
+
+// TestSessionExportEnsureValid tests SessionExport.EnsureValid.
+func TestSessionExportEnsureValid(t *testing.T) {
+	// Define test cases.
+	tests := []struct {
+		export   *SessionExport
+		expected bool
+	}{
+		{nil, false},
+		{&SessionExport{}, true},
+		{&SessionExport{Sessions: []*ExportedSession{nil}}, false},
+		{&SessionExport{Sessions: []*ExportedSession{{}}}, false},
+	}
+
+	// Process test cases.
+	for i, test := range tests {
+		if err := test.export.EnsureValid(); (err == nil) != test.expected {
+			t.Errorf("test index %d: unexpected validity: %t != %t", i, err == nil, test.expected)
+		}
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"sync"
+	"time"
 
+	"github.com/mutagen-io/mutagen/pkg/conditions"
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/identifier"
 	"github.com/mutagen-io/mutagen/pkg/logging"
@@ -20,6 +23,10 @@ const (
 	// reported by Manager.List for a single session before conflict list
 	// truncation for that session.
 	maximumListConflicts = 10
+	// maximumListNameCollisions is the maximum number of name collisions that
+	// will be reported by Manager.List for a single session before name
+	// collision list truncation for that session.
+	maximumListNameCollisions = 10
 	// maximumListScanProblems is the maximum number of scan problems that will
 	// be reported by Manager.List for a single endpoint in a session before
 	// scan problem list truncation for that endpoint.
@@ -42,6 +49,12 @@ type Manager struct {
 	sessionsLock *state.TrackingLock
 	// sessions maps sessions to their respective controllers.
 	sessions map[string]*controller
+	// labelLock serializes label operations.
+	labelLock sync.Mutex
+	// policyCancel cancels policy evaluation.
+	policyCancel context.CancelFunc
+	// policyDone is closed when policy evaluation has terminated.
+	policyDone chan struct{}
 }
 
 // NewManager creates a new Manager instance.
@@ -70,7 +83,7 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 			continue
 		}
 		logger.Info("Loading session", id)
-		if controller, err := loadSession(logger.Sublogger(identifier.Truncated(id)), tracker, id); err != nil {
+		if controller, err := loadSession(logger.Sublogger(identifier.Truncated(id)).With("session", id), tracker, id); err != nil {
 			logger.Warnf("Failed to load session %s: %v", id, err)
 			continue
 		} else {
@@ -78,14 +91,58 @@ func NewManager(logger *logging.Logger) (*Manager, error) {
 		}
 	}
 
-	// Success.
-	logger.Info("Session manager initialized")
-	return &Manager{
+	// Create the manager.
+	policyContext, policyCancel := context.WithCancel(context.Background())
+	manager := &Manager{
 		logger:       logger,
 		tracker:      tracker,
 		sessionsLock: sessionsLock,
 		sessions:     sessions,
-	}, nil
+		policyCancel: policyCancel,
+		policyDone:   make(chan struct{}),
+	}
+
+	// Start policy evaluation.
+	go manager.evaluatePolicies(policyContext)
+
+	// Success.
+	logger.Info("Session manager initialized")
+	return manager, nil
+}
+
+// evaluatePolicies periodically evaluates session policies until cancelled.
+func (m *Manager) evaluatePolicies(ctx context.Context) {
+	// Signal completion when done.
+	defer close(m.policyDone)
+
+	// Create a ticker to regulate evaluation and defer its shutdown.
+	ticker := time.NewTicker(policyEvaluationInterval)
+	defer ticker.Stop()
+
+	// Loop until cancelled, performing an initial evaluation immediately.
+	for {
+		// Detect host conditions (if any session's policies depend on them)
+		// and apply policies to each session.
+		controllers := m.allControllers()
+		var hostConditions conditions.Conditions
+		for _, controller := range controllers {
+			if requiresHostConditions(controller.session.Version, controller.session.Configuration) {
+				hostConditions = conditions.Detect()
+				break
+			}
+		}
+		now := time.Now()
+		for _, controller := range controllers {
+			controller.applyPolicies(hostConditions, now)
+		}
+
+		// Wait for the next evaluation.
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
 }
 
 // allControllers creates a list of all controllers managed by the manager.
@@ -119,7 +176,8 @@ func (m *Manager) findControllersBySpecification(specifications []string) ([]*co
 	for _, specification := range specifications {
 		var matched bool
 		for _, controller := range m.sessions {
-			if controller.session.Identifier == specification || controller.session.Name == specification {
+			name, _ := controller.nameAndLabels()
+			if controller.session.Identifier == specification || name == specification {
 				controllerSet[controller] = true
 				matched = true
 			}
@@ -155,7 +213,32 @@ func (m *Manager) findControllersByLabelSelector(labelSelector string) ([]*contr
 	// Loop over controllers and look for matches.
 	var controllers []*controller
 	for _, controller := range m.sessions {
-		if selector.Matches(controller.session.Labels) {
+		if _, labels := controller.nameAndLabels(); selector.Matches(labels) {
+			controllers = append(controllers, controller)
+		}
+	}
+
+	// Done.
+	return controllers, nil
+}
+
+// findControllersBySelectors generates a list of controllers matching all of
+// the specified selectors.
+func (m *Manager) findControllersBySelectors(selectors []string) ([]*controller, error) {
+	// Parse the selectors.
+	parsed, err := selection.ParseSelectors(selectors)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse selectors: %w", err)
+	}
+
+	// Grab the registry lock and defer its release.
+	m.sessionsLock.Lock()
+	defer m.sessionsLock.UnlockWithoutNotify()
+
+	// Loop over controllers and look for matches.
+	var controllers []*controller
+	for _, controller := range m.sessions {
+		if parsed.Matches(controller.selectionCandidate()) {
 			controllers = append(controllers, controller)
 		}
 	}
@@ -174,6 +257,8 @@ func (m *Manager) selectControllers(selection *selection.Selection) ([]*controll
 		return m.findControllersBySpecification(selection.Specifications)
 	} else if selection.LabelSelector != "" {
 		return m.findControllersByLabelSelector(selection.LabelSelector)
+	} else if len(selection.Selectors) > 0 {
+		return m.findControllersBySelectors(selection.Selectors)
 	} else {
 		// TODO: Should we panic here instead?
 		return nil, errors.New("invalid session selection")
@@ -188,6 +273,10 @@ func (m *Manager) Shutdown() {
 	// Terminate state tracking to terminate monitoring.
 	m.tracker.Terminate()
 
+	// Terminate policy evaluation and wait for it to complete.
+	m.policyCancel()
+	<-m.policyDone
+
 	// Grab the registry lock and defer its release.
 	m.sessionsLock.Lock()
 	defer m.sessionsLock.UnlockWithoutNotify()
@@ -211,6 +300,31 @@ func (m *Manager) Create(
 	labels map[string]string,
 	paused bool,
 	prompter string,
+) (string, error) {
+	return m.create(
+		ctx,
+		alpha, beta,
+		configuration, configurationAlpha, configurationBeta,
+		name,
+		labels,
+		paused,
+		nil, false,
+		prompter,
+	)
+}
+
+// create implements Create and Import, optionally seeding the new session with
+// an existing ancestor archive (see newSession for details).
+func (m *Manager) create(
+	ctx context.Context,
+	alpha, beta *url.URL,
+	configuration, configurationAlpha, configurationBeta *Configuration,
+	name string,
+	labels map[string]string,
+	paused bool,
+	archive *core.Archive,
+	verifyArchive bool,
+	prompter string,
 ) (string, error) {
 	// Create a unique session identifier.
 	id, err := identifier.New(identifier.PrefixSynchronization)
@@ -221,7 +335,7 @@ func (m *Manager) Create(
 	// Attempt to create a session.
 	controller, err := newSession(
 		ctx,
-		m.logger.Sublogger(identifier.Truncated(id)),
+		m.logger.Sublogger(identifier.Truncated(id)).With("session", id),
 		m.tracker,
 		id,
 		alpha, beta,
@@ -229,6 +343,8 @@ func (m *Manager) Create(
 		name,
 		labels,
 		paused,
+		archive,
+		verifyArchive,
 		prompter,
 	)
 	if err != nil {
@@ -240,10 +356,107 @@ func (m *Manager) Create(
 	m.sessions[controller.session.Identifier] = controller
 	m.sessionsLock.Unlock()
 
+	// Apply the session's policies immediately rather than waiting for the
+	// next periodic evaluation. We only detect host conditions if the
+	// session's policies depend on them.
+	var hostConditions conditions.Conditions
+	if requiresHostConditions(controller.session.Version, controller.session.Configuration) {
+		hostConditions = conditions.Detect()
+	}
+	controller.applyPolicies(hostConditions, time.Now())
+
 	// Done.
 	return controller.session.Identifier, nil
 }
 
+// Import tells the manager to create new sessions from exported session
+// definitions. Each session is assigned a new identifier. If useArchives is
+// true, then any exported ancestor archives are used to seed the new sessions,
+// otherwise they're ignored. Because a stale ancestor can cause reconciliation
+// to propagate deletions, the contents of both endpoints must match an
+// exported archive for it to be used, unless force is true. If paused is true,
+// then all sessions are created paused, otherwise sessions are only created
+// paused if they were paused at export time. The identifiers of the new
+// sessions are returned. If an error occurs, then sessions imported before the
+// failure are left in place.
+func (m *Manager) Import(
+	ctx context.Context,
+	export *SessionExport,
+	useArchives bool,
+	paused bool,
+	force bool,
+	prompter string,
+) ([]string, error) {
+	// Ensure that the export is valid.
+	if err := export.EnsureValid(); err != nil {
+		return nil, fmt.Errorf("invalid session export: %w", err)
+	}
+
+	// Create each session.
+	identifiers := make([]string, 0, len(export.Sessions))
+	for _, exported := range export.Sessions {
+		// Determine which archive (if any) to use.
+		var archive *core.Archive
+		if useArchives {
+			archive = exported.Archive
+		}
+
+		// Create the session.
+		session := exported.Session
+		identifier, err := m.create(
+			ctx,
+			session.Alpha, session.Beta,
+			session.Configuration, session.ConfigurationAlpha, session.ConfigurationBeta,
+			session.Name,
+			session.Labels,
+			paused || session.Paused,
+			archive, archive != nil && !force,
+			prompter,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("unable to import session %s (%d session(s) imported): %w",
+				session.Identifier, len(identifiers), err,
+			)
+		}
+		identifiers = append(identifiers, identifier)
+	}
+
+	// Success.
+	return identifiers, nil
+}
+
+// Export creates a portable export of the sessions matching the specified
+// selection, ordered by creation time. If includeArchives is true, then each
+// session's ancestor archive is included in the export.
+func (m *Manager) Export(_ context.Context, selection *selection.Selection, includeArchives bool) (*SessionExport, error) {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate requested sessions: %w", err)
+	}
+
+	// Export each session.
+	export := &SessionExport{Sessions: make([]*ExportedSession, len(controllers))}
+	for c, controller := range controllers {
+		if exported, err := controller.export(includeArchives); err != nil {
+			return nil, fmt.Errorf("unable to export session %s: %w", controller.session.Identifier, err)
+		} else {
+			export.Sessions[c] = exported
+		}
+	}
+
+	// Sort sessions by creation time.
+	sort.Slice(export.Sessions, func(i, j int) bool {
+		iTime := export.Sessions[i].Session.CreationTime
+		jTime := export.Sessions[j].Session.CreationTime
+		return iTime.Seconds < jTime.Seconds ||
+			(iTime.Seconds == jTime.Seconds && iTime.Nanos < jTime.Nanos)
+	})
+
+	// Success.
+	return export, nil
+}
+
 // List requests a state snapshot for the specified sessions. Session states
 // will be ordered by creation time, from oldest to newest. Problem and conflict
 // lists will sorted by path and truncated to reasonable lengths, and conflicts
@@ -296,6 +509,14 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 			state.Conflicts[c] = conflict.Slim()
 		}
 
+		// Sort and (potentially) truncate name collisions.
+		state.NameCollisions = core.CopyNameCollisions(state.NameCollisions)
+		core.SortNameCollisions(state.NameCollisions)
+		if len(state.NameCollisions) > maximumListNameCollisions {
+			state.ExcludedNameCollisions = uint64(len(state.NameCollisions) - maximumListNameCollisions)
+			state.NameCollisions = state.NameCollisions[:maximumListNameCollisions]
+		}
+
 		// Sort and (potentially) truncate alpha scan problems.
 		state.AlphaState.ScanProblems = core.CopyProblems(state.AlphaState.ScanProblems)
 		core.SortProblems(state.AlphaState.ScanProblems)
@@ -344,6 +565,54 @@ func (m *Manager) List(ctx context.Context, selection *selection.Selection, prev
 	return stateIndex, states, nil
 }
 
+// OverrideLogLevel temporarily raises the capture level of the log rings for
+// sessions matching the given selection. It returns a function that releases
+// the override, restoring the previous capture level.
+func (m *Manager) OverrideLogLevel(selection *selection.Selection, level logging.Level) (func(), error) {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate requested sessions: %w", err)
+	}
+
+	// Override the log ring capture levels.
+	releases := make([]func(), len(controllers))
+	for c, controller := range controllers {
+		releases[c] = controller.logs.Override(level)
+	}
+
+	// Success.
+	return func() {
+		for _, release := range releases {
+			release()
+		}
+	}, nil
+}
+
+// Logs returns captured log entries for the single session matching the given
+// selection. It returns the current log ring index and the entries recorded
+// since the previous index. If the previous index is 0, then all retained
+// entries are returned immediately, otherwise this method waits until new
+// entries are available or the provided context is cancelled.
+func (m *Manager) Logs(ctx context.Context, selection *selection.Selection, previousIndex uint64) (uint64, []*logging.Entry, error) {
+	// Extract the controller for the session of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to locate requested sessions: %w", err)
+	} else if len(controllers) != 1 {
+		return 0, nil, errors.New("selection must match exactly one session")
+	}
+
+	// Read log entries.
+	index, entries, err := controllers[0].logs.Entries(ctx, previousIndex)
+	if err != nil {
+		return 0, nil, fmt.Errorf("unable to read session logs: %w", err)
+	}
+
+	// Success.
+	return index, entries, nil
+}
+
 // Flush tells the manager to flush sessions matching the given specifications.
 func (m *Manager) Flush(ctx context.Context, selection *selection.Selection, prompter string, skipWait bool) error {
 	// Extract the controllers for the sessions of interest.
@@ -422,6 +691,90 @@ func (m *Manager) Reset(ctx context.Context, selection *selection.Selection, pro
 	return nil
 }
 
+// Restore tells the manager to restore snapshotted content for sessions
+// matching the given specifications. Content that existed at the specified
+// point in time is restored, restricted to the specified paths (if any).
+func (m *Manager) Restore(ctx context.Context, selection *selection.Selection, prompter string, before time.Time, paths []string) ([]*RestoreResult, error) {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return nil, fmt.Errorf("unable to locate requested sessions: %w", err)
+	}
+
+	// Attempt to restore.
+	results := make([]*RestoreResult, 0, len(controllers))
+	for _, controller := range controllers {
+		result, err := controller.restore(ctx, prompter, before, paths)
+		if err != nil {
+			return nil, fmt.Errorf("unable to restore session: %w", err)
+		}
+		results = append(results, result)
+	}
+
+	// Success.
+	return results, nil
+}
+
+// Label tells the manager to change the names and labels of sessions matching
+// the given specifications. Renaming is only supported if a single session is
+// selected.
+func (m *Manager) Label(
+	_ context.Context,
+	selection *selection.Selection,
+	rename bool, name string,
+	labels map[string]string, removals []string, overwrite bool,
+) error {
+	// Extract the controllers for the sessions of interest.
+	controllers, err := m.selectControllers(selection)
+	if err != nil {
+		return fmt.Errorf("unable to locate requested sessions: %w", err)
+	}
+
+	// Ensure that we're not renaming multiple sessions.
+	if rename && len(controllers) > 1 {
+		return errors.New("unable to rename multiple sessions")
+	}
+
+	// Lock label operations and defer their release. This ensures that the
+	// validation performed below remains accurate until changes are applied.
+	m.labelLock.Lock()
+	defer m.labelLock.Unlock()
+
+	// Compute and validate the new names and labels for all sessions before
+	// applying any changes, so that an invalid labeling of one session doesn't
+	// leave others modified. We also record the existing names and labels so
+	// that we can revert changes if necessary.
+	type labeling struct {
+		previousName, name     string
+		previousLabels, labels map[string]string
+	}
+	labelings := make([]labeling, len(controllers))
+	for c, controller := range controllers {
+		previousName, previousLabels := controller.nameAndLabels()
+		newName, newLabels, err := controller.labeling(rename, name, labels, removals, overwrite)
+		if err != nil {
+			return fmt.Errorf("unable to label session %s: %w", controller.session.Identifier, err)
+		}
+		labelings[c] = labeling{previousName, newName, previousLabels, newLabels}
+	}
+
+	// Apply the changes. If a change can't be applied (e.g. due to a failure
+	// to save the session), then revert any changes already applied.
+	for c, controller := range controllers {
+		if err := controller.setNameAndLabels(labelings[c].name, labelings[c].labels); err != nil {
+			for r, applied := range controllers[:c] {
+				if err := applied.setNameAndLabels(labelings[r].previousName, labelings[r].previousLabels); err != nil {
+					m.logger.Warnf("Unable to revert labeling for session %s: %v", applied.session.Identifier, err)
+				}
+			}
+			return fmt.Errorf("unable to label session %s: %w", controller.session.Identifier, err)
+		}
+	}
+
+	// Success.
+	return nil
+}
+
 // Terminate tells the manager to terminate sessions matching the given
 // specifications.
 func (m *Manager) Terminate(ctx context.Context, selection *selection.Selection, prompter string) error {
@@ -0,0 +1,136 @@
+package synchronization
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/conditions"
+)
+
+const (
+	// policyEvaluationInterval is the interval at which the manager evaluates
+	// session policies.
+	policyEvaluationInterval = 30 * time.Second
+)
+
+// ActiveHours represents a daily time range, in minutes since midnight. If End
+// is less than or equal to Start, then the range wraps around midnight.
+type ActiveHours struct {
+	// Start is the start of the range (inclusive).
+	Start int
+	// End is the end of the range (exclusive).
+	End int
+}
+
+// parseTimeOfDay parses a time of day in the form "HH:MM" and returns the
+// number of minutes since midnight.
+func parseTimeOfDay(text string) (int, error) {
+	value, err := time.Parse("15:04", text)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day (%s)", text)
+	}
+	return value.Hour()*60 + value.Minute(), nil
+}
+
+// ParseActiveHours parses an active hours specification of the form
+// "HH:MM-HH:MM".
+func ParseActiveHours(specification string) (ActiveHours, error) {
+	// Split the specification.
+	start, end, ok := strings.Cut(specification, "-")
+	if !ok {
+		return ActiveHours{}, errors.New("specification must be of the form HH:MM-HH:MM")
+	}
+
+	// Parse the components.
+	var result ActiveHours
+	var err error
+	if result.Start, err = parseTimeOfDay(start); err != nil {
+		return ActiveHours{}, err
+	} else if result.End, err = parseTimeOfDay(end); err != nil {
+		return ActiveHours{}, err
+	} else if result.Start == result.End {
+		return ActiveHours{}, errors.New("empty time range")
+	}
+
+	// Success.
+	return result, nil
+}
+
+// Contains determines whether or not the specified time falls within the
+// active hours (with respect to the time's location).
+func (h ActiveHours) Contains(t time.Time) bool {
+	minutes := t.Hour()*60 + t.Minute()
+	if h.Start < h.End {
+		return minutes >= h.Start && minutes < h.End
+	}
+	return minutes >= h.Start || minutes < h.End
+}
+
+// effectiveConditionPolicies returns the effective battery and metered
+// connection policies for a session, taking defaults into account.
+func effectiveConditionPolicies(version Version, configuration *Configuration) (ConditionPolicy, ConditionPolicy) {
+	batteryPolicy := configuration.BatteryPolicy
+	if batteryPolicy.IsDefault() {
+		batteryPolicy = version.DefaultConditionPolicy()
+	}
+	meteredPolicy := configuration.MeteredPolicy
+	if meteredPolicy.IsDefault() {
+		meteredPolicy = version.DefaultConditionPolicy()
+	}
+	return batteryPolicy, meteredPolicy
+}
+
+// requiresHostConditions determines whether or not a session's policies depend
+// on host conditions, i.e. whether or not host condition detection (which may
+// be costly) is necessary to evaluate them.
+func requiresHostConditions(version Version, configuration *Configuration) bool {
+	batteryPolicy, meteredPolicy := effectiveConditionPolicies(version, configuration)
+	return batteryPolicy.Pauses() || meteredPolicy.Pauses()
+}
+
+// policySuspension determines whether or not a session's policies require that
+// the session be suspended under the specified conditions at the specified
+// time. If so, it returns a description of the reason, otherwise it returns an
+// empty string. The configuration must be valid.
+func policySuspension(version Version, configuration *Configuration, hostConditions conditions.Conditions, now time.Time) string {
+	// Determine the effective condition policies.
+	batteryPolicy, meteredPolicy := effectiveConditionPolicies(version, configuration)
+
+	// Check host conditions.
+	if hostConditions.OnBattery && batteryPolicy.Pauses() {
+		return "host is running on battery power"
+	} else if hostConditions.Metered && meteredPolicy.Pauses() {
+		return "host is using a metered connection"
+	}
+
+	// Check active hours.
+	if configuration.ActiveHours != "" {
+		if activeHours, err := ParseActiveHours(configuration.ActiveHours); err == nil && !activeHours.Contains(now) {
+			return fmt.Sprintf("outside of active hours (%s)", configuration.ActiveHours)
+		}
+	}
+
+	// No suspension required.
+	return ""
+}
+
+// disconnectedRetryInterval determines whether or not a session's policies
+// require that a session that has been disconnected for the specified duration
+// be suspended. If so, it returns the reduced-frequency interval at which
+// reconnection should be attempted and true, otherwise it returns false.
+func disconnectedRetryInterval(version Version, configuration *Configuration, disconnected time.Duration) (time.Duration, bool) {
+	// Check whether or not the session has been disconnected long enough.
+	if configuration.DisconnectedTimeout == 0 ||
+		disconnected < time.Duration(configuration.DisconnectedTimeout)*time.Second {
+		return 0, false
+	}
+
+	// Compute the retry interval.
+	interval := configuration.DisconnectedRetryInterval
+	if interval == 0 {
+		interval = version.DefaultDisconnectedRetryInterval()
+	}
+	return time.Duration(interval) * time.Second, true
+}
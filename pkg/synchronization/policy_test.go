@@ -0,0 +1,172 @@
+package synchronization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mutagen-io/mutagen/pkg/conditions"
+)
+
+// TestParseActiveHours tests ParseActiveHours.
+func TestParseActiveHours(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		specification string
+		expected      ActiveHours
+		expectFailure bool
+	}{
+		{"", ActiveHours{}, true},
+		{"09:00", ActiveHours{}, true},
+		{"09:00-", ActiveHours{}, true},
+		{"9-17", ActiveHours{}, true},
+		{"09:00-24:00", ActiveHours{}, true},
+		{"09:00-09:00", ActiveHours{}, true},
+		{"09:00-17:30", ActiveHours{Start: 540, End: 1050}, false},
+		{"22:00-06:00", ActiveHours{Start: 1320, End: 360}, false},
+		{"00:00-23:59", ActiveHours{Start: 0, End: 1439}, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		result, err := ParseActiveHours(testCase.specification)
+		if err != nil {
+			if !testCase.expectFailure {
+				t.Errorf("unable to parse active hours (%s): %v", testCase.specification, err)
+			}
+		} else if testCase.expectFailure {
+			t.Error("parsing succeeded unexpectedly for specification:", testCase.specification)
+		} else if result != testCase.expected {
+			t.Errorf("parsed active hours (%v) do not match expected (%v)", result, testCase.expected)
+		}
+	}
+}
+
+// TestActiveHoursContains tests ActiveHours.Contains.
+func TestActiveHoursContains(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		specification string
+		hour, minute  int
+		expected      bool
+	}{
+		{"09:00-17:00", 8, 59, false},
+		{"09:00-17:00", 9, 0, true},
+		{"09:00-17:00", 16, 59, true},
+		{"09:00-17:00", 17, 0, false},
+		{"22:00-06:00", 21, 59, false},
+		{"22:00-06:00", 22, 0, true},
+		{"22:00-06:00", 0, 0, true},
+		{"22:00-06:00", 5, 59, true},
+		{"22:00-06:00", 6, 0, false},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		activeHours, err := ParseActiveHours(testCase.specification)
+		if err != nil {
+			t.Fatalf("unable to parse active hours (%s): %v", testCase.specification, err)
+		}
+		now := time.Date(2022, 1, 1, testCase.hour, testCase.minute, 0, 0, time.Local)
+		if result := activeHours.Contains(now); result != testCase.expected {
+			t.Errorf("active hours (%s) containment of %02d:%02d (%t) does not match expected (%t)",
+				testCase.specification, testCase.hour, testCase.minute, result, testCase.expected,
+			)
+		}
+	}
+}
+
+// TestPolicySuspension tests policySuspension.
+func TestPolicySuspension(t *testing.T) {
+	// Set up test cases.
+	morning := time.Date(2022, 1, 1, 8, 0, 0, 0, time.Local)
+	noon := time.Date(2022, 1, 1, 12, 0, 0, 0, time.Local)
+	testCases := []struct {
+		description     string
+		configuration   *Configuration
+		conditions      conditions.Conditions
+		now             time.Time
+		expectSuspended bool
+	}{
+		{"no policies", &Configuration{}, conditions.Conditions{OnBattery: true, Metered: true}, morning, false},
+		{"battery policy on mains", &Configuration{
+			BatteryPolicy: ConditionPolicy_ConditionPolicyPause,
+		}, conditions.Conditions{}, noon, false},
+		{"battery policy on battery", &Configuration{
+			BatteryPolicy: ConditionPolicy_ConditionPolicyPause,
+		}, conditions.Conditions{OnBattery: true}, noon, true},
+		{"ignored battery policy on battery", &Configuration{
+			BatteryPolicy: ConditionPolicy_ConditionPolicyIgnore,
+		}, conditions.Conditions{OnBattery: true}, noon, false},
+		{"metered policy on metered connection", &Configuration{
+			MeteredPolicy: ConditionPolicy_ConditionPolicyPause,
+		}, conditions.Conditions{Metered: true}, noon, true},
+		{"within active hours", &Configuration{
+			ActiveHours: "09:00-17:00",
+		}, conditions.Conditions{}, noon, false},
+		{"outside active hours", &Configuration{
+			ActiveHours: "09:00-17:00",
+		}, conditions.Conditions{}, morning, true},
+	}
+
+	// Process test cases.
+	for _, testCase := range testCases {
+		suspension := policySuspension(Version_Version1, testCase.configuration, testCase.conditions, testCase.now)
+		if suspended := suspension != ""; suspended != testCase.expectSuspended {
+			t.Errorf("%s: suspension status (%t) does not match expected (%t)",
+				testCase.description, suspended, testCase.expectSuspended,
+			)
+		}
+	}
+}
+
+// TestRequiresHostConditions tests requiresHostConditions.
+func TestRequiresHostConditions(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		configuration *Configuration
+		expected      bool
+	}{
+		{&Configuration{}, false},
+		{&Configuration{ActiveHours: "09:00-17:00"}, false},
+		{&Configuration{BatteryPolicy: ConditionPolicy_ConditionPolicyIgnore}, false},
+		{&Configuration{BatteryPolicy: ConditionPolicy_ConditionPolicyPause}, true},
+		{&Configuration{MeteredPolicy: ConditionPolicy_ConditionPolicyPause}, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		if result := requiresHostConditions(Version_Version1, testCase.configuration); result != testCase.expected {
+			t.Errorf("test case %d: result (%t) does not match expected (%t)", i, result, testCase.expected)
+		}
+	}
+}
+
+// TestDisconnectedRetryInterval tests disconnectedRetryInterval.
+func TestDisconnectedRetryInterval(t *testing.T) {
+	// Set up test cases.
+	testCases := []struct {
+		configuration    *Configuration
+		disconnected     time.Duration
+		expectedInterval time.Duration
+		expectSuspended  bool
+	}{
+		{&Configuration{}, 24 * time.Hour, 0, false},
+		{&Configuration{DisconnectedTimeout: 3600}, 59 * time.Minute, 0, false},
+		{&Configuration{DisconnectedTimeout: 3600}, time.Hour, 15 * time.Minute, true},
+		{&Configuration{DisconnectedTimeout: 3600, DisconnectedRetryInterval: 60}, 2 * time.Hour, time.Minute, true},
+	}
+
+	// Process test cases.
+	for i, testCase := range testCases {
+		interval, suspended := disconnectedRetryInterval(Version_Version1, testCase.configuration, testCase.disconnected)
+		if suspended != testCase.expectSuspended {
+			t.Errorf("test case %d: suspension status (%t) does not match expected (%t)",
+				i, suspended, testCase.expectSuspended,
+			)
+		} else if interval != testCase.expectedInterval {
+			t.Errorf("test case %d: retry interval (%s) does not match expected (%s)",
+				i, interval, testCase.expectedInterval,
+			)
+		}
+	}
+}
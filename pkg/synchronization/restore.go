@@ -0,0 +1,36 @@
+package synchronization
+
+import (
+	"errors"
+	"fmt"
+)
+
+// EnsureValid ensures that RestoreResult's invariants are respected.
+func (r *RestoreResult) EnsureValid() error {
+	// A nil restore result is not valid.
+	if r == nil {
+		return errors.New("nil restore result")
+	}
+
+	// Ensure that the session identifier is non-empty.
+	if r.Session == "" {
+		return errors.New("empty session identifier")
+	}
+
+	// Validate alpha problems.
+	for _, problem := range r.AlphaProblems {
+		if err := problem.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid alpha problem: %w", err)
+		}
+	}
+
+	// Validate beta problems.
+	for _, problem := range r.BetaProblems {
+		if err := problem.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid beta problem: %w", err)
+		}
+	}
+
+	// Success.
+	return nil
+}
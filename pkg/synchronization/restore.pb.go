@@ -0,0 +1,202 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.5
+// source: synchronization/restore.proto
+
+package synchronization
+
+import (
+	core "github.com/mutagen-io/mutagen/pkg/synchronization/core"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// RestoreResult encodes the results of a point-in-time restoration operation
+// for a single session.
+type RestoreResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Session is the session identifier.
+	Session string `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	// AlphaRestored are the paths restored on alpha.
+	AlphaRestored []string `protobuf:"bytes,2,rep,name=alphaRestored,proto3" json:"alphaRestored,omitempty"`
+	// AlphaProblems are the problems encountered while restoring on alpha.
+	AlphaProblems []*core.Problem `protobuf:"bytes,3,rep,name=alphaProblems,proto3" json:"alphaProblems,omitempty"`
+	// BetaRestored are the paths restored on beta.
+	BetaRestored []string `protobuf:"bytes,4,rep,name=betaRestored,proto3" json:"betaRestored,omitempty"`
+	// BetaProblems are the problems encountered while restoring on beta.
+	BetaProblems []*core.Problem `protobuf:"bytes,5,rep,name=betaProblems,proto3" json:"betaProblems,omitempty"`
+}
+
+func (x *RestoreResult) Reset() {
+	*x = RestoreResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_synchronization_restore_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreResult) ProtoMessage() {}
+
+func (x *RestoreResult) ProtoReflect() protoreflect.Message {
+	mi := &file_synchronization_restore_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreResult.ProtoReflect.Descriptor instead.
+func (*RestoreResult) Descriptor() ([]byte, []int) {
+	return file_synchronization_restore_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RestoreResult) GetSession() string {
+	if x != nil {
+		return x.Session
+	}
+	return ""
+}
+
+func (x *RestoreResult) GetAlphaRestored() []string {
+	if x != nil {
+		return x.AlphaRestored
+	}
+	return nil
+}
+
+func (x *RestoreResult) GetAlphaProblems() []*core.Problem {
+	if x != nil {
+		return x.AlphaProblems
+	}
+	return nil
+}
+
+func (x *RestoreResult) GetBetaRestored() []string {
+	if x != nil {
+		return x.BetaRestored
+	}
+	return nil
+}
+
+func (x *RestoreResult) GetBetaProblems() []*core.Problem {
+	if x != nil {
+		return x.BetaProblems
+	}
+	return nil
+}
+
+var File_synchronization_restore_proto protoreflect.FileDescriptor
+
+var file_synchronization_restore_proto_rawDesc = []byte{
+	0x0a, 0x1d, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x72, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x0f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x1a, 0x22, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x2f, 0x63, 0x6f, 0x72, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xdb, 0x01, 0x0a, 0x0d, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x24, 0x0a, 0x0d, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65,
+	0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x52, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x12, 0x33, 0x0a, 0x0d, 0x61, 0x6c, 0x70, 0x68, 0x61, 0x50,
+	0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
+	0x63, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x52, 0x0d, 0x61, 0x6c,
+	0x70, 0x68, 0x61, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x62,
+	0x65, 0x74, 0x61, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0c, 0x62, 0x65, 0x74, 0x61, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x12,
+	0x31, 0x0a, 0x0c, 0x62, 0x65, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65, 0x6d, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x63, 0x6f, 0x72, 0x65, 0x2e, 0x50, 0x72, 0x6f,
+	0x62, 0x6c, 0x65, 0x6d, 0x52, 0x0c, 0x62, 0x65, 0x74, 0x61, 0x50, 0x72, 0x6f, 0x62, 0x6c, 0x65,
+	0x6d, 0x73, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61,
+	0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e,
+	0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_synchronization_restore_proto_rawDescOnce sync.Once
+	file_synchronization_restore_proto_rawDescData = file_synchronization_restore_proto_rawDesc
+)
+
+func file_synchronization_restore_proto_rawDescGZIP() []byte {
+	file_synchronization_restore_proto_rawDescOnce.Do(func() {
+		file_synchronization_restore_proto_rawDescData = protoimpl.X.CompressGZIP(file_synchronization_restore_proto_rawDescData)
+	})
+	return file_synchronization_restore_proto_rawDescData
+}
+
+var file_synchronization_restore_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_synchronization_restore_proto_goTypes = []interface{}{
+	(*RestoreResult)(nil), // 0: synchronization.RestoreResult
+	(*core.Problem)(nil),  // 1: core.Problem
+}
+var file_synchronization_restore_proto_depIdxs = []int32{
+	1, // 0: synchronization.RestoreResult.alphaProblems:type_name -> core.Problem
+	1, // 1: synchronization.RestoreResult.betaProblems:type_name -> core.Problem
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_synchronization_restore_proto_init() }
+func file_synchronization_restore_proto_init() {
+	if File_synchronization_restore_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_synchronization_restore_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_synchronization_restore_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_synchronization_restore_proto_goTypes,
+		DependencyIndexes: file_synchronization_restore_proto_depIdxs,
+		MessageInfos:      file_synchronization_restore_proto_msgTypes,
+	}.Build()
+	File_synchronization_restore_proto = out.File
+	file_synchronization_restore_proto_rawDesc = nil
+	file_synchronization_restore_proto_goTypes = nil
+	file_synchronization_restore_proto_depIdxs = nil
+}
@@ -18,6 +18,8 @@ func (m ScanMode) MarshalText() ([]byte, error) {
 		result = "full"
 	case ScanMode_ScanModeAccelerated:
 		result = "accelerated"
+	case ScanMode_ScanModeTrusted:
+		result = "trusted"
 	default:
 		result = "unknown"
 	}
@@ -35,6 +37,8 @@ func (m *ScanMode) UnmarshalText(textBytes []byte) error {
 		*m = ScanMode_ScanModeFull
 	case "accelerated":
 		*m = ScanMode_ScanModeAccelerated
+	case "trusted":
+		*m = ScanMode_ScanModeTrusted
 	default:
 		return fmt.Errorf("unknown scan mode specification: %s", text)
 	}
@@ -51,6 +55,8 @@ func (m ScanMode) Supported() bool {
 		return true
 	case ScanMode_ScanModeAccelerated:
 		return true
+	case ScanMode_ScanModeTrusted:
+		return true
 	default:
 		return false
 	}
@@ -65,6 +71,8 @@ func (m ScanMode) Description() string {
 		return "Full"
 	case ScanMode_ScanModeAccelerated:
 		return "Accelerated"
+	case ScanMode_ScanModeTrusted:
+		return "Trusted"
 	default:
 		return "Unknown"
 	}
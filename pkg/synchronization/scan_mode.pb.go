@@ -34,6 +34,11 @@ const (
 	// ScanMode_ScanModeAccelerated specifies that scans should attempt to use
 	// watch-based acceleration.
 	ScanMode_ScanModeAccelerated ScanMode = 2
+	// ScanMode_ScanModeTrusted specifies that scans should attempt to use
+	// watch-based acceleration and that the most recent scan should be
+	// persisted and trusted (subject to validation using directory metadata)
+	// in order to accelerate the first scan after a restart.
+	ScanMode_ScanModeTrusted ScanMode = 3
 )
 
 // Enum value maps for ScanMode.
@@ -42,11 +47,13 @@ var (
 		0: "ScanModeDefault",
 		1: "ScanModeFull",
 		2: "ScanModeAccelerated",
+		3: "ScanModeTrusted",
 	}
 	ScanMode_value = map[string]int32{
 		"ScanModeDefault":     0,
 		"ScanModeFull":        1,
 		"ScanModeAccelerated": 2,
+		"ScanModeTrusted":     3,
 	}
 )
 
@@ -83,15 +90,16 @@ var file_synchronization_scan_mode_proto_rawDesc = []byte{
 	0x0a, 0x1f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f,
 	0x6e, 0x2f, 0x73, 0x63, 0x61, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x0f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2a, 0x4a, 0x0a, 0x08, 0x53, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x13,
+	0x6f, 0x6e, 0x2a, 0x5f, 0x0a, 0x08, 0x53, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x13,
 	0x0a, 0x0f, 0x53, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x44, 0x65, 0x66, 0x61, 0x75, 0x6c,
 	0x74, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x53, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x46,
 	0x75, 0x6c, 0x6c, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64,
-	0x65, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x10, 0x02, 0x42, 0x33,
-	0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x75, 0x74,
-	0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2f,
-	0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f, 0x6e, 0x69, 0x7a, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x41, 0x63, 0x63, 0x65, 0x6c, 0x65, 0x72, 0x61, 0x74, 0x65, 0x64, 0x10, 0x02, 0x12, 0x13,
+	0x0a, 0x0f, 0x53, 0x63, 0x61, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x54, 0x72, 0x75, 0x73, 0x74, 0x65,
+	0x64, 0x10, 0x03, 0x42, 0x33, 0x5a, 0x31, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6d, 0x75, 0x74, 0x61, 0x67, 0x65, 0x6e, 0x2d, 0x69, 0x6f, 0x2f, 0x6d, 0x75, 0x74,
+	0x61, 0x67, 0x65, 0x6e, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x68, 0x72, 0x6f,
+	0x6e, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -17,6 +17,7 @@ func TestScanModeUnmarshal(t *testing.T) {
 		{"asdf", ScanMode_ScanModeDefault, true},
 		{"full", ScanMode_ScanModeFull, false},
 		{"accelerated", ScanMode_ScanModeAccelerated, false},
+		{"trusted", ScanMode_ScanModeTrusted, false},
 	}
 
 	// Process test cases.
@@ -49,7 +50,8 @@ func TestScanModeSupported(t *testing.T) {
 		{ScanMode_ScanModeDefault, false},
 		{ScanMode_ScanModeFull, true},
 		{ScanMode_ScanModeAccelerated, true},
-		{(ScanMode_ScanModeAccelerated + 1), false},
+		{ScanMode_ScanModeTrusted, true},
+		{(ScanMode_ScanModeTrusted + 1), false},
 	}
 
 	// Process test cases.
@@ -75,7 +77,8 @@ func TestScanModeDescription(t *testing.T) {
 		{ScanMode_ScanModeDefault, "Default"},
 		{ScanMode_ScanModeFull, "Full"},
 		{ScanMode_ScanModeAccelerated, "Accelerated"},
-		{(ScanMode_ScanModeAccelerated + 1), "Unknown"},
+		{ScanMode_ScanModeTrusted, "Trusted"},
+		{(ScanMode_ScanModeTrusted + 1), "Unknown"},
 	}
 
 	// Process test cases.
@@ -68,9 +68,9 @@ type Session struct {
 	ConfigurationBeta *Configuration `protobuf:"bytes,12,opt,name=configurationBeta,proto3" json:"configurationBeta,omitempty"`
 	// Name is a user-friendly name for the session. It may be empty and is not
 	// guaranteed to be unique across all sessions. It is only used as a simpler
-	// handle for specifying sessions. It is static.
+	// handle for specifying sessions. It may be changed via labeling.
 	Name string `protobuf:"bytes,14,opt,name=name,proto3" json:"name,omitempty"`
-	// Labels are the session labels. They are static.
+	// Labels are the session labels. They may be changed via labeling.
 	Labels map[string]string `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// Paused indicates whether or not the session is marked as paused.
 	Paused bool `protobuf:"varint,10,opt,name=paused,proto3" json:"paused,omitempty"`
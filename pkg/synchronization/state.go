@@ -5,6 +5,10 @@ import (
 	"fmt"
 )
 
+// MaximumRecentChangeCycles is the maximum number of cycles for which changes
+// are recorded in State.RecentChanges.
+const MaximumRecentChangeCycles = 10
+
 // Description returns a human-readable description of the session status.
 func (s Status) Description() string {
 	switch s {
@@ -41,6 +45,14 @@ func (s Status) Description() string {
 	}
 }
 
+// IsHalted indicates whether or not the status indicates that synchronization
+// has been halted for safety reasons.
+func (s Status) IsHalted() bool {
+	return s == Status_HaltedOnRootEmptied ||
+		s == Status_HaltedOnRootDeletion ||
+		s == Status_HaltedOnRootTypeChange
+}
+
 // MarshalText implements encoding.TextMarshaler.MarshalText.
 func (s Status) MarshalText() ([]byte, error) {
 	var result string
@@ -120,6 +132,24 @@ func (s *EndpointState) ensureValid() error {
 	return nil
 }
 
+// ensureValid ensures that CycleChanges' invariants are respected.
+func (c *CycleChanges) ensureValid() error {
+	// A nil cycle changes record is not valid.
+	if c == nil {
+		return errors.New("nil cycle changes")
+	}
+
+	// Ensure that truncation is sane.
+	if c.ExcludedAlphaChanges > 0 && len(c.AlphaChanges) == 0 {
+		return errors.New("excluded alpha changes reported with no alpha changes reported")
+	} else if c.ExcludedBetaChanges > 0 && len(c.BetaChanges) == 0 {
+		return errors.New("excluded beta changes reported with no beta changes reported")
+	}
+
+	// Success.
+	return nil
+}
+
 // EnsureValid ensures that State's invariants are respected.
 func (s *State) EnsureValid() error {
 	// A nil state is not valid.
@@ -146,6 +176,16 @@ func (s *State) EnsureValid() error {
 		return errors.New("excluded conflicts reported with no conflicts reported")
 	}
 
+	// Ensure that all name collisions are valid and truncation is sane.
+	for _, c := range s.NameCollisions {
+		if err := c.EnsureValid(); err != nil {
+			return fmt.Errorf("invalid name collision detected: %w", err)
+		}
+	}
+	if s.ExcludedNameCollisions > 0 && len(s.NameCollisions) == 0 {
+		return errors.New("excluded name collisions reported with no name collisions reported")
+	}
+
 	// Ensure that endpoint states are valid.
 	if err := s.AlphaState.ensureValid(); err != nil {
 		return fmt.Errorf("invalid alpha endpoint state: %w", err)
@@ -153,6 +193,15 @@ func (s *State) EnsureValid() error {
 		return fmt.Errorf("invalid beta endpoint state: %w", err)
 	}
 
+	// Ensure that recent changes are valid and ordered.
+	for i, c := range s.RecentChanges {
+		if err := c.ensureValid(); err != nil {
+			return fmt.Errorf("invalid recent changes: %w", err)
+		} else if i > 0 && c.Cycle <= s.RecentChanges[i-1].Cycle {
+			return errors.New("recent changes not in ascending cycle order")
+		}
+	}
+
 	// Success.
 	return nil
 }
@@ -4,9 +4,11 @@ import (
 	"crypto/sha1"
 	"hash"
 	"math"
+	"runtime"
 
 	"github.com/mutagen-io/mutagen/pkg/filesystem"
 	"github.com/mutagen-io/mutagen/pkg/filesystem/behavior"
+	"github.com/mutagen-io/mutagen/pkg/prompting"
 	"github.com/mutagen-io/mutagen/pkg/synchronization/core"
 )
 
@@ -86,6 +88,17 @@ func (v Version) DefaultScanMode() ScanMode {
 	}
 }
 
+// DefaultScanParallelism returns the default scan parallelism for the session
+// version. This is the number of logical CPUs available on the system.
+func (v Version) DefaultScanParallelism() uint32 {
+	switch v {
+	case Version_Version1:
+		return uint32(runtime.NumCPU())
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultStageMode returns the default staging mode for the session version.
 func (v Version) DefaultStageMode() StageMode {
 	switch v {
@@ -128,6 +141,29 @@ func (v Version) DefaultWatchPollingInterval() uint32 {
 	}
 }
 
+// DefaultConditionPolicy returns the default condition policy for the session
+// version.
+func (v Version) DefaultConditionPolicy() ConditionPolicy {
+	switch v {
+	case Version_Version1:
+		return ConditionPolicy_ConditionPolicyIgnore
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultDisconnectedRetryInterval returns the default interval (in seconds) at
+// which reconnection is attempted once a session has been suspended due to
+// disconnection.
+func (v Version) DefaultDisconnectedRetryInterval() uint32 {
+	switch v {
+	case Version_Version1:
+		return 900
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultIgnoreVCSMode returns the default VCS ignore mode for the session
 // version.
 func (v Version) DefaultIgnoreVCSMode() core.IgnoreVCSMode {
@@ -168,6 +204,50 @@ func (v Version) DefaultPermissionsMode() core.PermissionsMode {
 	}
 }
 
+// DefaultModificationTimeMode returns the default modification time mode for
+// the session version.
+func (v Version) DefaultModificationTimeMode() core.ModificationTimeMode {
+	switch v {
+	case Version_Version1:
+		return core.ModificationTimeMode_ModificationTimeModeIgnore
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultNameCollisionPolicy returns the default name collision policy for the
+// session version.
+func (v Version) DefaultNameCollisionPolicy() core.NameCollisionPolicy {
+	switch v {
+	case Version_Version1:
+		return core.NameCollisionPolicy_NameCollisionPolicyReport
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultHardLinkMode returns the default hard link mode for the session
+// version.
+func (v Version) DefaultHardLinkMode() core.HardLinkMode {
+	switch v {
+	case Version_Version1:
+		return core.HardLinkMode_HardLinkModeIndependent
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
+// DefaultBackgroundPromptingMode returns the default background prompting mode
+// for the session version.
+func (v Version) DefaultBackgroundPromptingMode() prompting.BackgroundPromptingMode {
+	switch v {
+	case Version_Version1:
+		return prompting.BackgroundPromptingMode_BackgroundPromptingModeEnabled
+	default:
+		panic("unknown or unsupported session version")
+	}
+}
+
 // DefaultFileMode returns the default file permission mode for the session
 // version.
 func (v Version) DefaultFileMode() filesystem.Mode {
@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"runtime"
 	"time"
 
 	"github.com/spf13/pflag"
@@ -28,7 +29,7 @@ const (
 	cacheFile    = "cache_test"
 )
 
-var usage = `scan_bench [-h|--help] [-p|--profile] [-i|--ignore=<pattern>] <path>
+var usage = `scan_bench [-h|--help] [-p|--profile] [-i|--ignore=<pattern>] [-j|--parallelism=<count>] <path>
 `
 
 // ignoreCachesIntersectionEqual compares two ignore caches, ensuring that keys
@@ -60,8 +61,10 @@ func main() {
 	flagSet.SetOutput(io.Discard)
 	var ignores []string
 	var enableProfile bool
+	var parallelism int
 	flagSet.StringSliceVarP(&ignores, "ignore", "i", nil, "specify ignore paths")
 	flagSet.BoolVarP(&enableProfile, "profile", "p", false, "enable profiling")
+	flagSet.IntVarP(&parallelism, "parallelism", "j", runtime.NumCPU(), "specify scanning parallelism")
 	if err := flagSet.Parse(os.Args[1:]); err != nil {
 		if err == pflag.ErrHelp {
 			fmt.Fprint(os.Stdout, usage)
@@ -109,12 +112,18 @@ func main() {
 	snapshot, cache, ignoreCache, err := core.Scan(
 		ctx,
 		path,
-		nil, nil,
-		sha1.New(), nil,
+		nil, nil, false,
+		sha1.New, nil,
 		ignores, nil,
+		nil,
+		nil,
 		behavior.ProbeMode_ProbeModeProbe,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
 		core.PermissionsMode_PermissionsModePortable,
+		core.ModificationTimeMode_ModificationTimeModeIgnore,
+		core.HardLinkMode_HardLinkModeIndependent,
+		nil,
+		parallelism,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform cold scan: %w", err))
@@ -144,12 +153,18 @@ func main() {
 	newSnapshot, newCache, newIgnoreCache, err := core.Scan(
 		ctx,
 		path,
-		nil, nil,
-		sha1.New(), cache,
+		nil, nil, false,
+		sha1.New, cache,
 		ignores, ignoreCache,
+		nil,
+		nil,
 		behavior.ProbeMode_ProbeModeProbe,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
 		core.PermissionsMode_PermissionsModePortable,
+		core.ModificationTimeMode_ModificationTimeModeIgnore,
+		core.HardLinkMode_HardLinkModeIndependent,
+		nil,
+		parallelism,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform warm scan: %w", err))
@@ -187,12 +202,18 @@ func main() {
 	newSnapshot, newCache, newIgnoreCache, err = core.Scan(
 		ctx,
 		path,
-		nil, nil,
-		sha1.New(), cache,
+		nil, nil, false,
+		sha1.New, cache,
 		ignores, ignoreCache,
+		nil,
+		nil,
 		behavior.ProbeMode_ProbeModeProbe,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
 		core.PermissionsMode_PermissionsModePortable,
+		core.ModificationTimeMode_ModificationTimeModeIgnore,
+		core.HardLinkMode_HardLinkModeIndependent,
+		nil,
+		parallelism,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform second warm scan: %w", err))
@@ -228,12 +249,18 @@ func main() {
 	newSnapshot, newCache, newIgnoreCache, err = core.Scan(
 		ctx,
 		path,
-		snapshot, map[string]bool{"fake path": true},
-		sha1.New(), cache,
+		snapshot, map[string]bool{"fake path": true}, false,
+		sha1.New, cache,
 		ignores, ignoreCache,
+		nil,
+		nil,
 		behavior.ProbeMode_ProbeModeProbe,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
 		core.PermissionsMode_PermissionsModePortable,
+		core.ModificationTimeMode_ModificationTimeModeIgnore,
+		core.HardLinkMode_HardLinkModeIndependent,
+		nil,
+		parallelism,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform accelerated scan (with re-check paths): %w", err))
@@ -267,12 +294,18 @@ func main() {
 	newSnapshot, newCache, newIgnoreCache, err = core.Scan(
 		ctx,
 		path,
-		snapshot, nil,
-		sha1.New(), cache,
+		snapshot, nil, false,
+		sha1.New, cache,
 		ignores, ignoreCache,
+		nil,
+		nil,
 		behavior.ProbeMode_ProbeModeProbe,
 		core.SymbolicLinkMode_SymbolicLinkModePortable,
 		core.PermissionsMode_PermissionsModePortable,
+		core.ModificationTimeMode_ModificationTimeModeIgnore,
+		core.HardLinkMode_HardLinkModeIndependent,
+		nil,
+		parallelism,
 	)
 	if err != nil {
 		cmd.Fatal(fmt.Errorf("unable to perform accelerated scan (without re-check paths): %w", err))